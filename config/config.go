@@ -3,18 +3,33 @@ package config
 import (
 	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
+	"time"
 )
 
 type Config struct {
-	HTTP HTTP
-	Log  Log
-	PG   PG
+	HTTP        HTTP
+	GRPC        GRPC
+	Log         Log
+	PG          PG
+	Calendar    Calendar
+	Outbox      Outbox
+	NATS        NATS
+	Webhook     Webhook
+	Reminder    Reminder
+	PriceChange PriceChange
+	Budget      Budget
+	Charge      Charge
+	Anomaly     Anomaly
+	SMTP        SMTP
 }
 
 type (
 	HTTP struct {
 		Port string `env-required:"true" env:"HTTP_PORT"`
 	}
+	GRPC struct {
+		Port string `env-default:"9000" env:"GRPC_PORT"`
+	}
 	Log struct {
 		Level  string `env-required:"true" env:"LOG_LEVEL"`
 		Output string `env-required:"true" env:"LOG_OUTPUT"`
@@ -22,6 +37,47 @@ type (
 	PG struct {
 		Url string `env-required:"true" env:"PG_URL"`
 	}
+	Calendar struct {
+		Secret string `env:"CALENDAR_SECRET"` // calendar feed is disabled without it
+	}
+	Outbox struct {
+		Publisher string        `env-default:"stdout" env:"OUTBOX_PUBLISHER"` // stdout or nats
+		Interval  time.Duration `env-default:"1s" env:"OUTBOX_INTERVAL"`
+		BatchSize int           `env-default:"100" env:"OUTBOX_BATCH_SIZE"`
+	}
+	NATS struct {
+		Url string `env:"NATS_URL"`
+	}
+	Webhook struct {
+		Interval  time.Duration `env-default:"5s" env:"WEBHOOK_INTERVAL"`
+		BatchSize int           `env-default:"50" env:"WEBHOOK_BATCH_SIZE"`
+	}
+	Reminder struct {
+		Interval time.Duration `env-default:"24h" env:"REMINDER_INTERVAL"`
+		LeadDays int           `env-default:"3" env:"REMINDER_LEAD_DAYS"`  // used for users without own settings
+		Notifier string        `env-default:"log" env:"REMINDER_NOTIFIER"` // log or smtp
+	}
+	PriceChange struct {
+		Interval time.Duration `env-default:"1h" env:"PRICE_CHANGE_INTERVAL"`
+	}
+	Budget struct {
+		Interval time.Duration `env-default:"1h" env:"BUDGET_INTERVAL"`
+	}
+	Charge struct {
+		Interval      time.Duration `env-default:"1h" env:"CHARGE_INTERVAL"`
+		HorizonMonths int           `env-default:"12" env:"CHARGE_HORIZON_MONTHS"` // max months ahead of today for manual generation
+	}
+	Anomaly struct {
+		Interval  time.Duration `env-default:"24h" env:"ANOMALY_INTERVAL"`
+		Threshold int           `env-default:"20" env:"ANOMALY_THRESHOLD"` // percent of price increase
+	}
+	SMTP struct {
+		Host     string `env:"SMTP_HOST"`
+		Port     string `env-default:"25" env:"SMTP_PORT"`
+		Username string `env:"SMTP_USERNAME"`
+		Password string `env:"SMTP_PASSWORD"`
+		From     string `env:"SMTP_FROM"`
+	}
 )
 
 func NewConfig() (*Config, error) {
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
@@ -9,10 +10,13 @@ import (
 	"os"
 	"os/signal"
 	"subscription_service/config"
+	grpcserver "subscription_service/internal/controller/grpc"
 	v1 "subscription_service/internal/controller/http/v1"
 	"subscription_service/internal/repo"
 	"subscription_service/internal/service"
+	"subscription_service/pkg/notifier"
 	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/publisher"
 	"subscription_service/pkg/validator"
 	"syscall"
 	"time"
@@ -40,36 +44,103 @@ func Run() {
 	}
 	defer pg.Close()
 
+	// EVENTS PUBLISHER
+	pub, err := newPublisher(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("init publisher error")
+	}
+	defer func() { _ = pub.Close() }()
+
+	// REMINDERS NOTIFIER
+	ntf, err := newNotifier(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("init notifier error")
+	}
+
+	if cfg.Calendar.Secret == "" {
+		log.Warn().Msg("CALENDAR_SECRET is not set, calendar is disabled")
+	}
+
 	// INIT SERVICES
 	d := &service.ServicesDependencies{
-		Repos: repo.NewRepositories(pg),
+		Repos:            repo.NewRepositories(pg),
+		Publisher:        pub,
+		Notifier:         ntf,
+		CalendarSecret:   cfg.Calendar.Secret,
+		ReminderLeadDays: cfg.Reminder.LeadDays,
+		AnomalyThreshold: cfg.Anomaly.Threshold,
+		ChargeHorizon:    cfg.Charge.HorizonMonths,
 	}
 
 	services := service.NewServices(d)
 
+	// BACKGROUND WORKERS
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
+	workers := []<-chan struct{}{
+		runBatchWorker(workersCtx, "outbox relay", services.Outbox.Relay, cfg.Outbox.Interval, cfg.Outbox.BatchSize),
+		runBatchWorker(workersCtx, "webhook dispatcher", services.Webhook.Dispatch, cfg.Webhook.Interval, cfg.Webhook.BatchSize),
+		runPeriodicJob(workersCtx, "renewal reminder", func(ctx context.Context) error {
+			_, err := services.Reminder.Run(ctx, time.Now())
+			return err
+		}, cfg.Reminder.Interval),
+		runPeriodicJob(workersCtx, "price change", func(ctx context.Context) error {
+			_, err := services.Forecast.ApplyPriceChanges(ctx, time.Now())
+			return err
+		}, cfg.PriceChange.Interval),
+		runPeriodicJob(workersCtx, "budget check", func(ctx context.Context) error {
+			_, err := services.Budget.Check(ctx, time.Now())
+			return err
+		}, cfg.Budget.Interval),
+		runPeriodicJob(workersCtx, "charge generation", func(ctx context.Context) error {
+			// charges are generated up to current day of every user
+			_, err := services.Charge.Generate(ctx, time.Time{})
+			return err
+		}, cfg.Charge.Interval),
+		runPeriodicJob(workersCtx, "price anomaly detection", func(ctx context.Context) error {
+			_, err := services.Anomaly.Detect(ctx, time.Now())
+			return err
+		}, cfg.Anomaly.Interval),
+	}
+
 	// HTTP handler
 	h := echo.New()
 
-	h.Validator = validator.NewValidator()
+	v := validator.NewValidator()
+	h.Validator = v
 
 	v1.NewRouter(h, services)
 
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
 
+	// gRPC server
+	g := grpcserver.NewServer(services, v)
+
+	lis, err := net.Listen("tcp", net.JoinHostPort("", cfg.GRPC.Port))
+	if err != nil {
+		log.Fatal().Err(err).Msg("grpc listen error")
+	}
+
 	handlerCh := make(chan error, 1)
+	grpcCh := make(chan error, 1)
 
 	go func() {
 		handlerCh <- h.Start(net.JoinHostPort("", cfg.HTTP.Port))
 	}()
+	go func() {
+		grpcCh <- g.Serve(lis)
+	}()
 
-	log.Info().Msgf("app started, listen port %s", cfg.HTTP.Port)
+	log.Info().Msgf("app started, listen port %s, grpc port %s", cfg.HTTP.Port, cfg.GRPC.Port)
 
 	select {
 	case s := <-interrupt:
 		log.Info().Msgf("app signal %s", s.String())
 	case err = <-handlerCh:
 		log.Err(err).Msg("http server error")
+	case err = <-grpcCh:
+		log.Err(err).Msg("grpc server error")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -80,9 +151,55 @@ func Run() {
 		log.Err(err).Msg("http server shutdown error")
 	}
 
+	// stop grpc server, in-flight calls are finished
+	stopped := make(chan struct{})
+	go func() {
+		g.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		g.Stop()
+	}
+
+	// stop background workers
+	stopWorkers()
+	for _, done := range workers {
+		<-done
+	}
+
 	log.Info().Msg("app shutdown with exit code 0")
 }
 
+func newPublisher(cfg *config.Config) (publisher.Publisher, error) {
+	switch cfg.Outbox.Publisher {
+	case "nats":
+		return publisher.NewNats(cfg.NATS.Url)
+	case "stdout":
+		return publisher.NewWriter(os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", cfg.Outbox.Publisher)
+	}
+}
+
+func newNotifier(cfg *config.Config) (notifier.Notifier, error) {
+	switch cfg.Reminder.Notifier {
+	case "smtp":
+		return notifier.NewSMTP(notifier.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		}), nil
+	case "log":
+		return notifier.NewLog(), nil
+	default:
+		return nil, fmt.Errorf("unknown reminder notifier %q", cfg.Reminder.Notifier)
+	}
+}
+
 func init() {
 	if _, ok := os.LookupEnv("HTTP_PORT"); !ok {
 		if err := godotenv.Load(); err != nil {
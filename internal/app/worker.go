@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+type batchFunc func(ctx context.Context, limit int) (int, error)
+
+// runBatchWorker periodically calls fn until ctx is done. While fn returns full batches
+// it is called again without waiting for next tick. Returned channel is closed when worker is stopped
+func runBatchWorker(ctx context.Context, name string, fn batchFunc, interval time.Duration, batchSize int) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for ctx.Err() == nil {
+				n, err := fn(ctx, batchSize)
+				if err != nil || n < batchSize {
+					break
+				}
+			}
+		}
+	}()
+
+	log.Info().Msgf("%s worker started, interval %s", name, interval)
+	return done
+}
+
+// runPeriodicJob calls fn right away and then every interval until ctx is done.
+// Returned channel is closed when job is stopped
+func runPeriodicJob(ctx context.Context, name string, fn func(ctx context.Context) error, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := fn(ctx); err != nil {
+				log.Err(err).Msgf("%s job error", name)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	log.Info().Msgf("%s job started, interval %s", name, interval)
+	return done
+}
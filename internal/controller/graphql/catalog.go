@@ -0,0 +1,35 @@
+package graphql
+
+import (
+	"context"
+	"subscription_service/internal/service"
+)
+
+type serviceResolver struct {
+	s service.ServiceOutput
+}
+
+func (r *serviceResolver) Id() int32            { return int32(r.s.Id) }
+func (r *serviceResolver) Name() string         { return r.s.Name }
+func (r *serviceResolver) Category() *string    { return r.s.Category }
+func (r *serviceResolver) DefaultPrice() *int32 { return int32Ptr(r.s.DefaultPrice) }
+func (r *serviceResolver) YearlyPrice() *int32  { return int32Ptr(r.s.YearlyPrice) }
+func (r *serviceResolver) FamilyPrice() *int32  { return int32Ptr(r.s.FamilyPrice) }
+func (r *serviceResolver) FamilySize() *int32   { return int32Ptr(r.s.FamilySize) }
+func (r *serviceResolver) VendorUrl() *string   { return r.s.VendorUrl }
+func (r *serviceResolver) LogoUrl() *string     { return r.s.LogoUrl }
+
+func (r *serviceResolver) Aliases() []string {
+	if r.s.Aliases == nil {
+		return []string{}
+	}
+	return r.s.Aliases
+}
+
+func (r *serviceResolver) Subscriptions(ctx context.Context) ([]*subscriptionResolver, error) {
+	subscriptions, err := loadersFrom(ctx).serviceSubscriptions.Load(ctx, r.s.Id)()
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	return newSubscriptionResolvers(subscriptions), nil
+}
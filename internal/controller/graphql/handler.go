@@ -0,0 +1,35 @@
+package graphql
+
+import (
+	_ "embed"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"net/http"
+	"subscription_service/internal/service"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// maxDepth limits nesting of queries, types refer to each other
+	maxDepth = 8
+
+	// maxParallelism is number of list items resolved at once, loaders batch fields of all of them
+	maxParallelism = 100
+)
+
+// NewHandler returns handler of GraphQL queries over services, see schema.graphql. Nested subscriptions,
+// users, services and spend are loaded in batches for all items of list, loaders cache them within request
+func NewHandler(services *service.Services) http.Handler {
+	s := graphql.MustParseSchema(schema, &rootResolver{query: &queryResolver{services: services}},
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+	h := &relay.Handler{Schema: s}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := withLoaders(r.Context(), newLoaders(services))
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
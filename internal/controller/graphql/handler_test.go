@@ -0,0 +1,182 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	sub     *servicemocks.MockSubscription
+	user    *servicemocks.MockUser
+	catalog *servicemocks.MockCatalog
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestHandler(t *testing.T) {
+	type mockBehaviour func(m mocks)
+
+	alice := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+	bob := "2344696a-d069-4fad-a3ed-f27c13651c3a"
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	julyEnd := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	subscriptions := []service.SubscriptionOutput{
+		{Id: 1, ServiceId: 3, ServiceName: "Yandex Plus", Price: 300, UserId: alice, StartDate: "2025-01-01", Tags: []string{"music"}, Status: "active"},
+		{Id: 2, ServiceId: 5, ServiceName: "Netflix", Price: 1000, UserId: bob, StartDate: "2025-02-01", EndDate: ptr("2025-12-31"), Status: "active"},
+		{Id: 3, ServiceId: 3, ServiceName: "Yandex Plus", Price: 300, UserId: bob, StartDate: "2025-03-01", CategoryId: ptr(2), Status: "paused"},
+	}
+	users := []service.UserOutput{
+		{Id: alice, Email: ptr("alice@example.com"), Timezone: "UTC", Currency: "RUB"},
+		{Id: bob, Timezone: "Europe/Moscow", Currency: "USD"},
+	}
+	services := []service.ServiceOutput{
+		{Id: 3, Name: "Yandex Plus", Aliases: []string{"yandex"}, FamilyPrice: ptr(900), FamilySize: ptr(3)},
+		{Id: 5, Name: "Netflix"},
+	}
+
+	testCases := []struct {
+		testName      string
+		query         string
+		mockBehaviour mockBehaviour
+		expectBody    string
+	}{
+		{
+			testName: "subscriptions with users and services",
+			query:    `{ subscriptions(tag: "music") { id serviceName endDate categoryId tags user { id currency } service { name aliases } } }`,
+			mockBehaviour: func(m mocks) {
+				m.sub.EXPECT().FindAll(gomock.Any(), service.SubscriptionFilterInput{Tag: "music"}).Return(subscriptions, nil)
+				// users and services of all subscriptions are loaded at once
+				m.user.EXPECT().FindAll(gomock.Any()).Return(users, nil)
+				m.catalog.EXPECT().FindAll(gomock.Any()).Return(services, nil)
+			},
+			expectBody: `{"data": {"subscriptions": [
+				{"id": 1, "serviceName": "Yandex Plus", "endDate": null, "categoryId": null, "tags": ["music"],
+				 "user": {"id": "` + alice + `", "currency": "RUB"}, "service": {"name": "Yandex Plus", "aliases": ["yandex"]}},
+				{"id": 2, "serviceName": "Netflix", "endDate": "2025-12-31", "categoryId": null, "tags": [],
+				 "user": {"id": "` + bob + `", "currency": "USD"}, "service": {"name": "Netflix", "aliases": []}},
+				{"id": 3, "serviceName": "Yandex Plus", "endDate": null, "categoryId": 2, "tags": [],
+				 "user": {"id": "` + bob + `", "currency": "USD"}, "service": {"name": "Yandex Plus", "aliases": ["yandex"]}}
+			]}}`,
+		},
+		{
+			testName: "users with subscriptions and spend",
+			query:    `{ users { id email subscriptions { id status } spend(start: "2025-07-01", end: "2025-07-31") { gross net tax discount } } }`,
+			mockBehaviour: func(m mocks) {
+				m.user.EXPECT().FindAll(gomock.Any()).Return(users, nil)
+				// subscriptions and spend of all users are loaded at once
+				m.sub.EXPECT().FindAll(gomock.Any(), service.SubscriptionFilterInput{UserIds: []string{bob, alice}}).Return(subscriptions, nil)
+				m.sub.EXPECT().FindUserPrices(gomock.Any(), []string{bob, alice}, july, julyEnd).Return(map[string]service.PriceOutput{
+					alice: {Gross: 360, Net: 300, Tax: 60},
+					bob:   {Gross: 1000, Net: 1000, Discount: 100},
+				}, nil)
+			},
+			expectBody: `{"data": {"users": [
+				{"id": "` + alice + `", "email": "alice@example.com", "subscriptions": [{"id": 1, "status": "active"}],
+				 "spend": {"gross": 360, "net": 300, "tax": 60, "discount": 0}},
+				{"id": "` + bob + `", "email": null, "subscriptions": [{"id": 2, "status": "active"}, {"id": 3, "status": "paused"}],
+				 "spend": {"gross": 1000, "net": 1000, "tax": 0, "discount": 100}}
+			]}}`,
+		},
+		{
+			testName: "services with subscriptions",
+			query:    `{ services { id familyPrice familySize subscriptions { id userId } } }`,
+			mockBehaviour: func(m mocks) {
+				m.catalog.EXPECT().FindAll(gomock.Any()).Return(services, nil)
+				m.sub.EXPECT().FindAll(gomock.Any(), service.SubscriptionFilterInput{ServiceIds: []int{3, 5}}).Return(subscriptions, nil)
+			},
+			expectBody: `{"data": {"services": [
+				{"id": 3, "familyPrice": 900, "familySize": 3, "subscriptions": [{"id": 1, "userId": "` + alice + `"}, {"id": 3, "userId": "` + bob + `"}]},
+				{"id": 5, "familyPrice": null, "familySize": null, "subscriptions": [{"id": 2, "userId": "` + bob + `"}]}
+			]}}`,
+		},
+		{
+			testName: "subscription by id",
+			query:    `{ subscription(id: 2) { id price billingPeriod } }`,
+			mockBehaviour: func(m mocks) {
+				m.sub.EXPECT().FindById(gomock.Any(), 2).Return(service.SubscriptionOutput{Id: 2, Price: 1000, BillingPeriod: "monthly"}, nil)
+			},
+			expectBody: `{"data": {"subscription": {"id": 2, "price": 1000, "billingPeriod": "monthly"}}}`,
+		},
+		{
+			testName: "subscription not found",
+			query:    `{ subscription(id: 4) { id } }`,
+			mockBehaviour: func(m mocks) {
+				m.sub.EXPECT().FindById(gomock.Any(), 4).Return(service.SubscriptionOutput{}, service.ErrSubscriptionNotFound)
+			},
+			expectBody: `{"data": {"subscription": null}}`,
+		},
+		{
+			testName: "user not found",
+			query:    `{ user(id: "` + alice + `") { id } }`,
+			mockBehaviour: func(m mocks) {
+				m.user.EXPECT().FindById(gomock.Any(), alice).Return(service.UserOutput{}, service.ErrUserNotFound)
+			},
+			expectBody: `{"data": {"user": null}}`,
+		},
+		{
+			testName: "spend",
+			query:    `{ spend(start: "2025-07-01", end: "2025-07-31", serviceName: "Netflix", categoryId: 2, source: "ledger") { gross net } }`,
+			mockBehaviour: func(m mocks) {
+				m.sub.EXPECT().FindPrice(gomock.Any(), service.PriceInput{
+					ServiceName: "Netflix",
+					CategoryId:  2,
+					StartDate:   july,
+					EndDate:     julyEnd,
+					Source:      service.PriceSourceLedger,
+				}).Return(service.PriceOutput{Gross: 1200, Net: 1000}, nil)
+			},
+			expectBody: `{"data": {"spend": {"gross": 1200, "net": 1000}}}`,
+		},
+		{
+			testName:      "incorrect interval",
+			query:         `{ spend(start: "2025-07-31", end: "2025-07-01") { gross } }`,
+			mockBehaviour: func(m mocks) {},
+			expectBody:    `{"data": null, "errors": [{"message": "invalid end date", "path": ["spend"]}]}`,
+		},
+		{
+			testName: "unexpected error",
+			query:    `{ subscriptions { id } }`,
+			mockBehaviour: func(m mocks) {
+				m.sub.EXPECT().FindAll(gomock.Any(), service.SubscriptionFilterInput{}).Return(nil, errors.New("some error"))
+			},
+			expectBody: `{"data": null, "errors": [{"message": "internal error", "path": ["subscriptions"]}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			m := mocks{
+				sub:     servicemocks.NewMockSubscription(ctrl),
+				user:    servicemocks.NewMockUser(ctrl),
+				catalog: servicemocks.NewMockCatalog(ctrl),
+			}
+			tc.mockBehaviour(m)
+
+			h := NewHandler(&service.Services{Subscription: m.sub, User: m.user, Catalog: m.catalog})
+
+			body, _ := json.Marshal(map[string]string{"query": tc.query})
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
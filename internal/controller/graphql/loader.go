@@ -0,0 +1,151 @@
+package graphql
+
+import (
+	"context"
+	"github.com/graph-gophers/dataloader/v7"
+	"slices"
+	"subscription_service/internal/service"
+	"time"
+)
+
+type loadersKey struct{}
+
+// loaders batch loading of nested fields of list items, so list of n items costs one query per field instead of n
+type loaders struct {
+	userSubscriptions    *dataloader.Loader[string, []service.SubscriptionOutput]
+	serviceSubscriptions *dataloader.Loader[int, []service.SubscriptionOutput]
+	users                *dataloader.Loader[string, *service.UserOutput]
+	services             *dataloader.Loader[int, *service.ServiceOutput]
+	userSpend            *dataloader.Loader[spendKey, service.PriceOutput]
+}
+
+// spendKey is user with interval of spend
+type spendKey struct {
+	userId string
+	start  time.Time
+	end    time.Time
+}
+
+func newLoaders(services *service.Services) *loaders {
+	return &loaders{
+		userSubscriptions:    dataloader.NewBatchedLoader(loadUserSubscriptions(services.Subscription)),
+		serviceSubscriptions: dataloader.NewBatchedLoader(loadServiceSubscriptions(services.Subscription)),
+		users:                dataloader.NewBatchedLoader(loadUsers(services.User)),
+		services:             dataloader.NewBatchedLoader(loadServices(services.Catalog)),
+		userSpend:            dataloader.NewBatchedLoader(loadUserSpend(services.Subscription)),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func loadUserSubscriptions(sub service.Subscription) dataloader.BatchFunc[string, []service.SubscriptionOutput] {
+	return func(ctx context.Context, userIds []string) []*dataloader.Result[[]service.SubscriptionOutput] {
+		subscriptions, err := sub.FindAll(ctx, service.SubscriptionFilterInput{UserIds: sorted(userIds)})
+		if err != nil {
+			return failed[[]service.SubscriptionOutput](len(userIds), err)
+		}
+		byUser := make(map[string][]service.SubscriptionOutput)
+		for _, s := range subscriptions {
+			byUser[s.UserId] = append(byUser[s.UserId], s)
+		}
+		return results(userIds, func(id string) []service.SubscriptionOutput { return byUser[id] })
+	}
+}
+
+func loadServiceSubscriptions(sub service.Subscription) dataloader.BatchFunc[int, []service.SubscriptionOutput] {
+	return func(ctx context.Context, serviceIds []int) []*dataloader.Result[[]service.SubscriptionOutput] {
+		subscriptions, err := sub.FindAll(ctx, service.SubscriptionFilterInput{ServiceIds: sorted(serviceIds)})
+		if err != nil {
+			return failed[[]service.SubscriptionOutput](len(serviceIds), err)
+		}
+		byService := make(map[int][]service.SubscriptionOutput)
+		for _, s := range subscriptions {
+			byService[s.ServiceId] = append(byService[s.ServiceId], s)
+		}
+		return results(serviceIds, func(id int) []service.SubscriptionOutput { return byService[id] })
+	}
+}
+
+// loadUsers loads users of organization at once, unknown users are nil
+func loadUsers(user service.User) dataloader.BatchFunc[string, *service.UserOutput] {
+	return func(ctx context.Context, ids []string) []*dataloader.Result[*service.UserOutput] {
+		users, err := user.FindAll(ctx)
+		if err != nil {
+			return failed[*service.UserOutput](len(ids), err)
+		}
+		byId := make(map[string]*service.UserOutput, len(users))
+		for i := range users {
+			byId[users[i].Id] = &users[i]
+		}
+		return results(ids, func(id string) *service.UserOutput { return byId[id] })
+	}
+}
+
+// loadServices loads catalog of organization at once, unknown services are nil
+func loadServices(catalog service.Catalog) dataloader.BatchFunc[int, *service.ServiceOutput] {
+	return func(ctx context.Context, ids []int) []*dataloader.Result[*service.ServiceOutput] {
+		services, err := catalog.FindAll(ctx)
+		if err != nil {
+			return failed[*service.ServiceOutput](len(ids), err)
+		}
+		byId := make(map[int]*service.ServiceOutput, len(services))
+		for i := range services {
+			byId[services[i].Id] = &services[i]
+		}
+		return results(ids, func(id int) *service.ServiceOutput { return byId[id] })
+	}
+}
+
+// loadUserSpend finds spend of users with one query for every interval
+func loadUserSpend(sub service.Subscription) dataloader.BatchFunc[spendKey, service.PriceOutput] {
+	return func(ctx context.Context, keys []spendKey) []*dataloader.Result[service.PriceOutput] {
+		type interval struct{ start, end time.Time }
+
+		userIds := make(map[interval][]string)
+		for _, k := range keys {
+			i := interval{start: k.start, end: k.end}
+			userIds[i] = append(userIds[i], k.userId)
+		}
+
+		result := make([]*dataloader.Result[service.PriceOutput], len(keys))
+		for i, ids := range userIds {
+			prices, err := sub.FindUserPrices(ctx, sorted(ids), i.start, i.end)
+			for n, k := range keys {
+				if k.start.Equal(i.start) && k.end.Equal(i.end) {
+					result[n] = &dataloader.Result[service.PriceOutput]{Data: prices[k.userId], Error: err}
+				}
+			}
+		}
+		return result
+	}
+}
+
+// results returns results of keys in their order as batch function must
+func results[K comparable, V any](keys []K, find func(K) V) []*dataloader.Result[V] {
+	result := make([]*dataloader.Result[V], 0, len(keys))
+	for _, k := range keys {
+		result = append(result, &dataloader.Result[V]{Data: find(k)})
+	}
+	return result
+}
+
+func failed[V any](n int, err error) []*dataloader.Result[V] {
+	result := make([]*dataloader.Result[V], 0, n)
+	for range n {
+		result = append(result, &dataloader.Result[V]{Error: err})
+	}
+	return result
+}
+
+// sorted returns sorted copy of keys, so batches do not depend on order of loading
+func sorted[K int | string](keys []K) []K {
+	result := slices.Clone(keys)
+	slices.Sort(result)
+	return result
+}
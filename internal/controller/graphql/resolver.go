@@ -0,0 +1,198 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"subscription_service/internal/service"
+	"time"
+)
+
+// errInternal replaces unexpected errors, services log them and details are not exposed to client
+var errInternal = errors.New("internal error")
+
+// rootResolver resolves operations separately, field subscription of queries would be taken
+// for subscription operation otherwise
+type rootResolver struct {
+	query *queryResolver
+}
+
+func (r *rootResolver) Query() *queryResolver {
+	return r.query
+}
+
+type queryResolver struct {
+	services *service.Services
+}
+
+func (r *queryResolver) Subscription(ctx context.Context, args struct{ Id int32 }) (*subscriptionResolver, error) {
+	s, err := r.services.Subscription.FindById(ctx, int(args.Id))
+	if err != nil {
+		if errors.Is(err, service.ErrSubscriptionNotFound) {
+			return nil, nil
+		}
+		return nil, resolveError(err)
+	}
+	return &subscriptionResolver{s}, nil
+}
+
+func (r *queryResolver) Subscriptions(ctx context.Context, args struct {
+	CategoryId *int32
+	Tag        *string
+}) ([]*subscriptionResolver, error) {
+	var filter service.SubscriptionFilterInput
+	if args.CategoryId != nil {
+		if *args.CategoryId < 1 {
+			return nil, errors.New("invalid category id")
+		}
+		filter.CategoryId = int(*args.CategoryId)
+	}
+	if args.Tag != nil {
+		filter.Tag = *args.Tag
+	}
+
+	subscriptions, err := r.services.Subscription.FindAll(ctx, filter)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	return newSubscriptionResolvers(subscriptions), nil
+}
+
+func (r *queryResolver) User(ctx context.Context, args struct{ Id string }) (*userResolver, error) {
+	u, err := r.services.User.FindById(ctx, args.Id)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, resolveError(err)
+	}
+	return &userResolver{u}, nil
+}
+
+func (r *queryResolver) Users(ctx context.Context) ([]*userResolver, error) {
+	users, err := r.services.User.FindAll(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	result := make([]*userResolver, 0, len(users))
+	for _, u := range users {
+		result = append(result, &userResolver{u})
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Service(ctx context.Context, args struct{ Id int32 }) (*serviceResolver, error) {
+	s, err := r.services.Catalog.FindById(ctx, int(args.Id))
+	if err != nil {
+		if errors.Is(err, service.ErrServiceNotFound) {
+			return nil, nil
+		}
+		return nil, resolveError(err)
+	}
+	return &serviceResolver{s}, nil
+}
+
+func (r *queryResolver) Services(ctx context.Context) ([]*serviceResolver, error) {
+	services, err := r.services.Catalog.FindAll(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	result := make([]*serviceResolver, 0, len(services))
+	for _, s := range services {
+		result = append(result, &serviceResolver{s})
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Spend(ctx context.Context, args struct {
+	Start       string
+	End         string
+	ServiceName *string
+	UserId      *string
+	CategoryId  *int32
+	Tag         *string
+	Source      *string
+}) (*priceResolver, error) {
+	start, end, err := parseInterval(args.Start, args.End)
+	if err != nil {
+		return nil, err
+	}
+	input := service.PriceInput{
+		StartDate: start,
+		EndDate:   end,
+		Source:    service.PriceSourceSubscriptions,
+	}
+	if args.ServiceName != nil {
+		input.ServiceName = *args.ServiceName
+	}
+	if args.UserId != nil {
+		input.UserId = *args.UserId
+	}
+	if args.CategoryId != nil {
+		if *args.CategoryId < 1 {
+			return nil, errors.New("invalid category id")
+		}
+		input.CategoryId = int(*args.CategoryId)
+	}
+	if args.Tag != nil {
+		input.Tag = *args.Tag
+	}
+	if args.Source != nil {
+		switch *args.Source {
+		case service.PriceSourceSubscriptions, service.PriceSourceLedger:
+			input.Source = *args.Source
+		default:
+			return nil, errors.New("invalid price source")
+		}
+	}
+
+	price, err := r.services.Subscription.FindPrice(ctx, input)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	return &priceResolver{price}, nil
+}
+
+type priceResolver struct {
+	p service.PriceOutput
+}
+
+func (r *priceResolver) Gross() int32    { return int32(r.p.Gross) }
+func (r *priceResolver) Net() int32      { return int32(r.p.Net) }
+func (r *priceResolver) Tax() int32      { return int32(r.p.Tax) }
+func (r *priceResolver) Discount() int32 { return int32(r.p.Discount) }
+
+// resolveError passes errors of invalid arguments to client and hides unexpected ones
+func resolveError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound),
+		errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, service.ErrSubscriptionNotFound),
+		errors.Is(err, service.ErrServiceNotFound),
+		errors.Is(err, service.ErrCategoryNotFound),
+		errors.Is(err, service.ErrInvalidTimezone):
+		return err
+	default:
+		return errInternal
+	}
+}
+
+// parseInterval parses yyyy-mm-dd start and inclusive end of interval
+func parseInterval(start, end string) (time.Time, time.Time, error) {
+	s, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid start date")
+	}
+	e, err := time.Parse(time.DateOnly, end)
+	if err != nil || e.Before(s) {
+		return time.Time{}, time.Time{}, errors.New("invalid end date")
+	}
+	return s, e, nil
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
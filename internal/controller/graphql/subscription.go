@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"context"
+	"subscription_service/internal/service"
+)
+
+type subscriptionResolver struct {
+	s service.SubscriptionOutput
+}
+
+func newSubscriptionResolvers(subscriptions []service.SubscriptionOutput) []*subscriptionResolver {
+	result := make([]*subscriptionResolver, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		result = append(result, &subscriptionResolver{s})
+	}
+	return result
+}
+
+func (r *subscriptionResolver) Id() int32             { return int32(r.s.Id) }
+func (r *subscriptionResolver) ServiceId() int32      { return int32(r.s.ServiceId) }
+func (r *subscriptionResolver) ServiceName() string   { return r.s.ServiceName }
+func (r *subscriptionResolver) Price() int32          { return int32(r.s.Price) }
+func (r *subscriptionResolver) UserId() string        { return r.s.UserId }
+func (r *subscriptionResolver) StartDate() string     { return r.s.StartDate }
+func (r *subscriptionResolver) EndDate() *string      { return r.s.EndDate }
+func (r *subscriptionResolver) BillingPeriod() string { return r.s.BillingPeriod }
+func (r *subscriptionResolver) CategoryId() *int32    { return int32Ptr(r.s.CategoryId) }
+func (r *subscriptionResolver) TrialEndDate() *string { return r.s.TrialEndDate }
+func (r *subscriptionResolver) IntroPrice() *int32    { return int32Ptr(r.s.IntroPrice) }
+func (r *subscriptionResolver) IntroPeriods() int32   { return int32(r.s.IntroPeriods) }
+func (r *subscriptionResolver) TaxRate() int32        { return int32(r.s.TaxRate) }
+func (r *subscriptionResolver) TaxInclusive() bool    { return r.s.TaxInclusive }
+func (r *subscriptionResolver) LastUsedDate() *string { return r.s.LastUsedDate }
+func (r *subscriptionResolver) Status() string        { return r.s.Status }
+func (r *subscriptionResolver) CancelReason() *string { return r.s.CancelReason }
+
+func (r *subscriptionResolver) Tags() []string {
+	if r.s.Tags == nil {
+		return []string{}
+	}
+	return r.s.Tags
+}
+
+func (r *subscriptionResolver) User(ctx context.Context) (*userResolver, error) {
+	u, err := loadersFrom(ctx).users.Load(ctx, r.s.UserId)()
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	if u == nil {
+		return nil, nil
+	}
+	return &userResolver{*u}, nil
+}
+
+func (r *subscriptionResolver) Service(ctx context.Context) (*serviceResolver, error) {
+	s, err := loadersFrom(ctx).services.Load(ctx, r.s.ServiceId)()
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	if s == nil {
+		return nil, nil
+	}
+	return &serviceResolver{*s}, nil
+}
@@ -0,0 +1,39 @@
+package graphql
+
+import (
+	"context"
+	"subscription_service/internal/service"
+)
+
+type userResolver struct {
+	u service.UserOutput
+}
+
+func (r *userResolver) Id() string           { return r.u.Id }
+func (r *userResolver) Email() *string       { return r.u.Email }
+func (r *userResolver) DisplayName() *string { return r.u.DisplayName }
+func (r *userResolver) Timezone() string     { return r.u.Timezone }
+func (r *userResolver) Currency() string     { return r.u.Currency }
+
+func (r *userResolver) Subscriptions(ctx context.Context) ([]*subscriptionResolver, error) {
+	subscriptions, err := loadersFrom(ctx).userSubscriptions.Load(ctx, r.u.Id)()
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	return newSubscriptionResolvers(subscriptions), nil
+}
+
+func (r *userResolver) Spend(ctx context.Context, args struct {
+	Start string
+	End   string
+}) (*priceResolver, error) {
+	start, end, err := parseInterval(args.Start, args.End)
+	if err != nil {
+		return nil, err
+	}
+	price, err := loadersFrom(ctx).userSpend.Load(ctx, spendKey{userId: r.u.Id, start: start, end: end})()
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	return &priceResolver{price}, nil
+}
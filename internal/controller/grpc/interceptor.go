@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"subscription_service/internal/controller/grpc/pb"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/tenant"
+)
+
+// tenantKey is metadata key of organization, the same as X-Tenant header of HTTP API
+const tenantKey = "x-tenant"
+
+// errInvalidArgument is returned by handlers for requests failed to parse or validate
+var errInvalidArgument = status.Error(codes.InvalidArgument, "invalid argument")
+
+// recoverInterceptor turns panic of handler into INTERNAL status like Recover middleware of HTTP API
+func recoverInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Interface("panic", r).Str("method", info.FullMethod).Msg("grpc/recover panic in handler")
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// tenantInterceptor binds call to organization from x-tenant metadata.
+// Calls without tenant work with default organization. Like X-Tenant header of HTTP API the metadata is trusted
+// as is, so it must be set by proxy which authenticates callers
+func tenantInterceptor(organization service.Organization) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := tenant.DefaultOrganization
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(tenantKey); len(values) > 0 && values[0] != "" {
+			var err error
+
+			id, err = strconv.Atoi(values[0])
+			if err != nil || id < 1 {
+				return nil, errInvalidArgument
+			}
+			if _, err = organization.FindById(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(tenant.WithOrganization(ctx, id), req)
+	}
+}
+
+// errorInterceptor maps service errors to status codes the same way errorMiddleware of HTTP API maps them
+// to HTTP statuses. Conflicts with existing entities are ALREADY_EXISTS, conflicts with state of entity
+// are FAILED_PRECONDITION
+func errorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	return nil, toStatus(err).Err()
+}
+
+func toStatus(err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	// client needs conflicting subscriptions to resolve duplicate
+	var duplicate *service.DuplicateError
+	if errors.As(err, &duplicate) {
+		ids := make([]int64, 0, len(duplicate.Ids))
+		for _, id := range duplicate.Ids {
+			ids = append(ids, int64(id))
+		}
+		s := status.New(codes.AlreadyExists, duplicate.Error())
+		if d, err := s.WithDetails(&pb.DuplicateSubscriptions{SubscriptionIds: ids}); err == nil {
+			return d
+		}
+		return s
+	}
+
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound),
+		errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, service.ErrSubscriptionNotFound),
+		errors.Is(err, service.ErrShareNotFound),
+		errors.Is(err, service.ErrWebhookNotFound),
+		errors.Is(err, service.ErrWebhookDeliveryNotFound),
+		errors.Is(err, service.ErrBudgetNotFound),
+		errors.Is(err, service.ErrChargeNotFound),
+		errors.Is(err, service.ErrPriceChangeNotFound),
+		errors.Is(err, service.ErrServiceNotFound),
+		errors.Is(err, service.ErrCategoryNotFound):
+		return status.New(codes.NotFound, err.Error())
+
+	case errors.Is(err, service.ErrOrganizationAlreadyExists),
+		errors.Is(err, service.ErrUserAlreadyExists),
+		errors.Is(err, service.ErrServiceAlreadyExists),
+		errors.Is(err, service.ErrCategoryAlreadyExists):
+		return status.New(codes.AlreadyExists, err.Error())
+
+	case errors.Is(err, service.ErrUserInUse),
+		errors.Is(err, service.ErrServiceInUse),
+		errors.Is(err, service.ErrCategoryInUse),
+		errors.Is(err, service.ErrSubscriptionPaused),
+		errors.Is(err, service.ErrSubscriptionNotPaused),
+		errors.Is(err, service.ErrSubscriptionEnded),
+		errors.Is(err, service.ErrSubscriptionNotCancelled),
+		errors.Is(err, service.ErrInvalidChargeStatus):
+		return status.New(codes.FailedPrecondition, err.Error())
+
+	case errors.Is(err, service.ErrInvalidTimezone),
+		errors.Is(err, service.ErrInvalidShare),
+		errors.Is(err, service.ErrInvalidPriceChange),
+		errors.Is(err, service.ErrInvalidTrial),
+		errors.Is(err, service.ErrInvalidDiscount),
+		errors.Is(err, service.ErrInvalidPause),
+		errors.Is(err, service.ErrInvalidCancellation),
+		errors.Is(err, service.ErrInvalidCategoryParent):
+		return status.New(codes.InvalidArgument, err.Error())
+
+	case errors.Is(err, service.ErrInvalidCalendarToken):
+		return status.New(codes.PermissionDenied, err.Error())
+
+	default:
+		// unexpected errors are logged by services, details are not exposed to client
+		return status.New(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,1249 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: subscription.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubscriptionInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// service is set by service_id or by service_name, unknown name is added to catalog
+	ServiceId   int64   `protobuf:"varint,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	ServiceName string  `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Price       int64   `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	UserId      string  `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate   string  `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate     *string `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	// monthly (default) or yearly
+	BillingPeriod string   `protobuf:"bytes,7,opt,name=billing_period,json=billingPeriod,proto3" json:"billing_period,omitempty"`
+	CategoryId    *int64   `protobuf:"varint,8,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Tags          []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	TrialEndDate  *string  `protobuf:"bytes,10,opt,name=trial_end_date,json=trialEndDate,proto3,oneof" json:"trial_end_date,omitempty"`
+	IntroPrice    *int64   `protobuf:"varint,11,opt,name=intro_price,json=introPrice,proto3,oneof" json:"intro_price,omitempty"`
+	IntroPeriods  int64    `protobuf:"varint,12,opt,name=intro_periods,json=introPeriods,proto3" json:"intro_periods,omitempty"`
+	// tax_rate is percent, tax is added on top of price unless tax_inclusive
+	TaxRate       int64     `protobuf:"varint,13,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	TaxInclusive  bool      `protobuf:"varint,14,opt,name=tax_inclusive,json=taxInclusive,proto3" json:"tax_inclusive,omitempty"`
+	Discount      *Discount `protobuf:"bytes,15,opt,name=discount,proto3" json:"discount,omitempty"`
+	LastUsedDate  *string   `protobuf:"bytes,16,opt,name=last_used_date,json=lastUsedDate,proto3,oneof" json:"last_used_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriptionInput) Reset() {
+	*x = SubscriptionInput{}
+	mi := &file_subscription_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionInput) ProtoMessage() {}
+
+func (x *SubscriptionInput) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionInput.ProtoReflect.Descriptor instead.
+func (*SubscriptionInput) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscriptionInput) GetServiceId() int64 {
+	if x != nil {
+		return x.ServiceId
+	}
+	return 0
+}
+
+func (x *SubscriptionInput) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *SubscriptionInput) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *SubscriptionInput) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubscriptionInput) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *SubscriptionInput) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *SubscriptionInput) GetBillingPeriod() string {
+	if x != nil {
+		return x.BillingPeriod
+	}
+	return ""
+}
+
+func (x *SubscriptionInput) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *SubscriptionInput) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SubscriptionInput) GetTrialEndDate() string {
+	if x != nil && x.TrialEndDate != nil {
+		return *x.TrialEndDate
+	}
+	return ""
+}
+
+func (x *SubscriptionInput) GetIntroPrice() int64 {
+	if x != nil && x.IntroPrice != nil {
+		return *x.IntroPrice
+	}
+	return 0
+}
+
+func (x *SubscriptionInput) GetIntroPeriods() int64 {
+	if x != nil {
+		return x.IntroPeriods
+	}
+	return 0
+}
+
+func (x *SubscriptionInput) GetTaxRate() int64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *SubscriptionInput) GetTaxInclusive() bool {
+	if x != nil {
+		return x.TaxInclusive
+	}
+	return false
+}
+
+func (x *SubscriptionInput) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *SubscriptionInput) GetLastUsedDate() string {
+	if x != nil && x.LastUsedDate != nil {
+		return *x.LastUsedDate
+	}
+	return ""
+}
+
+// Discount is percent or fixed discount from start_date (start of subscription if empty) for months, forever if 0
+type Discount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// percent or fixed
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value         int64  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	StartDate     string `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	Months        int64  `protobuf:"varint,4,opt,name=months,proto3" json:"months,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Discount) Reset() {
+	*x = Discount{}
+	mi := &file_subscription_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Discount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discount) ProtoMessage() {}
+
+func (x *Discount) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discount.ProtoReflect.Descriptor instead.
+func (*Discount) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Discount) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Discount) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Discount) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Discount) GetMonths() int64 {
+	if x != nil {
+		return x.Months
+	}
+	return 0
+}
+
+type Pause struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *string                `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pause) Reset() {
+	*x = Pause{}
+	mi := &file_subscription_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pause) ProtoMessage() {}
+
+func (x *Pause) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pause.ProtoReflect.Descriptor instead.
+func (*Pause) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Pause) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Pause) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+type Subscription struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceId     int64                  `protobuf:"varint,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Price         int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate     string                 `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *string                `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	BillingPeriod string                 `protobuf:"bytes,8,opt,name=billing_period,json=billingPeriod,proto3" json:"billing_period,omitempty"`
+	CategoryId    *int64                 `protobuf:"varint,9,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	TrialEndDate  *string                `protobuf:"bytes,11,opt,name=trial_end_date,json=trialEndDate,proto3,oneof" json:"trial_end_date,omitempty"`
+	IntroPrice    *int64                 `protobuf:"varint,12,opt,name=intro_price,json=introPrice,proto3,oneof" json:"intro_price,omitempty"`
+	IntroPeriods  int64                  `protobuf:"varint,13,opt,name=intro_periods,json=introPeriods,proto3" json:"intro_periods,omitempty"`
+	TaxRate       int64                  `protobuf:"varint,14,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	TaxInclusive  bool                   `protobuf:"varint,15,opt,name=tax_inclusive,json=taxInclusive,proto3" json:"tax_inclusive,omitempty"`
+	Discount      *Discount              `protobuf:"bytes,16,opt,name=discount,proto3" json:"discount,omitempty"`
+	LastUsedDate  *string                `protobuf:"bytes,17,opt,name=last_used_date,json=lastUsedDate,proto3,oneof" json:"last_used_date,omitempty"`
+	// active, paused, cancelled or scheduled on current date of user
+	Status        string                 `protobuf:"bytes,18,opt,name=status,proto3" json:"status,omitempty"`
+	Pauses        []*Pause               `protobuf:"bytes,19,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	CancelReason  *string                `protobuf:"bytes,20,opt,name=cancel_reason,json=cancelReason,proto3,oneof" json:"cancel_reason,omitempty"`
+	CancelledAt   *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_subscription_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Subscription) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Subscription) GetServiceId() int64 {
+	if x != nil {
+		return x.ServiceId
+	}
+	return 0
+}
+
+func (x *Subscription) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Subscription) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Subscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetBillingPeriod() string {
+	if x != nil {
+		return x.BillingPeriod
+	}
+	return ""
+}
+
+func (x *Subscription) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *Subscription) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Subscription) GetTrialEndDate() string {
+	if x != nil && x.TrialEndDate != nil {
+		return *x.TrialEndDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetIntroPrice() int64 {
+	if x != nil && x.IntroPrice != nil {
+		return *x.IntroPrice
+	}
+	return 0
+}
+
+func (x *Subscription) GetIntroPeriods() int64 {
+	if x != nil {
+		return x.IntroPeriods
+	}
+	return 0
+}
+
+func (x *Subscription) GetTaxRate() int64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *Subscription) GetTaxInclusive() bool {
+	if x != nil {
+		return x.TaxInclusive
+	}
+	return false
+}
+
+func (x *Subscription) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Subscription) GetLastUsedDate() string {
+	if x != nil && x.LastUsedDate != nil {
+		return *x.LastUsedDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Subscription) GetPauses() []*Pause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+func (x *Subscription) GetCancelReason() string {
+	if x != nil && x.CancelReason != nil {
+		return *x.CancelReason
+	}
+	return ""
+}
+
+func (x *Subscription) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+type CreateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Subscription   *SubscriptionInput     `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	AllowDuplicate bool                   `protobuf:"varint,2,opt,name=allow_duplicate,json=allowDuplicate,proto3" json:"allow_duplicate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_subscription_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateRequest) GetSubscription() *SubscriptionInput {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetAllowDuplicate() bool {
+	if x != nil {
+		return x.AllowDuplicate
+	}
+	return false
+}
+
+type FindByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindByIdRequest) Reset() {
+	*x = FindByIdRequest{}
+	mi := &file_subscription_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByIdRequest) ProtoMessage() {}
+
+func (x *FindByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByIdRequest.ProtoReflect.Descriptor instead.
+func (*FindByIdRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FindByIdRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type FindAllRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// category_id includes subcategories, zero and empty fields are not applied
+	CategoryId int64  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Tag        string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	// page_size is 50 by default, 1000 at most
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is next_page_token of previous page, the first page if empty
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindAllRequest) Reset() {
+	*x = FindAllRequest{}
+	mi := &file_subscription_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindAllRequest) ProtoMessage() {}
+
+func (x *FindAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindAllRequest.ProtoReflect.Descriptor instead.
+func (*FindAllRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FindAllRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *FindAllRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *FindAllRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *FindAllRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type FindAllResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	// next_page_token is empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindAllResponse) Reset() {
+	*x = FindAllResponse{}
+	mi := &file_subscription_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindAllResponse) ProtoMessage() {}
+
+func (x *FindAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindAllResponse.ProtoReflect.Descriptor instead.
+func (*FindAllResponse) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FindAllResponse) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *FindAllResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type FindPriceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name or alias of subscription service
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// only part of price paid by user is counted for shared subscriptions
+	UserId     string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CategoryId int64  `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Tag        string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	StartDate  string `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate    string `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// subscriptions (default) sums prices of subscriptions active in the interval,
+	// ledger sums pending and paid charges billed in it
+	Source        string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindPriceRequest) Reset() {
+	*x = FindPriceRequest{}
+	mi := &file_subscription_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPriceRequest) ProtoMessage() {}
+
+func (x *FindPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPriceRequest.ProtoReflect.Descriptor instead.
+func (*FindPriceRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FindPriceRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *FindPriceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FindPriceRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *FindPriceRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *FindPriceRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *FindPriceRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *FindPriceRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// Price is amount paid for subscriptions: gross with tax, net without it. Discount is already subtracted
+type Price struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gross         int64                  `protobuf:"varint,1,opt,name=gross,proto3" json:"gross,omitempty"`
+	Net           int64                  `protobuf:"varint,2,opt,name=net,proto3" json:"net,omitempty"`
+	Tax           int64                  `protobuf:"varint,3,opt,name=tax,proto3" json:"tax,omitempty"`
+	Discount      int64                  `protobuf:"varint,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Price) Reset() {
+	*x = Price{}
+	mi := &file_subscription_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Price) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Price) ProtoMessage() {}
+
+func (x *Price) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Price.ProtoReflect.Descriptor instead.
+func (*Price) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Price) GetGross() int64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *Price) GetNet() int64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+func (x *Price) GetTax() int64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *Price) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+type UpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Subscription  *SubscriptionInput     `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_subscription_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRequest) GetSubscription() *SubscriptionInput {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_subscription_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// DuplicateSubscriptions is detail of ALREADY_EXISTS status of Create with conflicting subscriptions
+type DuplicateSubscriptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionIds []int64                `protobuf:"varint,1,rep,packed,name=subscription_ids,json=subscriptionIds,proto3" json:"subscription_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DuplicateSubscriptions) Reset() {
+	*x = DuplicateSubscriptions{}
+	mi := &file_subscription_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateSubscriptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateSubscriptions) ProtoMessage() {}
+
+func (x *DuplicateSubscriptions) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateSubscriptions.ProtoReflect.Descriptor instead.
+func (*DuplicateSubscriptions) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DuplicateSubscriptions) GetSubscriptionIds() []int64 {
+	if x != nil {
+		return x.SubscriptionIds
+	}
+	return nil
+}
+
+var File_subscription_proto protoreflect.FileDescriptor
+
+const file_subscription_proto_rawDesc = "" +
+	"\n" +
+	"\x12subscription.proto\x12\x0fsubscription.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8f\x05\n" +
+	"\x11SubscriptionInput\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\x03R\tserviceId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x03R\x05price\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tR\tstartDate\x12\x1e\n" +
+	"\bend_date\x18\x06 \x01(\tH\x00R\aendDate\x88\x01\x01\x12%\n" +
+	"\x0ebilling_period\x18\a \x01(\tR\rbillingPeriod\x12$\n" +
+	"\vcategory_id\x18\b \x01(\x03H\x01R\n" +
+	"categoryId\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12)\n" +
+	"\x0etrial_end_date\x18\n" +
+	" \x01(\tH\x02R\ftrialEndDate\x88\x01\x01\x12$\n" +
+	"\vintro_price\x18\v \x01(\x03H\x03R\n" +
+	"introPrice\x88\x01\x01\x12#\n" +
+	"\rintro_periods\x18\f \x01(\x03R\fintroPeriods\x12\x19\n" +
+	"\btax_rate\x18\r \x01(\x03R\ataxRate\x12#\n" +
+	"\rtax_inclusive\x18\x0e \x01(\bR\ftaxInclusive\x125\n" +
+	"\bdiscount\x18\x0f \x01(\v2\x19.subscription.v1.DiscountR\bdiscount\x12)\n" +
+	"\x0elast_used_date\x18\x10 \x01(\tH\x04R\flastUsedDate\x88\x01\x01B\v\n" +
+	"\t_end_dateB\x0e\n" +
+	"\f_category_idB\x11\n" +
+	"\x0f_trial_end_dateB\x0e\n" +
+	"\f_intro_priceB\x11\n" +
+	"\x0f_last_used_date\"k\n" +
+	"\bDiscount\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x16\n" +
+	"\x06months\x18\x04 \x01(\x03R\x06months\"S\n" +
+	"\x05Pause\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x1e\n" +
+	"\bend_date\x18\x02 \x01(\tH\x00R\aendDate\x88\x01\x01B\v\n" +
+	"\t_end_date\"\xdd\x06\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\x03R\tserviceId\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x06 \x01(\tR\tstartDate\x12\x1e\n" +
+	"\bend_date\x18\a \x01(\tH\x00R\aendDate\x88\x01\x01\x12%\n" +
+	"\x0ebilling_period\x18\b \x01(\tR\rbillingPeriod\x12$\n" +
+	"\vcategory_id\x18\t \x01(\x03H\x01R\n" +
+	"categoryId\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12)\n" +
+	"\x0etrial_end_date\x18\v \x01(\tH\x02R\ftrialEndDate\x88\x01\x01\x12$\n" +
+	"\vintro_price\x18\f \x01(\x03H\x03R\n" +
+	"introPrice\x88\x01\x01\x12#\n" +
+	"\rintro_periods\x18\r \x01(\x03R\fintroPeriods\x12\x19\n" +
+	"\btax_rate\x18\x0e \x01(\x03R\ataxRate\x12#\n" +
+	"\rtax_inclusive\x18\x0f \x01(\bR\ftaxInclusive\x125\n" +
+	"\bdiscount\x18\x10 \x01(\v2\x19.subscription.v1.DiscountR\bdiscount\x12)\n" +
+	"\x0elast_used_date\x18\x11 \x01(\tH\x04R\flastUsedDate\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x12 \x01(\tR\x06status\x12.\n" +
+	"\x06pauses\x18\x13 \x03(\v2\x16.subscription.v1.PauseR\x06pauses\x12(\n" +
+	"\rcancel_reason\x18\x14 \x01(\tH\x05R\fcancelReason\x88\x01\x01\x12=\n" +
+	"\fcancelled_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAtB\v\n" +
+	"\t_end_dateB\x0e\n" +
+	"\f_category_idB\x11\n" +
+	"\x0f_trial_end_dateB\x0e\n" +
+	"\f_intro_priceB\x11\n" +
+	"\x0f_last_used_dateB\x10\n" +
+	"\x0e_cancel_reason\"\x80\x01\n" +
+	"\rCreateRequest\x12F\n" +
+	"\fsubscription\x18\x01 \x01(\v2\".subscription.v1.SubscriptionInputR\fsubscription\x12'\n" +
+	"\x0fallow_duplicate\x18\x02 \x01(\bR\x0eallowDuplicate\"!\n" +
+	"\x0fFindByIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x7f\n" +
+	"\x0eFindAllRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"~\n" +
+	"\x0fFindAllResponse\x12C\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1d.subscription.v1.SubscriptionR\rsubscriptions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd3\x01\n" +
+	"\x10FindPriceRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\x03R\n" +
+	"categoryId\x12\x10\n" +
+	"\x03tag\x18\x04 \x01(\tR\x03tag\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x06 \x01(\tR\aendDate\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\"]\n" +
+	"\x05Price\x12\x14\n" +
+	"\x05gross\x18\x01 \x01(\x03R\x05gross\x12\x10\n" +
+	"\x03net\x18\x02 \x01(\x03R\x03net\x12\x10\n" +
+	"\x03tax\x18\x03 \x01(\x03R\x03tax\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x03R\bdiscount\"g\n" +
+	"\rUpdateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12F\n" +
+	"\fsubscription\x18\x02 \x01(\v2\".subscription.v1.SubscriptionInputR\fsubscription\"\x1f\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"C\n" +
+	"\x16DuplicateSubscriptions\x12)\n" +
+	"\x10subscription_ids\x18\x01 \x03(\x03R\x0fsubscriptionIds2\xbe\x03\n" +
+	"\x13SubscriptionService\x12@\n" +
+	"\x06Create\x12\x1e.subscription.v1.CreateRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\bFindById\x12 .subscription.v1.FindByIdRequest\x1a\x1d.subscription.v1.Subscription\x12L\n" +
+	"\aFindAll\x12\x1f.subscription.v1.FindAllRequest\x1a .subscription.v1.FindAllResponse\x12F\n" +
+	"\tFindPrice\x12!.subscription.v1.FindPriceRequest\x1a\x16.subscription.v1.Price\x12@\n" +
+	"\x06Update\x12\x1e.subscription.v1.UpdateRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\x06Delete\x12\x1e.subscription.v1.DeleteRequest\x1a\x16.google.protobuf.EmptyB2Z0subscription_service/internal/controller/grpc/pbb\x06proto3"
+
+var (
+	file_subscription_proto_rawDescOnce sync.Once
+	file_subscription_proto_rawDescData []byte
+)
+
+func file_subscription_proto_rawDescGZIP() []byte {
+	file_subscription_proto_rawDescOnce.Do(func() {
+		file_subscription_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_subscription_proto_rawDesc), len(file_subscription_proto_rawDesc)))
+	})
+	return file_subscription_proto_rawDescData
+}
+
+var file_subscription_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_subscription_proto_goTypes = []any{
+	(*SubscriptionInput)(nil),      // 0: subscription.v1.SubscriptionInput
+	(*Discount)(nil),               // 1: subscription.v1.Discount
+	(*Pause)(nil),                  // 2: subscription.v1.Pause
+	(*Subscription)(nil),           // 3: subscription.v1.Subscription
+	(*CreateRequest)(nil),          // 4: subscription.v1.CreateRequest
+	(*FindByIdRequest)(nil),        // 5: subscription.v1.FindByIdRequest
+	(*FindAllRequest)(nil),         // 6: subscription.v1.FindAllRequest
+	(*FindAllResponse)(nil),        // 7: subscription.v1.FindAllResponse
+	(*FindPriceRequest)(nil),       // 8: subscription.v1.FindPriceRequest
+	(*Price)(nil),                  // 9: subscription.v1.Price
+	(*UpdateRequest)(nil),          // 10: subscription.v1.UpdateRequest
+	(*DeleteRequest)(nil),          // 11: subscription.v1.DeleteRequest
+	(*DuplicateSubscriptions)(nil), // 12: subscription.v1.DuplicateSubscriptions
+	(*timestamppb.Timestamp)(nil),  // 13: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 14: google.protobuf.Empty
+}
+var file_subscription_proto_depIdxs = []int32{
+	1,  // 0: subscription.v1.SubscriptionInput.discount:type_name -> subscription.v1.Discount
+	1,  // 1: subscription.v1.Subscription.discount:type_name -> subscription.v1.Discount
+	2,  // 2: subscription.v1.Subscription.pauses:type_name -> subscription.v1.Pause
+	13, // 3: subscription.v1.Subscription.cancelled_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: subscription.v1.CreateRequest.subscription:type_name -> subscription.v1.SubscriptionInput
+	3,  // 5: subscription.v1.FindAllResponse.subscriptions:type_name -> subscription.v1.Subscription
+	0,  // 6: subscription.v1.UpdateRequest.subscription:type_name -> subscription.v1.SubscriptionInput
+	4,  // 7: subscription.v1.SubscriptionService.Create:input_type -> subscription.v1.CreateRequest
+	5,  // 8: subscription.v1.SubscriptionService.FindById:input_type -> subscription.v1.FindByIdRequest
+	6,  // 9: subscription.v1.SubscriptionService.FindAll:input_type -> subscription.v1.FindAllRequest
+	8,  // 10: subscription.v1.SubscriptionService.FindPrice:input_type -> subscription.v1.FindPriceRequest
+	10, // 11: subscription.v1.SubscriptionService.Update:input_type -> subscription.v1.UpdateRequest
+	11, // 12: subscription.v1.SubscriptionService.Delete:input_type -> subscription.v1.DeleteRequest
+	14, // 13: subscription.v1.SubscriptionService.Create:output_type -> google.protobuf.Empty
+	3,  // 14: subscription.v1.SubscriptionService.FindById:output_type -> subscription.v1.Subscription
+	7,  // 15: subscription.v1.SubscriptionService.FindAll:output_type -> subscription.v1.FindAllResponse
+	9,  // 16: subscription.v1.SubscriptionService.FindPrice:output_type -> subscription.v1.Price
+	14, // 17: subscription.v1.SubscriptionService.Update:output_type -> google.protobuf.Empty
+	14, // 18: subscription.v1.SubscriptionService.Delete:output_type -> google.protobuf.Empty
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_subscription_proto_init() }
+func file_subscription_proto_init() {
+	if File_subscription_proto != nil {
+		return
+	}
+	file_subscription_proto_msgTypes[0].OneofWrappers = []any{}
+	file_subscription_proto_msgTypes[2].OneofWrappers = []any{}
+	file_subscription_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subscription_proto_rawDesc), len(file_subscription_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_subscription_proto_goTypes,
+		DependencyIndexes: file_subscription_proto_depIdxs,
+		MessageInfos:      file_subscription_proto_msgTypes,
+	}.Build()
+	File_subscription_proto = out.File
+	file_subscription_proto_goTypes = nil
+	file_subscription_proto_depIdxs = nil
+}
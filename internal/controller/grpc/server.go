@@ -0,0 +1,23 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+	"subscription_service/internal/controller/grpc/pb"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+)
+
+// NewServer returns gRPC server with the same services as HTTP API, see pb/subscription.proto
+func NewServer(services *service.Services, v validator.Validator) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recoverInterceptor,
+			errorInterceptor,
+			tenantInterceptor(services.Organization),
+		),
+	)
+
+	pb.RegisterSubscriptionServiceServer(s, newSubscriptionServer(services.Subscription, v))
+
+	return s
+}
@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"subscription_service/internal/controller/grpc/pb"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/tenant"
+	"subscription_service/pkg/validator"
+	"testing"
+)
+
+// newTestClient serves services in memory and returns client connected to them
+func newTestClient(t *testing.T, services *service.Services) pb.SubscriptionServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+
+	s := NewServer(services, validator.NewValidator())
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewSubscriptionServiceClient(conn)
+}
+
+// inOrganization matches context bound to organization
+type inOrganization int
+
+func (m inOrganization) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	id, ok := tenant.Organization(ctx)
+	return ok && id == int(m)
+}
+
+func (m inOrganization) String() string {
+	return fmt.Sprintf("context of organization %d", int(m))
+}
+
+func TestTenantInterceptor(t *testing.T) {
+	type mockBehaviour func(o *servicemocks.MockOrganization, sub *servicemocks.MockSubscription)
+
+	testCases := []struct {
+		testName      string
+		tenant        string
+		mockBehaviour mockBehaviour
+		expectCode    codes.Code
+	}{
+		{
+			testName: "default organization",
+			mockBehaviour: func(o *servicemocks.MockOrganization, sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Delete(inOrganization(tenant.DefaultOrganization), 1).Return(nil)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "organization from metadata",
+			tenant:   "2",
+			mockBehaviour: func(o *servicemocks.MockOrganization, sub *servicemocks.MockSubscription) {
+				o.EXPECT().FindById(gomock.Any(), 2).Return(service.OrganizationOutput{Id: 2}, nil)
+				sub.EXPECT().Delete(inOrganization(2), 1).Return(nil)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "unknown organization",
+			tenant:   "5",
+			mockBehaviour: func(o *servicemocks.MockOrganization, sub *servicemocks.MockSubscription) {
+				o.EXPECT().FindById(gomock.Any(), 5).Return(service.OrganizationOutput{}, service.ErrOrganizationNotFound)
+			},
+			expectCode: codes.NotFound,
+		},
+		{
+			testName:      "invalid organization",
+			tenant:        "abc",
+			mockBehaviour: func(o *servicemocks.MockOrganization, sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName:      "zero organization",
+			tenant:        "0",
+			mockBehaviour: func(o *servicemocks.MockOrganization, sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			o := servicemocks.NewMockOrganization(ctrl)
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(o, sub)
+
+			client := newTestClient(t, &service.Services{Organization: o, Subscription: sub})
+
+			ctx := context.Background()
+			if tc.tenant != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, tenantKey, tc.tenant)
+			}
+			_, err := client.Delete(ctx, &pb.DeleteRequest{Id: 1})
+			assert.Equal(t, tc.expectCode, status.Code(err))
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	testCases := []struct {
+		testName   string
+		err        error
+		expectCode codes.Code
+	}{
+		{
+			testName:   "not found",
+			err:        service.ErrSubscriptionNotFound,
+			expectCode: codes.NotFound,
+		},
+		{
+			testName:   "wrapped not found",
+			err:        fmt.Errorf("find: %w", service.ErrCategoryNotFound),
+			expectCode: codes.NotFound,
+		},
+		{
+			testName:   "already exists",
+			err:        service.ErrServiceAlreadyExists,
+			expectCode: codes.AlreadyExists,
+		},
+		{
+			testName:   "state conflict",
+			err:        service.ErrSubscriptionPaused,
+			expectCode: codes.FailedPrecondition,
+		},
+		{
+			testName:   "invalid argument",
+			err:        service.ErrInvalidDiscount,
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			testName:   "permission denied",
+			err:        service.ErrInvalidCalendarToken,
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			testName:   "status is kept",
+			err:        errInvalidArgument,
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			testName:   "unexpected error",
+			err:        errors.New("some error"),
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expectCode, toStatus(tc.err).Code())
+		})
+	}
+}
+
+func TestToStatus_duplicate(t *testing.T) {
+	s := toStatus(&service.DuplicateError{Ids: []int{3, 7}})
+	assert.Equal(t, codes.AlreadyExists, s.Code())
+
+	details := s.Details()
+	require.Len(t, details, 1)
+	assert.Equal(t, []int64{3, 7}, details[0].(*pb.DuplicateSubscriptions).GetSubscriptionIds())
+}
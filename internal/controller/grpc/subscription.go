@@ -0,0 +1,302 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"subscription_service/internal/controller/grpc/pb"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"time"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+type subscriptionServer struct {
+	pb.UnimplementedSubscriptionServiceServer
+
+	sub service.Subscription
+	v   validator.Validator
+}
+
+func newSubscriptionServer(sub service.Subscription, v validator.Validator) *subscriptionServer {
+	return &subscriptionServer{
+		sub: sub,
+		v:   v,
+	}
+}
+
+// subscriptionInput is validated the same way as request body of HTTP API
+type subscriptionInput struct {
+	ServiceId     int      `validate:"omitempty,min=1"`
+	ServiceName   string   `validate:"required_without=ServiceId"`
+	Price         int      `validate:"required"`
+	UserId        string   `validate:"required,uuid4"`
+	StartDate     string   `validate:"required"`
+	BillingPeriod string   `validate:"omitempty,oneof=monthly yearly"`
+	CategoryId    *int     `validate:"omitempty,min=1"`
+	Tags          []string `validate:"max=20,dive,required,max=50"`
+	IntroPrice    *int     `validate:"omitempty,min=0"`
+	IntroPeriods  int      `validate:"min=0"`
+	TaxRate       int      `validate:"min=0,max=100"`
+	Discount      *discountInput
+}
+
+type discountInput struct {
+	Type   string `validate:"required,oneof=percent fixed"`
+	Value  int    `validate:"required,min=1"`
+	Months int    `validate:"min=0"`
+}
+
+func (s *subscriptionServer) Create(ctx context.Context, req *pb.CreateRequest) (*emptypb.Empty, error) {
+	input, err := s.parseInput(req.GetSubscription())
+	if err != nil {
+		return nil, errInvalidArgument
+	}
+	input.AllowDuplicate = req.GetAllowDuplicate()
+
+	if err = s.sub.Create(ctx, input); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *subscriptionServer) FindById(ctx context.Context, req *pb.FindByIdRequest) (*pb.Subscription, error) {
+	sub, err := s.sub.FindById(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return newSubscription(sub), nil
+}
+
+// FindAll pages subscriptions by id, page token is id of the last subscription of previous page. One subscription
+// more than page size is loaded to know whether the next page exists
+func (s *subscriptionServer) FindAll(ctx context.Context, req *pb.FindAllRequest) (*pb.FindAllResponse, error) {
+	if req.GetCategoryId() < 0 || req.GetPageSize() < 0 || req.GetPageSize() > maxPageSize {
+		return nil, errInvalidArgument
+	}
+	size := int(req.GetPageSize())
+	if size == 0 {
+		size = defaultPageSize
+	}
+	var after int
+	if token := req.GetPageToken(); token != "" {
+		var err error
+		if after, err = strconv.Atoi(token); err != nil || after < 1 {
+			return nil, errInvalidArgument
+		}
+	}
+
+	subscriptions, err := s.sub.FindAll(ctx, service.SubscriptionFilterInput{
+		CategoryId: int(req.GetCategoryId()),
+		Tag:        req.GetTag(),
+		AfterId:    after,
+		Limit:      size + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.FindAllResponse{Subscriptions: []*pb.Subscription{}}
+	if len(subscriptions) > size {
+		subscriptions = subscriptions[:size]
+		resp.NextPageToken = strconv.Itoa(subscriptions[size-1].Id)
+	}
+	for _, sub := range subscriptions {
+		resp.Subscriptions = append(resp.Subscriptions, newSubscription(sub))
+	}
+	return resp, nil
+}
+
+func (s *subscriptionServer) FindPrice(ctx context.Context, req *pb.FindPriceRequest) (*pb.Price, error) {
+	if req.GetCategoryId() < 0 {
+		return nil, errInvalidArgument
+	}
+	start, err := parseDate(req.GetStartDate())
+	if err != nil {
+		return nil, errInvalidArgument
+	}
+	end, err := parseDate(req.GetEndDate())
+	if err != nil {
+		return nil, errInvalidArgument
+	}
+	source := req.GetSource()
+	switch source {
+	case "":
+		source = service.PriceSourceSubscriptions
+	case service.PriceSourceSubscriptions, service.PriceSourceLedger:
+	default:
+		return nil, errInvalidArgument
+	}
+
+	price, err := s.sub.FindPrice(ctx, service.PriceInput{
+		ServiceName: req.GetServiceName(),
+		UserId:      req.GetUserId(),
+		CategoryId:  int(req.GetCategoryId()),
+		Tag:         req.GetTag(),
+		StartDate:   start,
+		EndDate:     end,
+		Source:      source,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Price{
+		Gross:    int64(price.Gross),
+		Net:      int64(price.Net),
+		Tax:      int64(price.Tax),
+		Discount: int64(price.Discount),
+	}, nil
+}
+
+func (s *subscriptionServer) Update(ctx context.Context, req *pb.UpdateRequest) (*emptypb.Empty, error) {
+	input, err := s.parseInput(req.GetSubscription())
+	if err != nil {
+		return nil, errInvalidArgument
+	}
+
+	if err = s.sub.Update(ctx, int(req.GetId()), input); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *subscriptionServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := s.sub.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// parseInput validates subscription of request and parses its dates
+func (s *subscriptionServer) parseInput(in *pb.SubscriptionInput) (service.SubscriptionInput, error) {
+	if in == nil {
+		return service.SubscriptionInput{}, errors.New("subscription is required")
+	}
+	input := subscriptionInput{
+		ServiceId:     int(in.GetServiceId()),
+		ServiceName:   in.GetServiceName(),
+		Price:         int(in.GetPrice()),
+		UserId:        in.GetUserId(),
+		StartDate:     in.GetStartDate(),
+		BillingPeriod: in.GetBillingPeriod(),
+		CategoryId:    intPtr(in.CategoryId),
+		Tags:          in.GetTags(),
+		IntroPrice:    intPtr(in.IntroPrice),
+		IntroPeriods:  int(in.GetIntroPeriods()),
+		TaxRate:       int(in.GetTaxRate()),
+	}
+	if d := in.GetDiscount(); d != nil {
+		input.Discount = &discountInput{Type: d.GetType(), Value: int(d.GetValue()), Months: int(d.GetMonths())}
+	}
+	if err := s.v.Validate(&input); err != nil {
+		return service.SubscriptionInput{}, err
+	}
+
+	start, err := parseDate(input.StartDate)
+	if err != nil {
+		return service.SubscriptionInput{}, err
+	}
+	result := service.SubscriptionInput{
+		ServiceId:     input.ServiceId,
+		ServiceName:   input.ServiceName,
+		Price:         input.Price,
+		UserId:        input.UserId,
+		StartDate:     start,
+		BillingPeriod: input.BillingPeriod,
+		CategoryId:    input.CategoryId,
+		Tags:          input.Tags,
+		IntroPrice:    input.IntroPrice,
+		IntroPeriods:  input.IntroPeriods,
+		TaxRate:       input.TaxRate,
+		TaxInclusive:  in.GetTaxInclusive(),
+	}
+	if result.EndDate, err = parseOptionalDate(in.EndDate); err != nil {
+		return service.SubscriptionInput{}, err
+	}
+	if result.TrialEndDate, err = parseOptionalDate(in.TrialEndDate); err != nil {
+		return service.SubscriptionInput{}, err
+	}
+	if result.LastUsedDate, err = parseOptionalDate(in.LastUsedDate); err != nil {
+		return service.SubscriptionInput{}, err
+	}
+	if d := input.Discount; d != nil {
+		result.Discount = &service.DiscountInput{Type: d.Type, Value: d.Value, Months: d.Months}
+		if ds := in.GetDiscount().GetStartDate(); ds != "" {
+			if result.Discount.StartDate, err = parseOptionalDate(&ds); err != nil {
+				return service.SubscriptionInput{}, err
+			}
+		}
+	}
+	return result, nil
+}
+
+func newSubscription(s service.SubscriptionOutput) *pb.Subscription {
+	result := &pb.Subscription{
+		Id:            int64(s.Id),
+		ServiceId:     int64(s.ServiceId),
+		ServiceName:   s.ServiceName,
+		Price:         int64(s.Price),
+		UserId:        s.UserId,
+		StartDate:     s.StartDate,
+		EndDate:       s.EndDate,
+		BillingPeriod: s.BillingPeriod,
+		CategoryId:    int64Ptr(s.CategoryId),
+		Tags:          s.Tags,
+		TrialEndDate:  s.TrialEndDate,
+		IntroPrice:    int64Ptr(s.IntroPrice),
+		IntroPeriods:  int64(s.IntroPeriods),
+		TaxRate:       int64(s.TaxRate),
+		TaxInclusive:  s.TaxInclusive,
+		LastUsedDate:  s.LastUsedDate,
+		Status:        s.Status,
+		CancelReason:  s.CancelReason,
+	}
+	if d := s.Discount; d != nil {
+		result.Discount = &pb.Discount{Type: d.Type, Value: int64(d.Value), StartDate: d.StartDate, Months: int64(d.Months)}
+	}
+	for _, p := range s.Pauses {
+		result.Pauses = append(result.Pauses, &pb.Pause{StartDate: p.StartDate, EndDate: p.EndDate})
+	}
+	if s.CancelledAt != nil {
+		result.CancelledAt = timestamppb.New(*s.CancelledAt)
+	}
+	return result
+}
+
+// parseDate parses yyyy-mm-dd date, legacy formats of HTTP API are not accepted
+func parseDate(s string) (time.Time, error) {
+	return time.Parse(time.DateOnly, s)
+}
+
+func parseOptionalDate(s *string) (*time.Time, error) {
+	if s == nil {
+		return nil, nil
+	}
+	t, err := parseDate(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func intPtr(v *int64) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+func int64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	i := int64(*v)
+	return &i
+}
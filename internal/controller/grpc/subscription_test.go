@@ -0,0 +1,549 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"subscription_service/internal/controller/grpc/pb"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+// defaultCtx matches context of calls without tenant
+var defaultCtx = inOrganization(tenant.DefaultOrganization)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestSubscriptionServer_Create(t *testing.T) {
+	type mockBehaviour func(sub *servicemocks.MockSubscription)
+
+	userId := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+
+	testCases := []struct {
+		testName      string
+		req           *pb.CreateRequest
+		mockBehaviour mockBehaviour
+		expectCode    codes.Code
+	}{
+		{
+			testName: "correct test",
+			req: &pb.CreateRequest{Subscription: &pb.SubscriptionInput{
+				ServiceName: "Yandex", Price: 1000, UserId: userId, StartDate: "2025-07-01", EndDate: ptr("2025-10-31"),
+				BillingPeriod: "monthly", CategoryId: ptr(int64(2)), Tags: []string{"music"}, TaxRate: 20,
+				Discount: &pb.Discount{Type: "percent", Value: 10, StartDate: "2025-08-01", Months: 3},
+			}},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Create(defaultCtx, service.SubscriptionInput{
+					ServiceName:   "Yandex",
+					Price:         1000,
+					UserId:        userId,
+					StartDate:     time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:       ptr(time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)),
+					BillingPeriod: "monthly",
+					CategoryId:    ptr(2),
+					Tags:          []string{"music"},
+					TaxRate:       20,
+					Discount: &service.DiscountInput{
+						Type: "percent", Value: 10, StartDate: ptr(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)), Months: 3,
+					},
+				}).Return(nil)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "allow duplicate",
+			req: &pb.CreateRequest{
+				Subscription:   &pb.SubscriptionInput{ServiceId: 3, Price: 1000, UserId: userId, StartDate: "2025-07-01"},
+				AllowDuplicate: true,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Create(defaultCtx, service.SubscriptionInput{
+					ServiceId:      3,
+					Price:          1000,
+					UserId:         userId,
+					StartDate:      time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					AllowDuplicate: true,
+				}).Return(nil)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "duplicate",
+			req: &pb.CreateRequest{
+				Subscription: &pb.SubscriptionInput{ServiceId: 3, Price: 1000, UserId: userId, StartDate: "2025-07-01"},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Create(defaultCtx, gomock.Any()).Return(&service.DuplicateError{Ids: []int{5}})
+			},
+			expectCode: codes.AlreadyExists,
+		},
+		{
+			testName: "without service",
+			req: &pb.CreateRequest{
+				Subscription: &pb.SubscriptionInput{Price: 1000, UserId: userId, StartDate: "2025-07-01"},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName: "incorrect user id",
+			req: &pb.CreateRequest{
+				Subscription: &pb.SubscriptionInput{ServiceName: "Yandex", Price: 1000, UserId: "123", StartDate: "2025-07-01"},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName: "incorrect date",
+			req: &pb.CreateRequest{
+				Subscription: &pb.SubscriptionInput{ServiceName: "Yandex", Price: 1000, UserId: userId, StartDate: "07-2025"},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName: "incorrect discount",
+			req: &pb.CreateRequest{
+				Subscription: &pb.SubscriptionInput{
+					ServiceName: "Yandex", Price: 1000, UserId: userId, StartDate: "2025-07-01",
+					Discount: &pb.Discount{Type: "gift", Value: 10},
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName:      "without subscription",
+			req:           &pb.CreateRequest{},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName: "service error",
+			req: &pb.CreateRequest{
+				Subscription: &pb.SubscriptionInput{ServiceId: 3, Price: 1000, UserId: userId, StartDate: "2025-07-01"},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Create(defaultCtx, gomock.Any()).Return(service.ErrServiceNotFound)
+			},
+			expectCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub)
+
+			client := newTestClient(t, &service.Services{Subscription: sub})
+
+			_, err := client.Create(context.Background(), tc.req)
+			assert.Equal(t, tc.expectCode, status.Code(err))
+		})
+	}
+}
+
+func TestSubscriptionServer_FindById(t *testing.T) {
+	type mockBehaviour func(sub *servicemocks.MockSubscription)
+
+	cancelledAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		id            int64
+		mockBehaviour mockBehaviour
+		expectOutput  *pb.Subscription
+		expectCode    codes.Code
+	}{
+		{
+			testName: "correct test",
+			id:       1,
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindById(defaultCtx, 1).Return(service.SubscriptionOutput{
+					Id: 1, ServiceId: 2, ServiceName: "Yandex", Price: 1000, UserId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate: "2025-07-01", EndDate: ptr("2025-09-30"), BillingPeriod: "monthly", CategoryId: ptr(4),
+					Tags: []string{"music"}, IntroPrice: ptr(100), IntroPeriods: 2,
+					Discount: &service.DiscountOutput{Type: "fixed", Value: 50, StartDate: "2025-07-01"},
+					Status:   "cancelled", Pauses: []service.PauseOutput{{StartDate: "2025-08-01", EndDate: ptr("2025-08-15")}},
+					CancelReason: ptr("too expensive"), CancelledAt: &cancelledAt,
+				}, nil)
+			},
+			expectOutput: &pb.Subscription{
+				Id: 1, ServiceId: 2, ServiceName: "Yandex", Price: 1000, UserId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				StartDate: "2025-07-01", EndDate: ptr("2025-09-30"), BillingPeriod: "monthly", CategoryId: ptr(int64(4)),
+				Tags: []string{"music"}, IntroPrice: ptr(int64(100)), IntroPeriods: 2,
+				Discount: &pb.Discount{Type: "fixed", Value: 50, StartDate: "2025-07-01"},
+				Status:   "cancelled", Pauses: []*pb.Pause{{StartDate: "2025-08-01", EndDate: ptr("2025-08-15")}},
+				CancelReason: ptr("too expensive"), CancelledAt: timestamppb.New(cancelledAt),
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "not found",
+			id:       2,
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindById(defaultCtx, 2).Return(service.SubscriptionOutput{}, service.ErrSubscriptionNotFound)
+			},
+			expectCode: codes.NotFound,
+		},
+		{
+			testName: "unexpected error",
+			id:       3,
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindById(defaultCtx, 3).Return(service.SubscriptionOutput{}, errors.New("some error"))
+			},
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub)
+
+			client := newTestClient(t, &service.Services{Subscription: sub})
+
+			output, err := client.FindById(context.Background(), &pb.FindByIdRequest{Id: tc.id})
+			assert.Equal(t, tc.expectCode, status.Code(err))
+			if tc.expectOutput != nil {
+				assert.True(t, proto.Equal(tc.expectOutput, output), "unexpected output %v", output)
+			}
+		})
+	}
+}
+
+func TestSubscriptionServer_FindAll(t *testing.T) {
+	type mockBehaviour func(sub *servicemocks.MockSubscription)
+
+	subscriptions := []service.SubscriptionOutput{
+		{Id: 1, ServiceName: "Yandex"},
+		{Id: 4, ServiceName: "Okko"},
+		{Id: 7, ServiceName: "Spotify"},
+	}
+	filter := func(after, limit int) service.SubscriptionFilterInput {
+		return service.SubscriptionFilterInput{CategoryId: 2, Tag: "music", AfterId: after, Limit: limit}
+	}
+
+	testCases := []struct {
+		testName      string
+		req           *pb.FindAllRequest
+		mockBehaviour mockBehaviour
+		expectIds     []int64
+		expectToken   string
+		expectCode    codes.Code
+	}{
+		{
+			testName: "all in one page",
+			req:      &pb.FindAllRequest{CategoryId: 2, Tag: "music"},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindAll(defaultCtx, filter(0, defaultPageSize+1)).Return(subscriptions, nil)
+			},
+			expectIds:  []int64{1, 4, 7},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "first page",
+			req:      &pb.FindAllRequest{CategoryId: 2, Tag: "music", PageSize: 2},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindAll(defaultCtx, filter(0, 3)).Return(subscriptions, nil)
+			},
+			expectIds:   []int64{1, 4},
+			expectToken: "4",
+			expectCode:  codes.OK,
+		},
+		{
+			testName: "last page",
+			req:      &pb.FindAllRequest{CategoryId: 2, Tag: "music", PageSize: 2, PageToken: "4"},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindAll(defaultCtx, filter(4, 3)).Return(subscriptions[2:], nil)
+			},
+			expectIds:  []int64{7},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "page exactly fits",
+			req:      &pb.FindAllRequest{CategoryId: 2, Tag: "music", PageSize: 3},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindAll(defaultCtx, filter(0, 4)).Return(subscriptions, nil)
+			},
+			expectIds:  []int64{1, 4, 7},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "empty",
+			req:      &pb.FindAllRequest{},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindAll(defaultCtx, service.SubscriptionFilterInput{Limit: defaultPageSize + 1}).Return(nil, nil)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName:      "incorrect page token",
+			req:           &pb.FindAllRequest{PageToken: "abc"},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName:      "too large page",
+			req:           &pb.FindAllRequest{PageSize: maxPageSize + 1},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName:      "incorrect category id",
+			req:           &pb.FindAllRequest{CategoryId: -1},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName: "unexpected error",
+			req:      &pb.FindAllRequest{},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindAll(defaultCtx, service.SubscriptionFilterInput{Limit: defaultPageSize + 1}).Return(nil, errors.New("some error"))
+			},
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub)
+
+			client := newTestClient(t, &service.Services{Subscription: sub})
+
+			output, err := client.FindAll(context.Background(), tc.req)
+			assert.Equal(t, tc.expectCode, status.Code(err))
+			if err != nil {
+				return
+			}
+			var ids []int64
+			for _, s := range output.GetSubscriptions() {
+				ids = append(ids, s.GetId())
+			}
+			assert.Equal(t, tc.expectIds, ids)
+			assert.Equal(t, tc.expectToken, output.GetNextPageToken())
+		})
+	}
+}
+
+func TestSubscriptionServer_FindPrice(t *testing.T) {
+	type mockBehaviour func(sub *servicemocks.MockSubscription)
+
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		req           *pb.FindPriceRequest
+		mockBehaviour mockBehaviour
+		expectOutput  *pb.Price
+		expectCode    codes.Code
+	}{
+		{
+			testName: "correct test",
+			req: &pb.FindPriceRequest{
+				ServiceName: "Yandex", UserId: "6114696a-d069-4fad-a3ed-f27c13651c3a", CategoryId: 2, Tag: "music",
+				StartDate: "2025-07-01", EndDate: "2025-09-30",
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindPrice(defaultCtx, service.PriceInput{
+					ServiceName: "Yandex",
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					CategoryId:  2,
+					Tag:         "music",
+					StartDate:   start,
+					EndDate:     end,
+					Source:      service.PriceSourceSubscriptions,
+				}).Return(service.PriceOutput{Gross: 3600, Net: 3000, Tax: 600, Discount: 100}, nil)
+			},
+			expectOutput: &pb.Price{Gross: 3600, Net: 3000, Tax: 600, Discount: 100},
+			expectCode:   codes.OK,
+		},
+		{
+			testName: "ledger",
+			req:      &pb.FindPriceRequest{StartDate: "2025-07-01", EndDate: "2025-09-30", Source: "ledger"},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindPrice(defaultCtx, service.PriceInput{
+					StartDate: start,
+					EndDate:   end,
+					Source:    service.PriceSourceLedger,
+				}).Return(service.PriceOutput{Gross: 3000, Net: 3000}, nil)
+			},
+			expectOutput: &pb.Price{Gross: 3000, Net: 3000},
+			expectCode:   codes.OK,
+		},
+		{
+			testName:      "incorrect source",
+			req:           &pb.FindPriceRequest{StartDate: "2025-07-01", EndDate: "2025-09-30", Source: "bank"},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName:      "without end date",
+			req:           &pb.FindPriceRequest{StartDate: "2025-07-01"},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+		{
+			testName: "unexpected error",
+			req:      &pb.FindPriceRequest{StartDate: "2025-07-01", EndDate: "2025-09-30"},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().FindPrice(defaultCtx, gomock.Any()).Return(service.PriceOutput{}, errors.New("some error"))
+			},
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub)
+
+			client := newTestClient(t, &service.Services{Subscription: sub})
+
+			output, err := client.FindPrice(context.Background(), tc.req)
+			assert.Equal(t, tc.expectCode, status.Code(err))
+			if tc.expectOutput != nil {
+				assert.True(t, proto.Equal(tc.expectOutput, output), "unexpected output %v", output)
+			}
+		})
+	}
+}
+
+func TestSubscriptionServer_Update(t *testing.T) {
+	type mockBehaviour func(sub *servicemocks.MockSubscription)
+
+	input := &pb.SubscriptionInput{
+		ServiceName: "Yandex", Price: 1200, UserId: "6114696a-d069-4fad-a3ed-f27c13651c3a", StartDate: "2025-07-01",
+		LastUsedDate: ptr("2025-09-14"),
+	}
+
+	testCases := []struct {
+		testName      string
+		req           *pb.UpdateRequest
+		mockBehaviour mockBehaviour
+		expectCode    codes.Code
+	}{
+		{
+			testName: "correct test",
+			req:      &pb.UpdateRequest{Id: 1, Subscription: input},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Update(defaultCtx, 1, service.SubscriptionInput{
+					ServiceName:  "Yandex",
+					Price:        1200,
+					UserId:       "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					LastUsedDate: ptr(time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)),
+				}).Return(nil)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "not found",
+			req:      &pb.UpdateRequest{Id: 2, Subscription: input},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Update(defaultCtx, 2, gomock.Any()).Return(service.ErrSubscriptionNotFound)
+			},
+			expectCode: codes.NotFound,
+		},
+		{
+			testName: "invalid trial",
+			req:      &pb.UpdateRequest{Id: 1, Subscription: input},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Update(defaultCtx, 1, gomock.Any()).Return(service.ErrInvalidTrial)
+			},
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			testName: "incorrect price",
+			req: &pb.UpdateRequest{Id: 1, Subscription: &pb.SubscriptionInput{
+				ServiceName: "Yandex", UserId: "6114696a-d069-4fad-a3ed-f27c13651c3a", StartDate: "2025-07-01",
+			}},
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {},
+			expectCode:    codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub)
+
+			client := newTestClient(t, &service.Services{Subscription: sub})
+
+			_, err := client.Update(context.Background(), tc.req)
+			assert.Equal(t, tc.expectCode, status.Code(err))
+		})
+	}
+}
+
+func TestSubscriptionServer_Delete(t *testing.T) {
+	type mockBehaviour func(sub *servicemocks.MockSubscription)
+
+	testCases := []struct {
+		testName      string
+		id            int64
+		mockBehaviour mockBehaviour
+		expectCode    codes.Code
+	}{
+		{
+			testName: "correct test",
+			id:       1,
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Delete(defaultCtx, 1).Return(nil)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			testName: "not found",
+			id:       2,
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Delete(defaultCtx, 2).Return(service.ErrSubscriptionNotFound)
+			},
+			expectCode: codes.NotFound,
+		},
+		{
+			testName: "unexpected error",
+			id:       3,
+			mockBehaviour: func(sub *servicemocks.MockSubscription) {
+				sub.EXPECT().Delete(defaultCtx, 3).Return(errors.New("some error"))
+			},
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub)
+
+			client := newTestClient(t, &service.Services{Subscription: sub})
+
+			_, err := client.Delete(context.Background(), &pb.DeleteRequest{Id: tc.id})
+			assert.Equal(t, tc.expectCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+	"time"
+)
+
+// maxReportMonths limits month interval of reports
+const maxReportMonths = 120
+
+type analyticsRouter struct {
+	analytics service.Analytics
+}
+
+func newAnalyticsRouter(g *echo.Group, analytics service.Analytics) {
+	r := &analyticsRouter{
+		analytics: analytics,
+	}
+
+	g.GET("/mrr", r.recurringSpend)
+	g.GET("/growth", r.growth)
+	g.GET("/services", r.services)
+	g.GET("/categories", r.categories)
+}
+
+// @Summary		MRR
+// @Description	Monthly recurring spend and number of active subscriptions per month. Yearly subscriptions are counted as 1/12 of price
+// @Tags			analytics
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	false	"user id"
+// @Param			start	query		string	true	"start of the time interval. Must be in format mm-yyyy"
+// @Param			end		query		string	true	"end of the time interval. Must be in format mm-yyyy"
+// @Success		200		{array}		service.RecurringSpendOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/analytics/mrr [get]
+func (r *analyticsRouter) recurringSpend(c echo.Context) error {
+	input, err := parseAnalyticsInput(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	s, err := r.analytics.RecurringSpend(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+// @Summary		Growth
+// @Description	New and churned subscriptions per month with net change. Subscription is churned in month of its end date
+// @Tags			analytics
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	false	"user id"
+// @Param			start	query		string	true	"start of the time interval. Must be in format mm-yyyy"
+// @Param			end		query		string	true	"end of the time interval. Must be in format mm-yyyy"
+// @Success		200		{array}		service.GrowthOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/analytics/growth [get]
+func (r *analyticsRouter) growth(c echo.Context) error {
+	input, err := parseAnalyticsInput(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	g, err := r.analytics.Growth(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, g)
+}
+
+// @Summary		Services
+// @Description	Services ordered by spend for time interval with number of subscriptions and average monthly price
+// @Tags			analytics
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	false	"user id"
+// @Param			start	query		string	true	"start of the time interval. Must be in format mm-yyyy"
+// @Param			end		query		string	true	"end of the time interval. Must be in format mm-yyyy"
+// @Param			limit	query		int		false	"number of top services, all by default"
+// @Success		200		{array}		service.ServiceStatsOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/analytics/services [get]
+func (r *analyticsRouter) services(c echo.Context) error {
+	input, err := parseAnalyticsInput(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	var limit int
+	if l := c.QueryParam("limit"); l != "" {
+		if limit, err = strconv.Atoi(l); err != nil || limit < 1 {
+			return c.NoContent(http.StatusBadRequest)
+		}
+	}
+
+	s, err := r.analytics.Services(c.Request().Context(), input, limit)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+// @Summary		Categories
+// @Description	Spend per category for time interval ordered by total spend. total_spend includes subcategories, category_id is null for uncategorized subscriptions
+// @Tags			analytics
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	false	"user id"
+// @Param			start	query		string	true	"start of the time interval. Must be in format mm-yyyy"
+// @Param			end		query		string	true	"end of the time interval. Must be in format mm-yyyy"
+// @Success		200		{array}		service.CategoryStatsOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/analytics/categories [get]
+func (r *analyticsRouter) categories(c echo.Context) error {
+	input, err := parseAnalyticsInput(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	s, err := r.analytics.Categories(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+func parseAnalyticsInput(c echo.Context) (service.AnalyticsInput, error) {
+	start, end, err := parseMonthRange(c)
+	if err != nil {
+		return service.AnalyticsInput{}, err
+	}
+	return service.AnalyticsInput{
+		UserId:    c.QueryParam("user_id"),
+		StartDate: start,
+		EndDate:   end,
+	}, nil
+}
+
+// parseMonthRange parses start and end query params in mm-yyyy format. Interval must be not longer than maxReportMonths
+func parseMonthRange(c echo.Context) (time.Time, time.Time, error) {
+	start, err := time.Parse("01-2006", c.QueryParam("start"))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := time.Parse("01-2006", c.QueryParam("end"))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end.Before(start) || end.After(start.AddDate(0, maxReportMonths-1, 0)) {
+		return time.Time{}, time.Time{}, errors.New("invalid month interval")
+	}
+	return start, end, nil
+}
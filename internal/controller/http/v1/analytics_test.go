@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsRouter_services(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.AnalyticsInput
+		limit int
+	}
+
+	type mockBehaviour func(a *servicemocks.MockAnalytics, args args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.AnalyticsInput{
+					UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+				},
+				limit: 1,
+			},
+			mockBehaviour: func(a *servicemocks.MockAnalytics, args args) {
+				a.EXPECT().Services(args.ctx, args.input, args.limit).Return([]service.ServiceStatsOutput{
+					{ServiceName: "Yandex Plus", Subscriptions: 2, AvgPrice: 350, Spend: 8400},
+				}, nil)
+			},
+			path:       "/api/v1/analytics/services?start=01-2025&end=12-2025&limit=1&user_id=60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			expectBody: `[{"service_name":"Yandex Plus","subscriptions":2,"avg_price":350,"spend":8400}]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "all services",
+			args: args{
+				ctx: tenantCtx,
+				input: service.AnalyticsInput{
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(a *servicemocks.MockAnalytics, args args) {
+				a.EXPECT().Services(args.ctx, args.input, 0).Return([]service.ServiceStatsOutput{}, nil)
+			},
+			path:       "/api/v1/analytics/services?start=01-2025&end=01-2025",
+			expectBody: `[]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "invalid limit",
+			mockBehaviour: func(a *servicemocks.MockAnalytics, args args) {},
+			path:          "/api/v1/analytics/services?start=01-2025&end=12-2025&limit=0",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "missing end",
+			mockBehaviour: func(a *servicemocks.MockAnalytics, args args) {},
+			path:          "/api/v1/analytics/services?start=01-2025",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "end before start",
+			mockBehaviour: func(a *servicemocks.MockAnalytics, args args) {},
+			path:          "/api/v1/analytics/services?start=12-2025&end=01-2025",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: tenantCtx,
+				input: service.AnalyticsInput{
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(a *servicemocks.MockAnalytics, args args) {
+				a.EXPECT().Services(args.ctx, args.input, 0).Return(nil, errors.New("some error"))
+			},
+			path:       "/api/v1/analytics/services?start=01-2025&end=01-2025",
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			a := servicemocks.NewMockAnalytics(ctrl)
+			tc.mockBehaviour(a, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Analytics: a})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
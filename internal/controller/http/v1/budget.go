@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+)
+
+type budgetRouter struct {
+	budget service.Budget
+}
+
+func newBudgetRouter(g *echo.Group, budget service.Budget) {
+	r := &budgetRouter{
+		budget: budget,
+	}
+
+	g.POST("", r.create)
+	g.GET("/all", r.findAll)
+	g.GET("/:id", r.findById)
+	g.GET("/:id/evaluation", r.evaluate)
+	g.PUT("/:id", r.update)
+	g.DELETE("/:id", r.delete)
+}
+
+type budgetInput struct {
+	UserId      string  `json:"user_id" validate:"required,uuid4"`
+	ServiceName *string `json:"service_name" validate:"omitempty,min=1"`
+	CategoryId  *int    `json:"category_id" validate:"omitempty,min=1"`
+	Amount      int     `json:"amount" validate:"required,min=1"`
+	Thresholds  []int   `json:"thresholds" validate:"dive,min=1,max=1000"`
+}
+
+type budgetCreateOutput struct {
+	Id int `json:"id"`
+}
+
+// @Summary		Create
+// @Description	Create monthly budget of user. Without service_name budget covers all user subscriptions, with category_id only subscriptions of category and its subcategories. Thresholds are percents of amount, 80 and 100 by default
+// @Tags			budget
+// @Accept			json
+// @Produce		json
+// @Param			input	body		budgetInput	true	"input"
+// @Success		200		{object}	budgetCreateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/budget [post]
+func (r *budgetRouter) create(c echo.Context) error {
+	var input budgetInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	id, err := r.budget.Create(c.Request().Context(), service.BudgetInput{
+		UserId:      input.UserId,
+		ServiceName: input.ServiceName,
+		CategoryId:  input.CategoryId,
+		Amount:      input.Amount,
+		Thresholds:  input.Thresholds,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, budgetCreateOutput{
+		Id: id,
+	})
+}
+
+// @Summary		Find All
+// @Description	Find all budgets, optionally of one user
+// @Tags			budget
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	false	"user id"
+// @Success		200		{array}		service.BudgetOutput
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/budget/all [get]
+func (r *budgetRouter) findAll(c echo.Context) error {
+	b, err := r.budget.FindAll(c.Request().Context(), c.QueryParam("user_id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// @Summary		Find by id
+// @Description	Find budget by id
+// @Tags			budget
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"id"
+// @Success		200	{object}	service.BudgetOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/budget/{id} [get]
+func (r *budgetRouter) findById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	b, err := r.budget.FindById(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// @Summary		Evaluation
+// @Description	Budget vs actual spend for every month of time interval
+// @Tags			budget
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int		true	"id"
+// @Param			start	query		string	true	"start of the time interval. Must be in format mm-yyyy"
+// @Param			end		query		string	true	"end of the time interval. Must be in format mm-yyyy"
+// @Param			source	query		string	false	"subscriptions (default) or ledger of charges"
+// @Success		200		{array}		service.BudgetMonthOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/budget/{id}/evaluation [get]
+func (r *budgetRouter) evaluate(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	start, end, err := parseMonthRange(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	source, err := parsePriceSource(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	e, err := r.budget.Evaluate(c.Request().Context(), id, start, end, source)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, e)
+}
+
+// @Summary		Update
+// @Description	Update budget by id
+// @Tags			budget
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int			true	"id"
+// @Param			input	body		budgetInput	true	"input"
+// @Success		200		{string}	string		"OK"
+// @Failure		400		{string}	string		"Bad Request"
+// @Failure		404		{string}	string		"Not Found"
+// @Failure		500		{string}	string		"Internal Server Error"
+// @Router			/api/v1/budget/{id} [put]
+func (r *budgetRouter) update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input budgetInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	err = r.budget.Update(c.Request().Context(), id, service.BudgetInput{
+		UserId:      input.UserId,
+		ServiceName: input.ServiceName,
+		CategoryId:  input.CategoryId,
+		Amount:      input.Amount,
+		Thresholds:  input.Thresholds,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Delete
+// @Description	Delete budget by id
+// @Tags			budget
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/budget/{id} [delete]
+func (r *budgetRouter) delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.budget.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,279 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestBudgetRouter_create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.BudgetInput
+	}
+
+	type mockBehaviour func(b *servicemocks.MockBudget, a args)
+
+	serviceName := "Yandex Plus"
+	categoryId := 5
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.BudgetInput{
+					UserId:      "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					ServiceName: &serviceName,
+					Amount:      1000,
+					Thresholds:  []int{50, 100},
+				},
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Create(a.ctx, a.input).Return(1, nil)
+			},
+			inputBody:  `{"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "service_name": "Yandex Plus", "amount": 1000, "thresholds": [50, 100]}`,
+			expectBody: `{"id":1}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "overall budget with default thresholds",
+			args: args{
+				ctx: tenantCtx,
+				input: service.BudgetInput{
+					UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					Amount: 1000,
+				},
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Create(a.ctx, a.input).Return(2, nil)
+			},
+			inputBody:  `{"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "amount": 1000}`,
+			expectBody: `{"id":2}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "budget of category",
+			args: args{
+				ctx: tenantCtx,
+				input: service.BudgetInput{
+					UserId:     "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					CategoryId: &categoryId,
+					Amount:     1000,
+				},
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Create(a.ctx, a.input).Return(3, nil)
+			},
+			inputBody:  `{"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "category_id": 5, "amount": 1000}`,
+			expectBody: `{"id":3}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "category not found",
+			args: args{
+				ctx: tenantCtx,
+				input: service.BudgetInput{
+					UserId:     "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					CategoryId: &categoryId,
+					Amount:     1000,
+				},
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Create(a.ctx, a.input).Return(0, service.ErrCategoryNotFound)
+			},
+			inputBody:  `{"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "category_id": 5, "amount": 1000}`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid category id",
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {},
+			inputBody:     `{"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "category_id": 0, "amount": 1000}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "zero amount",
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {},
+			inputBody:     `{"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "amount": 0}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid threshold",
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {},
+			inputBody:     `{"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "amount": 1000, "thresholds": [0]}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid user id",
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {},
+			inputBody:     `{"user_id": "foo", "amount": 1000}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			b := servicemocks.NewMockBudget(ctrl)
+			tc.mockBehaviour(b, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Budget: b})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/budget", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestBudgetRouter_evaluate(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     int
+		start  time.Time
+		end    time.Time
+		source string
+	}
+
+	type mockBehaviour func(b *servicemocks.MockBudget, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				id:     1,
+				start:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				end:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				source: service.PriceSourceSubscriptions,
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Evaluate(a.ctx, a.id, a.start, a.end, a.source).Return([]service.BudgetMonthOutput{
+					{Month: "07-2025", Amount: 1000, Spent: 850, Percent: 85, Remaining: 150, CrossedThresholds: []int{80}},
+				}, nil)
+			},
+			path:       "/api/v1/budget/1/evaluation?start=07-2025&end=07-2025",
+			expectBody: `[{"month":"07-2025","amount":1000,"spent":850,"percent":85,"remaining":150,"crossed_thresholds":[80]}]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "spend from ledger",
+			args: args{
+				ctx:    tenantCtx,
+				id:     1,
+				start:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				end:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				source: service.PriceSourceLedger,
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Evaluate(a.ctx, a.id, a.start, a.end, a.source).Return([]service.BudgetMonthOutput{
+					{Month: "07-2025", Amount: 1000, Spent: 500, Percent: 50, Remaining: 500, CrossedThresholds: []int{}},
+				}, nil)
+			},
+			path:       "/api/v1/budget/1/evaluation?start=07-2025&end=07-2025&source=ledger",
+			expectBody: `[{"month":"07-2025","amount":1000,"spent":500,"percent":50,"remaining":500,"crossed_thresholds":[]}]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "invalid source",
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {},
+			path:          "/api/v1/budget/1/evaluation?start=07-2025&end=07-2025&source=bank",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "budget not found",
+			args: args{
+				ctx:    tenantCtx,
+				id:     2,
+				start:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				end:    time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				source: service.PriceSourceSubscriptions,
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Evaluate(a.ctx, a.id, a.start, a.end, a.source).Return(nil, service.ErrBudgetNotFound)
+			},
+			path:       "/api/v1/budget/2/evaluation?start=07-2025&end=08-2025",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "end before start",
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {},
+			path:          "/api/v1/budget/1/evaluation?start=08-2025&end=07-2025",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "too long interval",
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {},
+			path:          "/api/v1/budget/1/evaluation?start=01-2000&end=01-2025",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    tenantCtx,
+				id:     1,
+				start:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				end:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				source: service.PriceSourceSubscriptions,
+			},
+			mockBehaviour: func(b *servicemocks.MockBudget, a args) {
+				b.EXPECT().Evaluate(a.ctx, a.id, a.start, a.end, a.source).Return(nil, errors.New("some error"))
+			},
+			path:       "/api/v1/budget/1/evaluation?start=07-2025&end=07-2025",
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			b := servicemocks.NewMockBudget(ctrl)
+			tc.mockBehaviour(b, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Budget: b})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
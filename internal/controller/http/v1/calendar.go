@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/url"
+	"subscription_service/internal/service"
+)
+
+const mimeTextCalendar = "text/calendar; charset=utf-8"
+
+type calendarRouter struct {
+	calendar service.Calendar
+}
+
+// newCalendarRouter registers feed in public group, because calendar apps can not send tenant header.
+// Organization of feed is taken from its token
+func newCalendarRouter(public, g *echo.Group, calendar service.Calendar) {
+	r := &calendarRouter{
+		calendar: calendar,
+	}
+
+	public.GET("/calendar.ics", r.feed)
+	g.GET("/calendar/link", r.link)
+}
+
+type calendarLinkOutput struct {
+	Url string `json:"url"`
+}
+
+// @Summary		Calendar link
+// @Description	Get tokenised url of user renewals calendar for subscribing from calendar apps. Token is bound to organization of request. Not found if calendar is disabled
+// @Tags			calendar
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	true	"user id"
+// @Success		200		{object}	calendarLinkOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/calendar/link [get]
+func (r *calendarRouter) link(c echo.Context) error {
+	userId := c.QueryParam("user_id")
+	if userId == "" {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	token, err := r.calendar.Token(c.Request().Context(), userId)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("user_id", userId)
+	query.Set("token", token)
+
+	return c.JSON(http.StatusOK, calendarLinkOutput{
+		Url: c.Scheme() + "://" + c.Request().Host + "/api/v1/subscription/calendar.ics?" + query.Encode(),
+	})
+}
+
+// @Summary		Calendar feed
+// @Description	iCalendar (RFC 5545) feed with recurring renewal events of user active subscriptions. Not found if calendar is disabled
+// @Tags			calendar
+// @Produce		text/calendar
+// @Param			user_id	query		string	true	"user id"
+// @Param			token	query		string	true	"calendar token from link"
+// @Success		200		{string}	string	"OK"
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		403		{string}	string	"Forbidden"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/calendar.ics [get]
+func (r *calendarRouter) feed(c echo.Context) error {
+	userId := c.QueryParam("user_id")
+	if userId == "" {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	feed, err := r.calendar.Feed(c.Request().Context(), userId, c.QueryParam("token"))
+	if err != nil {
+		return err
+	}
+	return c.Blob(http.StatusOK, mimeTextCalendar, feed)
+}
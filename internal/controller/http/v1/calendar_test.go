@@ -0,0 +1,197 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+)
+
+func TestCalendarRouter_feed(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		token  string
+	}
+
+	type mockBehaviour func(cal *servicemocks.MockCalendar, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		query         string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    context.Background(),
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				token:  "abc",
+			},
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {
+				cal.EXPECT().Feed(a.ctx, a.userId, a.token).Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&token=abc`,
+			expectBody: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "missing user id",
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {},
+			query:         `token=abc`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "invalid token",
+			args: args{
+				ctx:    context.Background(),
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				token:  "foobar",
+			},
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {
+				cal.EXPECT().Feed(a.ctx, a.userId, a.token).Return(nil, service.ErrInvalidCalendarToken)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&token=foobar`,
+			expectCode: http.StatusForbidden,
+		},
+		{
+			testName: "calendar disabled",
+			args: args{
+				ctx:    context.Background(),
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				token:  "abc",
+			},
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {
+				cal.EXPECT().Feed(a.ctx, a.userId, a.token).Return(nil, service.ErrCalendarDisabled)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&token=abc`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    context.Background(),
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				token:  "abc",
+			},
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {
+				cal.EXPECT().Feed(a.ctx, a.userId, a.token).Return(nil, errors.New("some error"))
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&token=abc`,
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			cal := servicemocks.NewMockCalendar(ctrl)
+			tc.mockBehaviour(cal, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Calendar: cal})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/calendar.ics?"+tc.query, nil)
+
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code)
+			assert.Equal(t, tc.expectBody, w.Body.String())
+		})
+	}
+}
+
+func TestCalendarRouter_link(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+	}
+
+	type mockBehaviour func(cal *servicemocks.MockCalendar, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		query         string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {
+				cal.EXPECT().Token(a.ctx, a.userId).Return("1.abc", nil)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a`,
+			expectBody: `{"url":"http://example.com/api/v1/subscription/calendar.ics?token=1.abc\u0026user_id=6114696a-d069-4fad-a3ed-f27c13651c3a"}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "missing user id",
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {},
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "user not found",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {
+				cal.EXPECT().Token(a.ctx, a.userId).Return("", service.ErrUserNotFound)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName: "calendar disabled",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(cal *servicemocks.MockCalendar, a args) {
+				cal.EXPECT().Token(a.ctx, a.userId).Return("", service.ErrCalendarDisabled)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a`,
+			expectCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			cal := servicemocks.NewMockCalendar(ctrl)
+			tc.mockBehaviour(cal, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Calendar: cal})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/calendar/link?"+tc.query, nil)
+
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code)
+			assert.Equal(t, tc.expectBody, w.Body.String())
+		})
+	}
+}
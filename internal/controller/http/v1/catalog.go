@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+)
+
+type catalogRouter struct {
+	catalog service.Catalog
+}
+
+func newCatalogRouter(g *echo.Group, catalog service.Catalog) {
+	r := &catalogRouter{
+		catalog: catalog,
+	}
+
+	g.POST("", r.create)
+	g.GET("/all", r.findAll)
+	g.GET("/:id", r.findById)
+	g.PUT("/:id", r.update)
+	g.DELETE("/:id", r.delete)
+}
+
+type serviceInput struct {
+	Name         string   `json:"name" validate:"required"`
+	Aliases      []string `json:"aliases" validate:"dive,required"`
+	Category     *string  `json:"category" validate:"omitempty,min=1"`
+	DefaultPrice *int     `json:"default_price" validate:"omitempty,min=0"`
+	YearlyPrice  *int     `json:"yearly_price" validate:"omitempty,min=0"`
+	FamilyPrice  *int     `json:"family_price" validate:"omitempty,min=0"`
+	FamilySize   *int     `json:"family_size" validate:"required_with=FamilyPrice,omitempty,min=2"`
+	VendorUrl    *string  `json:"vendor_url" validate:"omitempty,url"`
+	LogoUrl      *string  `json:"logo_url" validate:"omitempty,url"`
+}
+
+type serviceCreateOutput struct {
+	Id int `json:"id"`
+}
+
+// @Summary		Create
+// @Description	Add service to catalog. Name and aliases are matched case insensitive when subscriptions are created or filtered by service_name
+// @Tags			service
+// @Accept			json
+// @Produce		json
+// @Param			input	body		serviceInput	true	"input"
+// @Success		200		{object}	serviceCreateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		409		{string}	string	"Conflict"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/service [post]
+func (r *catalogRouter) create(c echo.Context) error {
+	var input serviceInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	id, err := r.catalog.Create(c.Request().Context(), newServiceInput(input))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, serviceCreateOutput{
+		Id: id,
+	})
+}
+
+// @Summary		Find All
+// @Description	Find all services of catalog
+// @Tags			service
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		service.ServiceOutput
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/service/all [get]
+func (r *catalogRouter) findAll(c echo.Context) error {
+	s, err := r.catalog.FindAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+// @Summary		Find by id
+// @Description	Find service of catalog by id
+// @Tags			service
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"id"
+// @Success		200	{object}	service.ServiceOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/service/{id} [get]
+func (r *catalogRouter) findById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	s, err := r.catalog.FindById(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+// @Summary		Update
+// @Description	Update service of catalog by id. Subscriptions of service get new name as well
+// @Tags			service
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int				true	"id"
+// @Param			input	body		serviceInput	true	"input"
+// @Success		200		{string}	string			"OK"
+// @Failure		400		{string}	string			"Bad Request"
+// @Failure		404		{string}	string			"Not Found"
+// @Failure		409		{string}	string			"Conflict"
+// @Failure		500		{string}	string			"Internal Server Error"
+// @Router			/api/v1/service/{id} [put]
+func (r *catalogRouter) update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input serviceInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.catalog.Update(c.Request().Context(), id, newServiceInput(input)); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Delete
+// @Description	Delete service of catalog by id. Service with subscriptions can't be deleted
+// @Tags			service
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		409	{string}	string	"Conflict"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/service/{id} [delete]
+func (r *catalogRouter) delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.catalog.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func newServiceInput(input serviceInput) service.ServiceInput {
+	return service.ServiceInput{
+		Name:         input.Name,
+		Aliases:      input.Aliases,
+		Category:     input.Category,
+		DefaultPrice: input.DefaultPrice,
+		YearlyPrice:  input.YearlyPrice,
+		FamilyPrice:  input.FamilyPrice,
+		FamilySize:   input.FamilySize,
+		VendorUrl:    input.VendorUrl,
+		LogoUrl:      input.LogoUrl,
+	}
+}
@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+)
+
+func TestCatalogRouter_create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.ServiceInput
+	}
+
+	type mockBehaviour func(c *servicemocks.MockCatalog, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.ServiceInput{
+					Name:         "Yandex Plus",
+					Aliases:      []string{"Яндекс Плюс"},
+					Category:     ptr("music"),
+					DefaultPrice: ptr(400),
+					VendorUrl:    ptr("https://plus.yandex.ru"),
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {
+				c.EXPECT().Create(a.ctx, a.input).Return(1, nil)
+			},
+			inputBody:  `{"name": "Yandex Plus", "aliases": ["Яндекс Плюс"], "category": "music", "default_price": 400, "vendor_url": "https://plus.yandex.ru"}`,
+			expectBody: `{"id":1}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "name is taken",
+			args: args{
+				ctx: tenantCtx,
+				input: service.ServiceInput{
+					Name: "Yandex Plus",
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {
+				c.EXPECT().Create(a.ctx, a.input).Return(0, service.ErrServiceAlreadyExists)
+			},
+			inputBody:  `{"name": "Yandex Plus"}`,
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName:      "missing name",
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {},
+			inputBody:     `{"aliases": ["Яндекс Плюс"]}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "empty alias",
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {},
+			inputBody:     `{"name": "Yandex Plus", "aliases": [""]}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid vendor url",
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {},
+			inputBody:     `{"name": "Yandex Plus", "vendor_url": "plus yandex"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			c := servicemocks.NewMockCatalog(ctrl)
+			tc.mockBehaviour(c, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Catalog: c})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/service", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestCatalogRouter_delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int
+	}
+
+	type mockBehaviour func(c *servicemocks.MockCatalog, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+			},
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {
+				c.EXPECT().Delete(a.ctx, a.id).Return(nil)
+			},
+			path:       "/api/v1/service/1",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "service in use",
+			args: args{
+				ctx: tenantCtx,
+				id:  2,
+			},
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {
+				c.EXPECT().Delete(a.ctx, a.id).Return(service.ErrServiceInUse)
+			},
+			path:       "/api/v1/service/2",
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: tenantCtx,
+				id:  3,
+			},
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {
+				c.EXPECT().Delete(a.ctx, a.id).Return(service.ErrServiceNotFound)
+			},
+			path:       "/api/v1/service/3",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid id",
+			mockBehaviour: func(c *servicemocks.MockCatalog, a args) {},
+			path:          "/api/v1/service/foo",
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			c := servicemocks.NewMockCatalog(ctrl)
+			tc.mockBehaviour(c, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Catalog: c})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+)
+
+type categoryRouter struct {
+	category service.Category
+}
+
+func newCategoryRouter(g *echo.Group, category service.Category) {
+	r := &categoryRouter{
+		category: category,
+	}
+
+	g.POST("", r.create)
+	g.GET("/all", r.findAll)
+	g.GET("/:id", r.findById)
+	g.PUT("/:id", r.update)
+	g.DELETE("/:id", r.delete)
+}
+
+type categoryInput struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	ParentId *int   `json:"parent_id" validate:"omitempty,min=1"`
+}
+
+type categoryCreateOutput struct {
+	Id int `json:"id"`
+}
+
+// @Summary		Create
+// @Description	Create category. Category without parent_id is top level, names are unique within parent
+// @Tags			category
+// @Accept			json
+// @Produce		json
+// @Param			input	body		categoryInput	true	"input"
+// @Success		200		{object}	categoryCreateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		409		{string}	string	"Conflict"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/category [post]
+func (r *categoryRouter) create(c echo.Context) error {
+	var input categoryInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	id, err := r.category.Create(c.Request().Context(), service.CategoryInput{
+		Name:     input.Name,
+		ParentId: input.ParentId,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, categoryCreateOutput{
+		Id: id,
+	})
+}
+
+// @Summary		Find All
+// @Description	Find all categories
+// @Tags			category
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		service.CategoryOutput
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/category/all [get]
+func (r *categoryRouter) findAll(c echo.Context) error {
+	categories, err := r.category.FindAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, categories)
+}
+
+// @Summary		Find by id
+// @Description	Find category by id
+// @Tags			category
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"id"
+// @Success		200	{object}	service.CategoryOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/category/{id} [get]
+func (r *categoryRouter) findById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	category, err := r.category.FindById(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, category)
+}
+
+// @Summary		Update
+// @Description	Rename or move category. Category can't be moved into itself or its subcategories
+// @Tags			category
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int				true	"id"
+// @Param			input	body		categoryInput	true	"input"
+// @Success		200		{string}	string			"OK"
+// @Failure		400		{string}	string			"Bad Request"
+// @Failure		404		{string}	string			"Not Found"
+// @Failure		409		{string}	string			"Conflict"
+// @Failure		500		{string}	string			"Internal Server Error"
+// @Router			/api/v1/category/{id} [put]
+func (r *categoryRouter) update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input categoryInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	err = r.category.Update(c.Request().Context(), id, service.CategoryInput{
+		Name:     input.Name,
+		ParentId: input.ParentId,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Delete
+// @Description	Delete category by id. Category with subcategories can't be deleted, its subscriptions become uncategorized
+// @Tags			category
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		409	{string}	string	"Conflict"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/category/{id} [delete]
+func (r *categoryRouter) delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.category.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,210 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+)
+
+func TestCategoryRouter_create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.CategoryInput
+	}
+
+	type mockBehaviour func(c *servicemocks.MockCategory, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.CategoryInput{
+					Name:     "Music",
+					ParentId: ptr(1),
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {
+				c.EXPECT().Create(a.ctx, a.input).Return(2, nil)
+			},
+			inputBody:  `{"name": "Music", "parent_id": 1}`,
+			expectBody: `{"id":2}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "name is taken",
+			args: args{
+				ctx: tenantCtx,
+				input: service.CategoryInput{
+					Name: "Entertainment",
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {
+				c.EXPECT().Create(a.ctx, a.input).Return(0, service.ErrCategoryAlreadyExists)
+			},
+			inputBody:  `{"name": "Entertainment"}`,
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "parent not found",
+			args: args{
+				ctx: tenantCtx,
+				input: service.CategoryInput{
+					Name:     "Music",
+					ParentId: ptr(5),
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {
+				c.EXPECT().Create(a.ctx, a.input).Return(0, service.ErrInvalidCategoryParent)
+			},
+			inputBody:  `{"name": "Music", "parent_id": 5}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName:      "missing name",
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {},
+			inputBody:     `{"parent_id": 1}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid parent id",
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {},
+			inputBody:     `{"name": "Music", "parent_id": 0}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			c := servicemocks.NewMockCategory(ctrl)
+			tc.mockBehaviour(c, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Category: c})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/category", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestCategoryRouter_update(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input service.CategoryInput
+	}
+
+	type mockBehaviour func(c *servicemocks.MockCategory, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		inputBody     string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				id:  2,
+				input: service.CategoryInput{
+					Name: "Music",
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {
+				c.EXPECT().Update(a.ctx, a.id, a.input).Return(nil)
+			},
+			path:       "/api/v1/category/2",
+			inputBody:  `{"name": "Music"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "move into subcategory",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+				input: service.CategoryInput{
+					Name:     "Entertainment",
+					ParentId: ptr(2),
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {
+				c.EXPECT().Update(a.ctx, a.id, a.input).Return(service.ErrInvalidCategoryParent)
+			},
+			path:       "/api/v1/category/1",
+			inputBody:  `{"name": "Entertainment", "parent_id": 2}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: tenantCtx,
+				id:  3,
+				input: service.CategoryInput{
+					Name: "Music",
+				},
+			},
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {
+				c.EXPECT().Update(a.ctx, a.id, a.input).Return(service.ErrCategoryNotFound)
+			},
+			path:       "/api/v1/category/3",
+			inputBody:  `{"name": "Music"}`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid id",
+			mockBehaviour: func(c *servicemocks.MockCategory, a args) {},
+			path:          "/api/v1/category/foo",
+			inputBody:     `{"name": "Music"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			c := servicemocks.NewMockCategory(ctrl)
+			tc.mockBehaviour(c, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Category: c})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, tc.path, bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+		})
+	}
+}
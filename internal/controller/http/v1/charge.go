@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/service"
+	"time"
+)
+
+type chargeRouter struct {
+	charge service.Charge
+}
+
+func newChargeRouter(g *echo.Group, charge service.Charge) {
+	r := &chargeRouter{
+		charge: charge,
+	}
+
+	g.POST("/generate", r.generate)
+	g.GET("/all", r.findAll)
+	g.GET("/:id", r.findById)
+	g.POST("/:id/pay", r.pay)
+	g.POST("/:id/fail", r.fail)
+	g.POST("/:id/refund", r.refund)
+}
+
+type generateInput struct {
+	Until *string `json:"until"`
+}
+
+type generateOutput struct {
+	Created int `json:"created"`
+}
+
+// @Summary		Generate
+// @Description	Record pending charge for every billing date of subscriptions up to until inclusive, current day of user by default. Until can be at most CHARGE_HORIZON_MONTHS ahead of today. Charges in free trial and paused periods are skipped, recorded charges are kept, so generation can be repeated
+// @Tags			charge
+// @Accept			json
+// @Produce		json
+// @Param			input	body		generateInput	false	"input"
+// @Success		200		{object}	generateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/charge/generate [post]
+func (r *chargeRouter) generate(c echo.Context) error {
+	var input generateInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var until time.Time
+
+	if input.Until != nil {
+		date, err := parseEndDate(*input.Until)
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+		until = date
+	}
+
+	created, err := r.charge.Generate(c.Request().Context(), until)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, generateOutput{
+		Created: created,
+	})
+}
+
+// @Summary		Find All
+// @Description	Find charges ordered by billing date
+// @Tags			charge
+// @Accept			json
+// @Produce		json
+// @Param			user_id			query		string	false	"user id"
+// @Param			subscription_id	query		int		false	"subscription id"
+// @Param			status			query		string	false	"pending, paid, failed or refunded"
+// @Param			start			query		string	false	"first billing date, yyyy-mm-dd or mm-yyyy"
+// @Param			end				query		string	false	"inclusive last billing date, yyyy-mm-dd or mm-yyyy (last day of month)"
+// @Success		200				{array}		service.ChargeOutput
+// @Failure		400				{string}	string	"Bad Request"
+// @Failure		500				{string}	string	"Internal Server Error"
+// @Router			/api/v1/charge/all [get]
+func (r *chargeRouter) findAll(c echo.Context) error {
+	filter, err := parseChargeFilter(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	charges, err := r.charge.FindAll(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, charges)
+}
+
+// @Summary		Find by id
+// @Description	Find charge by id
+// @Tags			charge
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"id"
+// @Success		200	{object}	service.ChargeOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/charge/{id} [get]
+func (r *chargeRouter) findById(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	charge, err := r.charge.FindById(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, charge)
+}
+
+// @Summary		Pay
+// @Description	Mark pending or failed charge as paid
+// @Tags			charge
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		409	{string}	string	"Conflict"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/charge/{id}/pay [post]
+func (r *chargeRouter) pay(c echo.Context) error {
+	return r.setStatus(c, dbmodel.ChargePaid)
+}
+
+// @Summary		Fail
+// @Description	Mark pending charge as failed
+// @Tags			charge
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		409	{string}	string	"Conflict"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/charge/{id}/fail [post]
+func (r *chargeRouter) fail(c echo.Context) error {
+	return r.setStatus(c, dbmodel.ChargeFailed)
+}
+
+// @Summary		Refund
+// @Description	Mark paid charge as refunded
+// @Tags			charge
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		409	{string}	string	"Conflict"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/charge/{id}/refund [post]
+func (r *chargeRouter) refund(c echo.Context) error {
+	return r.setStatus(c, dbmodel.ChargeRefunded)
+}
+
+func (r *chargeRouter) setStatus(c echo.Context, status string) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.charge.SetStatus(c.Request().Context(), id, status); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func parseChargeFilter(c echo.Context) (service.ChargeFilterInput, error) {
+	filter := service.ChargeFilterInput{
+		UserId: c.QueryParam("user_id"),
+		Status: c.QueryParam("status"),
+	}
+	switch filter.Status {
+	case "", dbmodel.ChargePending, dbmodel.ChargePaid, dbmodel.ChargeFailed, dbmodel.ChargeRefunded:
+	default:
+		return service.ChargeFilterInput{}, errors.New("invalid charge status")
+	}
+	if param := c.QueryParam("subscription_id"); param != "" {
+		id, err := strconv.Atoi(param)
+		if err != nil {
+			return service.ChargeFilterInput{}, err
+		}
+		filter.SubscriptionId = id
+	}
+	if param := c.QueryParam("start"); param != "" {
+		start, err := parseDate(param)
+		if err != nil {
+			return service.ChargeFilterInput{}, err
+		}
+		filter.StartDate = &start
+	}
+	if param := c.QueryParam("end"); param != "" {
+		end, err := parseEndDate(param)
+		if err != nil {
+			return service.ChargeFilterInput{}, err
+		}
+		filter.EndDate = &end
+	}
+	return filter, nil
+}
+
+// parsePriceSource parses optional source query param of reports, prices are computed from subscriptions by default
+func parsePriceSource(c echo.Context) (string, error) {
+	switch source := c.QueryParam("source"); source {
+	case "":
+		return service.PriceSourceSubscriptions, nil
+	case service.PriceSourceSubscriptions, service.PriceSourceLedger:
+		return source, nil
+	default:
+		return "", errors.New("invalid price source")
+	}
+}
@@ -0,0 +1,351 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestChargeRouter_generate(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		until time.Time
+	}
+
+	type mockBehaviour func(ch *servicemocks.MockCharge, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   tenantCtx,
+				until: time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().Generate(a.ctx, a.until).Return(3, nil)
+			},
+			inputBody:  `{"until":"2025-07-31"}`,
+			expectBody: `{"created":3}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "end of month",
+			args: args{
+				ctx:   tenantCtx,
+				until: time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().Generate(a.ctx, a.until).Return(1, nil)
+			},
+			inputBody:  `{"until":"07-2025"}`,
+			expectBody: `{"created":1}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "current day by default",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().Generate(a.ctx, time.Time{}).Return(0, nil)
+			},
+			inputBody:  `{}`,
+			expectBody: `{"created":0}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "incorrect until",
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {},
+			inputBody:     `{"until":"2025.07.31"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "until beyond horizon",
+			args: args{
+				ctx:   tenantCtx,
+				until: time.Date(2099, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().Generate(a.ctx, a.until).Return(0, service.ErrChargeBeyondHorizon)
+			},
+			inputBody:  `{"until":"2099-12-31"}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().Generate(a.ctx, time.Time{}).Return(0, errors.New("some error"))
+			},
+			inputBody:  `{}`,
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			ch := servicemocks.NewMockCharge(ctrl)
+			tc.mockBehaviour(ch, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Charge: ch})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/charge/generate", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestChargeRouter_findAll(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		filter service.ChargeFilterInput
+	}
+
+	type mockBehaviour func(ch *servicemocks.MockCharge, a args)
+
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 7, 10, 3, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		query         string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				filter: service.ChargeFilterInput{
+					UserId:         "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					SubscriptionId: 3,
+					Status:         dbmodel.ChargePending,
+					StartDate:      &start,
+					EndDate:        &end,
+				},
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().FindAll(a.ctx, a.filter).Return([]service.ChargeOutput{
+					{
+						Id:             1,
+						SubscriptionId: 3,
+						ServiceName:    "Yandex Plus",
+						UserId:         "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+						BillingDate:    "2025-07-10",
+						Amount:         400,
+						Status:         dbmodel.ChargePending,
+						CreatedAt:      createdAt,
+						UpdatedAt:      createdAt,
+					},
+				}, nil)
+			},
+			query: `user_id=60601fee-2bf1-4721-ae6f-7636e79a0cba&subscription_id=3&status=pending&start=2025-07-01&end=07-2025`,
+			expectBody: `[{"id":1,"subscription_id":3,"service_name":"Yandex Plus","user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba",` +
+				`"billing_date":"2025-07-10","amount":400,"tax":0,"discount":0,"status":"pending","created_at":"2025-07-10T03:00:00Z","updated_at":"2025-07-10T03:00:00Z"}]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "without filter",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().FindAll(a.ctx, a.filter).Return([]service.ChargeOutput{}, nil)
+			},
+			expectBody: `[]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "incorrect status",
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {},
+			query:         `status=cancelled`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "incorrect subscription id",
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {},
+			query:         `subscription_id=abc`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "incorrect start",
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {},
+			query:         `start=2025.07.01`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().FindAll(a.ctx, a.filter).Return(nil, errors.New("some error"))
+			},
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			ch := servicemocks.NewMockCharge(ctrl)
+			tc.mockBehaviour(ch, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Charge: ch})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/charge/all?"+tc.query, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestChargeRouter_setStatus(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     int64
+		status string
+	}
+
+	type mockBehaviour func(ch *servicemocks.MockCharge, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectCode    int
+	}{
+		{
+			testName: "pay",
+			args: args{
+				ctx:    tenantCtx,
+				id:     1,
+				status: dbmodel.ChargePaid,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().SetStatus(a.ctx, a.id, a.status).Return(nil)
+			},
+			path:       "/api/v1/charge/1/pay",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "fail",
+			args: args{
+				ctx:    tenantCtx,
+				id:     1,
+				status: dbmodel.ChargeFailed,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().SetStatus(a.ctx, a.id, a.status).Return(nil)
+			},
+			path:       "/api/v1/charge/1/fail",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "refund not paid charge",
+			args: args{
+				ctx:    tenantCtx,
+				id:     1,
+				status: dbmodel.ChargeRefunded,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().SetStatus(a.ctx, a.id, a.status).Return(service.ErrInvalidChargeStatus)
+			},
+			path:       "/api/v1/charge/1/refund",
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "charge not found",
+			args: args{
+				ctx:    tenantCtx,
+				id:     2,
+				status: dbmodel.ChargePaid,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().SetStatus(a.ctx, a.id, a.status).Return(service.ErrChargeNotFound)
+			},
+			path:       "/api/v1/charge/2/pay",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "incorrect id",
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {},
+			path:          "/api/v1/charge/abc/pay",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    tenantCtx,
+				id:     1,
+				status: dbmodel.ChargePaid,
+			},
+			mockBehaviour: func(ch *servicemocks.MockCharge, a args) {
+				ch.EXPECT().SetStatus(a.ctx, a.id, a.status).Return(errors.New("some error"))
+			},
+			path:       "/api/v1/charge/1/pay",
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			ch := servicemocks.NewMockCharge(ctrl)
+			tc.mockBehaviour(ch, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Charge: ch})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+		})
+	}
+}
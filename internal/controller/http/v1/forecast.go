@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+	"time"
+)
+
+const (
+	forecastDefaultMonths = 12
+	forecastMaxMonths     = 120
+)
+
+type forecastRouter struct {
+	forecast service.Forecast
+}
+
+func newForecastRouter(g *echo.Group, forecast service.Forecast) {
+	r := &forecastRouter{
+		forecast: forecast,
+	}
+
+	g.GET("/forecast", r.findForecast)
+	g.POST("/:id/price_changes", r.schedulePriceChange)
+	g.GET("/:id/price_changes", r.findPriceChanges)
+	g.DELETE("/:id/price_changes/:change_id", r.deletePriceChange)
+}
+
+// @Summary		Forecast
+// @Description	Project monthly spend of active subscriptions taking into account billing periods, end dates and scheduled price changes
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			months			query		int		false	"number of months, 12 by default"
+// @Param			start			query		string	false	"first month of forecast in format mm-yyyy, current month by default"
+// @Param			service_name	query		string	false	"name or alias of subscription service"
+// @Param			user_id			query		string	false	"user id"
+// @Success		200				{object}	service.ForecastOutput
+// @Failure		400				{string}	string	"Bad Request"
+// @Failure		500				{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/forecast [get]
+func (r *forecastRouter) findForecast(c echo.Context) error {
+	months := forecastDefaultMonths
+	if m := c.QueryParam("months"); m != "" {
+		var err error
+		if months, err = strconv.Atoi(m); err != nil || months < 1 || months > forecastMaxMonths {
+			return c.NoContent(http.StatusBadRequest)
+		}
+	}
+	start := time.Now()
+	if s := c.QueryParam("start"); s != "" {
+		var err error
+		if start, err = time.Parse("01-2006", s); err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+	}
+
+	f, err := r.forecast.Forecast(c.Request().Context(), service.ForecastInput{
+		UserId:      c.QueryParam("user_id"),
+		ServiceName: c.QueryParam("service_name"),
+		StartDate:   start,
+		Months:      months,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, f)
+}
+
+type priceChangeInput struct {
+	Price     int    `json:"price" validate:"required"`
+	StartDate string `json:"start_date" validate:"required"`
+}
+
+type priceChangeCreateOutput struct {
+	Id int `json:"id"`
+}
+
+// @Summary		Schedule price change
+// @Description	Schedule new price of subscription starting from month after subscription start. Change for the same month is replaced
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int					true	"subscription id"
+// @Param			input	body		priceChangeInput	true	"input"
+// @Success		200		{object}	priceChangeCreateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/price_changes [post]
+func (r *forecastRouter) schedulePriceChange(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input priceChangeInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	start, err := time.Parse("01-2006", input.StartDate)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	changeId, err := r.forecast.SchedulePriceChange(c.Request().Context(), id, service.PriceChangeInput{
+		Price:     input.Price,
+		StartDate: start,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, priceChangeCreateOutput{
+		Id: changeId,
+	})
+}
+
+// @Summary		Price changes
+// @Description	Scheduled price changes of subscription ordered by start date
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"subscription id"
+// @Success		200	{array}		service.PriceChangeOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/price_changes [get]
+func (r *forecastRouter) findPriceChanges(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	changes, err := r.forecast.FindPriceChanges(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, changes)
+}
+
+// @Summary		Delete price change
+// @Description	Delete scheduled price change of subscription
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int		true	"subscription id"
+// @Param			change_id	path		int		true	"price change id"
+// @Success		200			{string}	string	"OK"
+// @Failure		400			{string}	string	"Bad Request"
+// @Failure		404			{string}	string	"Not Found"
+// @Failure		500			{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/price_changes/{change_id} [delete]
+func (r *forecastRouter) deletePriceChange(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	changeId, err := strconv.Atoi(c.Param("change_id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.forecast.DeletePriceChange(c.Request().Context(), id, changeId); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
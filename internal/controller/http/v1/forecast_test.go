@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestForecastRouter_findForecast(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.ForecastInput
+	}
+
+	type mockBehaviour func(f *servicemocks.MockForecast, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.ForecastInput{
+					UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					Months:    1,
+				},
+			},
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {
+				f.EXPECT().Forecast(a.ctx, a.input).Return(service.ForecastOutput{
+					Total: 400,
+					Months: []service.ForecastMonthOutput{
+						{Month: "07-2025", Total: 400, Services: []service.ForecastServiceOutput{{ServiceName: "Yandex Plus", Price: 400}}},
+					},
+				}, nil)
+			},
+			path:       "/api/v1/subscription/forecast?months=1&start=07-2025&user_id=60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			expectBody: `{"total":400,"months":[{"month":"07-2025","total":400,"services":[{"service_name":"Yandex Plus","price":400}]}]}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "default months",
+			args: args{
+				ctx: tenantCtx,
+				input: service.ForecastInput{
+					StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					Months:    12,
+				},
+			},
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {
+				f.EXPECT().Forecast(a.ctx, a.input).Return(service.ForecastOutput{}, nil)
+			},
+			path:       "/api/v1/subscription/forecast?start=07-2025",
+			expectBody: `{"total":0,"months":null}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "invalid months",
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {},
+			path:          "/api/v1/subscription/forecast?months=0",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid start",
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {},
+			path:          "/api/v1/subscription/forecast?start=2025-07",
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			f := servicemocks.NewMockForecast(ctrl)
+			tc.mockBehaviour(f, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Forecast: f})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestForecastRouter_schedulePriceChange(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input service.PriceChangeInput
+	}
+
+	type mockBehaviour func(f *servicemocks.MockForecast, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.PriceChangeInput{Price: 500, StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {
+				f.EXPECT().SchedulePriceChange(a.ctx, a.id, a.input).Return(3, nil)
+			},
+			inputBody:  `{"price": 500, "start_date": "09-2025"}`,
+			expectBody: `{"id":3}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "change before subscription start",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.PriceChangeInput{Price: 500, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {
+				f.EXPECT().SchedulePriceChange(a.ctx, a.id, a.input).Return(0, service.ErrInvalidPriceChange)
+			},
+			inputBody:  `{"price": 500, "start_date": "01-2025"}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName: "subscription not found",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.PriceChangeInput{Price: 500, StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {
+				f.EXPECT().SchedulePriceChange(a.ctx, a.id, a.input).Return(0, service.ErrSubscriptionNotFound)
+			},
+			inputBody:  `{"price": 500, "start_date": "09-2025"}`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid date",
+			mockBehaviour: func(f *servicemocks.MockForecast, a args) {},
+			inputBody:     `{"price": 500, "start_date": "2025-09"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			f := servicemocks.NewMockForecast(ctrl)
+			tc.mockBehaviour(f, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Forecast: f})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/1/price_changes", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
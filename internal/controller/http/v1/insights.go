@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"subscription_service/internal/service"
+	"time"
+)
+
+type insightsRouter struct {
+	anomaly service.Anomaly
+	savings service.Savings
+}
+
+func newInsightsRouter(g *echo.Group, anomaly service.Anomaly, savings service.Savings) {
+	r := &insightsRouter{
+		anomaly: anomaly,
+		savings: savings,
+	}
+
+	g.GET("/anomalies", r.findAnomalies)
+	g.GET("/savings", r.findSavings)
+}
+
+type savingsInput struct {
+	UserId string `query:"user_id" validate:"required,uuid4"`
+	Date   string `query:"date"`
+}
+
+// @Summary		Find anomalies
+// @Description	Find price anomalies detected by background job, the latest first, optionally of one user. Increase is price raised compared with previous price of subscription, outlier is price above median price of the same service among users
+// @Tags			insights
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	false	"user id"
+// @Success		200		{array}		service.AnomalyOutput
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/insights/anomalies [get]
+func (r *insightsRouter) findAnomalies(c echo.Context) error {
+	anomalies, err := r.anomaly.FindAll(c.Request().Context(), c.QueryParam("user_id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, anomalies)
+}
+
+// @Summary		Find savings
+// @Description	Suggest savings on running subscriptions of user: duplicates and long unused subscriptions to cancel, family plan with other users of organization and yearly plan from catalog.
+// @Description	Every subscription gets at most one recommendation. date (yyyy-mm-dd) is today in users time zone by default
+// @Tags			insights
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	true	"user id"
+// @Param			date	query		string	false	"date"
+// @Success		200		{object}	service.SavingsOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/insights/savings [get]
+func (r *insightsRouter) findSavings(c echo.Context) error {
+	var input savingsInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var date *time.Time
+
+	if input.Date != "" {
+		t, err := time.Parse(time.DateOnly, input.Date)
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+		date = &t
+	}
+
+	s, err := r.savings.Recommend(c.Request().Context(), input.UserId, date)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
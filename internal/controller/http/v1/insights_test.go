@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestInsightsRouter_findAnomalies(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+	}
+
+	type mockBehaviour func(an *servicemocks.MockAnomaly, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	detectedAt := time.Date(2025, 7, 15, 3, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		query         string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+			},
+			mockBehaviour: func(an *servicemocks.MockAnomaly, a args) {
+				an.EXPECT().FindAll(a.ctx, a.userId).Return([]service.AnomalyOutput{
+					{
+						Id:             1,
+						SubscriptionId: 3,
+						ServiceName:    "Netflix",
+						UserId:         userId,
+						Kind:           dbmodel.AnomalyIncrease,
+						Price:          1000,
+						ReferencePrice: 800,
+						Percent:        25,
+						DetectedAt:     detectedAt,
+					},
+				}, nil)
+			},
+			query: `user_id=60601fee-2bf1-4721-ae6f-7636e79a0cba`,
+			expectBody: `[{"id":1,"subscription_id":3,"service_name":"Netflix","user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba",` +
+				`"kind":"increase","price":1000,"reference_price":800,"percent":25,"detected_at":"2025-07-15T03:00:00Z"}]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "without user",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(an *servicemocks.MockAnomaly, a args) {
+				an.EXPECT().FindAll(a.ctx, a.userId).Return([]service.AnomalyOutput{}, nil)
+			},
+			expectBody: `[]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(an *servicemocks.MockAnomaly, a args) {
+				an.EXPECT().FindAll(a.ctx, a.userId).Return(nil, errors.New("some error"))
+			},
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			an := servicemocks.NewMockAnomaly(ctrl)
+			tc.mockBehaviour(an, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Anomaly: an})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/insights/anomalies?"+tc.query, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestInsightsRouter_findSavings(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		date   *time.Time
+	}
+
+	type mockBehaviour func(sv *servicemocks.MockSavings, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	date := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		query         string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+				date:   &date,
+			},
+			mockBehaviour: func(sv *servicemocks.MockSavings, a args) {
+				sv.EXPECT().Recommend(a.ctx, a.userId, a.date).Return(service.SavingsOutput{
+					UserId:         userId,
+					Currency:       "RUB",
+					Date:           "2025-07-15",
+					MonthlySavings: 300,
+					Recommendations: []service.RecommendationOutput{
+						{Kind: "duplicate", ServiceId: 1, ServiceName: "Yandex Plus", SubscriptionIds: []int{1}, MonthlySavings: 300},
+					},
+				}, nil)
+			},
+			query: `user_id=60601fee-2bf1-4721-ae6f-7636e79a0cba&date=2025-07-15`,
+			expectBody: `{"user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba","currency":"RUB","date":"2025-07-15","monthly_savings":300,` +
+				`"recommendations":[{"kind":"duplicate","service_id":1,"service_name":"Yandex Plus","subscription_ids":[1],"monthly_savings":300}]}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "without user",
+			mockBehaviour: func(sv *servicemocks.MockSavings, a args) {},
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "incorrect date",
+			mockBehaviour: func(sv *servicemocks.MockSavings, a args) {},
+			query:         `user_id=60601fee-2bf1-4721-ae6f-7636e79a0cba&date=15-07-2025`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "user not found",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+			},
+			mockBehaviour: func(sv *servicemocks.MockSavings, a args) {
+				sv.EXPECT().Recommend(a.ctx, a.userId, a.date).Return(service.SavingsOutput{}, service.ErrUserNotFound)
+			},
+			query:      `user_id=60601fee-2bf1-4721-ae6f-7636e79a0cba`,
+			expectCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sv := servicemocks.NewMockSavings(ctrl)
+			tc.mockBehaviour(sv, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Savings: sv})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/insights/savings?"+tc.query, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			if tc.expectCode == http.StatusOK {
+				assert.Equal(t, tc.expectBody, rec.Body.String())
+			}
+		})
+	}
+}
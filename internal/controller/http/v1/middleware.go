@@ -4,9 +4,47 @@ import (
 	"errors"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"strconv"
 	"subscription_service/internal/service"
+	"subscription_service/pkg/tenant"
 )
 
+const tenantHeader = "X-Tenant"
+
+// tenantMiddleware binds request to organization from X-Tenant header. Requests without tenant work with
+// default organization. The header is not bound to any credential, so service must be reached only through
+// trusted proxy which authenticates callers and sets the header itself, overwriting one sent by client
+func tenantMiddleware(organization service.Organization) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			value := c.Request().Header.Get(tenantHeader)
+
+			id := tenant.DefaultOrganization
+
+			if value != "" {
+				var err error
+
+				id, err = strconv.Atoi(value)
+				if err != nil || id < 1 {
+					return c.NoContent(http.StatusBadRequest)
+				}
+				if _, err = organization.FindById(c.Request().Context(), id); err != nil {
+					return err
+				}
+			}
+
+			ctx := tenant.WithOrganization(c.Request().Context(), id)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+type duplicateSubscriptionOutput struct {
+	SubscriptionIds []int `json:"subscription_ids"`
+}
+
 func errorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		err := next(c)
@@ -14,10 +52,55 @@ func errorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return nil
 		}
 
+		// client needs conflicting subscriptions to resolve duplicate
+		var duplicate *service.DuplicateError
+		if errors.As(err, &duplicate) {
+			return c.JSON(http.StatusConflict, duplicateSubscriptionOutput{SubscriptionIds: duplicate.Ids})
+		}
+
 		switch {
-		case errors.Is(err, service.ErrSubscriptionNotFound):
+		case errors.Is(err, service.ErrOrganizationNotFound),
+			errors.Is(err, service.ErrUserNotFound),
+			errors.Is(err, service.ErrSubscriptionNotFound),
+			errors.Is(err, service.ErrShareNotFound),
+			errors.Is(err, service.ErrWebhookNotFound),
+			errors.Is(err, service.ErrWebhookDeliveryNotFound),
+			errors.Is(err, service.ErrBudgetNotFound),
+			errors.Is(err, service.ErrChargeNotFound),
+			errors.Is(err, service.ErrPriceChangeNotFound),
+			errors.Is(err, service.ErrServiceNotFound),
+			errors.Is(err, service.ErrCategoryNotFound),
+			errors.Is(err, service.ErrCalendarDisabled):
 			return c.NoContent(http.StatusNotFound)
 
+		case errors.Is(err, service.ErrOrganizationAlreadyExists),
+			errors.Is(err, service.ErrUserAlreadyExists),
+			errors.Is(err, service.ErrUserInUse),
+			errors.Is(err, service.ErrServiceAlreadyExists),
+			errors.Is(err, service.ErrServiceInUse),
+			errors.Is(err, service.ErrCategoryAlreadyExists),
+			errors.Is(err, service.ErrCategoryInUse),
+			errors.Is(err, service.ErrSubscriptionPaused),
+			errors.Is(err, service.ErrSubscriptionNotPaused),
+			errors.Is(err, service.ErrSubscriptionEnded),
+			errors.Is(err, service.ErrSubscriptionNotCancelled),
+			errors.Is(err, service.ErrInvalidChargeStatus):
+			return c.NoContent(http.StatusConflict)
+
+		case errors.Is(err, service.ErrInvalidTimezone),
+			errors.Is(err, service.ErrInvalidShare),
+			errors.Is(err, service.ErrInvalidPriceChange),
+			errors.Is(err, service.ErrInvalidTrial),
+			errors.Is(err, service.ErrInvalidDiscount),
+			errors.Is(err, service.ErrInvalidPause),
+			errors.Is(err, service.ErrInvalidCancellation),
+			errors.Is(err, service.ErrInvalidCategoryParent),
+			errors.Is(err, service.ErrChargeBeyondHorizon):
+			return c.NoContent(http.StatusBadRequest)
+
+		case errors.Is(err, service.ErrInvalidCalendarToken):
+			return c.NoContent(http.StatusForbidden)
+
 		default:
 			return c.NoContent(http.StatusInternalServerError)
 		}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/tenant"
+	"testing"
+)
+
+// tenantCtx is context of requests without tenant
+var tenantCtx = tenant.WithOrganization(context.Background(), tenant.DefaultOrganization)
+
+func TestTenantMiddleware(t *testing.T) {
+	type mockBehaviour func(o *servicemocks.MockOrganization, c *servicemocks.MockCategory)
+
+	testCases := []struct {
+		testName      string
+		header        string
+		query         string
+		mockBehaviour mockBehaviour
+		expectCode    int
+	}{
+		{
+			testName: "default organization",
+			mockBehaviour: func(o *servicemocks.MockOrganization, c *servicemocks.MockCategory) {
+				c.EXPECT().FindAll(tenantCtx).Return(nil, nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "organization from header",
+			header:   "2",
+			mockBehaviour: func(o *servicemocks.MockOrganization, c *servicemocks.MockCategory) {
+				o.EXPECT().FindById(gomock.Any(), 2).Return(service.OrganizationOutput{Id: 2}, nil)
+				c.EXPECT().FindAll(tenant.WithOrganization(context.Background(), 2)).Return(nil, nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "query parameter is ignored",
+			query:    "3",
+			mockBehaviour: func(o *servicemocks.MockOrganization, c *servicemocks.MockCategory) {
+				c.EXPECT().FindAll(tenantCtx).Return(nil, nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "unknown organization",
+			header:   "5",
+			mockBehaviour: func(o *servicemocks.MockOrganization, c *servicemocks.MockCategory) {
+				o.EXPECT().FindById(gomock.Any(), 5).Return(service.OrganizationOutput{}, service.ErrOrganizationNotFound)
+			},
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid organization",
+			header:        "abc",
+			mockBehaviour: func(o *servicemocks.MockOrganization, c *servicemocks.MockCategory) {},
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "zero organization",
+			header:        "0",
+			mockBehaviour: func(o *servicemocks.MockOrganization, c *servicemocks.MockCategory) {},
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			o := servicemocks.NewMockOrganization(ctrl)
+			c := servicemocks.NewMockCategory(ctrl)
+			tc.mockBehaviour(o, c)
+
+			e := echo.New()
+			NewRouter(e, &service.Services{Organization: o, Category: c})
+
+			target := "/api/v1/category/all"
+			if tc.query != "" {
+				target += "?tenant=" + tc.query
+			}
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			if tc.header != "" {
+				req.Header.Set("X-Tenant", tc.header)
+			}
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+)
+
+type organizationRouter struct {
+	organization service.Organization
+}
+
+func newOrganizationRouter(g *echo.Group, organization service.Organization) {
+	r := &organizationRouter{
+		organization: organization,
+	}
+
+	g.POST("", r.create)
+	g.GET("/all", r.findAll)
+	g.GET("/:id", r.findById)
+}
+
+type organizationInput struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type organizationCreateOutput struct {
+	Id int `json:"id"`
+}
+
+// @Summary		Create
+// @Description	Create organization. Its id is passed in X-Tenant header to work with its data
+// @Tags			organization
+// @Accept			json
+// @Produce		json
+// @Param			input	body		organizationInput	true	"input"
+// @Success		200		{object}	organizationCreateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		409		{string}	string	"Conflict"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/organization [post]
+func (r *organizationRouter) create(c echo.Context) error {
+	var input organizationInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	id, err := r.organization.Create(c.Request().Context(), service.OrganizationInput{
+		Name: input.Name,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, organizationCreateOutput{
+		Id: id,
+	})
+}
+
+// @Summary		Find All
+// @Description	Find all organizations
+// @Tags			organization
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		service.OrganizationOutput
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/organization/all [get]
+func (r *organizationRouter) findAll(c echo.Context) error {
+	organizations, err := r.organization.FindAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, organizations)
+}
+
+// @Summary		Find by id
+// @Description	Find organization by id
+// @Tags			organization
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"id"
+// @Success		200	{object}	service.OrganizationOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/organization/{id} [get]
+func (r *organizationRouter) findById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	organization, err := r.organization.FindById(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, organization)
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestOrganizationRouter_create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.OrganizationInput
+	}
+
+	type mockBehaviour func(o *servicemocks.MockOrganization, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				input: service.OrganizationInput{Name: "Acme"},
+			},
+			mockBehaviour: func(o *servicemocks.MockOrganization, a args) {
+				o.EXPECT().Create(a.ctx, a.input).Return(2, nil)
+			},
+			inputBody:  `{"name": "Acme"}`,
+			expectBody: `{"id":2}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "name is taken",
+			args: args{
+				ctx:   context.Background(),
+				input: service.OrganizationInput{Name: "default"},
+			},
+			mockBehaviour: func(o *servicemocks.MockOrganization, a args) {
+				o.EXPECT().Create(a.ctx, a.input).Return(0, service.ErrOrganizationAlreadyExists)
+			},
+			inputBody:  `{"name": "default"}`,
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName:      "missing name",
+			mockBehaviour: func(o *servicemocks.MockOrganization, a args) {},
+			inputBody:     `{}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			o := servicemocks.NewMockOrganization(ctrl)
+			tc.mockBehaviour(o, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Organization: o})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/organization", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestOrganizationRouter_findById(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type mockBehaviour func(o *servicemocks.MockOrganization)
+
+	testCases := []struct {
+		testName      string
+		id            string
+		mockBehaviour mockBehaviour
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			id:       "2",
+			mockBehaviour: func(o *servicemocks.MockOrganization) {
+				o.EXPECT().FindById(context.Background(), 2).Return(service.OrganizationOutput{
+					Id:        2,
+					Name:      "Acme",
+					CreatedAt: createdAt,
+				}, nil)
+			},
+			expectBody: `{"id":2,"name":"Acme","created_at":"2025-01-01T00:00:00Z"}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "not found",
+			id:       "5",
+			mockBehaviour: func(o *servicemocks.MockOrganization) {
+				o.EXPECT().FindById(context.Background(), 5).Return(service.OrganizationOutput{}, service.ErrOrganizationNotFound)
+			},
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid id",
+			id:            "abc",
+			mockBehaviour: func(o *servicemocks.MockOrganization) {},
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			o := servicemocks.NewMockOrganization(ctrl)
+			tc.mockBehaviour(o)
+
+			e := echo.New()
+			NewRouter(e, &service.Services{Organization: o})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/organization/"+tc.id, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"subscription_service/internal/service"
+)
+
+type reminderRouter struct {
+	reminder service.Reminder
+}
+
+func newReminderRouter(g *echo.Group, reminder service.Reminder) {
+	r := &reminderRouter{
+		reminder: reminder,
+	}
+
+	g.GET("/settings/:user_id", r.findSettings)
+	g.PUT("/settings/:user_id", r.updateSettings)
+}
+
+type reminderUserInput struct {
+	UserId string `param:"user_id" validate:"uuid4"`
+}
+
+// @Summary		Find settings
+// @Description	Find renewal reminder settings of user. Users without settings get default lead time
+// @Tags			reminder
+// @Accept			json
+// @Produce		json
+// @Param			user_id	path		string	true	"user id"
+// @Success		200		{object}	service.ReminderSettingsOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/reminder/settings/{user_id} [get]
+func (r *reminderRouter) findSettings(c echo.Context) error {
+	var input reminderUserInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	s, err := r.reminder.FindSettings(c.Request().Context(), input.UserId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+type reminderSettingsInput struct {
+	UserId   string  `param:"user_id" validate:"uuid4" swaggerignore:"true"`
+	LeadDays *int    `json:"lead_days" validate:"required,min=0,max=365"`
+	Email    *string `json:"email" validate:"omitempty,email"`
+}
+
+// @Summary		Update settings
+// @Description	Set how many days before charge user is reminded and email for reminders
+// @Tags			reminder
+// @Accept			json
+// @Produce		json
+// @Param			user_id	path		string					true	"user id"
+// @Param			input	body		reminderSettingsInput	true	"input"
+// @Success		200		{string}	string					"OK"
+// @Failure		400		{string}	string					"Bad Request"
+// @Failure		404		{string}	string					"Not Found"
+// @Failure		500		{string}	string					"Internal Server Error"
+// @Router			/api/v1/reminder/settings/{user_id} [put]
+func (r *reminderRouter) updateSettings(c echo.Context) error {
+	var input reminderSettingsInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	err := r.reminder.UpdateSettings(c.Request().Context(), input.UserId, service.ReminderSettingsInput{
+		LeadDays: *input.LeadDays,
+		Email:    input.Email,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+)
+
+func TestReminderRouter_findSettings(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+	}
+
+	type mockBehaviour func(r *servicemocks.MockReminder, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			},
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {
+				r.EXPECT().FindSettings(a.ctx, a.userId).Return(service.ReminderSettingsOutput{
+					UserId:   a.userId,
+					LeadDays: 3,
+				}, nil)
+			},
+			path:       "/api/v1/reminder/settings/60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			expectBody: `{"user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba","lead_days":3,"email":null}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "invalid user id",
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {},
+			path:          "/api/v1/reminder/settings/foo",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			},
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {
+				r.EXPECT().FindSettings(a.ctx, a.userId).Return(service.ReminderSettingsOutput{}, errors.New("some error"))
+			},
+			path:       "/api/v1/reminder/settings/60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			r := servicemocks.NewMockReminder(ctrl)
+			tc.mockBehaviour(r, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Reminder: r})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestReminderRouter_updateSettings(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		input  service.ReminderSettingsInput
+	}
+
+	type mockBehaviour func(r *servicemocks.MockReminder, a args)
+
+	email := "user@example.com"
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+				input: service.ReminderSettingsInput{
+					LeadDays: 7,
+					Email:    &email,
+				},
+			},
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {
+				r.EXPECT().UpdateSettings(a.ctx, a.userId, a.input).Return(nil)
+			},
+			inputBody:  `{"lead_days": 7, "email": "user@example.com"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "zero lead days",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+				input:  service.ReminderSettingsInput{LeadDays: 0},
+			},
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {
+				r.EXPECT().UpdateSettings(a.ctx, a.userId, a.input).Return(nil)
+			},
+			inputBody:  `{"lead_days": 0}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "user not found",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+				input:  service.ReminderSettingsInput{LeadDays: 3},
+			},
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {
+				r.EXPECT().UpdateSettings(a.ctx, a.userId, a.input).Return(service.ErrUserNotFound)
+			},
+			inputBody:  `{"lead_days": 3}`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "missing lead days",
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {},
+			inputBody:     `{"email": "user@example.com"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "negative lead days",
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {},
+			inputBody:     `{"lead_days": -1}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid email",
+			mockBehaviour: func(r *servicemocks.MockReminder, a args) {},
+			inputBody:     `{"lead_days": 3, "email": "foo"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			r := servicemocks.NewMockReminder(ctrl)
+			tc.mockBehaviour(r, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Reminder: r})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/reminder/settings/60601fee-2bf1-4721-ae6f-7636e79a0cba", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+		})
+	}
+}
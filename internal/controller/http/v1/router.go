@@ -5,6 +5,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	_ "subscription_service/docs"
+	"subscription_service/internal/controller/graphql"
 	"subscription_service/internal/service"
 )
 
@@ -15,9 +16,26 @@ func NewRouter(g *echo.Echo, services *service.Services) {
 	g.GET("/ping", ping)
 	g.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	v1 := g.Group("/api/v1")
+	newOrganizationRouter(g.Group("/api/v1/organization"), services.Organization)
 
+	v1 := g.Group("/api/v1", tenantMiddleware(services.Organization))
+
+	newUserRouter(v1.Group("/user"), services.User)
+	newStatementRouter(v1.Group("/user"), services.Statement)
 	newSubscriptionRouter(v1.Group("/subscription"), services.Subscription)
+	newCalendarRouter(g.Group("/api/v1/subscription"), v1.Group("/subscription"), services.Calendar)
+	newForecastRouter(v1.Group("/subscription"), services.Forecast)
+	newShareRouter(v1.Group("/subscription"), services.Share)
+	newCatalogRouter(v1.Group("/service"), services.Catalog)
+	newCategoryRouter(v1.Group("/category"), services.Category)
+	newWebhookRouter(v1.Group("/webhook"), services.Webhook)
+	newReminderRouter(v1.Group("/reminder"), services.Reminder)
+	newBudgetRouter(v1.Group("/budget"), services.Budget)
+	newChargeRouter(v1.Group("/charge"), services.Charge)
+	newAnalyticsRouter(v1.Group("/analytics"), services.Analytics)
+	newInsightsRouter(v1.Group("/insights"), services.Anomaly, services.Savings)
+
+	g.POST("/graphql", echo.WrapHandler(graphql.NewHandler(services)), tenantMiddleware(services.Organization))
 }
 
 func ping(c echo.Context) error {
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+)
+
+type shareRouter struct {
+	share service.Share
+}
+
+func newShareRouter(g *echo.Group, share service.Share) {
+	r := &shareRouter{
+		share: share,
+	}
+
+	g.GET("/settle", r.settleUp)
+	g.PUT("/:id/share", r.set)
+	g.GET("/:id/share", r.find)
+	g.DELETE("/:id/share", r.delete)
+}
+
+type shareInput struct {
+	Rule    string             `json:"rule" validate:"required,oneof=equal percentage fixed"`
+	Members []shareMemberInput `json:"members" validate:"required,min=1,max=20,dive"`
+}
+
+type shareMemberInput struct {
+	UserId  string `json:"user_id" validate:"required,uuid4"`
+	Percent *int   `json:"percent" validate:"omitempty,min=1,max=100"`
+	Amount  *int   `json:"amount" validate:"omitempty,min=1"`
+}
+
+// @Summary		Share
+// @Description	Share subscription with members replacing previous shares. Owner of subscription pays the rest of price.
+// @Description	Rule equal divides price equally, percentage requires percent and fixed requires amount of every member
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int			true	"subscription id"
+// @Param			input	body		shareInput	true	"input"
+// @Success		200		{string}	string		"OK"
+// @Failure		400		{string}	string		"Bad Request"
+// @Failure		404		{string}	string		"Not Found"
+// @Failure		500		{string}	string		"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/share [put]
+func (r *shareRouter) set(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input shareInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	members := make([]service.ShareMemberInput, 0, len(input.Members))
+	for _, m := range input.Members {
+		members = append(members, service.ShareMemberInput{
+			UserId:  m.UserId,
+			Percent: m.Percent,
+			Amount:  m.Amount,
+		})
+	}
+	err = r.share.Set(c.Request().Context(), id, service.ShareInput{
+		Rule:    input.Rule,
+		Members: members,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Find share
+// @Description	Split rule of shared subscription with monthly cost of owner and every member
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"subscription id"
+// @Success		200	{object}	service.ShareOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/share [get]
+func (r *shareRouter) find(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	s, err := r.share.Find(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+// @Summary		Unshare
+// @Description	Make subscription not shared, owner pays full price again
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"subscription id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/share [delete]
+func (r *shareRouter) delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = r.share.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Settle up
+// @Description	Who owes whom per month: members of shared subscriptions owe their share to owner on every charge.
+// @Description	Debts of two users to each other are netted
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			user_id	query		string	false	"only debts of user and to user"
+// @Param			start	query		string	true	"start of the time interval. Must be in format mm-yyyy"
+// @Param			end		query		string	true	"end of the time interval. Must be in format mm-yyyy"
+// @Success		200		{array}		service.SettleUpMonthOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/settle [get]
+func (r *shareRouter) settleUp(c echo.Context) error {
+	start, end, err := parseMonthRange(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	s, err := r.share.SettleUp(c.Request().Context(), service.SettleUpInput{
+		UserId:    c.QueryParam("user_id"),
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
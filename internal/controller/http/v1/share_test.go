@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestShareRouter_set(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		subscriptionId int
+		input          service.ShareInput
+	}
+
+	type mockBehaviour func(s *servicemocks.MockShare, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		inputBody     string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:            tenantCtx,
+				subscriptionId: 1,
+				input: service.ShareInput{
+					Rule: "percentage",
+					Members: []service.ShareMemberInput{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a", Percent: ptr(30)},
+					},
+				},
+			},
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {
+				s.EXPECT().Set(a.ctx, a.subscriptionId, a.input).Return(nil)
+			},
+			path:       "/api/v1/subscription/1/share",
+			inputBody:  `{"rule": "percentage", "members": [{"user_id": "2344696a-d069-4fad-a3ed-f27c13651c3a", "percent": 30}]}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "invalid shares",
+			args: args{
+				ctx:            tenantCtx,
+				subscriptionId: 1,
+				input: service.ShareInput{
+					Rule: "fixed",
+					Members: []service.ShareMemberInput{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a"},
+					},
+				},
+			},
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {
+				s.EXPECT().Set(a.ctx, a.subscriptionId, a.input).Return(service.ErrInvalidShare)
+			},
+			path:       "/api/v1/subscription/1/share",
+			inputBody:  `{"rule": "fixed", "members": [{"user_id": "2344696a-d069-4fad-a3ed-f27c13651c3a"}]}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName: "subscription not found",
+			args: args{
+				ctx:            tenantCtx,
+				subscriptionId: 2,
+				input: service.ShareInput{
+					Rule: "equal",
+					Members: []service.ShareMemberInput{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a"},
+					},
+				},
+			},
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {
+				s.EXPECT().Set(a.ctx, a.subscriptionId, a.input).Return(service.ErrSubscriptionNotFound)
+			},
+			path:       "/api/v1/subscription/2/share",
+			inputBody:  `{"rule": "equal", "members": [{"user_id": "2344696a-d069-4fad-a3ed-f27c13651c3a"}]}`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "unknown rule",
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {},
+			path:          "/api/v1/subscription/1/share",
+			inputBody:     `{"rule": "random", "members": [{"user_id": "2344696a-d069-4fad-a3ed-f27c13651c3a"}]}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "no members",
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {},
+			path:          "/api/v1/subscription/1/share",
+			inputBody:     `{"rule": "equal", "members": []}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid member id",
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {},
+			path:          "/api/v1/subscription/1/share",
+			inputBody:     `{"rule": "equal", "members": [{"user_id": "bob"}]}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			s := servicemocks.NewMockShare(ctrl)
+			tc.mockBehaviour(s, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Share: s})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, tc.path, bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+		})
+	}
+}
+
+func TestShareRouter_settleUp(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.SettleUpInput
+	}
+
+	type mockBehaviour func(s *servicemocks.MockShare, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		query         string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SettleUpInput{
+					UserId:    "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {
+				s.EXPECT().SettleUp(a.ctx, a.input).Return([]service.SettleUpMonthOutput{
+					{
+						Month: "01-2025",
+						Debts: []service.DebtOutput{
+							{From: "2344696a-d069-4fad-a3ed-f27c13651c3a", To: "6114696a-d069-4fad-a3ed-f27c13651c3a", Amount: 300},
+						},
+					},
+				}, nil)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&start=01-2025&end=01-2025`,
+			expectBody: `[{"month":"01-2025","debts":[{"from":"2344696a-d069-4fad-a3ed-f27c13651c3a","to":"6114696a-d069-4fad-a3ed-f27c13651c3a","amount":300}]}]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "end before start",
+			mockBehaviour: func(s *servicemocks.MockShare, a args) {},
+			query:         `start=03-2025&end=01-2025`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			s := servicemocks.NewMockShare(ctrl)
+			tc.mockBehaviour(s, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Share: s})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/settle?"+tc.query, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"subscription_service/internal/service"
+	"time"
+)
+
+const (
+	statementFormatHTML = "html"
+	statementFormatPDF  = "pdf"
+
+	mimeApplicationPDF = "application/pdf"
+)
+
+type statementRouter struct {
+	statement service.Statement
+}
+
+func newStatementRouter(g *echo.Group, statement service.Statement) {
+	r := &statementRouter{
+		statement: statement,
+	}
+
+	g.GET("/:id/statements/:month", r.find)
+}
+
+type statementInput struct {
+	Id     string `param:"id" validate:"uuid4"`
+	Month  string `param:"month" validate:"required"`
+	Format string `query:"format" validate:"omitempty,oneof=json html pdf"`
+}
+
+// @Summary		Statement
+// @Description	Monthly statement of user: own and shared subscriptions active in month with part of price paid by user and totals by currency.
+// @Description	Rendered as json, html or pdf depending on format
+// @Tags			user
+// @Produce		json,html,application/pdf
+// @Param			id		path		string	true	"id"
+// @Param			month	path		string	true	"month, yyyy-mm or mm-yyyy"
+// @Param			format	query		string	false	"json (default), html or pdf"
+// @Success		200		{object}	service.StatementOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/user/{id}/statements/{month} [get]
+func (r *statementRouter) find(c echo.Context) error {
+	var input statementInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	month, err := parseMonth(input.Month)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	ctx := c.Request().Context()
+
+	switch input.Format {
+	case statementFormatHTML:
+		html, err := r.statement.HTML(ctx, input.Id, month)
+		if err != nil {
+			return err
+		}
+		return c.HTMLBlob(http.StatusOK, html)
+
+	case statementFormatPDF:
+		pdf, err := r.statement.PDF(ctx, input.Id, month)
+		if err != nil {
+			return err
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=statement-%s.pdf", month.Format("2006-01")))
+		return c.Blob(http.StatusOK, mimeApplicationPDF, pdf)
+
+	default:
+		s, err := r.statement.Find(ctx, input.Id, month)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, s)
+	}
+}
+
+// parseMonth parses month in yyyy-mm or legacy mm-yyyy format
+func parseMonth(s string) (time.Time, error) {
+	if t, err := time.Parse(legacyMonthLayout, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01", s)
+}
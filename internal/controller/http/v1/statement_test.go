@@ -0,0 +1,185 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestStatementRouter_find(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		month  time.Time
+	}
+
+	type mockBehaviour func(st *servicemocks.MockStatement, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName          string
+		args              args
+		mockBehaviour     mockBehaviour
+		inputPath         string
+		expectBody        string
+		expectCode        int
+		expectContentType string
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+				month:  july,
+			},
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {
+				st.EXPECT().Find(a.ctx, a.userId, a.month).Return(service.StatementOutput{
+					UserId: userId,
+					Month:  "07-2025",
+					Items: []service.StatementItemOutput{
+						{
+							SubscriptionId: 1, ServiceName: "Yandex Plus", BillingPeriod: "monthly",
+							BillingDate: "2025-07-10", PeriodEnd: "2025-08-09", Price: 400, Amount: 200, Currency: "RUB", Shared: true,
+						},
+					},
+					Totals: []service.StatementTotalOutput{{Currency: "RUB", Amount: 200}},
+				}, nil)
+			},
+			inputPath: "/api/v1/user/" + userId + "/statements/2025-07",
+			expectBody: `{"user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba","month":"07-2025","items":[{"subscription_id":1,` +
+				`"service_name":"Yandex Plus","billing_period":"monthly","billing_date":"2025-07-10","period_end":"2025-08-09",` +
+				`"price":400,"amount":200,"currency":"RUB","shared":true}],"totals":[{"currency":"RUB","amount":200}]}` + "\n",
+			expectCode:        http.StatusOK,
+			expectContentType: echo.MIMEApplicationJSON,
+		},
+		{
+			testName: "legacy month",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+				month:  july,
+			},
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {
+				st.EXPECT().Find(a.ctx, a.userId, a.month).Return(service.StatementOutput{
+					UserId: userId,
+					Month:  "07-2025",
+					Items:  []service.StatementItemOutput{},
+					Totals: []service.StatementTotalOutput{},
+				}, nil)
+			},
+			inputPath:         "/api/v1/user/" + userId + "/statements/07-2025?format=json",
+			expectBody:        `{"user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba","month":"07-2025","items":[],"totals":[]}` + "\n",
+			expectCode:        http.StatusOK,
+			expectContentType: echo.MIMEApplicationJSON,
+		},
+		{
+			testName: "html",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+				month:  july,
+			},
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {
+				st.EXPECT().HTML(a.ctx, a.userId, a.month).Return([]byte("<html></html>"), nil)
+			},
+			inputPath:         "/api/v1/user/" + userId + "/statements/2025-07?format=html",
+			expectBody:        "<html></html>",
+			expectCode:        http.StatusOK,
+			expectContentType: echo.MIMETextHTMLCharsetUTF8,
+		},
+		{
+			testName: "pdf",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+				month:  july,
+			},
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {
+				st.EXPECT().PDF(a.ctx, a.userId, a.month).Return([]byte("%PDF-1.4"), nil)
+			},
+			inputPath:         "/api/v1/user/" + userId + "/statements/2025-07?format=pdf",
+			expectBody:        "%PDF-1.4",
+			expectCode:        http.StatusOK,
+			expectContentType: mimeApplicationPDF,
+		},
+		{
+			testName:      "incorrect id",
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {},
+			inputPath:     "/api/v1/user/1/statements/2025-07",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "incorrect month",
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {},
+			inputPath:     "/api/v1/user/" + userId + "/statements/2025-13",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "incorrect format",
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {},
+			inputPath:     "/api/v1/user/" + userId + "/statements/2025-07?format=xml",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "user not found",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+				month:  july,
+			},
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {
+				st.EXPECT().PDF(a.ctx, a.userId, a.month).Return(nil, service.ErrUserNotFound)
+			},
+			inputPath:  "/api/v1/user/" + userId + "/statements/2025-07?format=pdf",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    tenantCtx,
+				userId: userId,
+				month:  july,
+			},
+			mockBehaviour: func(st *servicemocks.MockStatement, a args) {
+				st.EXPECT().Find(a.ctx, a.userId, a.month).Return(service.StatementOutput{}, errors.New("some error"))
+			},
+			inputPath:  "/api/v1/user/" + userId + "/statements/2025-07",
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			st := servicemocks.NewMockStatement(ctrl)
+			tc.mockBehaviour(st, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Statement: st})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.inputPath, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			if tc.expectCode == http.StatusOK {
+				assert.Equal(t, tc.expectBody, rec.Body.String())
+				assert.Equal(t, tc.expectContentType, rec.Header().Get(echo.HeaderContentType))
+			}
+		})
+	}
+}
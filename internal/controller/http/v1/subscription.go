@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"errors"
 	"github.com/labstack/echo/v4"
 	"net/http"
 	"strconv"
@@ -21,27 +22,81 @@ func newSubscriptionRouter(g *echo.Group, sub service.Subscription) {
 	g.GET("/all", r.findAll)
 	g.GET("/:id", r.findById)
 	g.GET("/price", r.findPrice)
+	g.GET("/duplicates", r.findDuplicates)
 	g.PUT("/:id", r.update)
 	g.DELETE("/:id", r.delete)
+	g.POST("/:id/pause", r.pause)
+	g.POST("/:id/resume", r.resume)
+	g.POST("/:id/cancel", r.cancel)
+	g.POST("/:id/uncancel", r.uncancel)
 }
 
 type subscriptionInput struct {
-	ServiceName string  `json:"service_name" validate:"required"`
-	Price       int     `json:"price" validate:"required"`
-	UserId      string  `json:"user_id" validate:"required,uuid4"`
-	StartDate   string  `json:"start_date" validate:"required"`
-	EndDate     *string `json:"end_date"`
+	ServiceId     int      `json:"service_id" validate:"omitempty,min=1"`
+	ServiceName   string   `json:"service_name" validate:"required_without=ServiceId"`
+	Price         int      `json:"price" validate:"required"`
+	UserId        string   `json:"user_id" validate:"required,uuid4"`
+	StartDate     string   `json:"start_date" validate:"required"`
+	EndDate       *string  `json:"end_date"`
+	BillingPeriod string   `json:"billing_period" validate:"omitempty,oneof=monthly yearly"`
+	CategoryId    *int     `json:"category_id" validate:"omitempty,min=1"`
+	Tags          []string `json:"tags" validate:"max=20,dive,required,max=50"`
+	TrialEndDate  *string  `json:"trial_end_date"`
+	IntroPrice    *int     `json:"intro_price" validate:"omitempty,min=0"`
+	IntroPeriods  int      `json:"intro_periods" validate:"min=0"`
+
+	// TaxRate is percent, tax is added on top of price unless TaxInclusive
+	TaxRate      int            `json:"tax_rate" validate:"min=0,max=100"`
+	TaxInclusive bool           `json:"tax_inclusive"`
+	Discount     *discountInput `json:"discount"`
+
+	// LastUsedDate is the last day user used subscription, long unused ones are suggested for cancellation
+	LastUsedDate *string `json:"last_used_date"`
+}
+
+// discountInput is percent or fixed discount from start_date (start of subscription by default) for months, forever if 0
+type discountInput struct {
+	Type      string  `json:"type" validate:"required,oneof=percent fixed"`
+	Value     int     `json:"value" validate:"required,min=1"`
+	StartDate *string `json:"start_date"`
+	Months    int     `json:"months" validate:"min=0"`
+}
+
+type pauseInput struct {
+	StartDate string  `json:"start_date" validate:"required"`
+	EndDate   *string `json:"end_date"`
+}
+
+type resumeInput struct {
+	Date *string `json:"date"`
+}
+
+// legacyMonthLayout is mm-yyyy format of dates accepted for backward compatibility
+const legacyMonthLayout = "01-2006"
+
+// cancelAtMonth cancels subscription at the last day of month, kept for backward compatibility
+const cancelAtMonth = "month"
+
+type cancelInput struct {
+	When   string  `json:"when" validate:"required,oneof=immediately end_of_period date month"`
+	Date   *string `json:"date" validate:"required_if=When date"`
+	Month  *string `json:"month" validate:"required_if=When month"`
+	Reason *string `json:"reason" validate:"omitempty,max=500"`
 }
 
 // @Summary		Create
-// @Description	Create new subscription in database
+// @Description	Create new subscription in database. Service is set by service_id or by service_name, unknown name is added to catalog.
+// @Description	Subscription overlapping with another one of the same user and service is rejected unless allow_duplicate is true
 // @Tags			subscription
 // @Accept			json
 // @Produce		json
-// @Param			input	body		subscriptionInput	true	"input"
-// @Success		200		{string}	string				"OK"
-// @Failure		400		{string}	string				"Bad Request"
-// @Failure		500		{string}	string				"Internal Server Error"
+// @Param			input			body		subscriptionInput			true	"input"
+// @Param			allow_duplicate	query		bool						false	"create subscription even if it duplicates another one"
+// @Success		200				{string}	string						"OK"
+// @Failure		400				{string}	string						"Bad Request"
+// @Failure		404				{string}	string						"Not Found"
+// @Failure		409				{object}	duplicateSubscriptionOutput	"Conflict"
+// @Failure		500				{string}	string						"Internal Server Error"
 // @Router			/api/v1/subscription [post]
 func (r *subscriptionRouter) create(c echo.Context) error {
 	var input subscriptionInput
@@ -57,6 +112,11 @@ func (r *subscriptionRouter) create(c echo.Context) error {
 	if err != nil {
 		return c.NoContent(http.StatusBadRequest)
 	}
+	if v := c.QueryParam("allow_duplicate"); v != "" {
+		if s.AllowDuplicate, err = strconv.ParseBool(v); err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+	}
 
 	if err = r.sub.Create(c.Request().Context(), s); err != nil {
 		return err
@@ -69,12 +129,22 @@ func (r *subscriptionRouter) create(c echo.Context) error {
 // @Tags			subscription
 // @Accept			json
 // @Produce		json
-// @Success		200	{array}		service.SubscriptionInput
-// @Failure		400	{string}	string	"Bad Request"
-// @Failure		500	{string}	string	"Internal Server Error"
+// @Param			category_id	query		int		false	"category id, subcategories are included"
+// @Param			tag			query		string	false	"tag"
+// @Success		200			{array}		service.SubscriptionOutput
+// @Failure		400			{string}	string	"Bad Request"
+// @Failure		500			{string}	string	"Internal Server Error"
 // @Router			/api/v1/subscription/all [get]
 func (r *subscriptionRouter) findAll(c echo.Context) error {
-	s, err := r.sub.FindAll(c.Request().Context())
+	categoryId, err := parseCategoryId(c)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	s, err := r.sub.FindAll(c.Request().Context(), service.SubscriptionFilterInput{
+		CategoryId: categoryId,
+		Tag:        c.QueryParam("tag"),
+	})
 	if err != nil {
 		return err
 	}
@@ -104,29 +174,42 @@ func (r *subscriptionRouter) findById(c echo.Context) error {
 	return c.JSON(http.StatusOK, s)
 }
 
+// subscriptionPriceOutput keeps price for backward compatibility, it is the same as gross
 type subscriptionPriceOutput struct {
 	Price int `json:"price"`
+	service.PriceOutput
 }
 
 // @Summary		Price
-// @Description	Find total price for subscriptions for time interval
+// @Description	Find total price for subscriptions for time interval: gross paid with tax, net without it, tax and discount subtracted from price
 // @Tags			subscription
 // @Accept			json
 // @Produce		json
-// @Param			service_name	query		string	false	"name of subscription service"
-// @Param			user_id			query		string	false	"user id"
-// @Param			start			query		string	true	"start of the time interval. Must be in format mm-yyyy"
-// @Param			end				query		string	true	"end of the time interval. Must be in format mm-yyyy"
+// @Param			service_name	query		string	false	"name or alias of subscription service"
+// @Param			user_id			query		string	false	"user id, only part of price paid by user is counted for shared subscriptions"
+// @Param			category_id		query		int		false	"category id, subcategories are included"
+// @Param			tag				query		string	false	"tag"
+// @Param			start			query		string	true	"start of the time interval, yyyy-mm-dd or mm-yyyy"
+// @Param			end				query		string	true	"inclusive end of the time interval, yyyy-mm-dd or mm-yyyy (last day of month)"
+// @Param			source			query		string	false	"subscriptions (default) sums prices of subscriptions active in the interval, ledger sums pending and paid charges billed in it"
 // @Success		200				{object}	subscriptionPriceOutput
 // @Failure		400				{string}	string	"Bad Request"
 // @Failure		500				{string}	string	"Internal Server Error"
 // @Router			/api/v1/subscription/price [get]
 func (r *subscriptionRouter) findPrice(c echo.Context) error {
-	start, err := time.Parse("01-2006", c.QueryParam("start"))
+	categoryId, err := parseCategoryId(c)
 	if err != nil {
 		return c.NoContent(http.StatusBadRequest)
 	}
-	end, err := time.Parse("01-2006", c.QueryParam("end"))
+	start, err := parseDate(c.QueryParam("start"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	end, err := parseEndDate(c.QueryParam("end"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	source, err := parsePriceSource(c)
 	if err != nil {
 		return c.NoContent(http.StatusBadRequest)
 	}
@@ -134,17 +217,37 @@ func (r *subscriptionRouter) findPrice(c echo.Context) error {
 	price, err := r.sub.FindPrice(c.Request().Context(), service.PriceInput{
 		ServiceName: c.QueryParam("service_name"),
 		UserId:      c.QueryParam("user_id"),
+		CategoryId:  categoryId,
+		Tag:         c.QueryParam("tag"),
 		StartDate:   start,
 		EndDate:     end,
+		Source:      source,
 	})
 	if err != nil {
 		return err
 	}
 	return c.JSON(http.StatusOK, subscriptionPriceOutput{
-		Price: price,
+		Price:       price.Gross,
+		PriceOutput: price,
 	})
 }
 
+// @Summary		Duplicates
+// @Description	Find subscriptions of the same user and catalog service (aliases are matched) with overlapping periods
+// @Tags			subscription
+// @Produce		json
+// @Param			user_id	query		string	false	"user id, all users if empty"
+// @Success		200		{array}		service.DuplicateOutput
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/duplicates [get]
+func (r *subscriptionRouter) findDuplicates(c echo.Context) error {
+	duplicates, err := r.sub.FindDuplicates(c.Request().Context(), c.QueryParam("user_id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, duplicates)
+}
+
 // @Summary		Update
 // @Description	Update subscription in database by id
 // @Tags			subscription
@@ -206,23 +309,249 @@ func (r *subscriptionRouter) delete(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// @Summary		Pause
+// @Description	Pause subscription from start_date to inclusive end_date, without end_date until resume. Charges within pause are skipped
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int			true	"id"
+// @Param			input	body		pauseInput	true	"input"
+// @Success		200		{string}	string		"OK"
+// @Failure		400		{string}	string		"Bad Request"
+// @Failure		404		{string}	string		"Not Found"
+// @Failure		409		{string}	string		"Conflict"
+// @Failure		500		{string}	string		"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/pause [post]
+func (r *subscriptionRouter) pause(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input pauseInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	start, err := parseDate(input.StartDate)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	p := service.PauseInput{StartDate: start}
+	if input.EndDate != nil {
+		end, err := parseEndDate(*input.EndDate)
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+		p.EndDate = &end
+	}
+
+	if err = r.sub.Pause(c.Request().Context(), id, p); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Resume
+// @Description	Resume paused subscription from date, today of user by default
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int			true	"id"
+// @Param			input	body		resumeInput	false	"input"
+// @Success		200		{string}	string		"OK"
+// @Failure		400		{string}	string		"Bad Request"
+// @Failure		404		{string}	string		"Not Found"
+// @Failure		409		{string}	string		"Conflict"
+// @Failure		500		{string}	string		"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/resume [post]
+func (r *subscriptionRouter) resume(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input resumeInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	var res service.ResumeInput
+	if input.Date != nil {
+		date, err := parseDate(*input.Date)
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+		res.Date = &date
+	}
+
+	if err = r.sub.Resume(c.Request().Context(), id, res); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Cancel
+// @Description	Cancel subscription immediately, at the end of current billing period, on date or at the end of month, storing the reason. The end date is the last day of subscription
+// @Tags			subscription
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int			true	"id"
+// @Param			input	body		cancelInput	true	"input"
+// @Success		200		{string}	string		"OK"
+// @Failure		400		{string}	string		"Bad Request"
+// @Failure		404		{string}	string		"Not Found"
+// @Failure		409		{string}	string		"Conflict"
+// @Failure		500		{string}	string		"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/cancel [post]
+func (r *subscriptionRouter) cancel(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input cancelInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	cancel := service.CancelInput{When: input.When, Reason: input.Reason}
+	switch input.When {
+	case cancelAtMonth:
+		date, err := parseEndDate(*input.Month)
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+		cancel.When, cancel.Date = service.CancelAtDate, &date
+	case service.CancelAtDate:
+		date, err := parseDate(*input.Date)
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+		cancel.Date = &date
+	}
+
+	if err = r.sub.Cancel(c.Request().Context(), id, cancel); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Uncancel
+// @Description	Revoke cancellation of subscription while its end date is in the future
+// @Tags			subscription
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		409	{string}	string	"Conflict"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/subscription/{id}/uncancel [post]
+func (r *subscriptionRouter) uncancel(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.sub.Uncancel(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
 func parseInputDate(input subscriptionInput) (service.SubscriptionInput, error) {
-	start, err := time.Parse("01-2006", input.StartDate)
+	start, err := parseDate(input.StartDate)
 	if err != nil {
 		return service.SubscriptionInput{}, err
 	}
 	s := service.SubscriptionInput{
-		ServiceName: input.ServiceName,
-		Price:       input.Price,
-		UserId:      input.UserId,
-		StartDate:   start,
+		ServiceId:     input.ServiceId,
+		ServiceName:   input.ServiceName,
+		Price:         input.Price,
+		UserId:        input.UserId,
+		StartDate:     start,
+		BillingPeriod: input.BillingPeriod,
+		CategoryId:    input.CategoryId,
+		Tags:          input.Tags,
+		IntroPrice:    input.IntroPrice,
+		IntroPeriods:  input.IntroPeriods,
+		TaxRate:       input.TaxRate,
+		TaxInclusive:  input.TaxInclusive,
 	}
 	if input.EndDate != nil {
-		end, err := time.Parse("01-2006", *input.EndDate)
+		end, err := parseEndDate(*input.EndDate)
 		if err != nil {
 			return service.SubscriptionInput{}, err
 		}
 		s.EndDate = &end
 	}
+	if input.TrialEndDate != nil {
+		trialEnd, err := parseEndDate(*input.TrialEndDate)
+		if err != nil {
+			return service.SubscriptionInput{}, err
+		}
+		s.TrialEndDate = &trialEnd
+	}
+	if input.LastUsedDate != nil {
+		lastUsed, err := parseDate(*input.LastUsedDate)
+		if err != nil {
+			return service.SubscriptionInput{}, err
+		}
+		s.LastUsedDate = &lastUsed
+	}
+	if d := input.Discount; d != nil {
+		s.Discount = &service.DiscountInput{Type: d.Type, Value: d.Value, Months: d.Months}
+		if d.StartDate != nil {
+			start, err := parseDate(*d.StartDate)
+			if err != nil {
+				return service.SubscriptionInput{}, err
+			}
+			s.Discount.StartDate = &start
+		}
+	}
 	return s, nil
 }
+
+// parseDate parses ISO 8601 date, date with time is truncated to its day. Month in legacy mm-yyyy format
+// means its first day
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(legacyMonthLayout, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// parseEndDate parses inclusive end date like parseDate, but legacy month means its last day
+func parseEndDate(s string) (time.Time, error) {
+	if t, err := time.Parse(legacyMonthLayout, s); err == nil {
+		return t.AddDate(0, 1, -1), nil
+	}
+	return parseDate(s)
+}
+
+// parseCategoryId returns category_id query parameter, zero if it is not set
+func parseCategoryId(c echo.Context) (int, error) {
+	param := c.QueryParam("category_id")
+	if param == "" {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(param)
+	if err != nil || id < 1 {
+		return 0, errors.New("invalid category id")
+	}
+	return id, nil
+}
@@ -29,19 +29,21 @@ func TestSubscriptionRouter_create(t *testing.T) {
 		testName      string
 		args          args
 		mockBehaviour mockBehaviour
+		query         string
 		inputBody     string
+		expectBody    string
 		expectCode    int
 	}{
 		{
 			testName: "correct test",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				input: service.SubscriptionInput{
 					ServiceName: "Yandex",
 					Price:       1000,
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     ptr(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)),
+					EndDate:     ptr(time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)),
 				},
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
@@ -50,10 +52,52 @@ func TestSubscriptionRouter_create(t *testing.T) {
 			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "end_date": "10-2025"}`,
 			expectCode: http.StatusOK,
 		},
+		{
+			testName: "correct test with exact days",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+					EndDate:     ptr(time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(nil)
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "2025-07-25", "end_date": "2025-10-24T23:30:00+03:00"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "correct test with last used date",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName:  "Yandex",
+					Price:        1000,
+					UserId:       "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					LastUsedDate: ptr(time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(nil)
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "2025-07-01", "last_used_date": "2025-09-14"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "incorrect last used date",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "2025-07-01", "last_used_date": "yesterday"}`,
+			expectCode:    http.StatusBadRequest,
+		},
 		{
 			testName: "correct test without end date",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				input: service.SubscriptionInput{
 					ServiceName: "Yandex",
 					Price:       1000,
@@ -68,6 +112,214 @@ func TestSubscriptionRouter_create(t *testing.T) {
 			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025"}`,
 			expectCode: http.StatusOK,
 		},
+		{
+			testName: "correct test with yearly billing period",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName:   "Yandex",
+					Price:         6000,
+					UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:     time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:       nil,
+					BillingPeriod: "yearly",
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(nil)
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 6000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "billing_period": "yearly"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "by service id",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceId: 3,
+					Price:     1000,
+					UserId:    "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(nil)
+			},
+			inputBody:  `{"service_id": 3, "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "service not found",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceId: 4,
+					Price:     1000,
+					UserId:    "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(service.ErrServiceNotFound)
+			},
+			inputBody:  `{"service_id": 4, "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025"}`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName: "with trial and intro price",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName:  "Yandex",
+					Price:        1000,
+					UserId:       "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					TrialEndDate: ptr(time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)),
+					IntroPrice:   ptr(0),
+					IntroPeriods: 2,
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(nil)
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "trial_end_date": "08-2025", "intro_price": 0, "intro_periods": 2}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "trial ends before start",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName:  "Yandex",
+					Price:        1000,
+					UserId:       "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					TrialEndDate: ptr(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(service.ErrInvalidTrial)
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "trial_end_date": "06-2025"}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName:      "negative intro price",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "intro_price": -1, "intro_periods": 1}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid trial end date input",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "trial_end_date": "2025/08/01"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "with tax and discount",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName:  "Yandex",
+					Price:        1000,
+					UserId:       "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					TaxRate:      20,
+					TaxInclusive: true,
+					Discount: &service.DiscountInput{
+						Type:      "percent",
+						Value:     25,
+						StartDate: ptr(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)),
+						Months:    3,
+					},
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(nil)
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "tax_rate": 20, "tax_inclusive": true, "discount": {"type": "percent", "value": 25, "start_date": "2025-08-01", "months": 3}}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "percent discount above 100",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					Discount:    &service.DiscountInput{Type: "percent", Value: 150},
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(service.ErrInvalidDiscount)
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "discount": {"type": "percent", "value": 150}}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName:      "unknown discount type",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "discount": {"type": "coupon", "value": 10}}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "tax rate above 100",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "tax_rate": 120}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "duplicate",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(&service.DuplicateError{Ids: []int{2, 5}})
+			},
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025"}`,
+			expectBody: `{"subscription_ids":[2,5]}` + "\n",
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "allowed duplicate",
+			args: args{
+				ctx: tenantCtx,
+				input: service.SubscriptionInput{
+					ServiceName:    "Yandex",
+					Price:          1000,
+					UserId:         "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:      time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					AllowDuplicate: true,
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Create(a.ctx, a.input).Return(nil)
+			},
+			query:      "allow_duplicate=true",
+			inputBody:  `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "incorrect allow duplicate",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			query:         "allow_duplicate=maybe",
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "unknown billing period",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "billing_period": "weekly"}`,
+			expectCode:    http.StatusBadRequest,
+		},
 		{
 			testName:      "missing service field",
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
@@ -101,25 +353,25 @@ func TestSubscriptionRouter_create(t *testing.T) {
 		{
 			testName:      "invalid start date input",
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
-			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "2025-07-01"}`,
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "2025/07/01"}`,
 			expectCode:    http.StatusBadRequest,
 		},
 		{
 			testName:      "invalid end date input",
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
-			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "end_date": "2025-08-01"}`,
+			inputBody:     `{"service_name": "Yandex", "price": 1000, "user_id": "6114696a-d069-4fad-a3ed-f27c13651c3a", "start_date": "07-2025", "end_date": "2025/08/01"}`,
 			expectCode:    http.StatusBadRequest,
 		},
 		{
 			testName: "unexpected error",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				input: service.SubscriptionInput{
 					ServiceName: "Yandex",
 					Price:       1000,
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     ptr(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)),
+					EndDate:     ptr(time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)),
 				},
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
@@ -142,12 +394,15 @@ func TestSubscriptionRouter_create(t *testing.T) {
 			NewRouter(e, &service.Services{Subscription: sub})
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription", bytes.NewBufferString(tc.inputBody))
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription?"+tc.query, bytes.NewBufferString(tc.inputBody))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 			e.ServeHTTP(w, req)
 
 			assert.Equal(t, tc.expectCode, w.Code)
+			if tc.expectBody != "" {
+				assert.Equal(t, tc.expectBody, w.Body.String())
+			}
 		})
 	}
 }
@@ -171,27 +426,31 @@ func TestSubscriptionRouter_findById(t *testing.T) {
 		{
 			testName: "correct test",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				id:  1,
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
 				sub.EXPECT().FindById(a.ctx, a.id).Return(service.SubscriptionOutput{
-					Id:          a.id,
-					ServiceName: "Yandex",
-					Price:       1000,
-					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
-					StartDate:   "07-2025",
-					EndDate:     nil,
+					Id:            a.id,
+					ServiceName:   "Yandex",
+					Price:         1000,
+					UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:     "07-2025",
+					EndDate:       nil,
+					BillingPeriod: "monthly",
+					CategoryId:    ptr(2),
+					Tags:          []string{"family"},
+					Status:        "active",
 				}, nil)
 			},
 			inputId:    1,
-			expectBody: `{"id":1,"service_name":"Yandex","price":1000,"user_id":"6114696a-d069-4fad-a3ed-f27c13651c3a","start_date":"07-2025","end_date":null}` + "\n",
+			expectBody: `{"id":1,"service_id":0,"service_name":"Yandex","price":1000,"user_id":"6114696a-d069-4fad-a3ed-f27c13651c3a","start_date":"07-2025","end_date":null,"billing_period":"monthly","category_id":2,"tags":["family"],"status":"active"}` + "\n",
 			expectCode: http.StatusOK,
 		},
 		{
 			testName: "not found",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				id:  2,
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
@@ -203,7 +462,7 @@ func TestSubscriptionRouter_findById(t *testing.T) {
 		{
 			testName: "unexpected error",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				id:  1,
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
@@ -256,46 +515,98 @@ func TestSubscriptionRouter_findPrice(t *testing.T) {
 		{
 			testName: "correct test",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				input: service.PriceInput{
 					ServiceName: "Yandex",
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:     time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+					Source:      service.PriceSourceSubscriptions,
 				},
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
-				sub.EXPECT().FindPrice(a.ctx, a.input).Return(1000, nil)
+				sub.EXPECT().FindPrice(a.ctx, a.input).Return(service.PriceOutput{Gross: 1200, Net: 1000, Tax: 200, Discount: 100}, nil)
 			},
 			query:      `service_name=Yandex&user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&start=01-2025&end=03-2025`,
-			expectBody: `{"price":1000}` + "\n",
+			expectBody: `{"price":1200,"gross":1200,"net":1000,"tax":200,"discount":100}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "with category and tag",
+			args: args{
+				ctx: tenantCtx,
+				input: service.PriceInput{
+					UserId:     "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					CategoryId: 2,
+					Tag:        "family",
+					StartDate:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:    time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+					Source:     service.PriceSourceSubscriptions,
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().FindPrice(a.ctx, a.input).Return(service.PriceOutput{Gross: 500, Net: 500}, nil)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&category_id=2&tag=family&start=01-2025&end=03-2025`,
+			expectBody: `{"price":500,"gross":500,"net":500,"tax":0,"discount":0}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "from ledger",
+			args: args{
+				ctx: tenantCtx,
+				input: service.PriceInput{
+					UserId:    "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+					Source:    service.PriceSourceLedger,
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().FindPrice(a.ctx, a.input).Return(service.PriceOutput{Gross: 2400, Net: 2000, Tax: 400}, nil)
+			},
+			query:      `user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&start=2025-01-01&end=2025-03-31&source=ledger`,
+			expectBody: `{"price":2400,"gross":2400,"net":2000,"tax":400,"discount":0}` + "\n",
 			expectCode: http.StatusOK,
 		},
+		{
+			testName:      "incorrect source",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			query:         `start=01-2025&end=03-2025&source=bank`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "incorrect category id",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			query:         `category_id=abc&start=01-2025&end=03-2025`,
+			expectCode:    http.StatusBadRequest,
+		},
 		{
 			testName:      "incorrect start interval",
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
-			query:         `start=2025-01-01&end=03-2025`,
+			query:         `start=2025.01.01&end=03-2025`,
 			expectCode:    http.StatusBadRequest,
 		},
 		{
 			testName:      "incorrect end interval",
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
-			query:         `start=01-2025&end=2025-05-01`,
+			query:         `start=01-2025&end=2025.05.01`,
 			expectCode:    http.StatusBadRequest,
 		},
 		{
 			testName: "unexpected error",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				input: service.PriceInput{
 					ServiceName: "Yandex",
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:     time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+					Source:      service.PriceSourceSubscriptions,
 				},
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
-				sub.EXPECT().FindPrice(a.ctx, a.input).Return(0, errors.New("some error"))
+				sub.EXPECT().FindPrice(a.ctx, a.input).Return(service.PriceOutput{}, errors.New("some error"))
 			},
 			query:      `service_name=Yandex&user_id=6114696a-d069-4fad-a3ed-f27c13651c3a&start=01-2025&end=03-2025`,
 			expectCode: http.StatusInternalServerError,
@@ -345,14 +656,14 @@ func TestSubscriptionRouter_update(t *testing.T) {
 		{
 			testName: "correct test",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				id:  1,
 				input: service.SubscriptionInput{
 					ServiceName: "Yandex",
 					Price:       1000,
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+					EndDate:     ptr(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)),
 				},
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
@@ -365,14 +676,14 @@ func TestSubscriptionRouter_update(t *testing.T) {
 		{
 			testName: "not found",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				id:  2,
 				input: service.SubscriptionInput{
 					ServiceName: "Yandex",
 					Price:       1000,
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+					EndDate:     ptr(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)),
 				},
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
@@ -385,14 +696,14 @@ func TestSubscriptionRouter_update(t *testing.T) {
 		{
 			testName: "unexpected error",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenantCtx,
 				id:  2,
 				input: service.SubscriptionInput{
 					ServiceName: "Yandex",
 					Price:       1000,
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+					EndDate:     ptr(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)),
 				},
 			},
 			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
@@ -426,6 +737,474 @@ func TestSubscriptionRouter_update(t *testing.T) {
 	}
 }
 
-func ptr[T any](t T) *T {
-	return &t
+func TestSubscriptionRouter_pause(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input service.PauseInput
+	}
+
+	type mockBehaviour func(sub *servicemocks.MockSubscription, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputId       string
+		inputBody     string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+				input: service.PauseInput{
+					StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   ptr(time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Pause(a.ctx, a.id, a.input).Return(nil)
+			},
+			inputId:    "1",
+			inputBody:  `{"start_date": "07-2025", "end_date": "08-2025"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "until resume",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.PauseInput{StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Pause(a.ctx, a.id, a.input).Return(nil)
+			},
+			inputId:    "1",
+			inputBody:  `{"start_date": "07-2025"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "already paused",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.PauseInput{StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Pause(a.ctx, a.id, a.input).Return(service.ErrSubscriptionPaused)
+			},
+			inputId:    "1",
+			inputBody:  `{"start_date": "07-2025"}`,
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "invalid pause",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.PauseInput{StartDate: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Pause(a.ctx, a.id, a.input).Return(service.ErrInvalidPause)
+			},
+			inputId:    "1",
+			inputBody:  `{"start_date": "07-2020"}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx:   tenantCtx,
+				id:    2,
+				input: service.PauseInput{StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Pause(a.ctx, a.id, a.input).Return(service.ErrSubscriptionNotFound)
+			},
+			inputId:    "2",
+			inputBody:  `{"start_date": "07-2025"}`,
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "missing start date",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputId:       "1",
+			inputBody:     `{"end_date": "08-2025"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid end date input",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputId:       "1",
+			inputBody:     `{"start_date": "07-2025", "end_date": "2025/08/01"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "incorrect id",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputId:       "foo",
+			inputBody:     `{"start_date": "07-2025"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Subscription: sub})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/"+tc.inputId+"/pause", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code)
+		})
+	}
+}
+
+func TestSubscriptionRouter_resume(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input service.ResumeInput
+	}
+
+	type mockBehaviour func(sub *servicemocks.MockSubscription, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.ResumeInput{Date: ptr(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Resume(a.ctx, a.id, a.input).Return(nil)
+			},
+			inputBody:  `{"date": "09-2025"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "current month",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Resume(a.ctx, a.id, a.input).Return(nil)
+			},
+			inputBody:  ``,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "not paused",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Resume(a.ctx, a.id, a.input).Return(service.ErrSubscriptionNotPaused)
+			},
+			inputBody:  `{}`,
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName:      "invalid date input",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"date": "2025/09/01"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Subscription: sub})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/1/resume", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code)
+		})
+	}
+}
+
+func TestSubscriptionRouter_cancel(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input service.CancelInput
+	}
+
+	type mockBehaviour func(sub *servicemocks.MockSubscription, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.CancelInput{When: service.CancelEndOfPeriod, Reason: ptr("too expensive")},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Cancel(a.ctx, a.id, a.input).Return(nil)
+			},
+			inputBody:  `{"when": "end_of_period", "reason": "too expensive"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "at month",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.CancelInput{When: service.CancelAtDate, Date: ptr(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Cancel(a.ctx, a.id, a.input).Return(nil)
+			},
+			inputBody:  `{"when": "month", "month": "12-2025"}`,
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "already ended",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.CancelInput{When: service.CancelImmediately},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Cancel(a.ctx, a.id, a.input).Return(service.ErrSubscriptionEnded)
+			},
+			inputBody:  `{"when": "immediately"}`,
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "invalid cancellation",
+			args: args{
+				ctx:   tenantCtx,
+				id:    1,
+				input: service.CancelInput{When: service.CancelAtDate, Date: ptr(time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC))},
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Cancel(a.ctx, a.id, a.input).Return(service.ErrInvalidCancellation)
+			},
+			inputBody:  `{"when": "date", "date": "2020-01-15"}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName:      "month is required",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"when": "month"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "unknown mode",
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {},
+			inputBody:     `{"when": "tomorrow"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Subscription: sub})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/1/cancel", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code)
+		})
+	}
+}
+
+func TestSubscriptionRouter_uncancel(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int
+	}
+
+	type mockBehaviour func(sub *servicemocks.MockSubscription, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Uncancel(a.ctx, a.id).Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "not cancelled",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Uncancel(a.ctx, a.id).Return(service.ErrSubscriptionNotCancelled)
+			},
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: tenantCtx,
+				id:  1,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().Uncancel(a.ctx, a.id).Return(service.ErrSubscriptionNotFound)
+			},
+			expectCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Subscription: sub})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/1/uncancel", nil)
+
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code)
+		})
+	}
+}
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+func TestSubscriptionRouter_findDuplicates(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+	}
+
+	type mockBehaviour func(sub *servicemocks.MockSubscription, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		query         string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenantCtx,
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().FindDuplicates(a.ctx, a.userId).Return([]service.DuplicateOutput{
+					{UserId: a.userId, ServiceId: 3, ServiceName: "Yandex Plus", SubscriptionIds: []int{1, 4}},
+				}, nil)
+			},
+			query:      "user_id=6114696a-d069-4fad-a3ed-f27c13651c3a",
+			expectBody: `[{"user_id":"6114696a-d069-4fad-a3ed-f27c13651c3a","service_id":3,"service_name":"Yandex Plus","subscription_ids":[1,4]}]` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "all users",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().FindDuplicates(a.ctx, "").Return([]service.DuplicateOutput{}, nil)
+			},
+			expectBody: "[]\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: tenantCtx,
+			},
+			mockBehaviour: func(sub *servicemocks.MockSubscription, a args) {
+				sub.EXPECT().FindDuplicates(a.ctx, "").Return(nil, errors.New("some error"))
+			},
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := servicemocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Subscription: sub})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/duplicates?"+tc.query, nil)
+
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code)
+			assert.Equal(t, tc.expectBody, w.Body.String())
+		})
+	}
 }
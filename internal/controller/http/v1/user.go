@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"subscription_service/internal/service"
+	"time"
+)
+
+type userRouter struct {
+	user service.User
+}
+
+func newUserRouter(g *echo.Group, user service.User) {
+	r := &userRouter{
+		user: user,
+	}
+
+	g.POST("", r.create)
+	g.GET("/all", r.findAll)
+	g.GET("/:id", r.findById)
+	g.PUT("/:id", r.update)
+	g.DELETE("/:id", r.delete)
+	g.GET("/:id/subscriptions", r.findSubscriptions)
+	g.GET("/:id/summary", r.findSummary)
+}
+
+type userIdInput struct {
+	Id string `param:"id" validate:"uuid4"`
+}
+
+type userInput struct {
+	Id          string  `param:"id" swaggerignore:"true"`
+	Email       *string `json:"email" validate:"omitempty,email"`
+	DisplayName *string `json:"display_name" validate:"omitempty,max=100"`
+	Timezone    string  `json:"timezone" validate:"omitempty,max=64"`
+	Currency    string  `json:"currency" validate:"omitempty,iso4217"`
+}
+
+type userCreateOutput struct {
+	Id string `json:"id"`
+}
+
+// @Summary		Create
+// @Description	Create user with generated id. Time zone is IANA name (UTC by default), currency is ISO 4217 code (RUB by default)
+// @Tags			user
+// @Accept			json
+// @Produce		json
+// @Param			input	body		userInput	true	"input"
+// @Success		200		{object}	userCreateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		409		{string}	string	"Conflict"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/user [post]
+func (r *userRouter) create(c echo.Context) error {
+	var input userInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	id, err := r.user.Create(c.Request().Context(), service.UserInput{
+		Email:       input.Email,
+		DisplayName: input.DisplayName,
+		Timezone:    input.Timezone,
+		Currency:    input.Currency,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, userCreateOutput{
+		Id: id,
+	})
+}
+
+// @Summary		Find All
+// @Description	Find all users
+// @Tags			user
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		service.UserOutput
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/user/all [get]
+func (r *userRouter) findAll(c echo.Context) error {
+	users, err := r.user.FindAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, users)
+}
+
+// @Summary		Find by id
+// @Description	Find user by id
+// @Tags			user
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"id"
+// @Success		200	{object}	service.UserOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/user/{id} [get]
+func (r *userRouter) findById(c echo.Context) error {
+	var input userIdInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	u, err := r.user.FindById(c.Request().Context(), input.Id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, u)
+}
+
+// @Summary		Update
+// @Description	Update user profile and settings
+// @Tags			user
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string		true	"id"
+// @Param			input	body		userInput	true	"input"
+// @Success		200		{string}	string		"OK"
+// @Failure		400		{string}	string		"Bad Request"
+// @Failure		404		{string}	string		"Not Found"
+// @Failure		409		{string}	string		"Conflict"
+// @Failure		500		{string}	string		"Internal Server Error"
+// @Router			/api/v1/user/{id} [put]
+func (r *userRouter) update(c echo.Context) error {
+	var input userInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&userIdInput{Id: input.Id}); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	err := r.user.Update(c.Request().Context(), input.Id, service.UserInput{
+		Email:       input.Email,
+		DisplayName: input.DisplayName,
+		Timezone:    input.Timezone,
+		Currency:    input.Currency,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Delete
+// @Description	Delete user by id. User with subscriptions can't be deleted
+// @Tags			user
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		409	{string}	string	"Conflict"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/user/{id} [delete]
+func (r *userRouter) delete(c echo.Context) error {
+	var input userIdInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err := r.user.Delete(c.Request().Context(), input.Id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Find subscriptions
+// @Description	Find all subscriptions of user
+// @Tags			user
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"id"
+// @Success		200	{array}		service.SubscriptionOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/user/{id}/subscriptions [get]
+func (r *userRouter) findSubscriptions(c echo.Context) error {
+	var input userIdInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	s, err := r.user.Subscriptions(c.Request().Context(), input.Id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+// @Summary		Summary
+// @Description	Active subscriptions, monthly spend, charges of current month and next charge of user.
+// @Description	date (yyyy-mm-dd) is today in users time zone by default
+// @Tags			user
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string	true	"id"
+// @Param			date	query		string	false	"date"
+// @Success		200		{object}	service.UserSummaryOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		404		{string}	string	"Not Found"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/user/{id}/summary [get]
+func (r *userRouter) findSummary(c echo.Context) error {
+	var input userIdInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var date *time.Time
+
+	if d := c.QueryParam("date"); d != "" {
+		t, err := time.Parse(time.DateOnly, d)
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+		date = &t
+	}
+
+	s, err := r.user.Summary(c.Request().Context(), input.Id, date)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, s)
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+	"time"
+)
+
+func TestUserRouter_create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.UserInput
+	}
+
+	type mockBehaviour func(u *servicemocks.MockUser, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.UserInput{
+					Email:       ptr("user@example.com"),
+					DisplayName: ptr("User"),
+					Timezone:    "Europe/Moscow",
+					Currency:    "USD",
+				},
+			},
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {
+				u.EXPECT().Create(a.ctx, a.input).Return("6114696a-d069-4fad-a3ed-f27c13651c3a", nil)
+			},
+			inputBody:  `{"email": "user@example.com", "display_name": "User", "timezone": "Europe/Moscow", "currency": "USD"}`,
+			expectBody: `{"id":"6114696a-d069-4fad-a3ed-f27c13651c3a"}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "email is taken",
+			args: args{
+				ctx: tenantCtx,
+				input: service.UserInput{
+					Email: ptr("user@example.com"),
+				},
+			},
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {
+				u.EXPECT().Create(a.ctx, a.input).Return("", service.ErrUserAlreadyExists)
+			},
+			inputBody:  `{"email": "user@example.com"}`,
+			expectCode: http.StatusConflict,
+		},
+		{
+			testName: "invalid timezone",
+			args: args{
+				ctx: tenantCtx,
+				input: service.UserInput{
+					Timezone: "Mars/Olympus",
+				},
+			},
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {
+				u.EXPECT().Create(a.ctx, a.input).Return("", service.ErrInvalidTimezone)
+			},
+			inputBody:  `{"timezone": "Mars/Olympus"}`,
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid email",
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {},
+			inputBody:     `{"email": "user"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid currency",
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {},
+			inputBody:     `{"currency": "rub"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			u := servicemocks.NewMockUser(ctrl)
+			tc.mockBehaviour(u, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{User: u})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/user", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestUserRouter_findSummary(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		id   string
+		date *time.Time
+	}
+
+	type mockBehaviour func(u *servicemocks.MockUser, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:  tenantCtx,
+				id:   "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				date: ptr(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {
+				u.EXPECT().Summary(a.ctx, a.id, a.date).Return(service.UserSummaryOutput{
+					UserId:              a.id,
+					Currency:            "RUB",
+					ActiveSubscriptions: 1,
+					MonthlySpend:        1000,
+					MonthCharges:        1000,
+					NextCharge: &service.UserChargeOutput{
+						SubscriptionId: 1,
+						ServiceName:    "Yandex Plus",
+						Price:          1000,
+						Date:           "2025-04-01",
+					},
+				}, nil)
+			},
+			path:       "/api/v1/user/6114696a-d069-4fad-a3ed-f27c13651c3a/summary?date=2025-04-01",
+			expectBody: `{"user_id":"6114696a-d069-4fad-a3ed-f27c13651c3a","currency":"RUB","active_subscriptions":1,"monthly_spend":1000,"month_charges":1000,"next_charge":{"subscription_id":1,"service_name":"Yandex Plus","price":1000,"date":"2025-04-01"}}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: tenantCtx,
+				id:  "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {
+				u.EXPECT().Summary(a.ctx, a.id, a.date).Return(service.UserSummaryOutput{}, service.ErrUserNotFound)
+			},
+			path:       "/api/v1/user/6114696a-d069-4fad-a3ed-f27c13651c3a/summary",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid id",
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {},
+			path:          "/api/v1/user/foo/summary",
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "invalid date",
+			mockBehaviour: func(u *servicemocks.MockUser, a args) {},
+			path:          "/api/v1/user/6114696a-d069-4fad-a3ed-f27c13651c3a/summary?date=04-2025",
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			u := servicemocks.NewMockUser(ctrl)
+			tc.mockBehaviour(u, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{User: u})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
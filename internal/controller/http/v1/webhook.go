@@ -0,0 +1,220 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/service"
+)
+
+type webhookRouter struct {
+	webhook service.Webhook
+}
+
+func newWebhookRouter(g *echo.Group, webhook service.Webhook) {
+	r := &webhookRouter{
+		webhook: webhook,
+	}
+
+	g.POST("", r.create)
+	g.GET("/all", r.findAll)
+	g.GET("/:id", r.findById)
+	g.PUT("/:id", r.update)
+	g.DELETE("/:id", r.delete)
+	g.GET("/:id/deliveries", r.findDeliveries)
+	g.POST("/:id/deliveries/:delivery_id/redeliver", r.redeliver)
+}
+
+type webhookInput struct {
+	Url    string   `json:"url" validate:"required,url"`
+	Secret string   `json:"secret" validate:"required,min=16"`
+	Events []string `json:"events" validate:"dive,oneof=subscription.created subscription.updated subscription.cancelled subscription.deleted subscription.paused subscription.resumed subscription.uncancelled subscription.renewal_due subscription.trial_ending subscription.price_anomaly budget.threshold_crossed charge.paid charge.failed charge.refunded"`
+}
+
+type webhookCreateOutput struct {
+	Id int `json:"id"`
+}
+
+// @Summary		Create
+// @Description	Register webhook endpoint. Empty events list means all events
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Param			input	body		webhookInput	true	"input"
+// @Success		200		{object}	webhookCreateOutput
+// @Failure		400		{string}	string	"Bad Request"
+// @Failure		500		{string}	string	"Internal Server Error"
+// @Router			/api/v1/webhook [post]
+func (r *webhookRouter) create(c echo.Context) error {
+	var input webhookInput
+
+	if err := c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err := c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	id, err := r.webhook.Create(c.Request().Context(), service.WebhookInput{
+		Url:    input.Url,
+		Secret: input.Secret,
+		Events: input.Events,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, webhookCreateOutput{
+		Id: id,
+	})
+}
+
+// @Summary		Find All
+// @Description	Find all registered webhooks
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		service.WebhookOutput
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/webhook/all [get]
+func (r *webhookRouter) findAll(c echo.Context) error {
+	w, err := r.webhook.FindAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, w)
+}
+
+// @Summary		Find by id
+// @Description	Find webhook by id
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"id"
+// @Success		200	{object}	service.WebhookOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/webhook/{id} [get]
+func (r *webhookRouter) findById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	w, err := r.webhook.FindById(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, w)
+}
+
+// @Summary		Update
+// @Description	Update webhook url, secret and events filter
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int				true	"id"
+// @Param			input	body		webhookInput	true	"input"
+// @Success		200		{string}	string			"OK"
+// @Failure		400		{string}	string			"Bad Request"
+// @Failure		404		{string}	string			"Not Found"
+// @Failure		500		{string}	string			"Internal Server Error"
+// @Router			/api/v1/webhook/{id} [put]
+func (r *webhookRouter) update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var input webhookInput
+
+	if err = c.Bind(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if err = c.Validate(&input); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	err = r.webhook.Update(c.Request().Context(), id, service.WebhookInput{
+		Url:    input.Url,
+		Secret: input.Secret,
+		Events: input.Events,
+	})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Delete
+// @Description	Delete webhook with its deliveries
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int		true	"id"
+// @Success		200	{string}	string	"OK"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/webhook/{id} [delete]
+func (r *webhookRouter) delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.webhook.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary		Deliveries
+// @Description	Delivery log of webhook, newest first
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"id"
+// @Success		200	{array}		service.WebhookDeliveryOutput
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/v1/webhook/{id}/deliveries [get]
+func (r *webhookRouter) findDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	d, err := r.webhook.FindDeliveries(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, d)
+}
+
+// @Summary		Redeliver
+// @Description	Send delivery again, including dead ones
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int		true	"webhook id"
+// @Param			delivery_id	path		int		true	"delivery id"
+// @Success		200			{string}	string	"OK"
+// @Failure		400			{string}	string	"Bad Request"
+// @Failure		404			{string}	string	"Not Found"
+// @Failure		500			{string}	string	"Internal Server Error"
+// @Router			/api/v1/webhook/{id}/deliveries/{delivery_id}/redeliver [post]
+func (r *webhookRouter) redeliver(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	deliveryId, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err = r.webhook.Redeliver(c.Request().Context(), id, deliveryId); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
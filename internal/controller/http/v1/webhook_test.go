@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/validator"
+	"testing"
+)
+
+func TestWebhookRouter_create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input service.WebhookInput
+	}
+
+	type mockBehaviour func(w *servicemocks.MockWebhook, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		inputBody     string
+		expectBody    string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: tenantCtx,
+				input: service.WebhookInput{
+					Url:    "https://example.com/hook",
+					Secret: "0123456789abcdef",
+					Events: []string{"subscription.created", "subscription.renewal_due"},
+				},
+			},
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {
+				w.EXPECT().Create(a.ctx, a.input).Return(1, nil)
+			},
+			inputBody:  `{"url": "https://example.com/hook", "secret": "0123456789abcdef", "events": ["subscription.created", "subscription.renewal_due"]}`,
+			expectBody: `{"id":1}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "correct test without events filter",
+			args: args{
+				ctx: tenantCtx,
+				input: service.WebhookInput{
+					Url:    "https://example.com/hook",
+					Secret: "0123456789abcdef",
+				},
+			},
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {
+				w.EXPECT().Create(a.ctx, a.input).Return(2, nil)
+			},
+			inputBody:  `{"url": "https://example.com/hook", "secret": "0123456789abcdef"}`,
+			expectBody: `{"id":2}` + "\n",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName:      "invalid url",
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {},
+			inputBody:     `{"url": "example", "secret": "0123456789abcdef"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "short secret",
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {},
+			inputBody:     `{"url": "https://example.com/hook", "secret": "123"}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName:      "unknown event",
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {},
+			inputBody:     `{"url": "https://example.com/hook", "secret": "0123456789abcdef", "events": ["foo"]}`,
+			expectCode:    http.StatusBadRequest,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: tenantCtx,
+				input: service.WebhookInput{
+					Url:    "https://example.com/hook",
+					Secret: "0123456789abcdef",
+				},
+			},
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {
+				w.EXPECT().Create(a.ctx, a.input).Return(0, errors.New("some error"))
+			},
+			inputBody:  `{"url": "https://example.com/hook", "secret": "0123456789abcdef"}`,
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			w := servicemocks.NewMockWebhook(ctrl)
+			tc.mockBehaviour(w, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Webhook: w})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhook", bytes.NewBufferString(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestWebhookRouter_redeliver(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		webhookId  int
+		deliveryId int64
+	}
+
+	type mockBehaviour func(w *servicemocks.MockWebhook, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		path          string
+		expectCode    int
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:        tenantCtx,
+				webhookId:  1,
+				deliveryId: 5,
+			},
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {
+				w.EXPECT().Redeliver(a.ctx, a.webhookId, a.deliveryId).Return(nil)
+			},
+			path:       "/api/v1/webhook/1/deliveries/5/redeliver",
+			expectCode: http.StatusOK,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx:        tenantCtx,
+				webhookId:  1,
+				deliveryId: 6,
+			},
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {
+				w.EXPECT().Redeliver(a.ctx, a.webhookId, a.deliveryId).Return(service.ErrWebhookDeliveryNotFound)
+			},
+			path:       "/api/v1/webhook/1/deliveries/6/redeliver",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			testName:      "invalid delivery id",
+			mockBehaviour: func(w *servicemocks.MockWebhook, a args) {},
+			path:          "/api/v1/webhook/1/deliveries/foo/redeliver",
+			expectCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			w := servicemocks.NewMockWebhook(ctrl)
+			tc.mockBehaviour(w, tc.args)
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			NewRouter(e, &service.Services{Webhook: w})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+		})
+	}
+}
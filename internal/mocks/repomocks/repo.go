@@ -13,6 +13,43 @@ import (
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
+
 // MockSubscription is a mock of Subscription interface.
 type MockSubscription struct {
 	ctrl     *gomock.Controller
@@ -36,14 +73,29 @@ func (m *MockSubscription) EXPECT() *MockSubscriptionMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockSubscription) Create(ctx context.Context, s dbmodel.Subscription) error {
+// Cancel mocks base method.
+func (m *MockSubscription) Cancel(ctx context.Context, s dbmodel.Subscription) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret := m.ctrl.Call(m, "Cancel", ctx, s)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Cancel indicates an expected call of Cancel.
+func (mr *MockSubscriptionMockRecorder) Cancel(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockSubscription)(nil).Cancel), ctx, s)
+}
+
+// Create mocks base method.
+func (m *MockSubscription) Create(ctx context.Context, s dbmodel.Subscription) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Create indicates an expected call of Create.
 func (mr *MockSubscriptionMockRecorder) Create(ctx, s interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -64,19 +116,34 @@ func (mr *MockSubscriptionMockRecorder) Delete(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscription)(nil).Delete), ctx, id)
 }
 
+// FindActive mocks base method.
+func (m *MockSubscription) FindActive(ctx context.Context, userId string, date time.Time) ([]dbmodel.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", ctx, userId, date)
+	ret0, _ := ret[0].([]dbmodel.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockSubscriptionMockRecorder) FindActive(ctx, userId, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockSubscription)(nil).FindActive), ctx, userId, date)
+}
+
 // FindAll mocks base method.
-func (m *MockSubscription) FindAll(ctx context.Context) ([]dbmodel.Subscription, error) {
+func (m *MockSubscription) FindAll(ctx context.Context, f dbmodel.SubscriptionFilter) ([]dbmodel.Subscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, f)
 	ret0, _ := ret[0].([]dbmodel.Subscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockSubscriptionMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+func (mr *MockSubscriptionMockRecorder) FindAll(ctx, f interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSubscription)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSubscription)(nil).FindAll), ctx, f)
 }
 
 // FindById mocks base method.
@@ -94,19 +161,77 @@ func (mr *MockSubscriptionMockRecorder) FindById(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSubscription)(nil).FindById), ctx, id)
 }
 
+// FindCharges mocks base method.
+func (m *MockSubscription) FindCharges(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.SubscriptionCharge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCharges", ctx, userId, start, end)
+	ret0, _ := ret[0].([]dbmodel.SubscriptionCharge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCharges indicates an expected call of FindCharges.
+func (mr *MockSubscriptionMockRecorder) FindCharges(ctx, userId, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCharges", reflect.TypeOf((*MockSubscription)(nil).FindCharges), ctx, userId, start, end)
+}
+
 // FindPrice mocks base method.
-func (m *MockSubscription) FindPrice(ctx context.Context, service, userId string, start, end time.Time) (int, error) {
+func (m *MockSubscription) FindPrice(ctx context.Context, f dbmodel.SubscriptionFilter, start, end time.Time) (dbmodel.Price, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindPrice", ctx, service, userId, start, end)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "FindPrice", ctx, f, start, end)
+	ret0, _ := ret[0].(dbmodel.Price)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindPrice indicates an expected call of FindPrice.
-func (mr *MockSubscriptionMockRecorder) FindPrice(ctx, service, userId, start, end interface{}) *gomock.Call {
+func (mr *MockSubscriptionMockRecorder) FindPrice(ctx, f, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrice", reflect.TypeOf((*MockSubscription)(nil).FindPrice), ctx, f, start, end)
+}
+
+// FindUserPrices mocks base method.
+func (m *MockSubscription) FindUserPrices(ctx context.Context, userIds []string, start, end time.Time) (map[string]dbmodel.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserPrices", ctx, userIds, start, end)
+	ret0, _ := ret[0].(map[string]dbmodel.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserPrices indicates an expected call of FindUserPrices.
+func (mr *MockSubscriptionMockRecorder) FindUserPrices(ctx, userIds, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserPrices", reflect.TypeOf((*MockSubscription)(nil).FindUserPrices), ctx, userIds, start, end)
+}
+
+// SetTags mocks base method.
+func (m *MockSubscription) SetTags(ctx context.Context, subscriptionId int, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", ctx, subscriptionId, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockSubscriptionMockRecorder) SetTags(ctx, subscriptionId, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockSubscription)(nil).SetTags), ctx, subscriptionId, tags)
+}
+
+// Uncancel mocks base method.
+func (m *MockSubscription) Uncancel(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uncancel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Uncancel indicates an expected call of Uncancel.
+func (mr *MockSubscriptionMockRecorder) Uncancel(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrice", reflect.TypeOf((*MockSubscription)(nil).FindPrice), ctx, service, userId, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uncancel", reflect.TypeOf((*MockSubscription)(nil).Uncancel), ctx, id)
 }
 
 // Update mocks base method.
@@ -122,3 +247,1403 @@ func (mr *MockSubscriptionMockRecorder) Update(ctx, s interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscription)(nil).Update), ctx, s)
 }
+
+// MockShare is a mock of Share interface.
+type MockShare struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareMockRecorder
+}
+
+// MockShareMockRecorder is the mock recorder for MockShare.
+type MockShareMockRecorder struct {
+	mock *MockShare
+}
+
+// NewMockShare creates a new mock instance.
+func NewMockShare(ctrl *gomock.Controller) *MockShare {
+	mock := &MockShare{ctrl: ctrl}
+	mock.recorder = &MockShareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShare) EXPECT() *MockShareMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockShare) Delete(ctx context.Context, subscriptionId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subscriptionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShareMockRecorder) Delete(ctx, subscriptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShare)(nil).Delete), ctx, subscriptionId)
+}
+
+// FindBySubscription mocks base method.
+func (m *MockShare) FindBySubscription(ctx context.Context, subscriptionId int) (dbmodel.Split, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySubscription", ctx, subscriptionId)
+	ret0, _ := ret[0].(dbmodel.Split)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySubscription indicates an expected call of FindBySubscription.
+func (mr *MockShareMockRecorder) FindBySubscription(ctx, subscriptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySubscription", reflect.TypeOf((*MockShare)(nil).FindBySubscription), ctx, subscriptionId)
+}
+
+// FindDebts mocks base method.
+func (m *MockShare) FindDebts(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.ShareDebt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDebts", ctx, userId, start, end)
+	ret0, _ := ret[0].([]dbmodel.ShareDebt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDebts indicates an expected call of FindDebts.
+func (mr *MockShareMockRecorder) FindDebts(ctx, userId, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDebts", reflect.TypeOf((*MockShare)(nil).FindDebts), ctx, userId, start, end)
+}
+
+// Set mocks base method.
+func (m *MockShare) Set(ctx context.Context, split dbmodel.Split) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, split)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockShareMockRecorder) Set(ctx, split interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockShare)(nil).Set), ctx, split)
+}
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, s dbmodel.Service) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, s)
+}
+
+// Delete mocks base method.
+func (m *MockService) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockService) FindAll(ctx context.Context) ([]dbmodel.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]dbmodel.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockService)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockService) FindById(ctx context.Context, id int) (dbmodel.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockServiceMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockService)(nil).FindById), ctx, id)
+}
+
+// FindByName mocks base method.
+func (m *MockService) FindByName(ctx context.Context, name string) (dbmodel.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(dbmodel.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockServiceMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockService)(nil).FindByName), ctx, name)
+}
+
+// Update mocks base method.
+func (m *MockService) Update(ctx context.Context, s dbmodel.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceMockRecorder) Update(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), ctx, s)
+}
+
+// MockOrganization is a mock of Organization interface.
+type MockOrganization struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationMockRecorder
+}
+
+// MockOrganizationMockRecorder is the mock recorder for MockOrganization.
+type MockOrganizationMockRecorder struct {
+	mock *MockOrganization
+}
+
+// NewMockOrganization creates a new mock instance.
+func NewMockOrganization(ctrl *gomock.Controller) *MockOrganization {
+	mock := &MockOrganization{ctrl: ctrl}
+	mock.recorder = &MockOrganizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganization) EXPECT() *MockOrganizationMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganization) Create(ctx context.Context, o dbmodel.Organization) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, o)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationMockRecorder) Create(ctx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganization)(nil).Create), ctx, o)
+}
+
+// FindAll mocks base method.
+func (m *MockOrganization) FindAll(ctx context.Context) ([]dbmodel.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]dbmodel.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockOrganizationMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrganization)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockOrganization) FindById(ctx context.Context, id int) (dbmodel.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockOrganizationMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrganization)(nil).FindById), ctx, id)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUser) Create(ctx context.Context, u dbmodel.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, u)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserMockRecorder) Create(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), ctx, u)
+}
+
+// Delete mocks base method.
+func (m *MockUser) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), ctx, id)
+}
+
+// Ensure mocks base method.
+func (m *MockUser) Ensure(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ensure", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ensure indicates an expected call of Ensure.
+func (mr *MockUserMockRecorder) Ensure(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ensure", reflect.TypeOf((*MockUser)(nil).Ensure), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockUser) FindAll(ctx context.Context) ([]dbmodel.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]dbmodel.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockUserMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUser)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockUser) FindById(ctx context.Context, id string) (dbmodel.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockUserMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUser)(nil).FindById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockUser) Update(ctx context.Context, u dbmodel.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserMockRecorder) Update(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), ctx, u)
+}
+
+// MockCategory is a mock of Category interface.
+type MockCategory struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryMockRecorder
+}
+
+// MockCategoryMockRecorder is the mock recorder for MockCategory.
+type MockCategoryMockRecorder struct {
+	mock *MockCategory
+}
+
+// NewMockCategory creates a new mock instance.
+func NewMockCategory(ctrl *gomock.Controller) *MockCategory {
+	mock := &MockCategory{ctrl: ctrl}
+	mock.recorder = &MockCategoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategory) EXPECT() *MockCategoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategory) Create(ctx context.Context, c dbmodel.Category) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryMockRecorder) Create(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategory)(nil).Create), ctx, c)
+}
+
+// Delete mocks base method.
+func (m *MockCategory) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategory)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockCategory) FindAll(ctx context.Context) ([]dbmodel.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]dbmodel.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCategoryMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategory)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockCategory) FindById(ctx context.Context, id int) (dbmodel.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCategoryMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCategory)(nil).FindById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockCategory) Update(ctx context.Context, c dbmodel.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryMockRecorder) Update(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategory)(nil).Update), ctx, c)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockOutbox) Claim(ctx context.Context, limit int, lease time.Duration) ([]dbmodel.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, lease)
+	ret0, _ := ret[0].([]dbmodel.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockOutboxMockRecorder) Claim(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockOutbox)(nil).Claim), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockOutbox) Create(ctx context.Context, e dbmodel.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxMockRecorder) Create(ctx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutbox)(nil).Create), ctx, e)
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutbox) MarkFailed(ctx context.Context, id int64, reason string, nextAttempt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, reason, nextAttempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxMockRecorder) MarkFailed(ctx, id, reason, nextAttempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutbox)(nil).MarkFailed), ctx, id, reason, nextAttempt)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutbox) MarkPublished(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxMockRecorder) MarkPublished(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutbox)(nil).MarkPublished), ctx, id)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhook) Create(ctx context.Context, w dbmodel.Webhook) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, w)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookMockRecorder) Create(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), ctx, w)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockWebhook) FindAll(ctx context.Context) ([]dbmodel.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]dbmodel.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockWebhookMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockWebhook)(nil).FindAll), ctx)
+}
+
+// FindByEvent mocks base method.
+func (m *MockWebhook) FindByEvent(ctx context.Context, eventType string) ([]dbmodel.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEvent", ctx, eventType)
+	ret0, _ := ret[0].([]dbmodel.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEvent indicates an expected call of FindByEvent.
+func (mr *MockWebhookMockRecorder) FindByEvent(ctx, eventType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEvent", reflect.TypeOf((*MockWebhook)(nil).FindByEvent), ctx, eventType)
+}
+
+// FindById mocks base method.
+func (m *MockWebhook) FindById(ctx context.Context, id int) (dbmodel.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockWebhookMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockWebhook)(nil).FindById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockWebhook) Update(ctx context.Context, w dbmodel.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookMockRecorder) Update(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhook)(nil).Update), ctx, w)
+}
+
+// MockWebhookDelivery is a mock of WebhookDelivery interface.
+type MockWebhookDelivery struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryMockRecorder
+}
+
+// MockWebhookDeliveryMockRecorder is the mock recorder for MockWebhookDelivery.
+type MockWebhookDeliveryMockRecorder struct {
+	mock *MockWebhookDelivery
+}
+
+// NewMockWebhookDelivery creates a new mock instance.
+func NewMockWebhookDelivery(ctrl *gomock.Controller) *MockWebhookDelivery {
+	mock := &MockWebhookDelivery{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDelivery) EXPECT() *MockWebhookDeliveryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockWebhookDelivery) Claim(ctx context.Context, limit int, lease time.Duration) ([]dbmodel.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, lease)
+	ret0, _ := ret[0].([]dbmodel.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockWebhookDeliveryMockRecorder) Claim(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockWebhookDelivery)(nil).Claim), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockWebhookDelivery) Create(ctx context.Context, d dbmodel.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookDeliveryMockRecorder) Create(ctx, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDelivery)(nil).Create), ctx, d)
+}
+
+// FindById mocks base method.
+func (m *MockWebhookDelivery) FindById(ctx context.Context, id int64) (dbmodel.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockWebhookDeliveryMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockWebhookDelivery)(nil).FindById), ctx, id)
+}
+
+// FindByWebhook mocks base method.
+func (m *MockWebhookDelivery) FindByWebhook(ctx context.Context, webhookId, limit int) ([]dbmodel.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByWebhook", ctx, webhookId, limit)
+	ret0, _ := ret[0].([]dbmodel.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByWebhook indicates an expected call of FindByWebhook.
+func (mr *MockWebhookDeliveryMockRecorder) FindByWebhook(ctx, webhookId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWebhook", reflect.TypeOf((*MockWebhookDelivery)(nil).FindByWebhook), ctx, webhookId, limit)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockWebhookDelivery) UpdateStatus(ctx context.Context, d dbmodel.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockWebhookDeliveryMockRecorder) UpdateStatus(ctx, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockWebhookDelivery)(nil).UpdateStatus), ctx, d)
+}
+
+// MockReminder is a mock of Reminder interface.
+type MockReminder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderMockRecorder
+}
+
+// MockReminderMockRecorder is the mock recorder for MockReminder.
+type MockReminderMockRecorder struct {
+	mock *MockReminder
+}
+
+// NewMockReminder creates a new mock instance.
+func NewMockReminder(ctrl *gomock.Controller) *MockReminder {
+	mock := &MockReminder{ctrl: ctrl}
+	mock.recorder = &MockReminderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockReminder) Claim(ctx context.Context, r dbmodel.Reminder, lease time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, r, lease)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockReminderMockRecorder) Claim(ctx, r, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockReminder)(nil).Claim), ctx, r, lease)
+}
+
+// Create mocks base method.
+func (m *MockReminder) Create(ctx context.Context, r dbmodel.Reminder) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReminderMockRecorder) Create(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReminder)(nil).Create), ctx, r)
+}
+
+// FindAllSettings mocks base method.
+func (m *MockReminder) FindAllSettings(ctx context.Context) ([]dbmodel.ReminderSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllSettings", ctx)
+	ret0, _ := ret[0].([]dbmodel.ReminderSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllSettings indicates an expected call of FindAllSettings.
+func (mr *MockReminderMockRecorder) FindAllSettings(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllSettings", reflect.TypeOf((*MockReminder)(nil).FindAllSettings), ctx)
+}
+
+// FindSettings mocks base method.
+func (m *MockReminder) FindSettings(ctx context.Context, userId string) (dbmodel.ReminderSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSettings", ctx, userId)
+	ret0, _ := ret[0].(dbmodel.ReminderSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSettings indicates an expected call of FindSettings.
+func (mr *MockReminderMockRecorder) FindSettings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSettings", reflect.TypeOf((*MockReminder)(nil).FindSettings), ctx, userId)
+}
+
+// MarkSent mocks base method.
+func (m *MockReminder) MarkSent(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockReminderMockRecorder) MarkSent(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockReminder)(nil).MarkSent), ctx, id)
+}
+
+// UpsertSettings mocks base method.
+func (m *MockReminder) UpsertSettings(ctx context.Context, s dbmodel.ReminderSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSettings", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSettings indicates an expected call of UpsertSettings.
+func (mr *MockReminderMockRecorder) UpsertSettings(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSettings", reflect.TypeOf((*MockReminder)(nil).UpsertSettings), ctx, s)
+}
+
+// MockBudget is a mock of Budget interface.
+type MockBudget struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetMockRecorder
+}
+
+// MockBudgetMockRecorder is the mock recorder for MockBudget.
+type MockBudgetMockRecorder struct {
+	mock *MockBudget
+}
+
+// NewMockBudget creates a new mock instance.
+func NewMockBudget(ctrl *gomock.Controller) *MockBudget {
+	mock := &MockBudget{ctrl: ctrl}
+	mock.recorder = &MockBudgetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudget) EXPECT() *MockBudgetMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBudget) Create(ctx context.Context, b dbmodel.Budget) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, b)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBudgetMockRecorder) Create(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBudget)(nil).Create), ctx, b)
+}
+
+// CreateAlert mocks base method.
+func (m *MockBudget) CreateAlert(ctx context.Context, a dbmodel.BudgetAlert) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlert", ctx, a)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlert indicates an expected call of CreateAlert.
+func (mr *MockBudgetMockRecorder) CreateAlert(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlert", reflect.TypeOf((*MockBudget)(nil).CreateAlert), ctx, a)
+}
+
+// Delete mocks base method.
+func (m *MockBudget) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBudgetMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBudget)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockBudget) FindAll(ctx context.Context, userId string) ([]dbmodel.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userId)
+	ret0, _ := ret[0].([]dbmodel.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBudgetMockRecorder) FindAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBudget)(nil).FindAll), ctx, userId)
+}
+
+// FindById mocks base method.
+func (m *MockBudget) FindById(ctx context.Context, id int) (dbmodel.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockBudgetMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockBudget)(nil).FindById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockBudget) Update(ctx context.Context, b dbmodel.Budget) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBudgetMockRecorder) Update(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBudget)(nil).Update), ctx, b)
+}
+
+// MockPriceChange is a mock of PriceChange interface.
+type MockPriceChange struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceChangeMockRecorder
+}
+
+// MockPriceChangeMockRecorder is the mock recorder for MockPriceChange.
+type MockPriceChangeMockRecorder struct {
+	mock *MockPriceChange
+}
+
+// NewMockPriceChange creates a new mock instance.
+func NewMockPriceChange(ctrl *gomock.Controller) *MockPriceChange {
+	mock := &MockPriceChange{ctrl: ctrl}
+	mock.recorder = &MockPriceChangeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceChange) EXPECT() *MockPriceChangeMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPriceChange) Create(ctx context.Context, c dbmodel.PriceChange) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceChangeMockRecorder) Create(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceChange)(nil).Create), ctx, c)
+}
+
+// Delete mocks base method.
+func (m *MockPriceChange) Delete(ctx context.Context, subscriptionId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subscriptionId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPriceChangeMockRecorder) Delete(ctx, subscriptionId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPriceChange)(nil).Delete), ctx, subscriptionId, id)
+}
+
+// FindBySubscriptions mocks base method.
+func (m *MockPriceChange) FindBySubscriptions(ctx context.Context, subscriptionIds []int) ([]dbmodel.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySubscriptions", ctx, subscriptionIds)
+	ret0, _ := ret[0].([]dbmodel.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySubscriptions indicates an expected call of FindBySubscriptions.
+func (mr *MockPriceChangeMockRecorder) FindBySubscriptions(ctx, subscriptionIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySubscriptions", reflect.TypeOf((*MockPriceChange)(nil).FindBySubscriptions), ctx, subscriptionIds)
+}
+
+// FindDue mocks base method.
+func (m *MockPriceChange) FindDue(ctx context.Context, date time.Time) ([]dbmodel.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, date)
+	ret0, _ := ret[0].([]dbmodel.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockPriceChangeMockRecorder) FindDue(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockPriceChange)(nil).FindDue), ctx, date)
+}
+
+// MarkApplied mocks base method.
+func (m *MockPriceChange) MarkApplied(ctx context.Context, subscriptionId int, date time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkApplied", ctx, subscriptionId, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkApplied indicates an expected call of MarkApplied.
+func (mr *MockPriceChangeMockRecorder) MarkApplied(ctx, subscriptionId, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkApplied", reflect.TypeOf((*MockPriceChange)(nil).MarkApplied), ctx, subscriptionId, date)
+}
+
+// MockPause is a mock of Pause interface.
+type MockPause struct {
+	ctrl     *gomock.Controller
+	recorder *MockPauseMockRecorder
+}
+
+// MockPauseMockRecorder is the mock recorder for MockPause.
+type MockPauseMockRecorder struct {
+	mock *MockPause
+}
+
+// NewMockPause creates a new mock instance.
+func NewMockPause(ctrl *gomock.Controller) *MockPause {
+	mock := &MockPause{ctrl: ctrl}
+	mock.recorder = &MockPauseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPause) EXPECT() *MockPauseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPause) Create(ctx context.Context, p dbmodel.Pause) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPauseMockRecorder) Create(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPause)(nil).Create), ctx, p)
+}
+
+// Delete mocks base method.
+func (m *MockPause) Delete(ctx context.Context, subscriptionId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subscriptionId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPauseMockRecorder) Delete(ctx, subscriptionId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPause)(nil).Delete), ctx, subscriptionId, id)
+}
+
+// Update mocks base method.
+func (m *MockPause) Update(ctx context.Context, p dbmodel.Pause) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPauseMockRecorder) Update(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPause)(nil).Update), ctx, p)
+}
+
+// MockCharge is a mock of Charge interface.
+type MockCharge struct {
+	ctrl     *gomock.Controller
+	recorder *MockChargeMockRecorder
+}
+
+// MockChargeMockRecorder is the mock recorder for MockCharge.
+type MockChargeMockRecorder struct {
+	mock *MockCharge
+}
+
+// NewMockCharge creates a new mock instance.
+func NewMockCharge(ctrl *gomock.Controller) *MockCharge {
+	mock := &MockCharge{ctrl: ctrl}
+	mock.recorder = &MockChargeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCharge) EXPECT() *MockChargeMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCharge) Create(ctx context.Context, charges []dbmodel.Charge) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, charges)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChargeMockRecorder) Create(ctx, charges interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCharge)(nil).Create), ctx, charges)
+}
+
+// FindAll mocks base method.
+func (m *MockCharge) FindAll(ctx context.Context, f dbmodel.ChargeFilter) ([]dbmodel.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, f)
+	ret0, _ := ret[0].([]dbmodel.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockChargeMockRecorder) FindAll(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCharge)(nil).FindAll), ctx, f)
+}
+
+// FindAmount mocks base method.
+func (m *MockCharge) FindAmount(ctx context.Context, f dbmodel.SubscriptionFilter, start, end time.Time) (dbmodel.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAmount", ctx, f, start, end)
+	ret0, _ := ret[0].(dbmodel.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAmount indicates an expected call of FindAmount.
+func (mr *MockChargeMockRecorder) FindAmount(ctx, f, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAmount", reflect.TypeOf((*MockCharge)(nil).FindAmount), ctx, f, start, end)
+}
+
+// FindById mocks base method.
+func (m *MockCharge) FindById(ctx context.Context, id int64) (dbmodel.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dbmodel.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockChargeMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCharge)(nil).FindById), ctx, id)
+}
+
+// FindLastBillingDates mocks base method.
+func (m *MockCharge) FindLastBillingDates(ctx context.Context, subscriptionIds []int) (map[int]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastBillingDates", ctx, subscriptionIds)
+	ret0, _ := ret[0].(map[int]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastBillingDates indicates an expected call of FindLastBillingDates.
+func (mr *MockChargeMockRecorder) FindLastBillingDates(ctx, subscriptionIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastBillingDates", reflect.TypeOf((*MockCharge)(nil).FindLastBillingDates), ctx, subscriptionIds)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockCharge) UpdateStatus(ctx context.Context, id int64, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockChargeMockRecorder) UpdateStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCharge)(nil).UpdateStatus), ctx, id, from, to)
+}
+
+// MockAnomaly is a mock of Anomaly interface.
+type MockAnomaly struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnomalyMockRecorder
+}
+
+// MockAnomalyMockRecorder is the mock recorder for MockAnomaly.
+type MockAnomalyMockRecorder struct {
+	mock *MockAnomaly
+}
+
+// NewMockAnomaly creates a new mock instance.
+func NewMockAnomaly(ctrl *gomock.Controller) *MockAnomaly {
+	mock := &MockAnomaly{ctrl: ctrl}
+	mock.recorder = &MockAnomalyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnomaly) EXPECT() *MockAnomalyMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAnomaly) Create(ctx context.Context, a dbmodel.PriceAnomaly) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, a)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAnomalyMockRecorder) Create(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAnomaly)(nil).Create), ctx, a)
+}
+
+// FindAll mocks base method.
+func (m *MockAnomaly) FindAll(ctx context.Context, userId string) ([]dbmodel.PriceAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userId)
+	ret0, _ := ret[0].([]dbmodel.PriceAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAnomalyMockRecorder) FindAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAnomaly)(nil).FindAll), ctx, userId)
+}
+
+// FindPrices mocks base method.
+func (m *MockAnomaly) FindPrices(ctx context.Context, date time.Time) ([]dbmodel.SubscriptionPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPrices", ctx, date)
+	ret0, _ := ret[0].([]dbmodel.SubscriptionPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPrices indicates an expected call of FindPrices.
+func (mr *MockAnomalyMockRecorder) FindPrices(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrices", reflect.TypeOf((*MockAnomaly)(nil).FindPrices), ctx, date)
+}
+
+// MockAnalytics is a mock of Analytics interface.
+type MockAnalytics struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsMockRecorder
+}
+
+// MockAnalyticsMockRecorder is the mock recorder for MockAnalytics.
+type MockAnalyticsMockRecorder struct {
+	mock *MockAnalytics
+}
+
+// NewMockAnalytics creates a new mock instance.
+func NewMockAnalytics(ctrl *gomock.Controller) *MockAnalytics {
+	mock := &MockAnalytics{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalytics) EXPECT() *MockAnalyticsMockRecorder {
+	return m.recorder
+}
+
+// CategoryStats mocks base method.
+func (m *MockAnalytics) CategoryStats(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.CategoryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategoryStats", ctx, userId, start, end)
+	ret0, _ := ret[0].([]dbmodel.CategoryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategoryStats indicates an expected call of CategoryStats.
+func (mr *MockAnalyticsMockRecorder) CategoryStats(ctx, userId, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryStats", reflect.TypeOf((*MockAnalytics)(nil).CategoryStats), ctx, userId, start, end)
+}
+
+// Movements mocks base method.
+func (m *MockAnalytics) Movements(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.Movement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Movements", ctx, userId, start, end)
+	ret0, _ := ret[0].([]dbmodel.Movement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Movements indicates an expected call of Movements.
+func (mr *MockAnalyticsMockRecorder) Movements(ctx, userId, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Movements", reflect.TypeOf((*MockAnalytics)(nil).Movements), ctx, userId, start, end)
+}
+
+// RecurringSpend mocks base method.
+func (m *MockAnalytics) RecurringSpend(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.RecurringSpend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecurringSpend", ctx, userId, start, end)
+	ret0, _ := ret[0].([]dbmodel.RecurringSpend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecurringSpend indicates an expected call of RecurringSpend.
+func (mr *MockAnalyticsMockRecorder) RecurringSpend(ctx, userId, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecurringSpend", reflect.TypeOf((*MockAnalytics)(nil).RecurringSpend), ctx, userId, start, end)
+}
+
+// ServiceStats mocks base method.
+func (m *MockAnalytics) ServiceStats(ctx context.Context, userId string, start, end time.Time, limit int) ([]dbmodel.ServiceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceStats", ctx, userId, start, end, limit)
+	ret0, _ := ret[0].([]dbmodel.ServiceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceStats indicates an expected call of ServiceStats.
+func (mr *MockAnalyticsMockRecorder) ServiceStats(ctx, userId, start, end, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceStats", reflect.TypeOf((*MockAnalytics)(nil).ServiceStats), ctx, userId, start, end, limit)
+}
@@ -8,10 +8,205 @@ import (
 	context "context"
 	reflect "reflect"
 	service "subscription_service/internal/service"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockOrganization is a mock of Organization interface.
+type MockOrganization struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationMockRecorder
+}
+
+// MockOrganizationMockRecorder is the mock recorder for MockOrganization.
+type MockOrganizationMockRecorder struct {
+	mock *MockOrganization
+}
+
+// NewMockOrganization creates a new mock instance.
+func NewMockOrganization(ctrl *gomock.Controller) *MockOrganization {
+	mock := &MockOrganization{ctrl: ctrl}
+	mock.recorder = &MockOrganizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganization) EXPECT() *MockOrganizationMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganization) Create(ctx context.Context, input service.OrganizationInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganization)(nil).Create), ctx, input)
+}
+
+// FindAll mocks base method.
+func (m *MockOrganization) FindAll(ctx context.Context) ([]service.OrganizationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]service.OrganizationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockOrganizationMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrganization)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockOrganization) FindById(ctx context.Context, id int) (service.OrganizationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(service.OrganizationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockOrganizationMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrganization)(nil).FindById), ctx, id)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUser) Create(ctx context.Context, input service.UserInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), ctx, input)
+}
+
+// Delete mocks base method.
+func (m *MockUser) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockUser) FindAll(ctx context.Context) ([]service.UserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]service.UserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockUserMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUser)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockUser) FindById(ctx context.Context, id string) (service.UserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(service.UserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockUserMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUser)(nil).FindById), ctx, id)
+}
+
+// Subscriptions mocks base method.
+func (m *MockUser) Subscriptions(ctx context.Context, id string) ([]service.SubscriptionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscriptions", ctx, id)
+	ret0, _ := ret[0].([]service.SubscriptionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscriptions indicates an expected call of Subscriptions.
+func (mr *MockUserMockRecorder) Subscriptions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscriptions", reflect.TypeOf((*MockUser)(nil).Subscriptions), ctx, id)
+}
+
+// Summary mocks base method.
+func (m *MockUser) Summary(ctx context.Context, id string, date *time.Time) (service.UserSummaryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary", ctx, id, date)
+	ret0, _ := ret[0].(service.UserSummaryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockUserMockRecorder) Summary(ctx, id, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockUser)(nil).Summary), ctx, id, date)
+}
+
+// Update mocks base method.
+func (m *MockUser) Update(ctx context.Context, id string, input service.UserInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserMockRecorder) Update(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), ctx, id, input)
+}
+
 // MockSubscription is a mock of Subscription interface.
 type MockSubscription struct {
 	ctrl     *gomock.Controller
@@ -35,6 +230,20 @@ func (m *MockSubscription) EXPECT() *MockSubscriptionMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockSubscription) Cancel(ctx context.Context, id int, input service.CancelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockSubscriptionMockRecorder) Cancel(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockSubscription)(nil).Cancel), ctx, id, input)
+}
+
 // Create mocks base method.
 func (m *MockSubscription) Create(ctx context.Context, input service.SubscriptionInput) error {
 	m.ctrl.T.Helper()
@@ -64,18 +273,18 @@ func (mr *MockSubscriptionMockRecorder) Delete(ctx, id interface{}) *gomock.Call
 }
 
 // FindAll mocks base method.
-func (m *MockSubscription) FindAll(ctx context.Context) ([]service.SubscriptionOutput, error) {
+func (m *MockSubscription) FindAll(ctx context.Context, filter service.SubscriptionFilterInput) ([]service.SubscriptionOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
 	ret0, _ := ret[0].([]service.SubscriptionOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockSubscriptionMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+func (mr *MockSubscriptionMockRecorder) FindAll(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSubscription)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSubscription)(nil).FindAll), ctx, filter)
 }
 
 // FindById mocks base method.
@@ -93,11 +302,26 @@ func (mr *MockSubscriptionMockRecorder) FindById(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSubscription)(nil).FindById), ctx, id)
 }
 
+// FindDuplicates mocks base method.
+func (m *MockSubscription) FindDuplicates(ctx context.Context, userId string) ([]service.DuplicateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", ctx, userId)
+	ret0, _ := ret[0].([]service.DuplicateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockSubscriptionMockRecorder) FindDuplicates(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockSubscription)(nil).FindDuplicates), ctx, userId)
+}
+
 // FindPrice mocks base method.
-func (m *MockSubscription) FindPrice(ctx context.Context, input service.PriceInput) (int, error) {
+func (m *MockSubscription) FindPrice(ctx context.Context, input service.PriceInput) (service.PriceOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindPrice", ctx, input)
-	ret0, _ := ret[0].(int)
+	ret0, _ := ret[0].(service.PriceOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -108,6 +332,63 @@ func (mr *MockSubscriptionMockRecorder) FindPrice(ctx, input interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrice", reflect.TypeOf((*MockSubscription)(nil).FindPrice), ctx, input)
 }
 
+// FindUserPrices mocks base method.
+func (m *MockSubscription) FindUserPrices(ctx context.Context, userIds []string, start, end time.Time) (map[string]service.PriceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserPrices", ctx, userIds, start, end)
+	ret0, _ := ret[0].(map[string]service.PriceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserPrices indicates an expected call of FindUserPrices.
+func (mr *MockSubscriptionMockRecorder) FindUserPrices(ctx, userIds, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserPrices", reflect.TypeOf((*MockSubscription)(nil).FindUserPrices), ctx, userIds, start, end)
+}
+
+// Pause mocks base method.
+func (m *MockSubscription) Pause(ctx context.Context, id int, input service.PauseInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockSubscriptionMockRecorder) Pause(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockSubscription)(nil).Pause), ctx, id, input)
+}
+
+// Resume mocks base method.
+func (m *MockSubscription) Resume(ctx context.Context, id int, input service.ResumeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockSubscriptionMockRecorder) Resume(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockSubscription)(nil).Resume), ctx, id, input)
+}
+
+// Uncancel mocks base method.
+func (m *MockSubscription) Uncancel(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uncancel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Uncancel indicates an expected call of Uncancel.
+func (mr *MockSubscriptionMockRecorder) Uncancel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uncancel", reflect.TypeOf((*MockSubscription)(nil).Uncancel), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockSubscription) Update(ctx context.Context, id int, input service.SubscriptionInput) error {
 	m.ctrl.T.Helper()
@@ -121,3 +402,1121 @@ func (mr *MockSubscriptionMockRecorder) Update(ctx, id, input interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscription)(nil).Update), ctx, id, input)
 }
+
+// MockShare is a mock of Share interface.
+type MockShare struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareMockRecorder
+}
+
+// MockShareMockRecorder is the mock recorder for MockShare.
+type MockShareMockRecorder struct {
+	mock *MockShare
+}
+
+// NewMockShare creates a new mock instance.
+func NewMockShare(ctrl *gomock.Controller) *MockShare {
+	mock := &MockShare{ctrl: ctrl}
+	mock.recorder = &MockShareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShare) EXPECT() *MockShareMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockShare) Delete(ctx context.Context, subscriptionId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subscriptionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShareMockRecorder) Delete(ctx, subscriptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShare)(nil).Delete), ctx, subscriptionId)
+}
+
+// Find mocks base method.
+func (m *MockShare) Find(ctx context.Context, subscriptionId int) (service.ShareOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, subscriptionId)
+	ret0, _ := ret[0].(service.ShareOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockShareMockRecorder) Find(ctx, subscriptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockShare)(nil).Find), ctx, subscriptionId)
+}
+
+// Set mocks base method.
+func (m *MockShare) Set(ctx context.Context, subscriptionId int, input service.ShareInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, subscriptionId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockShareMockRecorder) Set(ctx, subscriptionId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockShare)(nil).Set), ctx, subscriptionId, input)
+}
+
+// SettleUp mocks base method.
+func (m *MockShare) SettleUp(ctx context.Context, input service.SettleUpInput) ([]service.SettleUpMonthOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleUp", ctx, input)
+	ret0, _ := ret[0].([]service.SettleUpMonthOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleUp indicates an expected call of SettleUp.
+func (mr *MockShareMockRecorder) SettleUp(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleUp", reflect.TypeOf((*MockShare)(nil).SettleUp), ctx, input)
+}
+
+// MockForecast is a mock of Forecast interface.
+type MockForecast struct {
+	ctrl     *gomock.Controller
+	recorder *MockForecastMockRecorder
+}
+
+// MockForecastMockRecorder is the mock recorder for MockForecast.
+type MockForecastMockRecorder struct {
+	mock *MockForecast
+}
+
+// NewMockForecast creates a new mock instance.
+func NewMockForecast(ctrl *gomock.Controller) *MockForecast {
+	mock := &MockForecast{ctrl: ctrl}
+	mock.recorder = &MockForecastMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockForecast) EXPECT() *MockForecastMockRecorder {
+	return m.recorder
+}
+
+// ApplyPriceChanges mocks base method.
+func (m *MockForecast) ApplyPriceChanges(ctx context.Context, date time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPriceChanges", ctx, date)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPriceChanges indicates an expected call of ApplyPriceChanges.
+func (mr *MockForecastMockRecorder) ApplyPriceChanges(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPriceChanges", reflect.TypeOf((*MockForecast)(nil).ApplyPriceChanges), ctx, date)
+}
+
+// DeletePriceChange mocks base method.
+func (m *MockForecast) DeletePriceChange(ctx context.Context, subscriptionId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceChange", ctx, subscriptionId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceChange indicates an expected call of DeletePriceChange.
+func (mr *MockForecastMockRecorder) DeletePriceChange(ctx, subscriptionId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceChange", reflect.TypeOf((*MockForecast)(nil).DeletePriceChange), ctx, subscriptionId, id)
+}
+
+// FindPriceChanges mocks base method.
+func (m *MockForecast) FindPriceChanges(ctx context.Context, subscriptionId int) ([]service.PriceChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPriceChanges", ctx, subscriptionId)
+	ret0, _ := ret[0].([]service.PriceChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPriceChanges indicates an expected call of FindPriceChanges.
+func (mr *MockForecastMockRecorder) FindPriceChanges(ctx, subscriptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPriceChanges", reflect.TypeOf((*MockForecast)(nil).FindPriceChanges), ctx, subscriptionId)
+}
+
+// Forecast mocks base method.
+func (m *MockForecast) Forecast(ctx context.Context, input service.ForecastInput) (service.ForecastOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forecast", ctx, input)
+	ret0, _ := ret[0].(service.ForecastOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forecast indicates an expected call of Forecast.
+func (mr *MockForecastMockRecorder) Forecast(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forecast", reflect.TypeOf((*MockForecast)(nil).Forecast), ctx, input)
+}
+
+// SchedulePriceChange mocks base method.
+func (m *MockForecast) SchedulePriceChange(ctx context.Context, subscriptionId int, input service.PriceChangeInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePriceChange", ctx, subscriptionId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePriceChange indicates an expected call of SchedulePriceChange.
+func (mr *MockForecastMockRecorder) SchedulePriceChange(ctx, subscriptionId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePriceChange", reflect.TypeOf((*MockForecast)(nil).SchedulePriceChange), ctx, subscriptionId, input)
+}
+
+// MockAnalytics is a mock of Analytics interface.
+type MockAnalytics struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsMockRecorder
+}
+
+// MockAnalyticsMockRecorder is the mock recorder for MockAnalytics.
+type MockAnalyticsMockRecorder struct {
+	mock *MockAnalytics
+}
+
+// NewMockAnalytics creates a new mock instance.
+func NewMockAnalytics(ctrl *gomock.Controller) *MockAnalytics {
+	mock := &MockAnalytics{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalytics) EXPECT() *MockAnalyticsMockRecorder {
+	return m.recorder
+}
+
+// Categories mocks base method.
+func (m *MockAnalytics) Categories(ctx context.Context, input service.AnalyticsInput) ([]service.CategoryStatsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories", ctx, input)
+	ret0, _ := ret[0].([]service.CategoryStatsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockAnalyticsMockRecorder) Categories(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockAnalytics)(nil).Categories), ctx, input)
+}
+
+// Growth mocks base method.
+func (m *MockAnalytics) Growth(ctx context.Context, input service.AnalyticsInput) ([]service.GrowthOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Growth", ctx, input)
+	ret0, _ := ret[0].([]service.GrowthOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Growth indicates an expected call of Growth.
+func (mr *MockAnalyticsMockRecorder) Growth(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Growth", reflect.TypeOf((*MockAnalytics)(nil).Growth), ctx, input)
+}
+
+// RecurringSpend mocks base method.
+func (m *MockAnalytics) RecurringSpend(ctx context.Context, input service.AnalyticsInput) ([]service.RecurringSpendOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecurringSpend", ctx, input)
+	ret0, _ := ret[0].([]service.RecurringSpendOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecurringSpend indicates an expected call of RecurringSpend.
+func (mr *MockAnalyticsMockRecorder) RecurringSpend(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecurringSpend", reflect.TypeOf((*MockAnalytics)(nil).RecurringSpend), ctx, input)
+}
+
+// Services mocks base method.
+func (m *MockAnalytics) Services(ctx context.Context, input service.AnalyticsInput, limit int) ([]service.ServiceStatsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Services", ctx, input, limit)
+	ret0, _ := ret[0].([]service.ServiceStatsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Services indicates an expected call of Services.
+func (mr *MockAnalyticsMockRecorder) Services(ctx, input, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Services", reflect.TypeOf((*MockAnalytics)(nil).Services), ctx, input, limit)
+}
+
+// MockCatalog is a mock of Catalog interface.
+type MockCatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogMockRecorder
+}
+
+// MockCatalogMockRecorder is the mock recorder for MockCatalog.
+type MockCatalogMockRecorder struct {
+	mock *MockCatalog
+}
+
+// NewMockCatalog creates a new mock instance.
+func NewMockCatalog(ctrl *gomock.Controller) *MockCatalog {
+	mock := &MockCatalog{ctrl: ctrl}
+	mock.recorder = &MockCatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalog) EXPECT() *MockCatalogMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCatalog) Create(ctx context.Context, input service.ServiceInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCatalogMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCatalog)(nil).Create), ctx, input)
+}
+
+// Delete mocks base method.
+func (m *MockCatalog) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCatalogMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCatalog)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockCatalog) FindAll(ctx context.Context) ([]service.ServiceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]service.ServiceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCatalogMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCatalog)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockCatalog) FindById(ctx context.Context, id int) (service.ServiceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(service.ServiceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCatalogMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCatalog)(nil).FindById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockCatalog) Update(ctx context.Context, id int, input service.ServiceInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCatalogMockRecorder) Update(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCatalog)(nil).Update), ctx, id, input)
+}
+
+// MockCategory is a mock of Category interface.
+type MockCategory struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryMockRecorder
+}
+
+// MockCategoryMockRecorder is the mock recorder for MockCategory.
+type MockCategoryMockRecorder struct {
+	mock *MockCategory
+}
+
+// NewMockCategory creates a new mock instance.
+func NewMockCategory(ctrl *gomock.Controller) *MockCategory {
+	mock := &MockCategory{ctrl: ctrl}
+	mock.recorder = &MockCategoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategory) EXPECT() *MockCategoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategory) Create(ctx context.Context, input service.CategoryInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategory)(nil).Create), ctx, input)
+}
+
+// Delete mocks base method.
+func (m *MockCategory) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategory)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockCategory) FindAll(ctx context.Context) ([]service.CategoryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]service.CategoryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCategoryMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategory)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockCategory) FindById(ctx context.Context, id int) (service.CategoryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(service.CategoryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCategoryMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCategory)(nil).FindById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockCategory) Update(ctx context.Context, id int, input service.CategoryInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryMockRecorder) Update(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategory)(nil).Update), ctx, id, input)
+}
+
+// MockCalendar is a mock of Calendar interface.
+type MockCalendar struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarMockRecorder
+}
+
+// MockCalendarMockRecorder is the mock recorder for MockCalendar.
+type MockCalendarMockRecorder struct {
+	mock *MockCalendar
+}
+
+// NewMockCalendar creates a new mock instance.
+func NewMockCalendar(ctrl *gomock.Controller) *MockCalendar {
+	mock := &MockCalendar{ctrl: ctrl}
+	mock.recorder = &MockCalendarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendar) EXPECT() *MockCalendarMockRecorder {
+	return m.recorder
+}
+
+// Feed mocks base method.
+func (m *MockCalendar) Feed(ctx context.Context, userId, token string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Feed", ctx, userId, token)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *MockCalendarMockRecorder) Feed(ctx, userId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*MockCalendar)(nil).Feed), ctx, userId, token)
+}
+
+// Token mocks base method.
+func (m *MockCalendar) Token(ctx context.Context, userId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token", ctx, userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockCalendarMockRecorder) Token(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockCalendar)(nil).Token), ctx, userId)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhook) Create(ctx context.Context, input service.WebhookInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), ctx, input)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), ctx, id)
+}
+
+// Dispatch mocks base method.
+func (m *MockWebhook) Dispatch(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockWebhookMockRecorder) Dispatch(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockWebhook)(nil).Dispatch), ctx, limit)
+}
+
+// FindAll mocks base method.
+func (m *MockWebhook) FindAll(ctx context.Context) ([]service.WebhookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]service.WebhookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockWebhookMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockWebhook)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockWebhook) FindById(ctx context.Context, id int) (service.WebhookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(service.WebhookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockWebhookMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockWebhook)(nil).FindById), ctx, id)
+}
+
+// FindDeliveries mocks base method.
+func (m *MockWebhook) FindDeliveries(ctx context.Context, webhookId int) ([]service.WebhookDeliveryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveries", ctx, webhookId)
+	ret0, _ := ret[0].([]service.WebhookDeliveryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveries indicates an expected call of FindDeliveries.
+func (mr *MockWebhookMockRecorder) FindDeliveries(ctx, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveries", reflect.TypeOf((*MockWebhook)(nil).FindDeliveries), ctx, webhookId)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhook) Redeliver(ctx context.Context, webhookId int, deliveryId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, webhookId, deliveryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookMockRecorder) Redeliver(ctx, webhookId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhook)(nil).Redeliver), ctx, webhookId, deliveryId)
+}
+
+// Update mocks base method.
+func (m *MockWebhook) Update(ctx context.Context, id int, input service.WebhookInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookMockRecorder) Update(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhook)(nil).Update), ctx, id, input)
+}
+
+// MockReminder is a mock of Reminder interface.
+type MockReminder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderMockRecorder
+}
+
+// MockReminderMockRecorder is the mock recorder for MockReminder.
+type MockReminderMockRecorder struct {
+	mock *MockReminder
+}
+
+// NewMockReminder creates a new mock instance.
+func NewMockReminder(ctrl *gomock.Controller) *MockReminder {
+	mock := &MockReminder{ctrl: ctrl}
+	mock.recorder = &MockReminderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
+	return m.recorder
+}
+
+// FindSettings mocks base method.
+func (m *MockReminder) FindSettings(ctx context.Context, userId string) (service.ReminderSettingsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSettings", ctx, userId)
+	ret0, _ := ret[0].(service.ReminderSettingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSettings indicates an expected call of FindSettings.
+func (mr *MockReminderMockRecorder) FindSettings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSettings", reflect.TypeOf((*MockReminder)(nil).FindSettings), ctx, userId)
+}
+
+// Run mocks base method.
+func (m *MockReminder) Run(ctx context.Context, date time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, date)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockReminderMockRecorder) Run(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockReminder)(nil).Run), ctx, date)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockReminder) UpdateSettings(ctx context.Context, userId string, input service.ReminderSettingsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, userId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockReminderMockRecorder) UpdateSettings(ctx, userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockReminder)(nil).UpdateSettings), ctx, userId, input)
+}
+
+// MockBudget is a mock of Budget interface.
+type MockBudget struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetMockRecorder
+}
+
+// MockBudgetMockRecorder is the mock recorder for MockBudget.
+type MockBudgetMockRecorder struct {
+	mock *MockBudget
+}
+
+// NewMockBudget creates a new mock instance.
+func NewMockBudget(ctrl *gomock.Controller) *MockBudget {
+	mock := &MockBudget{ctrl: ctrl}
+	mock.recorder = &MockBudgetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudget) EXPECT() *MockBudgetMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockBudget) Check(ctx context.Context, date time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, date)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockBudgetMockRecorder) Check(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockBudget)(nil).Check), ctx, date)
+}
+
+// Create mocks base method.
+func (m *MockBudget) Create(ctx context.Context, input service.BudgetInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBudgetMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBudget)(nil).Create), ctx, input)
+}
+
+// Delete mocks base method.
+func (m *MockBudget) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBudgetMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBudget)(nil).Delete), ctx, id)
+}
+
+// Evaluate mocks base method.
+func (m *MockBudget) Evaluate(ctx context.Context, id int, start, end time.Time, source string) ([]service.BudgetMonthOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, id, start, end, source)
+	ret0, _ := ret[0].([]service.BudgetMonthOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockBudgetMockRecorder) Evaluate(ctx, id, start, end, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockBudget)(nil).Evaluate), ctx, id, start, end, source)
+}
+
+// FindAll mocks base method.
+func (m *MockBudget) FindAll(ctx context.Context, userId string) ([]service.BudgetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userId)
+	ret0, _ := ret[0].([]service.BudgetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBudgetMockRecorder) FindAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBudget)(nil).FindAll), ctx, userId)
+}
+
+// FindById mocks base method.
+func (m *MockBudget) FindById(ctx context.Context, id int) (service.BudgetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(service.BudgetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockBudgetMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockBudget)(nil).FindById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockBudget) Update(ctx context.Context, id int, input service.BudgetInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBudgetMockRecorder) Update(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBudget)(nil).Update), ctx, id, input)
+}
+
+// MockStatement is a mock of Statement interface.
+type MockStatement struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementMockRecorder
+}
+
+// MockStatementMockRecorder is the mock recorder for MockStatement.
+type MockStatementMockRecorder struct {
+	mock *MockStatement
+}
+
+// NewMockStatement creates a new mock instance.
+func NewMockStatement(ctrl *gomock.Controller) *MockStatement {
+	mock := &MockStatement{ctrl: ctrl}
+	mock.recorder = &MockStatementMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatement) EXPECT() *MockStatementMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockStatement) Find(ctx context.Context, userId string, month time.Time) (service.StatementOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, userId, month)
+	ret0, _ := ret[0].(service.StatementOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockStatementMockRecorder) Find(ctx, userId, month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockStatement)(nil).Find), ctx, userId, month)
+}
+
+// HTML mocks base method.
+func (m *MockStatement) HTML(ctx context.Context, userId string, month time.Time) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HTML", ctx, userId, month)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HTML indicates an expected call of HTML.
+func (mr *MockStatementMockRecorder) HTML(ctx, userId, month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTML", reflect.TypeOf((*MockStatement)(nil).HTML), ctx, userId, month)
+}
+
+// PDF mocks base method.
+func (m *MockStatement) PDF(ctx context.Context, userId string, month time.Time) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PDF", ctx, userId, month)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PDF indicates an expected call of PDF.
+func (mr *MockStatementMockRecorder) PDF(ctx, userId, month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PDF", reflect.TypeOf((*MockStatement)(nil).PDF), ctx, userId, month)
+}
+
+// MockCharge is a mock of Charge interface.
+type MockCharge struct {
+	ctrl     *gomock.Controller
+	recorder *MockChargeMockRecorder
+}
+
+// MockChargeMockRecorder is the mock recorder for MockCharge.
+type MockChargeMockRecorder struct {
+	mock *MockCharge
+}
+
+// NewMockCharge creates a new mock instance.
+func NewMockCharge(ctrl *gomock.Controller) *MockCharge {
+	mock := &MockCharge{ctrl: ctrl}
+	mock.recorder = &MockChargeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCharge) EXPECT() *MockChargeMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockCharge) FindAll(ctx context.Context, filter service.ChargeFilterInput) ([]service.ChargeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]service.ChargeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockChargeMockRecorder) FindAll(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCharge)(nil).FindAll), ctx, filter)
+}
+
+// FindById mocks base method.
+func (m *MockCharge) FindById(ctx context.Context, id int64) (service.ChargeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(service.ChargeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockChargeMockRecorder) FindById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCharge)(nil).FindById), ctx, id)
+}
+
+// Generate mocks base method.
+func (m *MockCharge) Generate(ctx context.Context, until time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", ctx, until)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockChargeMockRecorder) Generate(ctx, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockCharge)(nil).Generate), ctx, until)
+}
+
+// SetStatus mocks base method.
+func (m *MockCharge) SetStatus(ctx context.Context, id int64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockChargeMockRecorder) SetStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockCharge)(nil).SetStatus), ctx, id, status)
+}
+
+// MockAnomaly is a mock of Anomaly interface.
+type MockAnomaly struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnomalyMockRecorder
+}
+
+// MockAnomalyMockRecorder is the mock recorder for MockAnomaly.
+type MockAnomalyMockRecorder struct {
+	mock *MockAnomaly
+}
+
+// NewMockAnomaly creates a new mock instance.
+func NewMockAnomaly(ctrl *gomock.Controller) *MockAnomaly {
+	mock := &MockAnomaly{ctrl: ctrl}
+	mock.recorder = &MockAnomalyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnomaly) EXPECT() *MockAnomalyMockRecorder {
+	return m.recorder
+}
+
+// Detect mocks base method.
+func (m *MockAnomaly) Detect(ctx context.Context, date time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detect", ctx, date)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detect indicates an expected call of Detect.
+func (mr *MockAnomalyMockRecorder) Detect(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockAnomaly)(nil).Detect), ctx, date)
+}
+
+// FindAll mocks base method.
+func (m *MockAnomaly) FindAll(ctx context.Context, userId string) ([]service.AnomalyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userId)
+	ret0, _ := ret[0].([]service.AnomalyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAnomalyMockRecorder) FindAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAnomaly)(nil).FindAll), ctx, userId)
+}
+
+// MockSavings is a mock of Savings interface.
+type MockSavings struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavingsMockRecorder
+}
+
+// MockSavingsMockRecorder is the mock recorder for MockSavings.
+type MockSavingsMockRecorder struct {
+	mock *MockSavings
+}
+
+// NewMockSavings creates a new mock instance.
+func NewMockSavings(ctrl *gomock.Controller) *MockSavings {
+	mock := &MockSavings{ctrl: ctrl}
+	mock.recorder = &MockSavingsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavings) EXPECT() *MockSavingsMockRecorder {
+	return m.recorder
+}
+
+// Recommend mocks base method.
+func (m *MockSavings) Recommend(ctx context.Context, userId string, date *time.Time) (service.SavingsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recommend", ctx, userId, date)
+	ret0, _ := ret[0].(service.SavingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recommend indicates an expected call of Recommend.
+func (mr *MockSavingsMockRecorder) Recommend(ctx, userId, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recommend", reflect.TypeOf((*MockSavings)(nil).Recommend), ctx, userId, date)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Relay mocks base method.
+func (m *MockOutbox) Relay(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockOutboxMockRecorder) Relay(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockOutbox)(nil).Relay), ctx, limit)
+}
@@ -0,0 +1,29 @@
+package dbmodel
+
+import "time"
+
+type RecurringSpend struct {
+	Month  time.Time
+	Active int
+	Amount int // monthly equivalent, yearly subscriptions are divided by 12
+}
+
+type Movement struct {
+	Month   time.Time
+	New     int
+	Churned int
+}
+
+type ServiceStats struct {
+	ServiceName   string
+	Subscriptions int
+	AvgPrice      int // monthly equivalent
+	Spend         int
+}
+
+// CategoryStats is spend of subscriptions assigned to category itself, nil category means uncategorized
+type CategoryStats struct {
+	CategoryId    *int
+	Subscriptions int
+	Spend         int
+}
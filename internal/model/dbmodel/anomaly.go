@@ -0,0 +1,37 @@
+package dbmodel
+
+import "time"
+
+const (
+	AnomalyIncrease = "increase" // price is raised compared with previous price of subscription
+	AnomalyOutlier  = "outlier"  // price is above median price of the same service
+)
+
+// SubscriptionPrice is current list price of active subscription with history of its prices
+type SubscriptionPrice struct {
+	SubscriptionId int
+	ServiceId      int
+	ServiceName    string
+	UserId         string
+	BillingPeriod  string
+	Price          int
+	Currency       string // currency of subscription owner
+	History        []int  // prices in order of change, the last one is current
+	OrganizationId int
+}
+
+// PriceAnomaly is price of subscription which differs from ReferencePrice by Percent: previous price of subscription
+// for increase and median price of service for outlier
+type PriceAnomaly struct {
+	Id             int
+	SubscriptionId int
+	ServiceName    string // read only
+	UserId         string // read only
+	Kind           string
+	Price          int
+	ReferencePrice int
+	Percent        int
+	DetectedAt     time.Time
+
+	OrganizationId int // organization of ctx on create, read only
+}
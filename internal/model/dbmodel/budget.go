@@ -0,0 +1,21 @@
+package dbmodel
+
+import "time"
+
+type Budget struct {
+	Id          int
+	UserId      string
+	ServiceName *string // nil means budget for all user subscriptions
+	CategoryId  *int    // nil means budget for all categories, otherwise category with its subcategories
+	Amount      int
+	Thresholds  []int // percents of amount
+	CreatedAt   time.Time
+
+	OrganizationId int // organization of ctx on create, read only
+}
+
+type BudgetAlert struct {
+	BudgetId  int
+	Month     time.Time
+	Threshold int
+}
@@ -0,0 +1,11 @@
+package dbmodel
+
+import "time"
+
+// Category groups subscriptions. Categories form a tree, top level categories have no parent
+type Category struct {
+	Id        int
+	Name      string
+	ParentId  *int
+	CreatedAt time.Time
+}
@@ -0,0 +1,37 @@
+package dbmodel
+
+import "time"
+
+const (
+	ChargePending  = "pending"
+	ChargePaid     = "paid"
+	ChargeFailed   = "failed"
+	ChargeRefunded = "refunded"
+)
+
+// Charge is one billing cycle of subscription materialised in ledger, there is at most one charge
+// of subscription on billing date
+type Charge struct {
+	Id             int64
+	SubscriptionId int
+	ServiceName    string // canonical name from catalog, read only
+	UserId         string // owner of subscription on billing date
+	BillingDate    time.Time
+	Amount         int // gross amount with tax
+	Tax            int
+	Discount       int
+	Status         string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	OrganizationId int // organization of ctx on create, read only
+}
+
+// ChargeFilter narrows charges, zero fields are not applied. Start and End are inclusive billing dates
+type ChargeFilter struct {
+	UserId         string
+	SubscriptionId int
+	Status         string
+	Start          *time.Time
+	End            *time.Time
+}
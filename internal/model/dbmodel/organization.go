@@ -0,0 +1,10 @@
+package dbmodel
+
+import "time"
+
+// Organization is a tenant: users, subscriptions, catalog, categories, budgets and webhooks belong to exactly one
+type Organization struct {
+	Id        int
+	Name      string
+	CreatedAt time.Time
+}
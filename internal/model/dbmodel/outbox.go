@@ -0,0 +1,36 @@
+package dbmodel
+
+import "time"
+
+const (
+	EventSubscriptionCreated     = "subscription.created"
+	EventSubscriptionUpdated     = "subscription.updated"
+	EventSubscriptionCancelled   = "subscription.cancelled"
+	EventSubscriptionUncancelled = "subscription.uncancelled"
+	EventSubscriptionDeleted     = "subscription.deleted"
+	EventSubscriptionPaused      = "subscription.paused"
+	EventSubscriptionResumed     = "subscription.resumed"
+	EventSubscriptionRenewal     = "subscription.renewal_due"
+	EventSubscriptionTrialEnd    = "subscription.trial_ending"
+	EventSubscriptionAnomaly     = "subscription.price_anomaly"
+
+	EventBudgetThreshold = "budget.threshold_crossed"
+
+	EventChargePaid     = "charge.paid"
+	EventChargeFailed   = "charge.failed"
+	EventChargeRefunded = "charge.refunded"
+)
+
+type OutboxEvent struct {
+	Id            int64
+	EventType     string
+	AggregateId   int
+	Payload       []byte
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+
+	OrganizationId int // organization of ctx on create
+}
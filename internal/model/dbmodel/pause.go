@@ -0,0 +1,13 @@
+package dbmodel
+
+import "time"
+
+// Pause is interval of months from StartDate to EndDate inclusive when subscription is not charged.
+// EndDate is nil until subscription is resumed
+type Pause struct {
+	Id             int
+	SubscriptionId int
+	StartDate      time.Time
+	EndDate        *time.Time
+	CreatedAt      time.Time
+}
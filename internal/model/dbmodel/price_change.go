@@ -0,0 +1,13 @@
+package dbmodel
+
+import "time"
+
+// PriceChange is a scheduled price of subscription starting from StartDate month
+type PriceChange struct {
+	Id             int
+	SubscriptionId int
+	Price          int
+	StartDate      time.Time
+	CreatedAt      time.Time
+	AppliedAt      *time.Time // time price was written to subscription, nil until StartDate month starts
+}
@@ -0,0 +1,22 @@
+package dbmodel
+
+import "time"
+
+const (
+	ReminderRenewal  = "renewal"
+	ReminderTrialEnd = "trial_end"
+)
+
+type ReminderSettings struct {
+	UserId   string
+	LeadDays int
+	Email    *string
+}
+
+type Reminder struct {
+	Id             int64
+	SubscriptionId int
+	Kind           string
+	BillingDate    time.Time  // trial end date for trial end reminders
+	SentAt         *time.Time // nil until notification is sent
+}
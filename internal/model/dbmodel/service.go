@@ -0,0 +1,18 @@
+package dbmodel
+
+import "time"
+
+// Service is a catalog entry subscriptions refer to. Aliases are other spellings of name used for search
+type Service struct {
+	Id           int
+	Name         string
+	Aliases      []string
+	Category     *string
+	DefaultPrice *int // monthly price
+	YearlyPrice  *int // price of yearly plan
+	FamilyPrice  *int // monthly price of family plan for up to FamilySize users
+	FamilySize   *int
+	VendorUrl    *string
+	LogoUrl      *string
+	CreatedAt    time.Time
+}
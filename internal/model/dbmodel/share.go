@@ -0,0 +1,49 @@
+package dbmodel
+
+import "time"
+
+// Split rules of shared subscription. Owner of subscription pays what is left after members shares
+const (
+	SplitEqual      = "equal"      // price is divided equally among owner and members
+	SplitPercentage = "percentage" // member pays Percent of price
+	SplitFixed      = "fixed"      // member pays Amount
+)
+
+// Split describes how subscription price is shared between its owner and members
+type Split struct {
+	SubscriptionId int
+	Rule           string
+	Shares         []Share
+	OwnerCost      int // read only
+}
+
+type Share struct {
+	UserId  string
+	Percent *int
+	Amount  *int
+	Cost    int // part of price paid by member, read only
+}
+
+// ShareDebt is amount member of shared subscription owes its owner for every charge at regular price.
+// Charges within trial and intro periods reduce debt in proportion to price, paused months are not charged
+type ShareDebt struct {
+	SubscriptionId int
+	OwnerId        string
+	UserId         string
+	Amount         int
+	Price          int
+	StartDate      time.Time
+	EndDate        *time.Time
+	BillingPeriod  string
+	TrialEndDate   *time.Time
+	IntroPrice     *int
+	IntroPeriods   int
+	Pauses         []Pause
+
+	TaxRate           int
+	TaxInclusive      bool
+	DiscountType      *string
+	DiscountValue     int
+	DiscountStartDate *time.Time
+	DiscountMonths    int
+}
@@ -2,11 +2,88 @@ package dbmodel
 
 import "time"
 
+const (
+	BillingMonthly = "monthly"
+	BillingYearly  = "yearly"
+)
+
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
 type Subscription struct {
-	Id          int
-	ServiceName string
-	Price       int
-	UserId      string
-	StartDate   time.Time
-	EndDate     *time.Time
+	Id             int
+	ServiceId      int
+	ServiceName    string // canonical name from catalog, read only
+	Price          int
+	UserId         string
+	StartDate      time.Time  // day of the first charge, the next ones are on its anniversaries
+	EndDate        *time.Time // inclusive last day of subscription
+	BillingPeriod  string
+	CategoryId     *int
+	Tags           []string // written separately by SetTags
+	OrganizationId int      // organization of ctx on create, read only
+	Timezone       string   // time zone of user, read only
+
+	// TrialEndDate is inclusive last day of free trial, charges within trial cost nothing.
+	// The first IntroPeriods charges after trial cost IntroPrice
+	TrialEndDate *time.Time
+	IntroPrice   *int
+	IntroPeriods int
+
+	Pauses []Pause // written separately by Pause repo, ordered by start date
+
+	// TaxRate is percent of tax included in price when TaxInclusive, otherwise it is added on top of price
+	TaxRate      int
+	TaxInclusive bool
+
+	// DiscountType is nil without discount. Charges from DiscountStartDate are reduced by DiscountValue percent
+	// or by fixed DiscountValue for DiscountMonths months, forever if it is zero
+	DiscountType      *string
+	DiscountValue     int
+	DiscountStartDate *time.Time
+	DiscountMonths    int
+
+	// LastUsedDate is the last day user reported using subscription, nil if it is unknown
+	LastUsedDate *time.Time
+
+	// CancelReason and CancelledAt are written by Cancel together with EndDate
+	CancelReason *string
+	CancelledAt  *time.Time
+}
+
+// Price is amount charged for subscriptions: Gross is paid with tax, Net is Gross without Tax.
+// Discount is already subtracted from both
+type Price struct {
+	Gross    int
+	Net      int
+	Tax      int
+	Discount int
+}
+
+// SubscriptionFilter narrows subscriptions, zero fields are not applied.
+// Category matches its subcategories as well
+type SubscriptionFilter struct {
+	UserId     string
+	ServiceId  int
+	CategoryId int
+	Tag        string
+
+	// UserIds and ServiceIds match any of them, used to load subscriptions of many users or services at once
+	UserIds    []string
+	ServiceIds []int
+
+	// AfterId and Limit page list of subscriptions ordered by id, zero values are not applied
+	AfterId int
+	Limit   int
+}
+
+// SubscriptionCharge is price of subscription charged on its last active day of interval with part of it paid by
+// one user: share of subscription shared with user or the rest of price of own one
+type SubscriptionCharge struct {
+	Subscription
+	Gross    int    // gross amount of the whole charge
+	Amount   int    // gross amount paid by user
+	Currency string // currency of subscription owner
 }
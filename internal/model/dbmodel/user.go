@@ -0,0 +1,17 @@
+package dbmodel
+
+import "time"
+
+const (
+	DefaultTimezone = "UTC"
+	DefaultCurrency = "RUB"
+)
+
+type User struct {
+	Id          string
+	Email       *string
+	DisplayName *string
+	Timezone    string // IANA time zone name
+	Currency    string // ISO 4217 code
+	CreatedAt   time.Time
+}
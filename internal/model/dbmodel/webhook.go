@@ -0,0 +1,32 @@
+package dbmodel
+
+import "time"
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+type Webhook struct {
+	Id        int
+	Url       string
+	Secret    string
+	Events    []string // empty means all events
+	CreatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	Id             int64
+	WebhookId      int
+	EventId        int64
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	LastStatusCode *int
+	LastError      *string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
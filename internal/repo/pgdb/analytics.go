@@ -0,0 +1,173 @@
+package pgdb
+
+import (
+	"context"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/pkg/postgres"
+	"time"
+)
+
+// Analytics queries aggregate subscriptions by months of generate_series. Subscription is active in month
+// if its billing anniversary in the month is within start and inclusive end date and is not paused. Monthly price of subscription
+// is its gross price on the anniversary, so trial, intro periods, discount and tax are taken into account. Empty user id means all users,
+// NULL organization means all organizations
+
+const (
+	recurringSpendSQL = `
+SELECT m::date,
+       COUNT(s.id),
+       COALESCE(ROUND(SUM(subscription_gross(s, subscription_anniversary(s, m::date)) / CASE WHEN s.billing_period = 'yearly' THEN 12.0 ELSE 1 END)), 0)::int
+FROM generate_series($1::date, $2::date, interval '1 month') AS m
+         LEFT JOIN subscription s
+                   ON subscription_active(s, m::date)
+                       AND ($3 = '' OR s.user_id = $3)
+                       AND ($4::int IS NULL OR s.organization_id = $4)
+GROUP BY m
+ORDER BY m`
+
+	movementsSQL = `
+SELECT m::date,
+       COUNT(s.id) FILTER (WHERE date_trunc('month', s.start_date) = m),
+       COUNT(s.id) FILTER (WHERE date_trunc('month', s.end_date) = m)
+FROM generate_series($1::date, $2::date, interval '1 month') AS m
+         LEFT JOIN subscription s
+                   ON (date_trunc('month', s.start_date) = m OR date_trunc('month', s.end_date) = m)
+                       AND ($3 = '' OR s.user_id = $3)
+                       AND ($4::int IS NULL OR s.organization_id = $4)
+GROUP BY m
+ORDER BY m`
+
+	serviceStatsSQL = `
+WITH active AS (SELECT sv.name                                                                              AS service_name,
+                       AVG(subscription_gross(s, subscription_anniversary(s, m::date)) / CASE WHEN s.billing_period = 'yearly' THEN 12.0 ELSE 1 END) AS monthly_price,
+                       SUM(subscription_gross(s, subscription_anniversary(s, m::date)) / CASE WHEN s.billing_period = 'yearly' THEN 12.0 ELSE 1 END) AS spend
+                FROM subscription s
+                         JOIN services sv ON sv.id = s.service_id
+                         JOIN generate_series($1::date, $2::date, interval '1 month') AS m
+                              ON subscription_active(s, m::date)
+                WHERE ($3 = '' OR s.user_id = $3)
+                  AND ($5::int IS NULL OR s.organization_id = $5)
+                GROUP BY s.id, sv.name)
+SELECT service_name,
+       COUNT(*),
+       ROUND(AVG(monthly_price))::int,
+       ROUND(SUM(spend))::int AS total
+FROM active
+GROUP BY service_name
+ORDER BY total DESC, service_name
+LIMIT $4`
+
+	categoryStatsSQL = `
+WITH active AS (SELECT s.category_id,
+                       SUM(subscription_gross(s, subscription_anniversary(s, m::date)) / CASE WHEN s.billing_period = 'yearly' THEN 12.0 ELSE 1 END) AS spend
+                FROM subscription s
+                         JOIN generate_series($1::date, $2::date, interval '1 month') AS m
+                              ON subscription_active(s, m::date)
+                WHERE ($3 = '' OR s.user_id = $3)
+                  AND ($4::int IS NULL OR s.organization_id = $4)
+                GROUP BY s.id)
+SELECT category_id,
+       COUNT(*),
+       ROUND(SUM(spend))::int
+FROM active
+GROUP BY category_id
+ORDER BY category_id NULLS LAST`
+)
+
+type AnalyticsRepo struct {
+	*postgres.Postgres
+}
+
+func NewAnalyticsRepo(pg *postgres.Postgres) *AnalyticsRepo {
+	return &AnalyticsRepo{pg}
+}
+
+// RecurringSpend returns monthly recurring spend and number of active subscriptions for every month from start to end
+func (r *AnalyticsRepo) RecurringSpend(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.RecurringSpend, error) {
+	rows, err := r.Conn(ctx).Query(ctx, recurringSpendSQL, start, end, userId, tenantArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.RecurringSpend
+
+	for rows.Next() {
+		var s dbmodel.RecurringSpend
+
+		if err = rows.Scan(&s.Month, &s.Active, &s.Amount); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// Movements returns number of started and ended subscriptions for every month from start to end.
+// Subscription is churned in month of its end date
+func (r *AnalyticsRepo) Movements(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.Movement, error) {
+	rows, err := r.Conn(ctx).Query(ctx, movementsSQL, start, end, userId, tenantArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Movement
+
+	for rows.Next() {
+		var m dbmodel.Movement
+
+		if err = rows.Scan(&m.Month, &m.New, &m.Churned); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// ServiceStats returns services ordered by spend from start to end. Limit 0 means all services
+func (r *AnalyticsRepo) ServiceStats(ctx context.Context, userId string, start, end time.Time, limit int) ([]dbmodel.ServiceStats, error) {
+	var l any
+	if limit > 0 {
+		l = limit
+	}
+	rows, err := r.Conn(ctx).Query(ctx, serviceStatsSQL, start, end, userId, l, tenantArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.ServiceStats
+
+	for rows.Next() {
+		var s dbmodel.ServiceStats
+
+		if err = rows.Scan(&s.ServiceName, &s.Subscriptions, &s.AvgPrice, &s.Spend); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// CategoryStats returns spend from start to end of subscriptions grouped by their own category.
+// Subcategories are not rolled up into parents
+func (r *AnalyticsRepo) CategoryStats(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.CategoryStats, error) {
+	rows, err := r.Conn(ctx).Query(ctx, categoryStatsSQL, start, end, userId, tenantArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.CategoryStats
+
+	for rows.Next() {
+		var s dbmodel.CategoryStats
+
+		if err = rows.Scan(&s.CategoryId, &s.Subscriptions, &s.Spend); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
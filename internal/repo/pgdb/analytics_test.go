@@ -0,0 +1,79 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"time"
+)
+
+func (s *pgdbTestSuite) createAnalyticsSubscriptions() {
+	end := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	subscriptions := []dbmodel.Subscription{
+		{ServiceId: s.serviceId("Yandex Plus"), Price: 400, UserId: s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"), StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: dbmodel.BillingMonthly},
+		{ServiceId: s.serviceId("Spotify"), Price: 1200, UserId: s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"), StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: dbmodel.BillingYearly},
+		{ServiceId: s.serviceId("Yandex Plus"), Price: 300, UserId: s.userId("4c2f3e0b-7f0a-4b43-9d0c-0a1f5b0f2c11"), StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), EndDate: &end, BillingPeriod: dbmodel.BillingMonthly},
+	}
+	for _, sub := range subscriptions {
+		if _, err := s.sub.Create(s.ctx, sub); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (s *pgdbTestSuite) TestAnalyticsRepo_RecurringSpend() {
+	s.createAnalyticsSubscriptions()
+
+	actual, err := s.analytics.RecurringSpend(s.ctx, "", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal([]dbmodel.RecurringSpend{
+		{Month: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Active: 1, Amount: 100},
+		{Month: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Active: 3, Amount: 800},
+		{Month: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), Active: 3, Amount: 800},
+		{Month: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), Active: 2, Amount: 500},
+	}, actual)
+}
+
+func (s *pgdbTestSuite) TestAnalyticsRepo_RecurringSpendAnniversary() {
+	end := time.Date(2025, 9, 24, 0, 0, 0, 0, time.UTC)
+	_, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     s.serviceId("Yandex Plus"),
+		Price:         400,
+		UserId:        s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate:     time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+		EndDate:       &end,
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// subscription ends the day before its September anniversary
+	actual, err := s.analytics.RecurringSpend(s.ctx, "", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal([]dbmodel.RecurringSpend{
+		{Month: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Active: 1, Amount: 400},
+		{Month: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), Active: 1, Amount: 400},
+		{Month: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), Active: 0, Amount: 0},
+	}, actual)
+}
+
+func (s *pgdbTestSuite) TestAnalyticsRepo_Movements() {
+	s.createAnalyticsSubscriptions()
+
+	actual, err := s.analytics.Movements(s.ctx, "", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal([]dbmodel.Movement{
+		{Month: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), New: 1, Churned: 0},
+		{Month: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), New: 2, Churned: 0},
+		{Month: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), New: 0, Churned: 1},
+	}, actual)
+}
+
+func (s *pgdbTestSuite) TestAnalyticsRepo_ServiceStats() {
+	s.createAnalyticsSubscriptions()
+
+	actual, err := s.analytics.ServiceStats(s.ctx, "", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), 1)
+	s.Assert().NoError(err)
+	s.Assert().Equal([]dbmodel.ServiceStats{
+		{ServiceName: "Yandex Plus", Subscriptions: 2, AvgPrice: 350, Spend: 1400},
+	}, actual)
+}
@@ -0,0 +1,143 @@
+package pgdb
+
+import (
+	"context"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+const (
+	priceAnomalyTable = "price_anomaly"
+)
+
+type AnomalyRepo struct {
+	*postgres.Postgres
+}
+
+func NewAnomalyRepo(pg *postgres.Postgres) *AnomalyRepo {
+	return &AnomalyRepo{pg}
+}
+
+// FindPrices returns list prices of subscriptions active on date with history of their prices
+func (r *AnomalyRepo) FindPrices(ctx context.Context, date time.Time) ([]dbmodel.SubscriptionPrice, error) {
+	sql, args, _ := r.Builder.
+		Select(
+			"s.id",
+			"s.service_id",
+			"sv.name",
+			"s.user_id",
+			"s.billing_period",
+			"s.price",
+			"COALESCE(u.currency, 'RUB')",
+			"ARRAY(SELECT h.price FROM subscription_price_history h WHERE h.subscription_id = s.id ORDER BY h.changed_at, h.id)",
+			"s.organization_id",
+		).
+		From(subscriptionFrom).
+		LeftJoin("users u ON u.id = s.user_id").
+		Where(subscriptionOverlapSQL, date, date).
+		Where(tenantFilter(ctx, "s.organization_id")).
+		OrderBy("s.id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.SubscriptionPrice
+
+	for rows.Next() {
+		var p dbmodel.SubscriptionPrice
+
+		err = rows.Scan(
+			&p.SubscriptionId,
+			&p.ServiceId,
+			&p.ServiceName,
+			&p.UserId,
+			&p.BillingPeriod,
+			&p.Price,
+			&p.Currency,
+			&p.History,
+			&p.OrganizationId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Create records anomaly and reports whether it is new. Anomaly of the same kind and price of subscription
+// is recorded once
+func (r *AnomalyRepo) Create(ctx context.Context, a dbmodel.PriceAnomaly) (bool, error) {
+	sql, args, _ := r.Builder.
+		Insert(priceAnomalyTable).
+		Columns("subscription_id", "kind", "price", "reference_price", "percent", "organization_id").
+		Values(a.SubscriptionId, a.Kind, a.Price, a.ReferencePrice, a.Percent, tenant.OrganizationOrDefault(ctx)).
+		Suffix("ON CONFLICT (subscription_id, kind, price) DO NOTHING").
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// FindAll returns anomalies of subscriptions of user, of all users if it is empty, the latest first
+func (r *AnomalyRepo) FindAll(ctx context.Context, userId string) ([]dbmodel.PriceAnomaly, error) {
+	b := r.Builder.
+		Select(
+			"a.id",
+			"a.subscription_id",
+			"sv.name",
+			"s.user_id",
+			"a.kind",
+			"a.price",
+			"a.reference_price",
+			"a.percent",
+			"a.detected_at",
+			"a.organization_id",
+		).
+		From("price_anomaly a JOIN subscription s ON s.id = a.subscription_id JOIN services sv ON sv.id = s.service_id").
+		Where(tenantFilter(ctx, "a.organization_id"))
+
+	if userId != "" {
+		b = b.Where("s.user_id = ?", userId)
+	}
+	sql, args, _ := b.OrderBy("a.detected_at DESC", "a.id DESC").ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.PriceAnomaly
+
+	for rows.Next() {
+		var a dbmodel.PriceAnomaly
+
+		err = rows.Scan(
+			&a.Id,
+			&a.SubscriptionId,
+			&a.ServiceName,
+			&a.UserId,
+			&a.Kind,
+			&a.Price,
+			&a.ReferencePrice,
+			&a.Percent,
+			&a.DetectedAt,
+			&a.OrganizationId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
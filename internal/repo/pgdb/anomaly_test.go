@@ -0,0 +1,90 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestAnomalyRepo_FindPrices() {
+	userId := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+	sub := dbmodel.Subscription{
+		ServiceId: s.serviceId("Netflix"),
+		Price:     800,
+		UserId:    userId,
+		StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+	}
+	id, err := s.sub.Create(s.ctx, sub)
+	if err != nil {
+		panic(err)
+	}
+	sub.Id = id
+
+	// history is written only when price changes
+	for _, price := range []int{1000, 1000} {
+		sub.Price = price
+		if err = s.sub.Update(s.ctx, sub); err != nil {
+			panic(err)
+		}
+	}
+	end := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	_, err = s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Netflix"),
+		Price:     700,
+		UserId:    userId,
+		StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		EndDate:   &end,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	actual, err := s.anomaly.FindPrices(s.ctx, time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal([]dbmodel.SubscriptionPrice{
+		{
+			SubscriptionId: id,
+			ServiceId:      sub.ServiceId,
+			ServiceName:    "Netflix",
+			UserId:         userId,
+			BillingPeriod:  dbmodel.BillingMonthly,
+			Price:          1000,
+			Currency:       "RUB",
+			History:        []int{800, 1000},
+			OrganizationId: 1,
+		},
+	}, actual)
+}
+
+func (s *pgdbTestSuite) TestAnomalyRepo_Create() {
+	userId := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Netflix"),
+		Price:     1000,
+		UserId:    userId,
+		StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+	a := dbmodel.PriceAnomaly{SubscriptionId: id, Kind: dbmodel.AnomalyIncrease, Price: 1000, ReferencePrice: 800, Percent: 25}
+
+	created, err := s.anomaly.Create(s.ctx, a)
+	s.Assert().NoError(err)
+	s.Assert().True(created)
+
+	created, err = s.anomaly.Create(s.ctx, a)
+	s.Assert().NoError(err)
+	s.Assert().False(created)
+
+	actual, err := s.anomaly.FindAll(s.ctx, userId)
+	s.Assert().NoError(err)
+	s.Assert().Len(actual, 1)
+	s.Assert().Equal("Netflix", actual[0].ServiceName)
+	s.Assert().Equal(userId, actual[0].UserId)
+	s.Assert().Equal(800, actual[0].ReferencePrice)
+	s.Assert().Equal(1, actual[0].OrganizationId)
+
+	actual, err = s.anomaly.FindAll(s.ctx, "4c2f3e0b-7f0a-4b43-9d0c-0a1f5b0f2c11")
+	s.Assert().NoError(err)
+	s.Assert().Empty(actual)
+}
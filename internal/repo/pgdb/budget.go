@@ -0,0 +1,164 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+)
+
+const (
+	budgetTable      = "budget"
+	budgetAlertTable = "budget_alert"
+)
+
+type BudgetRepo struct {
+	*postgres.Postgres
+}
+
+func NewBudgetRepo(pg *postgres.Postgres) *BudgetRepo {
+	return &BudgetRepo{pg}
+}
+
+func (r *BudgetRepo) Create(ctx context.Context, b dbmodel.Budget) (int, error) {
+	sql, args, _ := r.Builder.
+		Insert(budgetTable).
+		Columns("user_id", "service_name", "category_id", "amount", "thresholds", "organization_id").
+		Values(b.UserId, b.ServiceName, b.CategoryId, b.Amount, b.Thresholds, tenant.OrganizationOrDefault(ctx)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *BudgetRepo) FindById(ctx context.Context, id int) (dbmodel.Budget, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "user_id", "service_name", "category_id", "amount", "thresholds", "created_at", "organization_id").
+		From(budgetTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	var b dbmodel.Budget
+
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&b.Id,
+		&b.UserId,
+		&b.ServiceName,
+		&b.CategoryId,
+		&b.Amount,
+		&b.Thresholds,
+		&b.CreatedAt,
+		&b.OrganizationId,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.Budget{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.Budget{}, err
+	}
+	return b, nil
+}
+
+// FindAll returns budgets of user or budgets of all users if userId is empty
+func (r *BudgetRepo) FindAll(ctx context.Context, userId string) ([]dbmodel.Budget, error) {
+	b := r.Builder.
+		Select("id", "user_id", "service_name", "category_id", "amount", "thresholds", "created_at", "organization_id").
+		From(budgetTable)
+
+	if userId != "" {
+		b = b.Where("user_id = ?", userId)
+	}
+	sql, args, _ := b.Where(tenantFilter(ctx, "organization_id")).OrderBy("id").ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Budget
+
+	for rows.Next() {
+		var budget dbmodel.Budget
+
+		err = rows.Scan(
+			&budget.Id,
+			&budget.UserId,
+			&budget.ServiceName,
+			&budget.CategoryId,
+			&budget.Amount,
+			&budget.Thresholds,
+			&budget.CreatedAt,
+			&budget.OrganizationId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, budget)
+	}
+	return result, nil
+}
+
+func (r *BudgetRepo) Update(ctx context.Context, b dbmodel.Budget) error {
+	sql, args, _ := r.Builder.
+		Update(budgetTable).
+		Set("user_id", b.UserId).
+		Set("service_name", b.ServiceName).
+		Set("category_id", b.CategoryId).
+		Set("amount", b.Amount).
+		Set("thresholds", b.Thresholds).
+		Where("id = ?", b.Id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *BudgetRepo) Delete(ctx context.Context, id int) error {
+	sql, args, _ := r.Builder.
+		Delete(budgetTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// CreateAlert records crossed threshold. It returns false if alert for this month and threshold was already recorded
+func (r *BudgetRepo) CreateAlert(ctx context.Context, a dbmodel.BudgetAlert) (bool, error) {
+	sql, args, _ := r.Builder.
+		Insert(budgetAlertTable).
+		Columns("budget_id", "month", "threshold").
+		Values(a.BudgetId, a.Month, a.Threshold).
+		Suffix("ON CONFLICT (budget_id, month, threshold) DO NOTHING").
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
@@ -0,0 +1,83 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestBudgetRepo_FindAll() {
+	serviceName := "Yandex Plus"
+	budgets := []dbmodel.Budget{
+		{UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba", Amount: 1000, Thresholds: []int{80, 100}},
+		{UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba", ServiceName: &serviceName, Amount: 400, Thresholds: []int{100}},
+		{UserId: "4c2f3e0b-7f0a-4b43-9d0c-0a1f5b0f2c11", Amount: 2000, Thresholds: []int{50}},
+	}
+	for i, b := range budgets {
+		id, err := s.budget.Create(s.ctx, b)
+		if err != nil {
+			panic(err)
+		}
+		budgets[i].Id = id
+	}
+
+	actual, err := s.budget.FindAll(s.ctx, "60601fee-2bf1-4721-ae6f-7636e79a0cba")
+	s.Assert().NoError(err)
+	s.Assert().Len(actual, 2)
+	s.Assert().Equal(budgets[1].ServiceName, actual[1].ServiceName)
+	s.Assert().Equal(budgets[1].Thresholds, actual[1].Thresholds)
+
+	actual, err = s.budget.FindAll(s.ctx, "")
+	s.Assert().NoError(err)
+	s.Assert().Len(actual, 3)
+}
+
+func (s *pgdbTestSuite) TestBudgetRepo_Category() {
+	categoryId, err := s.category.Create(s.ctx, dbmodel.Category{Name: "Music"})
+	if err != nil {
+		panic(err)
+	}
+	id, err := s.budget.Create(s.ctx, dbmodel.Budget{
+		UserId:     "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		CategoryId: &categoryId,
+		Amount:     1000,
+		Thresholds: []int{100},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	actual, err := s.budget.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(&categoryId, actual.CategoryId)
+
+	// category with budget can't be deleted
+	s.Assert().ErrorIs(s.category.Delete(s.ctx, categoryId), pgerrs.ErrReferenced)
+
+	actual.CategoryId = nil
+	s.Assert().NoError(s.budget.Update(s.ctx, actual))
+
+	actual, err = s.budget.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Nil(actual.CategoryId)
+}
+
+func (s *pgdbTestSuite) TestBudgetRepo_CreateAlert() {
+	id, err := s.budget.Create(s.ctx, dbmodel.Budget{
+		UserId:     "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		Amount:     1000,
+		Thresholds: []int{80, 100},
+	})
+	if err != nil {
+		panic(err)
+	}
+	a := dbmodel.BudgetAlert{BudgetId: id, Month: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Threshold: 80}
+
+	created, err := s.budget.CreateAlert(s.ctx, a)
+	s.Assert().NoError(err)
+	s.Assert().True(created)
+
+	created, err = s.budget.CreateAlert(s.ctx, a)
+	s.Assert().NoError(err)
+	s.Assert().False(created)
+}
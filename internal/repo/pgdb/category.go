@@ -0,0 +1,124 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+)
+
+const (
+	categoryTable = "category"
+)
+
+type CategoryRepo struct {
+	*postgres.Postgres
+}
+
+func NewCategoryRepo(pg *postgres.Postgres) *CategoryRepo {
+	return &CategoryRepo{pg}
+}
+
+func (r *CategoryRepo) Create(ctx context.Context, c dbmodel.Category) (int, error) {
+	sql, args, _ := r.Builder.
+		Insert(categoryTable).
+		Columns("name", "parent_id", "organization_id").
+		Values(c.Name, c.ParentId, tenant.OrganizationOrDefault(ctx)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, constraintErr(err)
+	}
+	return id, nil
+}
+
+func (r *CategoryRepo) FindById(ctx context.Context, id int) (dbmodel.Category, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "name", "parent_id", "created_at").
+		From(categoryTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	var c dbmodel.Category
+
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&c.Id, &c.Name, &c.ParentId, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.Category{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.Category{}, err
+	}
+	return c, nil
+}
+
+func (r *CategoryRepo) FindAll(ctx context.Context) ([]dbmodel.Category, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "name", "parent_id", "created_at").
+		From(categoryTable).
+		Where(tenantFilter(ctx, "organization_id")).
+		OrderBy("id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Category
+
+	for rows.Next() {
+		var c dbmodel.Category
+
+		if err = rows.Scan(&c.Id, &c.Name, &c.ParentId, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (r *CategoryRepo) Update(ctx context.Context, c dbmodel.Category) error {
+	sql, args, _ := r.Builder.
+		Update(categoryTable).
+		Set("name", c.Name).
+		Set("parent_id", c.ParentId).
+		Where("id = ?", c.Id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return constraintErr(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes category. It fails with pgerrs.ErrReferenced while category has subcategories,
+// subscriptions of category become uncategorized
+func (r *CategoryRepo) Delete(ctx context.Context, id int) error {
+	sql, args, _ := r.Builder.
+		Delete(categoryTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return constraintErr(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestCategoryRepo_Create() {
+	parent, err := s.category.Create(s.ctx, dbmodel.Category{Name: "Entertainment"})
+	s.Assert().NoError(err)
+
+	child, err := s.category.Create(s.ctx, dbmodel.Category{Name: "Streaming", ParentId: &parent})
+	s.Assert().NoError(err)
+
+	actual, err := s.category.FindById(s.ctx, child)
+	s.Assert().NoError(err)
+	s.Assert().Equal("Streaming", actual.Name)
+	s.Assert().Equal(&parent, actual.ParentId)
+
+	// the same name is allowed in other parent only
+	_, err = s.category.Create(s.ctx, dbmodel.Category{Name: "streaming", ParentId: &parent})
+	s.Assert().Equal(pgerrs.ErrAlreadyExists, err)
+	_, err = s.category.Create(s.ctx, dbmodel.Category{Name: "Streaming"})
+	s.Assert().NoError(err)
+}
+
+func (s *pgdbTestSuite) TestCategoryRepo_Delete() {
+	parent, err := s.category.Create(s.ctx, dbmodel.Category{Name: "Entertainment"})
+	if err != nil {
+		panic(err)
+	}
+	child, err := s.category.Create(s.ctx, dbmodel.Category{Name: "Streaming", ParentId: &parent})
+	if err != nil {
+		panic(err)
+	}
+	subId, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:  s.serviceId("Yandex Plus"),
+		Price:      400,
+		UserId:     s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		CategoryId: &child,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	s.Assert().Equal(pgerrs.ErrReferenced, s.category.Delete(s.ctx, parent))
+	s.Assert().NoError(s.category.Delete(s.ctx, child))
+	s.Assert().Equal(pgerrs.ErrNotFound, s.category.Delete(s.ctx, child))
+
+	sub, err := s.sub.FindById(s.ctx, subId)
+	s.Assert().NoError(err)
+	s.Assert().Nil(sub.CategoryId)
+}
@@ -0,0 +1,236 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+const (
+	chargeTable = "charge"
+	chargeFrom  = "charge ch JOIN subscription s ON s.id = ch.subscription_id JOIN services sv ON sv.id = s.service_id"
+
+	// chargeBilledSQL matches charges which are or will be paid, failed and refunded ones cost nothing
+	chargeBilledSQL = "ch.status IN ('pending', 'paid')"
+)
+
+var chargeColumns = []string{
+	"ch.id",
+	"ch.subscription_id",
+	"sv.name",
+	"ch.user_id",
+	"ch.billing_date",
+	"ch.amount",
+	"ch.tax",
+	"ch.discount",
+	"ch.status",
+	"ch.created_at",
+	"ch.updated_at",
+	"ch.organization_id",
+}
+
+type ChargeRepo struct {
+	*postgres.Postgres
+}
+
+func NewChargeRepo(pg *postgres.Postgres) *ChargeRepo {
+	return &ChargeRepo{pg}
+}
+
+// Create records charges and returns number of created ones. Charges already recorded for their subscription
+// and billing date are skipped, so generation of ledger can be repeated
+func (r *ChargeRepo) Create(ctx context.Context, charges []dbmodel.Charge) (int, error) {
+	if len(charges) == 0 {
+		return 0, nil
+	}
+	b := r.Builder.
+		Insert(chargeTable).
+		Columns("subscription_id", "user_id", "billing_date", "amount", "tax", "discount", "organization_id")
+
+	for _, c := range charges {
+		b = b.Values(c.SubscriptionId, c.UserId, c.BillingDate, c.Amount, c.Tax, c.Discount, tenant.OrganizationOrDefault(ctx))
+	}
+	sql, args, _ := b.Suffix("ON CONFLICT (subscription_id, billing_date) DO NOTHING").ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (r *ChargeRepo) FindById(ctx context.Context, id int64) (dbmodel.Charge, error) {
+	sql, args, _ := r.Builder.
+		Select(chargeColumns...).
+		From(chargeFrom).
+		Where("ch.id = ?", id).
+		Where(tenantFilter(ctx, "ch.organization_id")).
+		ToSql()
+
+	c, err := scanCharge(r.Conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.Charge{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.Charge{}, err
+	}
+	return c, nil
+}
+
+// FindAll returns filtered charges ordered by billing date
+func (r *ChargeRepo) FindAll(ctx context.Context, f dbmodel.ChargeFilter) ([]dbmodel.Charge, error) {
+	b := r.Builder.
+		Select(chargeColumns...).
+		From(chargeFrom)
+
+	if f.UserId != "" {
+		b = b.Where("ch.user_id = ?", f.UserId)
+	}
+	if f.SubscriptionId != 0 {
+		b = b.Where("ch.subscription_id = ?", f.SubscriptionId)
+	}
+	if f.Status != "" {
+		b = b.Where("ch.status = ?", f.Status)
+	}
+	if f.Start != nil {
+		b = b.Where("ch.billing_date >= ?", *f.Start)
+	}
+	if f.End != nil {
+		b = b.Where("ch.billing_date <= ?", *f.End)
+	}
+	sql, args, _ := b.
+		Where(tenantFilter(ctx, "ch.organization_id")).
+		OrderBy("ch.billing_date", "ch.id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Charge
+
+	for rows.Next() {
+		c, err := scanCharge(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// UpdateStatus moves charge from status to another one. It returns pgerrs.ErrNotFound if there is no charge
+// with id in from status, e.g. when it was changed concurrently
+func (r *ChargeRepo) UpdateStatus(ctx context.Context, id int64, from, to string) error {
+	sql, args, _ := r.Builder.
+		Update(chargeTable).
+		Set("status", to).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where("id = ?", id).
+		Where("status = ?", from).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// FindLastBillingDates returns billing date of the last recorded charge of every subscription having charges
+func (r *ChargeRepo) FindLastBillingDates(ctx context.Context, subscriptionIds []int) (map[int]time.Time, error) {
+	sql, args, _ := r.Builder.
+		Select("subscription_id", "max(billing_date)").
+		From(chargeTable).
+		Where("subscription_id = ANY(?)", subscriptionIds).
+		Where(tenantFilter(ctx, "organization_id")).
+		GroupBy("subscription_id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[int]time.Time)
+
+	for rows.Next() {
+		var (
+			id   int
+			date time.Time
+		)
+		if err = rows.Scan(&id, &date); err != nil {
+			return nil, err
+		}
+		result[id] = date
+	}
+	return result, nil
+}
+
+// FindAmount sums pending and paid charges of filtered subscriptions billed within interval. With user filter
+// only part of charge paid by user is summed, the same way as subscription price
+func (r *ChargeRepo) FindAmount(ctx context.Context, f dbmodel.SubscriptionFilter, start, end time.Time) (dbmodel.Price, error) {
+	amounts := []string{"ch.amount", "ch.tax", "ch.discount"}
+	b := r.Builder.
+		Select().
+		From("charge ch JOIN subscription s ON s.id = ch.subscription_id")
+
+	if f.UserId != "" {
+		// share of user is scaled by the same ratio as price
+		for i, amount := range amounts {
+			amounts[i] = "CASE WHEN s.price = 0 THEN 0 ELSE c.amount * " + amount + " / s.price END"
+		}
+		b = b.Join("subscription_cost c ON c.subscription_id = s.id AND c.user_id = ?", f.UserId)
+		f.UserId = ""
+	}
+	for _, amount := range amounts {
+		b = b.Column("COALESCE(SUM(" + amount + "), 0)")
+	}
+
+	sql, args, _ := filterSubscriptions(b, f).
+		Where(chargeBilledSQL).
+		Where("ch.billing_date BETWEEN ? AND ?", start, end).
+		Where(tenantFilter(ctx, "ch.organization_id")).
+		ToSql()
+
+	var price dbmodel.Price
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&price.Gross, &price.Tax, &price.Discount); err != nil {
+		return dbmodel.Price{}, err
+	}
+	price.Net = price.Gross - price.Tax
+	return price, nil
+}
+
+func scanCharge(row pgx.Row) (dbmodel.Charge, error) {
+	var c dbmodel.Charge
+
+	err := row.Scan(
+		&c.Id,
+		&c.SubscriptionId,
+		&c.ServiceName,
+		&c.UserId,
+		&c.BillingDate,
+		&c.Amount,
+		&c.Tax,
+		&c.Discount,
+		&c.Status,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.OrganizationId,
+	)
+	return c, err
+}
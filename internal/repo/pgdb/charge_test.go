@@ -0,0 +1,142 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestChargeRepo_Create() {
+	userId := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    userId,
+		StartDate: time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+	charges := []dbmodel.Charge{
+		{SubscriptionId: id, UserId: userId, BillingDate: time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC), Amount: 400},
+		{SubscriptionId: id, UserId: userId, BillingDate: time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), Amount: 400},
+	}
+
+	created, err := s.charge.Create(s.ctx, charges)
+	s.Assert().NoError(err)
+	s.Assert().Equal(2, created)
+
+	charges = append(charges, dbmodel.Charge{SubscriptionId: id, UserId: userId, BillingDate: time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC), Amount: 400})
+	created, err = s.charge.Create(s.ctx, charges)
+	s.Assert().NoError(err)
+	s.Assert().Equal(1, created)
+
+	created, err = s.charge.Create(s.ctx, nil)
+	s.Assert().NoError(err)
+	s.Assert().Equal(0, created)
+
+	actual, err := s.charge.FindAll(s.ctx, dbmodel.ChargeFilter{SubscriptionId: id})
+	s.Assert().NoError(err)
+	s.Assert().Len(actual, 3)
+	for i, c := range actual {
+		s.Assert().Equal(charges[i].BillingDate, c.BillingDate)
+		s.Assert().Equal("Yandex Plus", c.ServiceName)
+		s.Assert().Equal(dbmodel.ChargePending, c.Status)
+		s.Assert().Equal(1, c.OrganizationId)
+	}
+
+	last, err := s.charge.FindLastBillingDates(s.ctx, []int{id, id + 1})
+	s.Assert().NoError(err)
+	// subscription without charges is not returned
+	s.Assert().Equal(map[int]time.Time{id: time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC)}, last)
+}
+
+func (s *pgdbTestSuite) TestChargeRepo_UpdateStatus() {
+	userId := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    userId,
+		StartDate: time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+	_, err = s.charge.Create(s.ctx, []dbmodel.Charge{
+		{SubscriptionId: id, UserId: userId, BillingDate: time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC), Amount: 400},
+	})
+	if err != nil {
+		panic(err)
+	}
+	charges, err := s.charge.FindAll(s.ctx, dbmodel.ChargeFilter{})
+	if err != nil {
+		panic(err)
+	}
+	chargeId := charges[0].Id
+
+	s.Assert().NoError(s.charge.UpdateStatus(s.ctx, chargeId, dbmodel.ChargePending, dbmodel.ChargePaid))
+	s.Assert().ErrorIs(s.charge.UpdateStatus(s.ctx, chargeId, dbmodel.ChargePending, dbmodel.ChargeFailed), pgerrs.ErrNotFound)
+	s.Assert().ErrorIs(s.charge.UpdateStatus(s.ctx, chargeId+1, dbmodel.ChargePending, dbmodel.ChargePaid), pgerrs.ErrNotFound)
+
+	actual, err := s.charge.FindById(s.ctx, chargeId)
+	s.Assert().NoError(err)
+	s.Assert().Equal(dbmodel.ChargePaid, actual.Status)
+
+	_, err = s.charge.FindById(s.ctx, chargeId+1)
+	s.Assert().ErrorIs(err, pgerrs.ErrNotFound)
+
+	paid, err := s.charge.FindAll(s.ctx, dbmodel.ChargeFilter{UserId: userId, Status: dbmodel.ChargePaid})
+	s.Assert().NoError(err)
+	s.Assert().Len(paid, 1)
+
+	pending, err := s.charge.FindAll(s.ctx, dbmodel.ChargeFilter{Status: dbmodel.ChargePending})
+	s.Assert().NoError(err)
+	s.Assert().Empty(pending)
+}
+
+func (s *pgdbTestSuite) TestChargeRepo_FindAmount() {
+	userId := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    userId,
+		StartDate: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+	_, err = s.charge.Create(s.ctx, []dbmodel.Charge{
+		{SubscriptionId: id, UserId: userId, BillingDate: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), Amount: 200},
+		{SubscriptionId: id, UserId: userId, BillingDate: time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC), Amount: 400},
+		{SubscriptionId: id, UserId: userId, BillingDate: time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), Amount: 400},
+	})
+	if err != nil {
+		panic(err)
+	}
+	charges, err := s.charge.FindAll(s.ctx, dbmodel.ChargeFilter{})
+	if err != nil {
+		panic(err)
+	}
+	// refunded charge costs nothing
+	if err = s.charge.UpdateStatus(s.ctx, charges[1].Id, dbmodel.ChargePending, dbmodel.ChargePaid); err != nil {
+		panic(err)
+	}
+	if err = s.charge.UpdateStatus(s.ctx, charges[1].Id, dbmodel.ChargePaid, dbmodel.ChargeRefunded); err != nil {
+		panic(err)
+	}
+
+	amount, err := s.charge.FindAmount(s.ctx, dbmodel.SubscriptionFilter{},
+		time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal(600, amount.Gross)
+
+	amount, err = s.charge.FindAmount(s.ctx, dbmodel.SubscriptionFilter{UserId: userId},
+		time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal(400, amount.Gross)
+
+	amount, err = s.charge.FindAmount(s.ctx, dbmodel.SubscriptionFilter{ServiceId: s.serviceId("Okko")},
+		time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal(0, amount.Gross)
+}
@@ -0,0 +1,28 @@
+package pgdb
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"subscription_service/internal/repo/pgerrs"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// constraintErr converts constraint violations to repository errors, other errors are returned as is
+func constraintErr(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case uniqueViolation:
+		return pgerrs.ErrAlreadyExists
+	case foreignKeyViolation:
+		return pgerrs.ErrReferenced
+	default:
+		return err
+	}
+}
@@ -13,10 +13,25 @@ import (
 
 type pgdbTestSuite struct {
 	suite.Suite
-	ctx context.Context
-	pg  *postgres.Postgres
-	m   *migrate.Migrate
-	sub *SubscriptionRepo
+	ctx         context.Context
+	pg          *postgres.Postgres
+	m           *migrate.Migrate
+	org         *OrganizationRepo
+	user        *UserRepo
+	sub         *SubscriptionRepo
+	share       *ShareRepo
+	service     *ServiceRepo
+	category    *CategoryRepo
+	outbox      *OutboxRepo
+	webhook     *WebhookRepo
+	delivery    *WebhookDeliveryRepo
+	reminder    *ReminderRepo
+	budget      *BudgetRepo
+	priceChange *PriceChangeRepo
+	pause       *PauseRepo
+	charge      *ChargeRepo
+	anomaly     *AnomalyRepo
+	analytics   *AnalyticsRepo
 }
 
 func (s *pgdbTestSuite) SetupTest() {
@@ -38,7 +53,22 @@ func (s *pgdbTestSuite) SetupTest() {
 	}
 	s.pg = pg
 
+	s.org = NewOrganizationRepo(pg)
+	s.user = NewUserRepo(pg)
 	s.sub = NewSubscriptionRepo(pg)
+	s.share = NewShareRepo(pg)
+	s.service = NewServiceRepo(pg)
+	s.category = NewCategoryRepo(pg)
+	s.outbox = NewOutboxRepo(pg)
+	s.webhook = NewWebhookRepo(pg)
+	s.delivery = NewWebhookDeliveryRepo(pg)
+	s.reminder = NewReminderRepo(pg)
+	s.budget = NewBudgetRepo(pg)
+	s.priceChange = NewPriceChangeRepo(pg)
+	s.pause = NewPauseRepo(pg)
+	s.charge = NewChargeRepo(pg)
+	s.anomaly = NewAnomalyRepo(pg)
+	s.analytics = NewAnalyticsRepo(pg)
 }
 
 func (s *pgdbTestSuite) TearDownTest() {
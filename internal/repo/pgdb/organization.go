@@ -0,0 +1,83 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+)
+
+const (
+	organizationTable = "organization"
+)
+
+type OrganizationRepo struct {
+	*postgres.Postgres
+}
+
+func NewOrganizationRepo(pg *postgres.Postgres) *OrganizationRepo {
+	return &OrganizationRepo{pg}
+}
+
+func (r *OrganizationRepo) Create(ctx context.Context, o dbmodel.Organization) (int, error) {
+	sql, args, _ := r.Builder.
+		Insert(organizationTable).
+		Columns("name").
+		Values(o.Name).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, constraintErr(err)
+	}
+	return id, nil
+}
+
+func (r *OrganizationRepo) FindById(ctx context.Context, id int) (dbmodel.Organization, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "name", "created_at").
+		From(organizationTable).
+		Where("id = ?", id).
+		ToSql()
+
+	var o dbmodel.Organization
+
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&o.Id, &o.Name, &o.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.Organization{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.Organization{}, err
+	}
+	return o, nil
+}
+
+func (r *OrganizationRepo) FindAll(ctx context.Context) ([]dbmodel.Organization, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "name", "created_at").
+		From(organizationTable).
+		OrderBy("id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Organization
+
+	for rows.Next() {
+		var o dbmodel.Organization
+
+		if err = rows.Scan(&o.Id, &o.Name, &o.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestOrganizationRepo_Create() {
+	id, err := s.org.Create(s.ctx, dbmodel.Organization{Name: "Acme"})
+	s.Assert().NoError(err)
+
+	actual, err := s.org.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal("Acme", actual.Name)
+
+	_, err = s.org.Create(s.ctx, dbmodel.Organization{Name: "DEFAULT"})
+	s.Assert().Equal(pgerrs.ErrAlreadyExists, err)
+
+	all, err := s.org.FindAll(s.ctx)
+	s.Assert().NoError(err)
+	s.Assert().Len(all, 2)
+	s.Assert().Equal(tenant.DefaultOrganization, all[0].Id)
+}
+
+func (s *pgdbTestSuite) TestTenantIsolation() {
+	orgId, err := s.org.Create(s.ctx, dbmodel.Organization{Name: "Acme"})
+	s.Require().NoError(err)
+
+	acme := tenant.WithOrganization(s.ctx, orgId)
+	def := tenant.WithOrganization(s.ctx, tenant.DefaultOrganization)
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	s.Require().NoError(s.user.Ensure(acme, userId))
+	s.Assert().Equal(pgerrs.ErrNotFound, s.user.Ensure(def, userId))
+
+	serviceId, err := s.service.Create(acme, dbmodel.Service{Name: "Yandex Plus"})
+	s.Require().NoError(err)
+	_, err = s.service.Create(def, dbmodel.Service{Name: "Yandex Plus"})
+	s.Assert().NoError(err, "names are unique within organization")
+
+	subId, err := s.sub.Create(acme, dbmodel.Subscription{
+		ServiceId: serviceId,
+		Price:     400,
+		UserId:    userId,
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+
+	sub, err := s.sub.FindById(acme, subId)
+	s.Assert().NoError(err)
+	s.Assert().Equal(orgId, sub.OrganizationId)
+
+	_, err = s.sub.FindById(def, subId)
+	s.Assert().Equal(pgerrs.ErrNotFound, err)
+	s.Assert().Equal(pgerrs.ErrNotFound, s.sub.Delete(def, subId))
+
+	all, err := s.sub.FindAll(def, dbmodel.SubscriptionFilter{})
+	s.Assert().NoError(err)
+	s.Assert().Empty(all)
+
+	// background jobs work without tenant and see all organizations
+	all, err = s.sub.FindAll(s.ctx, dbmodel.SubscriptionFilter{})
+	s.Assert().NoError(err)
+	s.Assert().Len(all, 1)
+}
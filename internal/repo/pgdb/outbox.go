@@ -0,0 +1,123 @@
+package pgdb
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"sort"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+const (
+	outboxTable = "outbox"
+)
+
+type OutboxRepo struct {
+	*postgres.Postgres
+}
+
+func NewOutboxRepo(pg *postgres.Postgres) *OutboxRepo {
+	return &OutboxRepo{pg}
+}
+
+func (r *OutboxRepo) Create(ctx context.Context, e dbmodel.OutboxEvent) error {
+	sql, args, _ := r.Builder.
+		Insert(outboxTable).
+		Columns("event_type", "aggregate_id", "payload", "organization_id").
+		Values(e.EventType, e.AggregateId, e.Payload, tenant.OrganizationOrDefault(ctx)).
+		ToSql()
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Claim takes unpublished events which are due to be sent and postpones their next attempt by lease, so
+// concurrent relays skip them while they are published. Claimed event not marked within lease, e.g. because relay
+// crashed, is taken again
+func (r *OutboxRepo) Claim(ctx context.Context, limit int, lease time.Duration) ([]dbmodel.OutboxEvent, error) {
+	due := squirrel.
+		Select("id").
+		From(outboxTable).
+		Where("published_at IS NULL AND next_attempt_at <= now()").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, _ := r.Builder.
+		Update(outboxTable).
+		Set("next_attempt_at", squirrel.Expr("now() + make_interval(secs => ?)", lease.Seconds())).
+		Where(squirrel.Expr("id IN (?)", due)).
+		Suffix("RETURNING id, event_type, aggregate_id, payload, created_at, attempts, organization_id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.OutboxEvent
+
+	for rows.Next() {
+		var e dbmodel.OutboxEvent
+
+		err = rows.Scan(
+			&e.Id,
+			&e.EventType,
+			&e.AggregateId,
+			&e.Payload,
+			&e.CreatedAt,
+			&e.Attempts,
+			&e.OrganizationId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result, nil
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, id int64) error {
+	sql, args, _ := r.Builder.
+		Update(outboxTable).
+		Set("published_at", time.Now()).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", nil).
+		Where("id = ?", id).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id int64, reason string, nextAttempt time.Time) error {
+	sql, args, _ := r.Builder.
+		Update(outboxTable).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", reason).
+		Set("next_attempt_at", nextAttempt).
+		Where("id = ?", id).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestOutboxRepo_Claim() {
+	events := []dbmodel.OutboxEvent{
+		{EventType: dbmodel.EventSubscriptionCreated, AggregateId: 1, Payload: []byte(`{"id": 1}`)},
+		{EventType: dbmodel.EventSubscriptionUpdated, AggregateId: 1, Payload: []byte(`{"id": 1}`)},
+		{EventType: dbmodel.EventSubscriptionDeleted, AggregateId: 1, Payload: []byte(`{"id": 1}`)},
+	}
+	for _, e := range events {
+		if err := s.outbox.Create(s.ctx, e); err != nil {
+			panic(err)
+		}
+	}
+
+	pending, err := s.outbox.Claim(s.ctx, 10, 0)
+	s.Assert().NoError(err)
+	s.Assert().Len(pending, 3)
+
+	// claimed events are skipped until lease ends
+	claimed, err := s.outbox.Claim(s.ctx, 10, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Len(claimed, 3)
+	claimed, err = s.outbox.Claim(s.ctx, 10, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Empty(claimed)
+
+	s.Assert().NoError(s.outbox.MarkPublished(s.ctx, pending[0].Id))
+	s.Assert().NoError(s.outbox.MarkFailed(s.ctx, pending[1].Id, "broker is down", time.Now().Add(time.Hour)))
+	_, err = s.pg.Pool.Exec(s.ctx, "UPDATE outbox SET next_attempt_at = now() WHERE id = $1", pending[2].Id)
+	s.Require().NoError(err)
+
+	pending, err = s.outbox.Claim(s.ctx, 10, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Len(pending, 1)
+	s.Assert().Equal(dbmodel.EventSubscriptionDeleted, pending[0].EventType)
+	s.Assert().JSONEq(`{"id": 1}`, string(pending[0].Payload))
+
+	var (
+		attempts  int
+		lastError *string
+	)
+	err = s.pg.Pool.QueryRow(s.ctx, "SELECT attempts, last_error FROM outbox WHERE event_type = $1", dbmodel.EventSubscriptionUpdated).
+		Scan(&attempts, &lastError)
+	s.Assert().NoError(err)
+	s.Assert().Equal(1, attempts)
+	s.Assert().Equal(ptr("broker is down"), lastError)
+
+	s.Assert().Equal(pgerrs.ErrNotFound, s.outbox.MarkPublished(s.ctx, 0))
+}
+
+func (s *pgdbTestSuite) TestOutboxRepo_WithinTransaction() {
+	sub := dbmodel.Subscription{
+		ServiceId:     s.serviceId("Yandex"),
+		Price:         1000,
+		UserId:        s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	}
+
+	// outbox insert fails, so subscription insert must be rolled back as well
+	err := s.pg.WithinTransaction(s.ctx, func(ctx context.Context) error {
+		if _, err := s.sub.Create(ctx, sub); err != nil {
+			return err
+		}
+		return s.outbox.Create(ctx, dbmodel.OutboxEvent{EventType: dbmodel.EventSubscriptionCreated, Payload: []byte(`not a json`)})
+	})
+	s.Assert().Error(err)
+
+	all, err := s.sub.FindAll(s.ctx, dbmodel.SubscriptionFilter{})
+	s.Assert().NoError(err)
+	s.Assert().Empty(all)
+
+	errCommit := errors.New("commit me not")
+	err = s.pg.WithinTransaction(s.ctx, func(ctx context.Context) error {
+		if _, err := s.sub.Create(ctx, sub); err != nil {
+			return err
+		}
+		return errCommit
+	})
+	s.Assert().Equal(errCommit, err)
+
+	err = s.pg.WithinTransaction(s.ctx, func(ctx context.Context) error {
+		id, err := s.sub.Create(ctx, sub)
+		if err != nil {
+			return err
+		}
+		return s.outbox.Create(ctx, dbmodel.OutboxEvent{EventType: dbmodel.EventSubscriptionCreated, AggregateId: id, Payload: []byte(`{}`)})
+	})
+	s.Assert().NoError(err)
+
+	all, err = s.sub.FindAll(s.ctx, dbmodel.SubscriptionFilter{})
+	s.Assert().NoError(err)
+	s.Assert().Len(all, 1)
+
+	pending, err := s.outbox.Claim(s.ctx, 10, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Len(pending, 1)
+	s.Assert().Equal(all[0].Id, pending[0].AggregateId)
+}
@@ -0,0 +1,95 @@
+package pgdb
+
+import (
+	"context"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"time"
+)
+
+const (
+	pauseTable = "subscription_pause"
+)
+
+type PauseRepo struct {
+	*postgres.Postgres
+}
+
+func NewPauseRepo(pg *postgres.Postgres) *PauseRepo {
+	return &PauseRepo{pg}
+}
+
+func (r *PauseRepo) Create(ctx context.Context, p dbmodel.Pause) (int, error) {
+	sql, args, _ := r.Builder.
+		Insert(pauseTable).
+		Columns("subscription_id", "start_date", "end_date").
+		Values(p.SubscriptionId, p.StartDate, p.EndDate).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Update sets the last paused month of pause
+func (r *PauseRepo) Update(ctx context.Context, p dbmodel.Pause) error {
+	sql, args, _ := r.Builder.
+		Update(pauseTable).
+		Set("end_date", p.EndDate).
+		Where("id = ? AND subscription_id = ?", p.Id, p.SubscriptionId).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PauseRepo) Delete(ctx context.Context, subscriptionId, id int) error {
+	sql, args, _ := r.Builder.
+		Delete(pauseTable).
+		Where("id = ? AND subscription_id = ?", id, subscriptionId).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// pauseColumns selects pauses of subscription aliased as s as arrays of ids, start and end dates ordered by start date
+var pauseColumns = []string{
+	"ARRAY(SELECT p.id FROM subscription_pause p WHERE p.subscription_id = s.id ORDER BY p.start_date)",
+	"ARRAY(SELECT p.start_date FROM subscription_pause p WHERE p.subscription_id = s.id ORDER BY p.start_date)",
+	"ARRAY(SELECT p.end_date FROM subscription_pause p WHERE p.subscription_id = s.id ORDER BY p.start_date)",
+}
+
+// newPauses zips arrays selected by pauseColumns
+func newPauses(subscriptionId int, ids []int, starts []time.Time, ends []*time.Time) []dbmodel.Pause {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]dbmodel.Pause, 0, len(ids))
+	for i, id := range ids {
+		result = append(result, dbmodel.Pause{
+			Id:             id,
+			SubscriptionId: subscriptionId,
+			StartDate:      starts[i],
+			EndDate:        ends[i],
+		})
+	}
+	return result
+}
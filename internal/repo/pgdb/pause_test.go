@@ -0,0 +1,52 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestPauseRepo() {
+	serviceId := s.serviceId("Yandex Plus")
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     serviceId,
+		Price:         400,
+		UserId:        s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	open, err := s.pause.Create(s.ctx, dbmodel.Pause{SubscriptionId: id, StartDate: july})
+	s.Assert().NoError(err)
+	closed, err := s.pause.Create(s.ctx, dbmodel.Pause{SubscriptionId: id, StartDate: march, EndDate: &march})
+	s.Assert().NoError(err)
+
+	sub, err := s.sub.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal([]dbmodel.Pause{
+		{Id: closed, SubscriptionId: id, StartDate: march, EndDate: &march},
+		{Id: open, SubscriptionId: id, StartDate: july},
+	}, sub.Pauses)
+
+	// paused months are not charged
+	price, err := s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{ServiceId: serviceId}, august, august)
+	s.Assert().NoError(err)
+	s.Assert().Equal(0, price.Gross)
+
+	s.Assert().NoError(s.pause.Update(s.ctx, dbmodel.Pause{Id: open, SubscriptionId: id, EndDate: &july}))
+
+	price, err = s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{ServiceId: serviceId}, august, august)
+	s.Assert().NoError(err)
+	s.Assert().Equal(400, price.Gross)
+
+	s.Assert().NoError(s.pause.Delete(s.ctx, id, closed))
+	s.Assert().ErrorIs(s.pause.Delete(s.ctx, id, closed), pgerrs.ErrNotFound)
+	s.Assert().ErrorIs(s.pause.Update(s.ctx, dbmodel.Pause{Id: closed, SubscriptionId: id}), pgerrs.ErrNotFound)
+}
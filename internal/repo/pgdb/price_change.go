@@ -0,0 +1,126 @@
+package pgdb
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"time"
+)
+
+const (
+	priceChangeTable = "price_change"
+)
+
+var priceChangeColumns = []string{"id", "subscription_id", "price", "start_date", "created_at", "applied_at"}
+
+type PriceChangeRepo struct {
+	*postgres.Postgres
+}
+
+func NewPriceChangeRepo(pg *postgres.Postgres) *PriceChangeRepo {
+	return &PriceChangeRepo{pg}
+}
+
+// Create schedules price change. Change already scheduled for the same month is replaced and applied again
+func (r *PriceChangeRepo) Create(ctx context.Context, c dbmodel.PriceChange) (int, error) {
+	sql, args, _ := r.Builder.
+		Insert(priceChangeTable).
+		Columns("subscription_id", "price", "start_date").
+		Values(c.SubscriptionId, c.Price, c.StartDate).
+		Suffix("ON CONFLICT (subscription_id, start_date) DO UPDATE SET price = excluded.price, applied_at = NULL RETURNING id").
+		ToSql()
+
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// FindBySubscriptions returns price changes of subscriptions ordered by start date
+func (r *PriceChangeRepo) FindBySubscriptions(ctx context.Context, subscriptionIds []int) ([]dbmodel.PriceChange, error) {
+	sql, args, _ := r.Builder.
+		Select(priceChangeColumns...).
+		From(priceChangeTable).
+		Where("subscription_id = ANY(?)", subscriptionIds).
+		OrderBy("start_date", "id").
+		ToSql()
+
+	return r.findMany(ctx, sql, args...)
+}
+
+// FindDue returns the latest not applied price change of every subscription starting not after date.
+// Background job reads changes of all organizations
+func (r *PriceChangeRepo) FindDue(ctx context.Context, date time.Time) ([]dbmodel.PriceChange, error) {
+	sql, args, _ := r.Builder.
+		Select(priceChangeColumns...).
+		Options("DISTINCT ON (subscription_id)").
+		From(priceChangeTable).
+		Where("applied_at IS NULL AND start_date <= ?", date).
+		Where(tenantSubscriptionFilter(ctx, "subscription_id")).
+		OrderBy("subscription_id", "start_date DESC").
+		ToSql()
+
+	return r.findMany(ctx, sql, args...)
+}
+
+// MarkApplied marks not applied price changes of subscription starting not after date as applied
+func (r *PriceChangeRepo) MarkApplied(ctx context.Context, subscriptionId int, date time.Time) error {
+	sql, args, _ := r.Builder.
+		Update(priceChangeTable).
+		Set("applied_at", squirrel.Expr("now()")).
+		Where("subscription_id = ? AND applied_at IS NULL AND start_date <= ?", subscriptionId, date).
+		ToSql()
+
+	_, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	return err
+}
+
+func (r *PriceChangeRepo) findMany(ctx context.Context, sql string, args ...any) ([]dbmodel.PriceChange, error) {
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.PriceChange
+
+	for rows.Next() {
+		var c dbmodel.PriceChange
+
+		err = rows.Scan(
+			&c.Id,
+			&c.SubscriptionId,
+			&c.Price,
+			&c.StartDate,
+			&c.CreatedAt,
+			&c.AppliedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// Delete deletes price change of subscription of organization of ctx
+func (r *PriceChangeRepo) Delete(ctx context.Context, subscriptionId, id int) error {
+	sql, args, _ := r.Builder.
+		Delete(priceChangeTable).
+		Where("id = ? AND subscription_id = ?", id, subscriptionId).
+		Where(tenantSubscriptionFilter(ctx, "subscription_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestPriceChangeRepo_Create() {
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	october := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	september := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err = s.priceChange.Create(s.ctx, dbmodel.PriceChange{SubscriptionId: id, Price: 600, StartDate: october})
+	s.Assert().NoError(err)
+	first, err := s.priceChange.Create(s.ctx, dbmodel.PriceChange{SubscriptionId: id, Price: 450, StartDate: september})
+	s.Assert().NoError(err)
+	// the same month replaces price
+	second, err := s.priceChange.Create(s.ctx, dbmodel.PriceChange{SubscriptionId: id, Price: 500, StartDate: september})
+	s.Assert().NoError(err)
+	s.Assert().Equal(first, second)
+
+	actual, err := s.priceChange.FindBySubscriptions(s.ctx, []int{id})
+	s.Assert().NoError(err)
+	s.Assert().Len(actual, 2)
+	s.Assert().Equal(500, actual[0].Price)
+	s.Assert().Equal(september, actual[0].StartDate)
+	s.Assert().Equal(600, actual[1].Price)
+
+	s.Assert().NoError(s.priceChange.Delete(s.ctx, id, first))
+	s.Assert().ErrorIs(s.priceChange.Delete(s.ctx, id, first), pgerrs.ErrNotFound)
+}
+
+func (s *pgdbTestSuite) TestPriceChangeRepo_DeleteOtherOrganization() {
+	orgId, err := s.org.Create(s.ctx, dbmodel.Organization{Name: "Acme"})
+	s.Require().NoError(err)
+
+	acme := tenant.WithOrganization(s.ctx, orgId)
+	def := tenant.WithOrganization(s.ctx, tenant.DefaultOrganization)
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	s.Require().NoError(s.user.Ensure(acme, userId))
+	serviceId, err := s.service.Create(acme, dbmodel.Service{Name: "Yandex Plus"})
+	s.Require().NoError(err)
+	subId, err := s.sub.Create(acme, dbmodel.Subscription{
+		ServiceId: serviceId,
+		Price:     400,
+		UserId:    userId,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+
+	id, err := s.priceChange.Create(acme, dbmodel.PriceChange{
+		SubscriptionId: subId,
+		Price:          500,
+		StartDate:      time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+
+	// change of subscription of another organization is not deleted
+	s.Assert().ErrorIs(s.priceChange.Delete(def, subId, id), pgerrs.ErrNotFound)
+
+	changes, err := s.priceChange.FindBySubscriptions(acme, []int{subId})
+	s.Assert().NoError(err)
+	s.Assert().Len(changes, 1)
+
+	s.Assert().NoError(s.priceChange.Delete(acme, subId, id))
+}
+
+func (s *pgdbTestSuite) TestPriceChangeRepo_FindDue() {
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	september := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	october := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	date := time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)
+
+	for price, month := range map[int]time.Time{450: august, 500: september, 600: october} {
+		_, err = s.priceChange.Create(s.ctx, dbmodel.PriceChange{SubscriptionId: id, Price: price, StartDate: month})
+		s.Require().NoError(err)
+	}
+
+	// the latest started change only
+	due, err := s.priceChange.FindDue(s.ctx, date)
+	s.Assert().NoError(err)
+	s.Require().Len(due, 1)
+	s.Assert().Equal(500, due[0].Price)
+	s.Assert().Equal(september, due[0].StartDate)
+
+	s.Assert().NoError(s.priceChange.MarkApplied(s.ctx, id, date))
+	due, err = s.priceChange.FindDue(s.ctx, date)
+	s.Assert().NoError(err)
+	s.Assert().Empty(due)
+
+	changes, err := s.priceChange.FindBySubscriptions(s.ctx, []int{id})
+	s.Assert().NoError(err)
+	s.Require().Len(changes, 3)
+	s.Assert().NotNil(changes[0].AppliedAt)
+	s.Assert().NotNil(changes[1].AppliedAt)
+	s.Assert().Nil(changes[2].AppliedAt)
+
+	// replaced price is applied again
+	_, err = s.priceChange.Create(s.ctx, dbmodel.PriceChange{SubscriptionId: id, Price: 550, StartDate: september})
+	s.Require().NoError(err)
+	due, err = s.priceChange.FindDue(s.ctx, date)
+	s.Assert().NoError(err)
+	s.Require().Len(due, 1)
+	s.Assert().Equal(550, due[0].Price)
+}
@@ -0,0 +1,148 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"time"
+)
+
+const (
+	reminderTable         = "reminder"
+	reminderSettingsTable = "reminder_settings"
+)
+
+type ReminderRepo struct {
+	*postgres.Postgres
+}
+
+func NewReminderRepo(pg *postgres.Postgres) *ReminderRepo {
+	return &ReminderRepo{pg}
+}
+
+func (r *ReminderRepo) FindSettings(ctx context.Context, userId string) (dbmodel.ReminderSettings, error) {
+	sql, args, _ := r.Builder.
+		Select("user_id", "lead_days", "email").
+		From(reminderSettingsTable).
+		Where("user_id = ?", userId).
+		Where(tenantUserFilter(ctx, "user_id")).
+		ToSql()
+
+	var s dbmodel.ReminderSettings
+
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&s.UserId,
+		&s.LeadDays,
+		&s.Email,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.ReminderSettings{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.ReminderSettings{}, err
+	}
+	return s, nil
+}
+
+func (r *ReminderRepo) FindAllSettings(ctx context.Context) ([]dbmodel.ReminderSettings, error) {
+	sql, args, _ := r.Builder.
+		Select("user_id", "lead_days", "email").
+		From(reminderSettingsTable).
+		Where(tenantUserFilter(ctx, "user_id")).
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.ReminderSettings
+
+	for rows.Next() {
+		var s dbmodel.ReminderSettings
+
+		if err = rows.Scan(&s.UserId, &s.LeadDays, &s.Email); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (r *ReminderRepo) UpsertSettings(ctx context.Context, s dbmodel.ReminderSettings) error {
+	sql, args, _ := r.Builder.
+		Insert(reminderSettingsTable).
+		Columns("user_id", "lead_days", "email").
+		Values(s.UserId, s.LeadDays, s.Email).
+		SuffixExpr(squirrel.Expr(
+			"ON CONFLICT (user_id) DO UPDATE SET lead_days = excluded.lead_days, email = excluded.email WHERE ?",
+			tenantUserFilter(ctx, "reminder_settings.user_id"),
+		)).
+		ToSql()
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Create records reminder to be sent. It returns false if reminder of this kind for this date was already recorded
+func (r *ReminderRepo) Create(ctx context.Context, rem dbmodel.Reminder) (bool, error) {
+	sql, args, _ := r.Builder.
+		Insert(reminderTable).
+		Columns("subscription_id", "kind", "billing_date").
+		Values(rem.SubscriptionId, rem.Kind, rem.BillingDate).
+		Suffix("ON CONFLICT (subscription_id, kind, billing_date) DO NOTHING").
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// Claim takes recorded reminder which is not sent and not claimed by another run, and leases it for sending.
+// Reminder not marked sent within lease, e.g. because notification failed, is taken again
+func (r *ReminderRepo) Claim(ctx context.Context, rem dbmodel.Reminder, lease time.Duration) (int64, error) {
+	sql, args, _ := r.Builder.
+		Update(reminderTable).
+		Set("lease_until", squirrel.Expr("now() + make_interval(secs => ?)", lease.Seconds())).
+		Where("subscription_id = ? AND kind = ? AND billing_date = ?", rem.SubscriptionId, rem.Kind, rem.BillingDate).
+		Where("sent_at IS NULL AND (lease_until IS NULL OR lease_until <= now())").
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id int64
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, pgerrs.ErrNotFound
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *ReminderRepo) MarkSent(ctx context.Context, id int64) error {
+	sql, args, _ := r.Builder.
+		Update(reminderTable).
+		Set("sent_at", squirrel.Expr("now()")).
+		Set("lease_until", nil).
+		Where("id = ?", id).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
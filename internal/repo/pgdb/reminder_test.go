@@ -0,0 +1,94 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestReminderRepo_Create() {
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+	r := dbmodel.Reminder{SubscriptionId: id, Kind: dbmodel.ReminderRenewal, BillingDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)}
+
+	created, err := s.reminder.Create(s.ctx, r)
+	s.Assert().NoError(err)
+	s.Assert().True(created)
+
+	created, err = s.reminder.Create(s.ctx, r)
+	s.Assert().NoError(err)
+	s.Assert().False(created)
+
+	r.Kind = dbmodel.ReminderTrialEnd
+	created, err = s.reminder.Create(s.ctx, r)
+	s.Assert().NoError(err)
+	s.Assert().True(created)
+
+	r.BillingDate = time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	created, err = s.reminder.Create(s.ctx, r)
+	s.Assert().NoError(err)
+	s.Assert().True(created)
+}
+
+func (s *pgdbTestSuite) TestReminderRepo_Claim() {
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+	r := dbmodel.Reminder{SubscriptionId: id, Kind: dbmodel.ReminderRenewal, BillingDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)}
+
+	// not recorded
+	_, err = s.reminder.Claim(s.ctx, r, time.Minute)
+	s.Assert().ErrorIs(err, pgerrs.ErrNotFound)
+
+	_, err = s.reminder.Create(s.ctx, r)
+	s.Require().NoError(err)
+
+	// lease of failed run ends, reminder is taken again
+	first, err := s.reminder.Claim(s.ctx, r, 0)
+	s.Assert().NoError(err)
+	second, err := s.reminder.Claim(s.ctx, r, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Equal(first, second)
+
+	// leased by another run
+	_, err = s.reminder.Claim(s.ctx, r, time.Minute)
+	s.Assert().ErrorIs(err, pgerrs.ErrNotFound)
+
+	// sent reminder is not taken
+	s.Assert().NoError(s.reminder.MarkSent(s.ctx, second))
+	_, err = s.reminder.Claim(s.ctx, r, 0)
+	s.Assert().ErrorIs(err, pgerrs.ErrNotFound)
+}
+
+func (s *pgdbTestSuite) TestReminderRepo_UpsertSettings() {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	_, err := s.reminder.FindSettings(s.ctx, userId)
+	s.Assert().ErrorIs(err, pgerrs.ErrNotFound)
+
+	email := "user@example.com"
+	expect := dbmodel.ReminderSettings{UserId: userId, LeadDays: 5, Email: &email}
+
+	s.Assert().NoError(s.reminder.UpsertSettings(s.ctx, expect))
+	actual, err := s.reminder.FindSettings(s.ctx, userId)
+	s.Assert().NoError(err)
+	s.Assert().Equal(expect, actual)
+
+	expect = dbmodel.ReminderSettings{UserId: userId, LeadDays: 1}
+
+	s.Assert().NoError(s.reminder.UpsertSettings(s.ctx, expect))
+	actual, err = s.reminder.FindSettings(s.ctx, userId)
+	s.Assert().NoError(err)
+	s.Assert().Equal(expect, actual)
+}
@@ -0,0 +1,181 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"strings"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+)
+
+const (
+	serviceTable = "services"
+)
+
+var serviceColumns = []string{
+	"id",
+	"name",
+	"aliases",
+	"category",
+	"default_price",
+	"yearly_price",
+	"family_price",
+	"family_size",
+	"vendor_url",
+	"logo_url",
+	"created_at",
+}
+
+type ServiceRepo struct {
+	*postgres.Postgres
+}
+
+func NewServiceRepo(pg *postgres.Postgres) *ServiceRepo {
+	return &ServiceRepo{pg}
+}
+
+func (r *ServiceRepo) Create(ctx context.Context, s dbmodel.Service) (int, error) {
+	sql, args, _ := r.Builder.
+		Insert(serviceTable).
+		Columns("name", "aliases", "category", "default_price", "yearly_price", "family_price", "family_size", "vendor_url", "logo_url", "organization_id").
+		Values(s.Name, nonNil(s.Aliases), s.Category, s.DefaultPrice, s.YearlyPrice, s.FamilyPrice, s.FamilySize, s.VendorUrl, s.LogoUrl, tenant.OrganizationOrDefault(ctx)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, constraintErr(err)
+	}
+	return id, nil
+}
+
+func (r *ServiceRepo) FindById(ctx context.Context, id int) (dbmodel.Service, error) {
+	sql, args, _ := r.Builder.
+		Select(serviceColumns...).
+		From(serviceTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	return r.findOne(ctx, sql, args...)
+}
+
+// FindByName finds service by name or one of aliases. Case and repeated spaces are ignored
+func (r *ServiceRepo) FindByName(ctx context.Context, name string) (dbmodel.Service, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+
+	sql, args, _ := r.Builder.
+		Select(serviceColumns...).
+		From(serviceTable).
+		Where("(lower(name) = ? OR EXISTS (SELECT 1 FROM unnest(aliases) AS a WHERE lower(a) = ?))", name, name).
+		Where(tenantFilter(ctx, "organization_id")).
+		OrderBy("id").
+		Limit(1).
+		ToSql()
+
+	return r.findOne(ctx, sql, args...)
+}
+
+func (r *ServiceRepo) FindAll(ctx context.Context) ([]dbmodel.Service, error) {
+	sql, args, _ := r.Builder.
+		Select(serviceColumns...).
+		From(serviceTable).
+		Where(tenantFilter(ctx, "organization_id")).
+		OrderBy("name").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Service
+
+	for rows.Next() {
+		s, err := scanService(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (r *ServiceRepo) Update(ctx context.Context, s dbmodel.Service) error {
+	sql, args, _ := r.Builder.
+		Update(serviceTable).
+		Set("name", s.Name).
+		Set("aliases", nonNil(s.Aliases)).
+		Set("category", s.Category).
+		Set("default_price", s.DefaultPrice).
+		Set("yearly_price", s.YearlyPrice).
+		Set("family_price", s.FamilyPrice).
+		Set("family_size", s.FamilySize).
+		Set("vendor_url", s.VendorUrl).
+		Set("logo_url", s.LogoUrl).
+		Where("id = ?", s.Id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return constraintErr(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes service. It fails with pgerrs.ErrReferenced while subscriptions refer to the service
+func (r *ServiceRepo) Delete(ctx context.Context, id int) error {
+	sql, args, _ := r.Builder.
+		Delete(serviceTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return constraintErr(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ServiceRepo) findOne(ctx context.Context, sql string, args ...any) (dbmodel.Service, error) {
+	s, err := scanService(r.Conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.Service{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.Service{}, err
+	}
+	return s, nil
+}
+
+func scanService(row pgx.Row) (dbmodel.Service, error) {
+	var s dbmodel.Service
+
+	err := row.Scan(
+		&s.Id,
+		&s.Name,
+		&s.Aliases,
+		&s.Category,
+		&s.DefaultPrice,
+		&s.YearlyPrice,
+		&s.FamilyPrice,
+		&s.FamilySize,
+		&s.VendorUrl,
+		&s.LogoUrl,
+		&s.CreatedAt,
+	)
+	return s, err
+}
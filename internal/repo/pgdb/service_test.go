@@ -0,0 +1,85 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+// serviceId returns id of catalog service with given name, creating it if needed
+func (s *pgdbTestSuite) serviceId(name string) int {
+	svc, err := s.service.FindByName(s.ctx, name)
+	if err == nil {
+		return svc.Id
+	}
+	id, err := s.service.Create(s.ctx, dbmodel.Service{Name: name})
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func (s *pgdbTestSuite) TestServiceRepo_Create() {
+	svc := dbmodel.Service{
+		Name:         "Yandex Plus",
+		Aliases:      []string{"Яндекс Плюс", "yandex+"},
+		Category:     ptr("music"),
+		DefaultPrice: ptr(400),
+		YearlyPrice:  ptr(4000),
+		FamilyPrice:  ptr(600),
+		FamilySize:   ptr(4),
+		VendorUrl:    ptr("https://plus.yandex.ru"),
+	}
+
+	id, err := s.service.Create(s.ctx, svc)
+	s.Assert().NoError(err)
+
+	actual, err := s.service.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(svc.Name, actual.Name)
+	s.Assert().Equal(svc.Aliases, actual.Aliases)
+	s.Assert().Equal(svc.Category, actual.Category)
+	s.Assert().Equal(svc.DefaultPrice, actual.DefaultPrice)
+	s.Assert().Equal(svc.YearlyPrice, actual.YearlyPrice)
+	s.Assert().Equal(svc.FamilyPrice, actual.FamilyPrice)
+	s.Assert().Equal(svc.FamilySize, actual.FamilySize)
+	s.Assert().Equal(svc.VendorUrl, actual.VendorUrl)
+	s.Assert().Nil(actual.LogoUrl)
+
+	_, err = s.service.Create(s.ctx, dbmodel.Service{Name: "yandex plus"})
+	s.Assert().Equal(pgerrs.ErrAlreadyExists, err)
+}
+
+func (s *pgdbTestSuite) TestServiceRepo_FindByName() {
+	id, err := s.service.Create(s.ctx, dbmodel.Service{Name: "Yandex Plus", Aliases: []string{"Яндекс Плюс"}})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, name := range []string{"Yandex Plus", "  yandex   PLUS ", "яндекс плюс"} {
+		actual, err := s.service.FindByName(s.ctx, name)
+		s.Assert().NoError(err)
+		s.Assert().Equal(id, actual.Id)
+	}
+
+	_, err = s.service.FindByName(s.ctx, "Netflix")
+	s.Assert().Equal(pgerrs.ErrNotFound, err)
+}
+
+func (s *pgdbTestSuite) TestServiceRepo_Delete() {
+	id := s.serviceId("Yandex Plus")
+
+	_, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: id,
+		Price:     400,
+		UserId:    s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	s.Assert().Equal(pgerrs.ErrReferenced, s.service.Delete(s.ctx, id))
+	s.Assert().Equal(pgerrs.ErrNotFound, s.service.Delete(s.ctx, 0))
+	s.Assert().NoError(s.service.Delete(s.ctx, s.serviceId("Spotify")))
+}
@@ -0,0 +1,206 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"time"
+)
+
+const (
+	splitTable = "subscription_split"
+	shareTable = "subscription_share"
+)
+
+type ShareRepo struct {
+	*postgres.Postgres
+}
+
+func NewShareRepo(pg *postgres.Postgres) *ShareRepo {
+	return &ShareRepo{pg}
+}
+
+// Set replaces split of subscription with the new one
+func (r *ShareRepo) Set(ctx context.Context, split dbmodel.Split) error {
+	if err := r.Delete(ctx, split.SubscriptionId); err != nil && !errors.Is(err, pgerrs.ErrNotFound) {
+		return err
+	}
+
+	sql, args, _ := r.Builder.
+		Insert(splitTable).
+		Columns("subscription_id", "rule").
+		Values(split.SubscriptionId, split.Rule).
+		ToSql()
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return constraintErr(err)
+	}
+
+	b := r.Builder.
+		Insert(shareTable).
+		Columns("subscription_id", "user_id", "percent", "amount")
+
+	for _, sh := range split.Shares {
+		b = b.Values(split.SubscriptionId, sh.UserId, sh.Percent, sh.Amount)
+	}
+	sql, args, _ = b.ToSql()
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return constraintErr(err)
+	}
+	return nil
+}
+
+// FindBySubscription returns split of subscription with costs of owner and members
+func (r *ShareRepo) FindBySubscription(ctx context.Context, subscriptionId int) (dbmodel.Split, error) {
+	sql, args, _ := r.Builder.
+		Select("sp.rule", "c.amount").
+		From(splitTable+" sp").
+		Join("subscription s ON s.id = sp.subscription_id").
+		Join("subscription_cost c ON c.subscription_id = s.id AND c.user_id = s.user_id").
+		Where("sp.subscription_id = ?", subscriptionId).
+		Where(tenantFilter(ctx, "s.organization_id")).
+		ToSql()
+
+	split := dbmodel.Split{SubscriptionId: subscriptionId}
+
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&split.Rule, &split.OwnerCost)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.Split{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.Split{}, err
+	}
+
+	sql, args, _ = r.Builder.
+		Select("sh.user_id", "sh.percent", "sh.amount", "c.amount").
+		From(shareTable+" sh").
+		Join("subscription_cost c ON c.subscription_id = sh.subscription_id AND c.user_id = sh.user_id").
+		Where("sh.subscription_id = ?", subscriptionId).
+		OrderBy("sh.user_id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return dbmodel.Split{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sh dbmodel.Share
+
+		if err = rows.Scan(&sh.UserId, &sh.Percent, &sh.Amount, &sh.Cost); err != nil {
+			return dbmodel.Split{}, err
+		}
+		split.Shares = append(split.Shares, sh)
+	}
+	return split, nil
+}
+
+// FindDebts returns shares of subscriptions active in interval. With userId only debts of user and debts to user
+// are returned
+func (r *ShareRepo) FindDebts(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.ShareDebt, error) {
+	columns := append([]string{
+		"s.id",
+		"s.user_id",
+		"c.user_id",
+		"c.amount",
+		"s.price",
+		"s.start_date",
+		"s.end_date",
+		"s.billing_period",
+		"s.trial_end_date",
+		"s.intro_price",
+		"s.intro_periods",
+		"s.tax_rate",
+		"s.tax_inclusive",
+		"s.discount_type",
+		"s.discount_value",
+		"s.discount_start_date",
+		"s.discount_months",
+	}, pauseColumns...)
+
+	b := r.Builder.
+		Select(columns...).
+		From(shareTable + " sh").
+		Join("subscription s ON s.id = sh.subscription_id").
+		Join("subscription_cost c ON c.subscription_id = sh.subscription_id AND c.user_id = sh.user_id").
+		Where(squirrel.Expr(subscriptionOverlapSQL, end, start)).
+		Where(tenantFilter(ctx, "s.organization_id"))
+
+	if userId != "" {
+		b = b.Where("(s.user_id = ? OR sh.user_id = ?)", userId, userId)
+	}
+	sql, args, _ := b.OrderBy("s.id", "c.user_id").ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.ShareDebt
+
+	for rows.Next() {
+		var (
+			d           dbmodel.ShareDebt
+			pauseIds    []int
+			pauseStarts []time.Time
+			pauseEnds   []*time.Time
+		)
+
+		err = rows.Scan(
+			&d.SubscriptionId,
+			&d.OwnerId,
+			&d.UserId,
+			&d.Amount,
+			&d.Price,
+			&d.StartDate,
+			&d.EndDate,
+			&d.BillingPeriod,
+			&d.TrialEndDate,
+			&d.IntroPrice,
+			&d.IntroPeriods,
+			&d.TaxRate,
+			&d.TaxInclusive,
+			&d.DiscountType,
+			&d.DiscountValue,
+			&d.DiscountStartDate,
+			&d.DiscountMonths,
+			&pauseIds,
+			&pauseStarts,
+			&pauseEnds,
+		)
+		if err != nil {
+			return nil, err
+		}
+		d.Pauses = newPauses(d.SubscriptionId, pauseIds, pauseStarts, pauseEnds)
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// Delete makes subscription not shared
+func (r *ShareRepo) Delete(ctx context.Context, subscriptionId int) error {
+	// subquery keeps default placeholders, outer builder numbers them
+	owned := squirrel.Select("id").From(subscriptionTable).Where(tenantFilter(ctx, "organization_id"))
+
+	sql, args, _ := r.Builder.
+		Delete(splitTable).
+		Where("subscription_id = ?", subscriptionId).
+		Where(squirrel.Expr("subscription_id IN (?)", owned)).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
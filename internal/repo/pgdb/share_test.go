@@ -0,0 +1,138 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestShareRepo_Set() {
+	owner := s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a")
+	bob := s.userId("2344696a-d069-4fad-a3ed-f27c13651c3a")
+	carol := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     s.serviceId("Yandex Plus"),
+		Price:         1000,
+		UserId:        owner,
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = s.share.FindBySubscription(s.ctx, id)
+	s.Assert().Equal(pgerrs.ErrNotFound, err)
+
+	err = s.share.Set(s.ctx, dbmodel.Split{
+		SubscriptionId: id,
+		Rule:           dbmodel.SplitEqual,
+		Shares:         []dbmodel.Share{{UserId: bob}, {UserId: carol}},
+	})
+	s.Assert().NoError(err)
+
+	// remainder of equal split is paid by owner
+	split, err := s.share.FindBySubscription(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(dbmodel.Split{
+		SubscriptionId: id,
+		Rule:           dbmodel.SplitEqual,
+		OwnerCost:      334,
+		Shares:         []dbmodel.Share{{UserId: bob, Cost: 333}, {UserId: carol, Cost: 333}},
+	}, split)
+
+	err = s.share.Set(s.ctx, dbmodel.Split{
+		SubscriptionId: id,
+		Rule:           dbmodel.SplitPercentage,
+		Shares:         []dbmodel.Share{{UserId: bob, Percent: ptr(30)}},
+	})
+	s.Assert().NoError(err)
+
+	start, end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	price, err := s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{UserId: bob}, start, end)
+	s.Assert().NoError(err)
+	s.Assert().Equal(300, price.Gross)
+
+	price, err = s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{UserId: owner}, start, end)
+	s.Assert().NoError(err)
+	s.Assert().Equal(700, price.Gross)
+
+	price, err = s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{UserId: carol}, start, end)
+	s.Assert().NoError(err)
+	s.Assert().Equal(0, price.Gross)
+
+	debts, err := s.share.FindDebts(s.ctx, bob, start, end)
+	s.Assert().NoError(err)
+	s.Assert().Equal([]dbmodel.ShareDebt{{
+		SubscriptionId: id,
+		OwnerId:        owner,
+		UserId:         bob,
+		Amount:         300,
+		StartDate:      start,
+		BillingPeriod:  dbmodel.BillingMonthly,
+	}}, debts)
+
+	debts, err = s.share.FindDebts(s.ctx, carol, start, end)
+	s.Assert().NoError(err)
+	s.Assert().Empty(debts)
+
+	s.Assert().NoError(s.share.Delete(s.ctx, id))
+	s.Assert().Equal(pgerrs.ErrNotFound, s.share.Delete(s.ctx, id))
+
+	price, err = s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{UserId: owner}, start, end)
+	s.Assert().NoError(err)
+	s.Assert().Equal(1000, price.Gross)
+}
+
+func (s *pgdbTestSuite) TestShareRepo_FixedAbovePrice() {
+	owner := s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a")
+	bob := s.userId("2344696a-d069-4fad-a3ed-f27c13651c3a")
+	carol := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     s.serviceId("Yandex Plus"),
+		Price:         1000,
+		UserId:        owner,
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = s.share.Set(s.ctx, dbmodel.Split{
+		SubscriptionId: id,
+		Rule:           dbmodel.SplitFixed,
+		Shares:         []dbmodel.Share{{UserId: bob, Amount: ptr(500)}, {UserId: carol, Amount: ptr(300)}},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// price is lowered below sum of fixed shares after split is set
+	sub, err := s.sub.FindById(s.ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	sub.Price = 400
+	if err = s.sub.Update(s.ctx, sub); err != nil {
+		panic(err)
+	}
+
+	split, err := s.share.FindBySubscription(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(0, split.OwnerCost)
+	s.Assert().Equal([]dbmodel.Share{
+		{UserId: bob, Amount: ptr(500), Cost: 250},
+		{UserId: carol, Amount: ptr(300), Cost: 150},
+	}, split.Shares)
+
+	start, end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	for userId, expect := range map[string]int{owner: 0, bob: 250, carol: 150} {
+		price, err := s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{UserId: userId}, start, end)
+		s.Assert().NoError(err)
+		s.Assert().Equal(expect, price.Gross, userId)
+	}
+}
@@ -8,13 +8,72 @@ import (
 	"subscription_service/internal/model/dbmodel"
 	"subscription_service/internal/repo/pgerrs"
 	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
 	"time"
 )
 
 const (
-	subscriptionTable = "subscription"
+	subscriptionTable    = "subscription"
+	subscriptionTagTable = "subscription_tag"
+
+	// subscriptionFrom joins catalog to read canonical service name
+	subscriptionFrom = "subscription s JOIN services sv ON sv.id = s.service_id"
+
+	// subscriptionDaySQL is the last active day of subscription s in interval ending at argument,
+	// LEAST ignores NULL end date
+	subscriptionDaySQL = "LEAST(s.end_date, ?::date)"
+
+	// subscriptionOverlapSQL matches subscriptions s active on any day of interval from start to end inclusive,
+	// arguments are end and start
+	subscriptionOverlapSQL = "s.start_date <= ? AND (s.end_date IS NULL OR s.end_date >= ?)"
+
+	insertTagsSQL = "INSERT INTO tag (organization_id, name) SELECT organization_id, unnest($2::varchar[]) FROM subscription WHERE id = $1 ON CONFLICT (organization_id, name) DO NOTHING"
+	linkTagsSQL   = "INSERT INTO subscription_tag (subscription_id, tag_id) SELECT s.id, t.id FROM subscription s JOIN tag t ON t.organization_id = s.organization_id WHERE s.id = $1 AND t.name = ANY($2)"
 )
 
+var subscriptionColumns = append([]string{
+	"s.id",
+	"s.service_id",
+	"sv.name",
+	"s.price",
+	"s.user_id",
+	"s.start_date",
+	"s.end_date",
+	"s.billing_period",
+	"s.category_id",
+	"NULLIF(ARRAY(SELECT t.name FROM subscription_tag st JOIN tag t ON t.id = st.tag_id WHERE st.subscription_id = s.id ORDER BY t.name), '{}')",
+	"s.organization_id",
+	"s.trial_end_date",
+	"s.intro_price",
+	"s.intro_periods",
+	"s.cancel_reason",
+	"s.cancelled_at",
+	"COALESCE((SELECT u.timezone FROM users u WHERE u.id = s.user_id), 'UTC')",
+	"s.tax_rate",
+	"s.tax_inclusive",
+	"s.discount_type",
+	"s.discount_value",
+	"s.discount_start_date",
+	"s.discount_months",
+	"s.last_used_date",
+}, pauseColumns...)
+
+// subscriptionAmounts are gross, tax and discount of subscription s charged on its last active day of interval
+var subscriptionAmounts = []string{
+	"subscription_gross(s, " + subscriptionDaySQL + ")",
+	"subscription_tax(s, " + subscriptionDaySQL + ")",
+	"subscription_discount(s, " + subscriptionDaySQL + ")",
+}
+
+// userAmounts are subscriptionAmounts of part c of subscription paid by user, scaled by the same ratio as price
+func userAmounts() []string {
+	result := make([]string, 0, len(subscriptionAmounts))
+	for _, amount := range subscriptionAmounts {
+		result = append(result, "CASE WHEN s.price = 0 THEN 0 ELSE c.amount * "+amount+" / s.price END")
+	}
+	return result
+}
+
 type SubscriptionRepo struct {
 	*postgres.Postgres
 }
@@ -23,109 +82,292 @@ func NewSubscriptionRepo(pg *postgres.Postgres) *SubscriptionRepo {
 	return &SubscriptionRepo{pg}
 }
 
-func (r *SubscriptionRepo) Create(ctx context.Context, s dbmodel.Subscription) error {
+func (r *SubscriptionRepo) Create(ctx context.Context, s dbmodel.Subscription) (int, error) {
 	sql, args, _ := r.Builder.
 		Insert(subscriptionTable).
-		Columns("service_name", "price", "user_id", "start_date", "end_date").
-		Values(s.ServiceName, s.Price, s.UserId, s.StartDate, s.EndDate).
+		Columns(
+			"service_id",
+			"price",
+			"user_id",
+			"start_date",
+			"end_date",
+			"billing_period",
+			"category_id",
+			"organization_id",
+			"trial_end_date",
+			"intro_price",
+			"intro_periods",
+			"tax_rate",
+			"tax_inclusive",
+			"discount_type",
+			"discount_value",
+			"discount_start_date",
+			"discount_months",
+			"last_used_date",
+		).
+		Values(
+			s.ServiceId,
+			s.Price,
+			s.UserId,
+			s.StartDate,
+			s.EndDate,
+			s.BillingPeriod,
+			s.CategoryId,
+			tenant.OrganizationOrDefault(ctx),
+			s.TrialEndDate,
+			s.IntroPrice,
+			s.IntroPeriods,
+			s.TaxRate,
+			s.TaxInclusive,
+			s.DiscountType,
+			s.DiscountValue,
+			s.DiscountStartDate,
+			s.DiscountMonths,
+			s.LastUsedDate,
+		).
+		Suffix("RETURNING id").
 		ToSql()
 
-	if _, err := r.Pool.Exec(ctx, sql, args...); err != nil {
-		return err
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, err
 	}
-	return nil
+	return id, nil
 }
 
 func (r *SubscriptionRepo) FindById(ctx context.Context, id int) (dbmodel.Subscription, error) {
 	sql, args, _ := r.Builder.
-		Select("service_name", "price", "user_id", "start_date", "end_date").
-		From(subscriptionTable).
-		Where("id = ?", id).
+		Select(subscriptionColumns...).
+		From(subscriptionFrom).
+		Where("s.id = ?", id).
+		Where(tenantFilter(ctx, "s.organization_id")).
 		ToSql()
 
-	var s dbmodel.Subscription
-
-	err := r.Pool.QueryRow(ctx, sql, args...).Scan(
-		&s.ServiceName,
-		&s.Price,
-		&s.UserId,
-		&s.StartDate,
-		&s.EndDate,
-	)
+	s, err := scanSubscription(r.Conn(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return dbmodel.Subscription{}, pgerrs.ErrNotFound
 		}
+		return dbmodel.Subscription{}, err
 	}
 	return s, nil
 }
 
-func (r *SubscriptionRepo) FindAll(ctx context.Context) ([]dbmodel.Subscription, error) {
-	sql, args, _ := r.Builder.
-		Select("id", "service_name", "price", "user_id", "start_date", "end_date").
-		From(subscriptionTable).
+func (r *SubscriptionRepo) FindAll(ctx context.Context, f dbmodel.SubscriptionFilter) ([]dbmodel.Subscription, error) {
+	b := r.Builder.
+		Select(subscriptionColumns...).
+		From(subscriptionFrom)
+
+	if f.AfterId != 0 {
+		b = b.Where("s.id > ?", f.AfterId)
+	}
+	if f.Limit != 0 {
+		b = b.Limit(uint64(f.Limit))
+	}
+
+	sql, args, _ := filterSubscriptions(b, f).
+		Where(tenantFilter(ctx, "s.organization_id")).
+		OrderBy("s.id").
+		ToSql()
+
+	return r.findMany(ctx, sql, args...)
+}
+
+func (r *SubscriptionRepo) FindActive(ctx context.Context, userId string, date time.Time) ([]dbmodel.Subscription, error) {
+	b := r.Builder.
+		Select(subscriptionColumns...).
+		From(subscriptionFrom).
+		Where("(s.end_date IS NULL OR s.end_date >= ?)", date).
+		Where(tenantFilter(ctx, "s.organization_id"))
+
+	if userId != "" {
+		b = b.Where("s.user_id = ?", userId)
+	}
+	sql, args, _ := b.OrderBy("s.id").ToSql()
+
+	return r.findMany(ctx, sql, args...)
+}
+
+// FindPrice sums prices of filtered subscriptions active on any day of interval. Price of subscription is the one charged
+// on its last active day of interval, so trial, intro periods, discount and tax are taken into account. With user filter
+// only part of price paid by user is summed: share of subscriptions shared with user and the rest of price of own ones
+func (r *SubscriptionRepo) FindPrice(ctx context.Context, f dbmodel.SubscriptionFilter, start, end time.Time) (dbmodel.Price, error) {
+	amounts := subscriptionAmounts
+	b := r.Builder.
+		Select().
+		From("subscription s")
+
+	if f.UserId != "" {
+		amounts = userAmounts()
+		b = b.Join("subscription_cost c ON c.subscription_id = s.id AND c.user_id = ?", f.UserId)
+		f.UserId = ""
+	}
+	for _, amount := range amounts {
+		b = b.Column(squirrel.Expr("COALESCE(SUM("+amount+"), 0)", end))
+	}
+
+	sql, args, _ := filterSubscriptions(b, f).
+		Where(squirrel.Expr(subscriptionOverlapSQL, end, start)).
+		Where(tenantFilter(ctx, "s.organization_id")).
 		ToSql()
 
-	rows, err := r.Pool.Query(ctx, sql, args...)
+	var price dbmodel.Price
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&price.Gross, &price.Tax, &price.Discount); err != nil {
+		return dbmodel.Price{}, err
+	}
+	price.Net = price.Gross - price.Tax
+	return price, nil
+}
+
+// FindUserPrices sums prices like FindPrice with user filter for every of users at once.
+// Users without subscriptions active in interval are missing in result
+func (r *SubscriptionRepo) FindUserPrices(ctx context.Context, userIds []string, start, end time.Time) (map[string]dbmodel.Price, error) {
+	b := r.Builder.
+		Select("c.user_id").
+		From("subscription s").
+		Join("subscription_cost c ON c.subscription_id = s.id AND c.user_id = ANY(?)", userIds)
+
+	for _, amount := range userAmounts() {
+		b = b.Column(squirrel.Expr("COALESCE(SUM("+amount+"), 0)", end))
+	}
+
+	sql, args, _ := b.
+		Where(squirrel.Expr(subscriptionOverlapSQL, end, start)).
+		Where(tenantFilter(ctx, "s.organization_id")).
+		GroupBy("c.user_id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var result []dbmodel.Subscription
+	result := make(map[string]dbmodel.Price)
 
 	for rows.Next() {
-		var s dbmodel.Subscription
-
-		err = rows.Scan(
-			&s.Id,
-			&s.ServiceName,
-			&s.Price,
-			&s.UserId,
-			&s.StartDate,
-			&s.EndDate,
+		var (
+			userId string
+			price  dbmodel.Price
 		)
-		if err != nil {
+		if err = rows.Scan(&userId, &price.Gross, &price.Tax, &price.Discount); err != nil {
 			return nil, err
 		}
-		result = append(result, s)
+		price.Net = price.Gross - price.Tax
+		result[userId] = price
 	}
 	return result, nil
 }
 
-func (r *SubscriptionRepo) FindPrice(ctx context.Context, service, userId string, start, end time.Time) (int, error) {
-	b := r.Builder.
-		Select("COALESCE(SUM(price), 0)").
-		From(subscriptionTable)
+// FindCharges returns prices of subscriptions which user pays for, own and shared with user, active on any day of
+// interval. Prices are the same as summed by FindPrice with user filter, amount is part of price paid by user
+// in currency of subscription owner
+func (r *SubscriptionRepo) FindCharges(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.SubscriptionCharge, error) {
+	sql, args, _ := r.Builder.
+		Select(subscriptionColumns...).
+		Column(squirrel.Expr(subscriptionAmounts[0], end)).
+		Column(squirrel.Expr(userAmounts()[0], end)).
+		Column("u.currency").
+		From(subscriptionFrom).
+		Join("subscription_cost c ON c.subscription_id = s.id AND c.user_id = ?", userId).
+		Join("users u ON u.id = s.user_id").
+		Where(squirrel.Expr(subscriptionOverlapSQL, end, start)).
+		Where(tenantFilter(ctx, "s.organization_id")).
+		OrderBy("s.start_date", "s.id").
+		ToSql()
 
-	if userId != "" {
-		b = b.Where("user_id = ?", userId)
-	}
-	if service != "" {
-		b = b.Where("service_name = ?", service)
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
 	}
-	sql, args, _ := b.Where(squirrel.Expr("start_date <= ? AND (end_date IS NULL OR end_date >= ?)", end, start)).ToSql()
+	defer rows.Close()
 
-	var price int
+	var result []dbmodel.SubscriptionCharge
 
-	if err := r.Pool.QueryRow(ctx, sql, args...).Scan(&price); err != nil {
-		return 0, err
+	for rows.Next() {
+		var c dbmodel.SubscriptionCharge
+
+		c.Subscription, err = scanSubscription(rows, &c.Gross, &c.Amount, &c.Currency)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
 	}
-	return price, nil
+	return result, nil
 }
 
+// Update replaces subscription. Cancellation is kept only while end date is not changed
 func (r *SubscriptionRepo) Update(ctx context.Context, s dbmodel.Subscription) error {
 	sql, args, _ := r.Builder.
 		Update(subscriptionTable).
-		Set("service_name", s.ServiceName).
+		Set("service_id", s.ServiceId).
 		Set("price", s.Price).
 		Set("user_id", s.UserId).
 		Set("start_date", s.StartDate).
 		Set("end_date", s.EndDate).
+		Set("billing_period", s.BillingPeriod).
+		Set("category_id", s.CategoryId).
+		Set("trial_end_date", s.TrialEndDate).
+		Set("intro_price", s.IntroPrice).
+		Set("intro_periods", s.IntroPeriods).
+		Set("tax_rate", s.TaxRate).
+		Set("tax_inclusive", s.TaxInclusive).
+		Set("discount_type", s.DiscountType).
+		Set("discount_value", s.DiscountValue).
+		Set("discount_start_date", s.DiscountStartDate).
+		Set("discount_months", s.DiscountMonths).
+		Set("last_used_date", s.LastUsedDate).
+		Set("cancel_reason", squirrel.Expr("CASE WHEN end_date IS NOT DISTINCT FROM ?::date THEN cancel_reason END", s.EndDate)).
+		Set("cancelled_at", squirrel.Expr("CASE WHEN end_date IS NOT DISTINCT FROM ?::date THEN cancelled_at END", s.EndDate)).
 		Where("id = ?", s.Id).
+		Where(tenantFilter(ctx, "organization_id")).
 		ToSql()
 
-	result, err := r.Pool.Exec(ctx, sql, args...)
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// Cancel sets end date of subscription together with reason and time of cancellation
+func (r *SubscriptionRepo) Cancel(ctx context.Context, s dbmodel.Subscription) error {
+	sql, args, _ := r.Builder.
+		Update(subscriptionTable).
+		Set("end_date", s.EndDate).
+		Set("cancel_reason", s.CancelReason).
+		Set("cancelled_at", s.CancelledAt).
+		Where("id = ?", s.Id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// Uncancel makes subscription open-ended again
+func (r *SubscriptionRepo) Uncancel(ctx context.Context, id int) error {
+	sql, args, _ := r.Builder.
+		Update(subscriptionTable).
+		Set("end_date", nil).
+		Set("cancel_reason", nil).
+		Set("cancelled_at", nil).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
@@ -139,9 +381,10 @@ func (r *SubscriptionRepo) Delete(ctx context.Context, id int) error {
 	sql, args, _ := r.Builder.
 		Delete(subscriptionTable).
 		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
 		ToSql()
 
-	result, err := r.Pool.Exec(ctx, sql, args...)
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
@@ -150,3 +393,116 @@ func (r *SubscriptionRepo) Delete(ctx context.Context, id int) error {
 	}
 	return nil
 }
+
+// SetTags replaces tags of subscription. Tags are created on first use in organization of subscription
+func (r *SubscriptionRepo) SetTags(ctx context.Context, subscriptionId int, tags []string) error {
+	sql, args, _ := r.Builder.
+		Delete(subscriptionTagTable).
+		Where("subscription_id = ?", subscriptionId).
+		ToSql()
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, err := r.Conn(ctx).Exec(ctx, insertTagsSQL, subscriptionId, tags); err != nil {
+		return err
+	}
+	if _, err := r.Conn(ctx).Exec(ctx, linkTagsSQL, subscriptionId, tags); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *SubscriptionRepo) findMany(ctx context.Context, sql string, args ...any) ([]dbmodel.Subscription, error) {
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Subscription
+
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// scanSubscription scans subscriptionColumns, extra destinations are scanned from columns following them
+func scanSubscription(row pgx.Row, extra ...any) (dbmodel.Subscription, error) {
+	var (
+		s           dbmodel.Subscription
+		pauseIds    []int
+		pauseStarts []time.Time
+		pauseEnds   []*time.Time
+	)
+
+	dest := append([]any{
+		&s.Id,
+		&s.ServiceId,
+		&s.ServiceName,
+		&s.Price,
+		&s.UserId,
+		&s.StartDate,
+		&s.EndDate,
+		&s.BillingPeriod,
+		&s.CategoryId,
+		&s.Tags,
+		&s.OrganizationId,
+		&s.TrialEndDate,
+		&s.IntroPrice,
+		&s.IntroPeriods,
+		&s.CancelReason,
+		&s.CancelledAt,
+		&s.Timezone,
+		&s.TaxRate,
+		&s.TaxInclusive,
+		&s.DiscountType,
+		&s.DiscountValue,
+		&s.DiscountStartDate,
+		&s.DiscountMonths,
+		&s.LastUsedDate,
+		&pauseIds,
+		&pauseStarts,
+		&pauseEnds,
+	}, extra...)
+
+	err := row.Scan(dest...)
+	s.Pauses = newPauses(s.Id, pauseIds, pauseStarts, pauseEnds)
+	return s, err
+}
+
+// filterSubscriptions adds conditions of filter to query of subscriptions aliased as s
+func filterSubscriptions(b squirrel.SelectBuilder, f dbmodel.SubscriptionFilter) squirrel.SelectBuilder {
+	if f.UserId != "" {
+		b = b.Where("s.user_id = ?", f.UserId)
+	}
+	if f.ServiceId != 0 {
+		b = b.Where("s.service_id = ?", f.ServiceId)
+	}
+	if f.UserIds != nil {
+		b = b.Where("s.user_id = ANY(?)", f.UserIds)
+	}
+	if f.ServiceIds != nil {
+		b = b.Where("s.service_id = ANY(?)", f.ServiceIds)
+	}
+	if f.CategoryId != 0 {
+		b = b.Where(`s.category_id IN (
+WITH RECURSIVE tree AS (SELECT id FROM category WHERE id = ?
+                        UNION ALL
+                        SELECT c.id FROM category c JOIN tree ON c.parent_id = tree.id)
+SELECT id FROM tree)`, f.CategoryId)
+	}
+	if f.Tag != "" {
+		b = b.Where("EXISTS (SELECT 1 FROM subscription_tag st JOIN tag t ON t.id = st.tag_id WHERE st.subscription_id = s.id AND t.name = ?)", f.Tag)
+	}
+	return b
+}
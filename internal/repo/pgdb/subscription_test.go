@@ -1,8 +1,10 @@
 package pgdb
 
 import (
+	"context"
 	"subscription_service/internal/model/dbmodel"
 	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
 	"testing"
 	"time"
 )
@@ -16,22 +18,24 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_Create() {
 		{
 			testName: "correct test",
 			sub: dbmodel.Subscription{
-				ServiceName: "Yandex",
-				Price:       1000,
-				UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
-				StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-				EndDate:     ptr(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+				ServiceName:   "Yandex",
+				Price:         1000,
+				UserId:        s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+				StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:       ptr(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+				BillingPeriod: dbmodel.BillingMonthly,
 			},
 			expectErr: nil,
 		},
 		{
 			testName: "correct test with null end date",
 			sub: dbmodel.Subscription{
-				ServiceName: "Google",
-				Price:       500,
-				UserId:      "2234696a-d069-4fad-a3ed-f27c13651c3a",
-				StartDate:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-				EndDate:     nil,
+				ServiceName:   "Google",
+				Price:         500,
+				UserId:        s.userId("2234696a-d069-4fad-a3ed-f27c13651c3a"),
+				StartDate:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:       nil,
+				BillingPeriod: dbmodel.BillingYearly,
 			},
 			expectErr: nil,
 		},
@@ -39,25 +43,29 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_Create() {
 
 	for _, tc := range testCases {
 		s.T().Run(tc.testName, func(t *testing.T) {
-			err := s.sub.Create(s.ctx, tc.sub)
+			tc.sub.ServiceId = s.serviceId(tc.sub.ServiceName)
+
+			id, err := s.sub.Create(s.ctx, tc.sub)
 
 			s.Assert().Equal(tc.expectErr, err)
 
 			if tc.expectErr == nil {
 				sql, args, _ := s.pg.Builder.
-					Select("service_name", "price", "user_id", "start_date", "end_date").
-					From(subscriptionTable).
-					Where("user_id = ?", tc.sub.UserId).
+					Select("s.service_id", "sv.name", "s.price", "s.user_id", "s.start_date", "s.end_date", "s.billing_period").
+					From(subscriptionFrom).
+					Where("s.id = ?", id).
 					ToSql()
 
 				var actual dbmodel.Subscription
 
 				err = s.pg.Pool.QueryRow(s.ctx, sql, args...).Scan(
+					&actual.ServiceId,
 					&actual.ServiceName,
 					&actual.Price,
 					&actual.UserId,
 					&actual.StartDate,
 					&actual.EndDate,
+					&actual.BillingPeriod,
 				)
 				s.Assert().NoError(err)
 				s.Assert().Equal(tc.sub, actual)
@@ -68,17 +76,22 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_Create() {
 
 func (s *pgdbTestSuite) TestSubscriptionRepo_FindById() {
 	defaultSub := dbmodel.Subscription{
-		ServiceName: "Yandex",
-		Price:       1000,
-		UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
-		StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-		EndDate:     ptr(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+		ServiceId:     s.serviceId("Yandex"),
+		ServiceName:   "Yandex",
+		Price:         1000,
+		UserId:        s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       ptr(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+		BillingPeriod: dbmodel.BillingMonthly,
+
+		OrganizationId: tenant.DefaultOrganization,
+		Timezone:       dbmodel.DefaultTimezone,
 	}
 
 	sql, args, _ := s.pg.Builder.
 		Insert(subscriptionTable).
-		Columns("service_name", "price", "user_id", "start_date", "end_date").
-		Values(defaultSub.ServiceName, defaultSub.Price, defaultSub.UserId, defaultSub.StartDate, defaultSub.EndDate).
+		Columns("service_id", "price", "user_id", "start_date", "end_date").
+		Values(defaultSub.ServiceId, defaultSub.Price, defaultSub.UserId, defaultSub.StartDate, defaultSub.EndDate).
 		Suffix("RETURNING id").
 		ToSql()
 
@@ -87,6 +100,7 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_FindById() {
 	if err := s.pg.Pool.QueryRow(s.ctx, sql, args...).Scan(&defaultId); err != nil {
 		panic(err)
 	}
+	defaultSub.Id = defaultId
 
 	testCases := []struct {
 		testName     string
@@ -117,50 +131,81 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_FindById() {
 	}
 }
 
+func (s *pgdbTestSuite) TestSubscriptionRepo_LastUsedDate() {
+	sub := dbmodel.Subscription{
+		ServiceId:    s.serviceId("Yandex"),
+		Price:        1000,
+		UserId:       s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+		StartDate:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		LastUsedDate: ptr(time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)),
+	}
+	id, err := s.sub.Create(s.ctx, sub)
+	if err != nil {
+		panic(err)
+	}
+
+	actual, err := s.sub.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(sub.LastUsedDate, actual.LastUsedDate)
+
+	sub.Id, sub.LastUsedDate = id, nil
+	s.Assert().NoError(s.sub.Update(s.ctx, sub))
+
+	actual, err = s.sub.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Nil(actual.LastUsedDate)
+}
+
 func (s *pgdbTestSuite) TestSubscriptionRepo_FindPrice() {
 	subscriptions := []dbmodel.Subscription{
 		{
 			ServiceName: "Yandex",
 			Price:       500,
-			UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			UserId:      s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
 			StartDate:   time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
 			EndDate:     ptr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
 		},
 		{
 			ServiceName: "Yandex",
 			Price:       500,
-			UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			UserId:      s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
 			StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
 			EndDate:     nil,
 		},
 		{
 			ServiceName: "Google",
 			Price:       1000,
-			UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			UserId:      s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
 			StartDate:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
 			EndDate:     ptr(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)),
 		},
 		{
 			ServiceName: "Google",
 			Price:       1000,
-			UserId:      "2344696a-d069-4fad-a3ed-f27c13651c3a",
+			UserId:      s.userId("2344696a-d069-4fad-a3ed-f27c13651c3a"),
 			StartDate:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
 			EndDate:     ptr(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)),
 		},
 		{
 			ServiceName: "VK",
 			Price:       400,
-			UserId:      "2344696a-d069-4fad-a3ed-f27c13651c3a",
+			UserId:      s.userId("2344696a-d069-4fad-a3ed-f27c13651c3a"),
 			StartDate:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
 			EndDate:     ptr(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)),
 		},
 	}
 
+	services := map[string]int{
+		"Yandex": s.serviceId("Yandex"),
+		"Google": s.serviceId("Google"),
+		"VK":     s.serviceId("VK"),
+	}
+
 	for _, sub := range subscriptions {
 		sql, args, _ := s.pg.Builder.
 			Insert(subscriptionTable).
-			Columns("service_name", "price", "user_id", "start_date", "end_date").
-			Values(sub.ServiceName, sub.Price, sub.UserId, sub.StartDate, sub.EndDate).
+			Columns("service_id", "price", "user_id", "start_date", "end_date").
+			Values(services[sub.ServiceName], sub.Price, sub.UserId, sub.StartDate, sub.EndDate).
 			ToSql()
 
 		if _, err := s.pg.Pool.Exec(s.ctx, sql, args...); err != nil {
@@ -244,7 +289,258 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_FindPrice() {
 
 	for _, tc := range testCases {
 		s.T().Run(tc.testName, func(t *testing.T) {
-			price, err := s.sub.FindPrice(s.ctx, tc.service, tc.userId, tc.start, tc.end)
+			price, err := s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{UserId: tc.userId, ServiceId: services[tc.service]}, tc.start, tc.end)
+
+			s.Assert().NoError(err)
+
+			s.Assert().Equal(tc.expectPrice, price.Gross)
+		})
+	}
+}
+
+func (s *pgdbTestSuite) TestSubscriptionRepo_FindCharges() {
+	bob := s.userId("2344696a-d069-4fad-a3ed-f27c13651c3a")
+	owner, err := s.user.Create(s.ctx, dbmodel.User{Timezone: dbmodel.DefaultTimezone, Currency: "USD"})
+	if err != nil {
+		panic(err)
+	}
+
+	own, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     s.serviceId("Yandex Plus"),
+		Price:         300,
+		UserId:        bob,
+		StartDate:     time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+	shared, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     s.serviceId("Netflix"),
+		Price:         1000,
+		UserId:        owner,
+		StartDate:     time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+	// ended before interval
+	_, err = s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     s.serviceId("VK"),
+		Price:         200,
+		UserId:        bob,
+		StartDate:     time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       ptr(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)),
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = s.share.Set(s.ctx, dbmodel.Split{
+		SubscriptionId: shared,
+		Rule:           dbmodel.SplitPercentage,
+		Shares:         []dbmodel.Share{{UserId: bob, Percent: ptr(25)}},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	february, februaryEnd := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	charges, err := s.sub.FindCharges(s.ctx, bob, february, februaryEnd)
+	s.Assert().NoError(err)
+	s.Require().Len(charges, 2)
+
+	s.Assert().Equal(own, charges[0].Id)
+	s.Assert().Equal(300, charges[0].Gross)
+	s.Assert().Equal(300, charges[0].Amount)
+	s.Assert().Equal(dbmodel.DefaultCurrency, charges[0].Currency)
+
+	s.Assert().Equal(shared, charges[1].Id)
+	s.Assert().Equal("Netflix", charges[1].ServiceName)
+	s.Assert().Equal(1000, charges[1].Gross)
+	s.Assert().Equal(250, charges[1].Amount)
+	s.Assert().Equal("USD", charges[1].Currency)
+
+	// owner pays the rest of shared subscription only
+	charges, err = s.sub.FindCharges(s.ctx, owner, february, februaryEnd)
+	s.Assert().NoError(err)
+	s.Require().Len(charges, 1)
+	s.Assert().Equal(750, charges[0].Amount)
+
+	// prices of users at once are the same as their charges
+	prices, err := s.sub.FindUserPrices(s.ctx, []string{bob, owner, "6114696a-d069-4fad-a3ed-f27c13651c3a"},
+		time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+	s.Assert().NoError(err)
+	s.Assert().Equal(map[string]dbmodel.Price{
+		bob:   {Gross: 550, Net: 550},
+		owner: {Gross: 750, Net: 750},
+	}, prices)
+}
+
+// statement of user is built from charges, its total must be the price of user subscriptions in the same month
+func (s *pgdbTestSuite) TestSubscriptionRepo_FindChargesMatchFindPrice() {
+	bob := s.userId("2344696a-d069-4fad-a3ed-f27c13651c3a")
+	owner := s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a")
+
+	subscriptions := []dbmodel.Subscription{
+		// starts at the end of month
+		{ServiceId: s.serviceId("Yandex Plus"), Price: 300, UserId: bob, StartDate: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), BillingPeriod: dbmodel.BillingMonthly},
+		// intro price, discount and tax on top of price
+		{
+			ServiceId:         s.serviceId("Spotify"),
+			Price:             500,
+			UserId:            bob,
+			StartDate:         time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			BillingPeriod:     dbmodel.BillingMonthly,
+			IntroPrice:        ptr(100),
+			IntroPeriods:      3,
+			TaxRate:           20,
+			DiscountType:      ptr(dbmodel.DiscountFixed),
+			DiscountValue:     10,
+			DiscountStartDate: ptr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		// free trial in the first month
+		{ServiceId: s.serviceId("Kinopoisk"), Price: 400, UserId: bob, StartDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), TrialEndDate: ptr(time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)), BillingPeriod: dbmodel.BillingMonthly},
+		// ends in the middle of March
+		{ServiceId: s.serviceId("VK"), Price: 200, UserId: bob, StartDate: time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)), BillingPeriod: dbmodel.BillingMonthly},
+		// yearly
+		{ServiceId: s.serviceId("Okko"), Price: 2400, UserId: bob, StartDate: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), BillingPeriod: dbmodel.BillingYearly},
+		// shared with bob
+		{ServiceId: s.serviceId("Netflix"), Price: 999, UserId: owner, StartDate: time.Date(2024, 12, 5, 0, 0, 0, 0, time.UTC), BillingPeriod: dbmodel.BillingMonthly},
+	}
+	var shared int
+	for _, sub := range subscriptions {
+		id, err := s.sub.Create(s.ctx, sub)
+		if err != nil {
+			panic(err)
+		}
+		shared = id
+	}
+	err := s.share.Set(s.ctx, dbmodel.Split{
+		SubscriptionId: shared,
+		Rule:           dbmodel.SplitPercentage,
+		Shares:         []dbmodel.Share{{UserId: bob, Percent: ptr(33)}},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, userId := range []string{bob, owner} {
+		for month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); month.Year() == 2025 && month.Month() <= 6; month = month.AddDate(0, 1, 0) {
+			end := month.AddDate(0, 1, -1)
+
+			charges, err := s.sub.FindCharges(s.ctx, userId, month, end)
+			s.Require().NoError(err)
+
+			var total int
+			for _, c := range charges {
+				total += c.Amount
+			}
+
+			price, err := s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{UserId: userId}, month, end)
+			s.Require().NoError(err)
+			s.Assert().Equal(price.Gross, total, "user %s, month %s", userId, month.Format("01-2006"))
+		}
+	}
+}
+
+func (s *pgdbTestSuite) TestSubscriptionRepo_FindPriceTrial() {
+	serviceId := s.serviceId("Netflix")
+
+	_, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     serviceId,
+		Price:         1000,
+		UserId:        s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+		TrialEndDate:  ptr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		IntroPrice:    ptr(500),
+		IntroPeriods:  2,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	testCases := []struct {
+		testName    string
+		end         time.Time
+		expectPrice int
+	}{
+		{
+			testName:    "within trial",
+			end:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectPrice: 0,
+		},
+		{
+			testName:    "within intro periods",
+			end:         time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectPrice: 500,
+		},
+		{
+			testName:    "after intro periods",
+			end:         time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			expectPrice: 1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.testName, func(t *testing.T) {
+			price, err := s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{ServiceId: serviceId}, tc.end, tc.end)
+
+			s.Assert().NoError(err)
+
+			s.Assert().Equal(tc.expectPrice, price.Gross)
+		})
+	}
+}
+
+func (s *pgdbTestSuite) TestSubscriptionRepo_FindPriceDiscount() {
+	serviceId := s.serviceId("Netflix")
+
+	_, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:         serviceId,
+		Price:             1000,
+		UserId:            s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+		StartDate:         time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		BillingPeriod:     dbmodel.BillingMonthly,
+		TaxRate:           20,
+		DiscountType:      ptr(dbmodel.DiscountPercent),
+		DiscountValue:     25,
+		DiscountStartDate: ptr(time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)),
+		DiscountMonths:    3,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	testCases := []struct {
+		testName    string
+		end         time.Time
+		expectPrice dbmodel.Price
+	}{
+		{
+			testName:    "before discount",
+			end:         time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			expectPrice: dbmodel.Price{Gross: 1200, Net: 1000, Tax: 200},
+		},
+		{
+			testName:    "tax on discounted price",
+			end:         time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+			expectPrice: dbmodel.Price{Gross: 900, Net: 750, Tax: 150, Discount: 250},
+		},
+		{
+			testName:    "discount is over",
+			end:         time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC),
+			expectPrice: dbmodel.Price{Gross: 1200, Net: 1000, Tax: 200},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.testName, func(t *testing.T) {
+			price, err := s.sub.FindPrice(s.ctx, dbmodel.SubscriptionFilter{ServiceId: serviceId}, tc.end, tc.end)
 
 			s.Assert().NoError(err)
 
@@ -253,11 +549,271 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_FindPrice() {
 	}
 }
 
+func (s *pgdbTestSuite) TestSubscriptionRepo_FindActive() {
+	subscriptions := []dbmodel.Subscription{
+		{
+			ServiceName:   "Yandex",
+			Price:         500,
+			UserId:        s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+			StartDate:     time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:       ptr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+			BillingPeriod: dbmodel.BillingMonthly,
+
+			OrganizationId: tenant.DefaultOrganization,
+			Timezone:       dbmodel.DefaultTimezone,
+		},
+		{
+			ServiceName:   "Yandex",
+			Price:         500,
+			UserId:        s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+			StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:       nil,
+			BillingPeriod: dbmodel.BillingMonthly,
+
+			OrganizationId: tenant.DefaultOrganization,
+			Timezone:       dbmodel.DefaultTimezone,
+		},
+		{
+			ServiceName:   "Google",
+			Price:         10000,
+			UserId:        s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a"),
+			StartDate:     time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:       ptr(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+			BillingPeriod: dbmodel.BillingYearly,
+
+			OrganizationId: tenant.DefaultOrganization,
+			Timezone:       dbmodel.DefaultTimezone,
+		},
+		{
+			ServiceName:   "VK",
+			Price:         400,
+			UserId:        s.userId("2344696a-d069-4fad-a3ed-f27c13651c3a"),
+			StartDate:     time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:       nil,
+			BillingPeriod: dbmodel.BillingMonthly,
+
+			OrganizationId: tenant.DefaultOrganization,
+			Timezone:       dbmodel.DefaultTimezone,
+		},
+	}
+
+	for i, sub := range subscriptions {
+		subscriptions[i].ServiceId = s.serviceId(sub.ServiceName)
+
+		sql, args, _ := s.pg.Builder.
+			Insert(subscriptionTable).
+			Columns("service_id", "price", "user_id", "start_date", "end_date", "billing_period").
+			Values(subscriptions[i].ServiceId, sub.Price, sub.UserId, sub.StartDate, sub.EndDate, sub.BillingPeriod).
+			Suffix("RETURNING id").
+			ToSql()
+
+		if err := s.pg.Pool.QueryRow(s.ctx, sql, args...).Scan(&subscriptions[i].Id); err != nil {
+			panic(err)
+		}
+	}
+
+	testCases := []struct {
+		testName     string
+		userId       string
+		date         time.Time
+		expectOutput []dbmodel.Subscription
+	}{
+		{
+			testName:     "all users",
+			userId:       "",
+			date:         time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectOutput: []dbmodel.Subscription{subscriptions[1], subscriptions[2], subscriptions[3]},
+		},
+		{
+			testName:     "by user",
+			userId:       "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			date:         time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectOutput: []dbmodel.Subscription{subscriptions[1], subscriptions[2]},
+		},
+		{
+			testName:     "end date is inclusive",
+			userId:       "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			date:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectOutput: []dbmodel.Subscription{subscriptions[0], subscriptions[1], subscriptions[2]},
+		},
+		{
+			testName:     "user without subscriptions",
+			userId:       "1111696a-d069-4fad-a3ed-f27c13651c3a",
+			date:         time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectOutput: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.testName, func(t *testing.T) {
+			actual, err := s.sub.FindActive(s.ctx, tc.userId, tc.date)
+
+			s.Assert().NoError(err)
+			s.Assert().Equal(tc.expectOutput, actual)
+		})
+	}
+}
+
+func (s *pgdbTestSuite) TestSubscriptionRepo_Filter() {
+	entertainment, err := s.category.Create(s.ctx, dbmodel.Category{Name: "Entertainment"})
+	if err != nil {
+		panic(err)
+	}
+	streaming, err := s.category.Create(s.ctx, dbmodel.Category{Name: "Streaming", ParentId: &entertainment})
+	if err != nil {
+		panic(err)
+	}
+
+	subscriptions := []dbmodel.Subscription{
+		{ServiceId: s.serviceId("Netflix"), Price: 800, CategoryId: &streaming, Tags: []string{"family", "video"}},
+		{ServiceId: s.serviceId("Steam"), Price: 300, CategoryId: &entertainment, Tags: []string{"games"}},
+		{ServiceId: s.serviceId("AWS"), Price: 2000},
+	}
+	for i, sub := range subscriptions {
+		sub.UserId = s.userId("6114696a-d069-4fad-a3ed-f27c13651c3a")
+		sub.StartDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		id, err := s.sub.Create(s.ctx, sub)
+		if err != nil {
+			panic(err)
+		}
+		if err = s.sub.SetTags(s.ctx, id, sub.Tags); err != nil {
+			panic(err)
+		}
+		subscriptions[i].Id = id
+	}
+
+	testCases := []struct {
+		testName    string
+		filter      dbmodel.SubscriptionFilter
+		expectIds   []int
+		expectPrice int
+	}{
+		{
+			testName:    "category with subcategories",
+			filter:      dbmodel.SubscriptionFilter{CategoryId: entertainment},
+			expectIds:   []int{subscriptions[0].Id, subscriptions[1].Id},
+			expectPrice: 1100,
+		},
+		{
+			testName:    "subcategory",
+			filter:      dbmodel.SubscriptionFilter{CategoryId: streaming},
+			expectIds:   []int{subscriptions[0].Id},
+			expectPrice: 800,
+		},
+		{
+			testName:    "tag",
+			filter:      dbmodel.SubscriptionFilter{Tag: "games"},
+			expectIds:   []int{subscriptions[1].Id},
+			expectPrice: 300,
+		},
+		{
+			testName:    "category and tag",
+			filter:      dbmodel.SubscriptionFilter{CategoryId: entertainment, Tag: "video"},
+			expectIds:   []int{subscriptions[0].Id},
+			expectPrice: 800,
+		},
+		{
+			testName:    "any of services",
+			filter:      dbmodel.SubscriptionFilter{ServiceIds: []int{s.serviceId("Steam"), s.serviceId("AWS")}},
+			expectIds:   []int{subscriptions[1].Id, subscriptions[2].Id},
+			expectPrice: 2300,
+		},
+		{
+			testName:    "any of users",
+			filter:      dbmodel.SubscriptionFilter{UserIds: []string{"6114696a-d069-4fad-a3ed-f27c13651c3a", "2344696a-d069-4fad-a3ed-f27c13651c3a"}},
+			expectIds:   []int{subscriptions[0].Id, subscriptions[1].Id, subscriptions[2].Id},
+			expectPrice: 3100,
+		},
+		{
+			// page is not applied to price
+			testName:    "page",
+			filter:      dbmodel.SubscriptionFilter{CategoryId: entertainment, AfterId: subscriptions[0].Id, Limit: 1},
+			expectIds:   []int{subscriptions[1].Id},
+			expectPrice: 1100,
+		},
+		{
+			testName:    "none of users",
+			filter:      dbmodel.SubscriptionFilter{UserIds: []string{}},
+			expectIds:   []int{},
+			expectPrice: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.testName, func(t *testing.T) {
+			all, err := s.sub.FindAll(s.ctx, tc.filter)
+			s.Assert().NoError(err)
+
+			ids := make([]int, 0, len(all))
+			for _, sub := range all {
+				ids = append(ids, sub.Id)
+			}
+			s.Assert().Equal(tc.expectIds, ids)
+
+			price, err := s.sub.FindPrice(s.ctx, tc.filter, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+			s.Assert().NoError(err)
+			s.Assert().Equal(tc.expectPrice, price.Gross)
+		})
+	}
+
+	// tags are replaced
+	s.Assert().NoError(s.sub.SetTags(s.ctx, subscriptions[0].Id, []string{"video"}))
+	sub, err := s.sub.FindById(s.ctx, subscriptions[0].Id)
+	s.Assert().NoError(err)
+	s.Assert().Equal([]string{"video"}, sub.Tags)
+}
+
+func (s *pgdbTestSuite) TestSubscriptionRepo_TagsOtherOrganization() {
+	orgId, err := s.org.Create(s.ctx, dbmodel.Organization{Name: "Acme"})
+	s.Require().NoError(err)
+
+	acme := tenant.WithOrganization(s.ctx, orgId)
+	def := tenant.WithOrganization(s.ctx, tenant.DefaultOrganization)
+
+	create := func(ctx context.Context, userId string) int {
+		s.Require().NoError(s.user.Ensure(ctx, userId))
+		serviceId, err := s.service.Create(ctx, dbmodel.Service{Name: "Netflix"})
+		s.Require().NoError(err)
+		id, err := s.sub.Create(ctx, dbmodel.Subscription{
+			ServiceId: serviceId,
+			Price:     800,
+			UserId:    userId,
+			StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		s.Require().NoError(err)
+		s.Require().NoError(s.sub.SetTags(ctx, id, []string{"family"}))
+		return id
+	}
+
+	defId := create(def, "6114696a-d069-4fad-a3ed-f27c13651c3a")
+	acmeId := create(acme, "60601fee-2bf1-4721-ae6f-7636e79a0cba")
+
+	// tag of the same name is created in each organization
+	var count int
+	s.Require().NoError(s.pg.Pool.QueryRow(s.ctx, "SELECT count(*) FROM tag WHERE name = 'family'").Scan(&count))
+	s.Assert().Equal(2, count)
+
+	for _, tc := range []struct {
+		ctx      context.Context
+		expectId int
+	}{
+		{ctx: def, expectId: defId},
+		{ctx: acme, expectId: acmeId},
+	} {
+		all, err := s.sub.FindAll(tc.ctx, dbmodel.SubscriptionFilter{Tag: "family"})
+		s.Assert().NoError(err)
+		s.Require().Len(all, 1)
+		s.Assert().Equal(tc.expectId, all[0].Id)
+		s.Assert().Equal([]string{"family"}, all[0].Tags)
+	}
+}
+
 func (s *pgdbTestSuite) TestSubscriptionRepo_Delete() {
 	sql, args, _ := s.pg.Builder.
 		Insert(subscriptionTable).
-		Columns("service_name", "price", "user_id", "start_date", "end_date").
-		Values("Yandex", 100, "6114696a-d069-4fad-a3ed-f27c13651c3a", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil).
+		Columns("service_id", "price", "user_id", "start_date", "end_date").
+		Values(s.serviceId("Yandex"), 100, "6114696a-d069-4fad-a3ed-f27c13651c3a", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil).
 		Suffix("RETURNING id").
 		ToSql()
 
@@ -307,3 +863,43 @@ func (s *pgdbTestSuite) TestSubscriptionRepo_Delete() {
 func ptr[T any](t T) *T {
 	return &t
 }
+
+func (s *pgdbTestSuite) TestSubscriptionRepo_Cancel() {
+	id, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId:     s.serviceId("Kinopoisk"),
+		Price:         300,
+		UserId:        s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	end := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	reason := "too expensive"
+	cancelledAt := time.Date(2025, 8, 15, 12, 0, 0, 0, time.UTC)
+
+	s.Assert().NoError(s.sub.Cancel(s.ctx, dbmodel.Subscription{Id: id, EndDate: &end, CancelReason: &reason, CancelledAt: &cancelledAt}))
+
+	sub, err := s.sub.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(&end, sub.EndDate)
+	s.Assert().Equal(&reason, sub.CancelReason)
+	s.Assert().Equal(&cancelledAt, sub.CancelledAt)
+
+	// update keeps cancellation while end date is the same
+	s.Assert().NoError(s.sub.Update(s.ctx, sub))
+	sub, err = s.sub.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(&reason, sub.CancelReason)
+
+	s.Assert().NoError(s.sub.Uncancel(s.ctx, id))
+	sub, err = s.sub.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Nil(sub.EndDate)
+	s.Assert().Nil(sub.CancelReason)
+	s.Assert().Nil(sub.CancelledAt)
+
+	s.Assert().ErrorIs(s.sub.Uncancel(s.ctx, id+1000), pgerrs.ErrNotFound)
+}
@@ -0,0 +1,41 @@
+package pgdb
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"subscription_service/pkg/tenant"
+)
+
+// tenantFilter restricts query to organization of ctx, column is organization_id of queried table.
+// Queries without organization in ctx, e.g. of background jobs, are not restricted
+func tenantFilter(ctx context.Context, column string) squirrel.Sqlizer {
+	if id, ok := tenant.Organization(ctx); ok {
+		return squirrel.Eq{column: id}
+	}
+	return squirrel.Eq{}
+}
+
+// tenantArg is organization of ctx as argument of raw queries, nil if there is no one
+func tenantArg(ctx context.Context) any {
+	if id, ok := tenant.Organization(ctx); ok {
+		return id
+	}
+	return nil
+}
+
+// tenantUserFilter restricts query to rows of users of organization of ctx, column is user id of queried table
+func tenantUserFilter(ctx context.Context, column string) squirrel.Sqlizer {
+	if id, ok := tenant.Organization(ctx); ok {
+		return squirrel.Expr(column+" IN (SELECT id FROM users WHERE organization_id = ?)", id)
+	}
+	return squirrel.Eq{}
+}
+
+// tenantSubscriptionFilter restricts query to rows of subscriptions of organization of ctx, column is subscription id
+// of queried table
+func tenantSubscriptionFilter(ctx context.Context, column string) squirrel.Sqlizer {
+	if id, ok := tenant.Organization(ctx); ok {
+		return squirrel.Expr(column+" IN (SELECT id FROM subscription WHERE organization_id = ?)", id)
+	}
+	return squirrel.Eq{}
+}
@@ -0,0 +1,152 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+)
+
+const (
+	userTable = "users"
+)
+
+type UserRepo struct {
+	*postgres.Postgres
+}
+
+func NewUserRepo(pg *postgres.Postgres) *UserRepo {
+	return &UserRepo{pg}
+}
+
+// Create inserts user with generated id
+func (r *UserRepo) Create(ctx context.Context, u dbmodel.User) (string, error) {
+	sql, args, _ := r.Builder.
+		Insert(userTable).
+		Columns("email", "display_name", "timezone", "currency", "organization_id").
+		Values(u.Email, u.DisplayName, u.Timezone, u.Currency, tenant.OrganizationOrDefault(ctx)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id string
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return "", constraintErr(err)
+	}
+	return id, nil
+}
+
+// Ensure creates user with default settings in organization of ctx unless user with id already exists.
+// It returns pgerrs.ErrNotFound if user belongs to another organization
+func (r *UserRepo) Ensure(ctx context.Context, id string) error {
+	sql, args, _ := r.Builder.
+		Insert(userTable).
+		Columns("id", "organization_id").
+		Values(id, tenant.OrganizationOrDefault(ctx)).
+		Suffix("ON CONFLICT (id) DO UPDATE SET id = excluded.id WHERE users.organization_id = excluded.organization_id RETURNING id").
+		ToSql()
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgerrs.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *UserRepo) FindById(ctx context.Context, id string) (dbmodel.User, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "email", "display_name", "timezone", "currency", "created_at").
+		From(userTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	var u dbmodel.User
+
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&u.Id,
+		&u.Email,
+		&u.DisplayName,
+		&u.Timezone,
+		&u.Currency,
+		&u.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.User{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.User{}, err
+	}
+	return u, nil
+}
+
+func (r *UserRepo) FindAll(ctx context.Context) ([]dbmodel.User, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "email", "display_name", "timezone", "currency", "created_at").
+		From(userTable).
+		Where(tenantFilter(ctx, "organization_id")).
+		OrderBy("created_at", "id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.User
+
+	for rows.Next() {
+		var u dbmodel.User
+
+		if err = rows.Scan(&u.Id, &u.Email, &u.DisplayName, &u.Timezone, &u.Currency, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+	return result, nil
+}
+
+func (r *UserRepo) Update(ctx context.Context, u dbmodel.User) error {
+	sql, args, _ := r.Builder.
+		Update(userTable).
+		Set("email", u.Email).
+		Set("display_name", u.DisplayName).
+		Set("timezone", u.Timezone).
+		Set("currency", u.Currency).
+		Where("id = ?", u.Id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return constraintErr(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes user. It fails with pgerrs.ErrReferenced while user has subscriptions
+func (r *UserRepo) Delete(ctx context.Context, id string) error {
+	sql, args, _ := r.Builder.
+		Delete(userTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return constraintErr(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+// userId makes sure user with id exists, subscriptions reference users
+func (s *pgdbTestSuite) userId(id string) string {
+	if err := s.user.Ensure(s.ctx, id); err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func (s *pgdbTestSuite) TestUserRepo_Create() {
+	u := dbmodel.User{
+		Email:       ptr("user@example.com"),
+		DisplayName: ptr("User"),
+		Timezone:    "Europe/Moscow",
+		Currency:    "USD",
+	}
+
+	id, err := s.user.Create(s.ctx, u)
+	s.Assert().NoError(err)
+	s.Assert().NotEmpty(id)
+
+	actual, err := s.user.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	u.Id, u.CreatedAt = id, actual.CreatedAt
+	s.Assert().Equal(u, actual)
+
+	_, err = s.user.Create(s.ctx, dbmodel.User{
+		Email:    ptr("USER@example.com"),
+		Timezone: dbmodel.DefaultTimezone,
+		Currency: dbmodel.DefaultCurrency,
+	})
+	s.Assert().Equal(pgerrs.ErrAlreadyExists, err)
+}
+
+func (s *pgdbTestSuite) TestUserRepo_Ensure() {
+	id := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	s.Assert().NoError(s.user.Ensure(s.ctx, id))
+	s.Assert().NoError(s.user.Ensure(s.ctx, id))
+
+	actual, err := s.user.FindById(s.ctx, id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(dbmodel.DefaultTimezone, actual.Timezone)
+	s.Assert().Equal(dbmodel.DefaultCurrency, actual.Currency)
+}
+
+func (s *pgdbTestSuite) TestUserRepo_Delete() {
+	id := s.userId("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+
+	subId, err := s.sub.Create(s.ctx, dbmodel.Subscription{
+		ServiceId: s.serviceId("Yandex Plus"),
+		Price:     400,
+		UserId:    id,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	s.Assert().Equal(pgerrs.ErrReferenced, s.user.Delete(s.ctx, id))
+	s.Assert().NoError(s.sub.Delete(s.ctx, subId))
+	s.Assert().NoError(s.user.Delete(s.ctx, id))
+	s.Assert().Equal(pgerrs.ErrNotFound, s.user.Delete(s.ctx, id))
+}
@@ -0,0 +1,161 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"subscription_service/pkg/tenant"
+)
+
+const (
+	webhookTable = "webhook"
+)
+
+type WebhookRepo struct {
+	*postgres.Postgres
+}
+
+func NewWebhookRepo(pg *postgres.Postgres) *WebhookRepo {
+	return &WebhookRepo{pg}
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, w dbmodel.Webhook) (int, error) {
+	sql, args, _ := r.Builder.
+		Insert(webhookTable).
+		Columns("url", "secret", "events", "organization_id").
+		Values(w.Url, w.Secret, nonNil(w.Events), tenant.OrganizationOrDefault(ctx)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id int
+
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *WebhookRepo) FindById(ctx context.Context, id int) (dbmodel.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "url", "secret", "events", "created_at").
+		From(webhookTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	var w dbmodel.Webhook
+
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&w.Id,
+		&w.Url,
+		&w.Secret,
+		&w.Events,
+		&w.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.Webhook{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.Webhook{}, err
+	}
+	return w, nil
+}
+
+func (r *WebhookRepo) FindAll(ctx context.Context) ([]dbmodel.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "url", "secret", "events", "created_at").
+		From(webhookTable).
+		Where(tenantFilter(ctx, "organization_id")).
+		OrderBy("id").
+		ToSql()
+
+	return r.findMany(ctx, sql, args...)
+}
+
+// FindByEvent returns webhooks subscribed to eventType, including webhooks without events filter
+func (r *WebhookRepo) FindByEvent(ctx context.Context, eventType string) ([]dbmodel.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Select("id", "url", "secret", "events", "created_at").
+		From(webhookTable).
+		Where("(cardinality(events) = 0 OR ? = ANY(events))", eventType).
+		Where(tenantFilter(ctx, "organization_id")).
+		OrderBy("id").
+		ToSql()
+
+	return r.findMany(ctx, sql, args...)
+}
+
+func (r *WebhookRepo) Update(ctx context.Context, w dbmodel.Webhook) error {
+	sql, args, _ := r.Builder.
+		Update(webhookTable).
+		Set("url", w.Url).
+		Set("secret", w.Secret).
+		Set("events", nonNil(w.Events)).
+		Where("id = ?", w.Id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) Delete(ctx context.Context, id int) error {
+	sql, args, _ := r.Builder.
+		Delete(webhookTable).
+		Where("id = ?", id).
+		Where(tenantFilter(ctx, "organization_id")).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) findMany(ctx context.Context, sql string, args ...any) ([]dbmodel.Webhook, error) {
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.Webhook
+
+	for rows.Next() {
+		var w dbmodel.Webhook
+
+		err = rows.Scan(
+			&w.Id,
+			&w.Url,
+			&w.Secret,
+			&w.Events,
+			&w.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+	return result, nil
+}
+
+// nonNil prevents inserting NULL into not null array column
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,174 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"sort"
+	"strings"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/postgres"
+	"time"
+)
+
+const (
+	webhookDeliveryTable = "webhook_delivery"
+)
+
+var webhookDeliveryColumns = []string{
+	"id",
+	"webhook_id",
+	"event_id",
+	"event_type",
+	"payload",
+	"status",
+	"attempts",
+	"last_status_code",
+	"last_error",
+	"next_attempt_at",
+	"created_at",
+	"delivered_at",
+}
+
+type WebhookDeliveryRepo struct {
+	*postgres.Postgres
+}
+
+func NewWebhookDeliveryRepo(pg *postgres.Postgres) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{pg}
+}
+
+// Create adds delivery of event to webhook. Repeated delivery of the same event is ignored
+func (r *WebhookDeliveryRepo) Create(ctx context.Context, d dbmodel.WebhookDelivery) error {
+	sql, args, _ := r.Builder.
+		Insert(webhookDeliveryTable).
+		Columns("webhook_id", "event_id", "event_type", "payload").
+		Values(d.WebhookId, d.EventId, d.EventType, d.Payload).
+		Suffix("ON CONFLICT (webhook_id, event_id) DO NOTHING").
+		ToSql()
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepo) FindById(ctx context.Context, id int64) (dbmodel.WebhookDelivery, error) {
+	sql, args, _ := r.Builder.
+		Select(webhookDeliveryColumns...).
+		From(webhookDeliveryTable).
+		Where("id = ?", id).
+		ToSql()
+
+	d, err := scanWebhookDelivery(r.Conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbmodel.WebhookDelivery{}, pgerrs.ErrNotFound
+		}
+		return dbmodel.WebhookDelivery{}, err
+	}
+	return d, nil
+}
+
+// FindByWebhook returns deliveries of webhook, newest first
+func (r *WebhookDeliveryRepo) FindByWebhook(ctx context.Context, webhookId int, limit int) ([]dbmodel.WebhookDelivery, error) {
+	sql, args, _ := r.Builder.
+		Select(webhookDeliveryColumns...).
+		From(webhookDeliveryTable).
+		Where("webhook_id = ?", webhookId).
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	return r.findMany(ctx, sql, args...)
+}
+
+// Claim takes pending deliveries which are due to be sent and postpones their next attempt by lease, so
+// concurrent dispatchers skip them while they are sent. Claimed delivery not updated within lease, e.g. because
+// dispatcher crashed, is taken again
+func (r *WebhookDeliveryRepo) Claim(ctx context.Context, limit int, lease time.Duration) ([]dbmodel.WebhookDelivery, error) {
+	due := squirrel.
+		Select("id").
+		From(webhookDeliveryTable).
+		Where("status = ? AND next_attempt_at <= now()", dbmodel.DeliveryPending).
+		OrderBy("next_attempt_at", "id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, _ := r.Builder.
+		Update(webhookDeliveryTable).
+		Set("next_attempt_at", squirrel.Expr("now() + make_interval(secs => ?)", lease.Seconds())).
+		Where(squirrel.Expr("id IN (?)", due)).
+		Suffix("RETURNING " + strings.Join(webhookDeliveryColumns, ", ")).
+		ToSql()
+
+	deliveries, err := r.findMany(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].Id < deliveries[j].Id })
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepo) UpdateStatus(ctx context.Context, d dbmodel.WebhookDelivery) error {
+	sql, args, _ := r.Builder.
+		Update(webhookDeliveryTable).
+		Set("status", d.Status).
+		Set("attempts", d.Attempts).
+		Set("last_status_code", d.LastStatusCode).
+		Set("last_error", d.LastError).
+		Set("next_attempt_at", d.NextAttemptAt).
+		Set("delivered_at", d.DeliveredAt).
+		Where("id = ?", d.Id).
+		ToSql()
+
+	result, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgerrs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepo) findMany(ctx context.Context, sql string, args ...any) ([]dbmodel.WebhookDelivery, error) {
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dbmodel.WebhookDelivery
+
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+func scanWebhookDelivery(row pgx.Row) (dbmodel.WebhookDelivery, error) {
+	var d dbmodel.WebhookDelivery
+
+	err := row.Scan(
+		&d.Id,
+		&d.WebhookId,
+		&d.EventId,
+		&d.EventType,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.NextAttemptAt,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	)
+	return d, err
+}
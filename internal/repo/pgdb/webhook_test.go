@@ -0,0 +1,110 @@
+package pgdb
+
+import (
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+	"time"
+)
+
+func (s *pgdbTestSuite) TestWebhookRepo_FindByEvent() {
+	webhooks := []dbmodel.Webhook{
+		{Url: "https://example.com/all", Secret: "secret"},
+		{Url: "https://example.com/created", Secret: "secret", Events: []string{dbmodel.EventSubscriptionCreated}},
+		{Url: "https://example.com/deleted", Secret: "secret", Events: []string{dbmodel.EventSubscriptionDeleted, dbmodel.EventSubscriptionRenewal}},
+	}
+	for i, w := range webhooks {
+		id, err := s.webhook.Create(s.ctx, w)
+		if err != nil {
+			panic(err)
+		}
+		webhooks[i].Id = id
+	}
+
+	testCases := []struct {
+		testName  string
+		eventType string
+		expectIds []int
+	}{
+		{
+			testName:  "created",
+			eventType: dbmodel.EventSubscriptionCreated,
+			expectIds: []int{webhooks[0].Id, webhooks[1].Id},
+		},
+		{
+			testName:  "renewal due",
+			eventType: dbmodel.EventSubscriptionRenewal,
+			expectIds: []int{webhooks[0].Id, webhooks[2].Id},
+		},
+		{
+			testName:  "updated",
+			eventType: dbmodel.EventSubscriptionUpdated,
+			expectIds: []int{webhooks[0].Id},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.testName, func(t *testing.T) {
+			actual, err := s.webhook.FindByEvent(s.ctx, tc.eventType)
+
+			s.Assert().NoError(err)
+
+			var ids []int
+			for _, w := range actual {
+				ids = append(ids, w.Id)
+			}
+			s.Assert().Equal(tc.expectIds, ids)
+		})
+	}
+}
+
+func (s *pgdbTestSuite) TestWebhookDeliveryRepo() {
+	webhookId, err := s.webhook.Create(s.ctx, dbmodel.Webhook{Url: "https://example.com", Secret: "secret"})
+	if err != nil {
+		panic(err)
+	}
+
+	d := dbmodel.WebhookDelivery{
+		WebhookId: webhookId,
+		EventId:   1,
+		EventType: dbmodel.EventSubscriptionCreated,
+		Payload:   []byte(`{"id": 1}`),
+	}
+	s.Assert().NoError(s.delivery.Create(s.ctx, d))
+	// the same event is enqueued only once
+	s.Assert().NoError(s.delivery.Create(s.ctx, d))
+
+	due, err := s.delivery.Claim(s.ctx, 10, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Len(due, 1)
+	s.Assert().Equal(dbmodel.DeliveryPending, due[0].Status)
+	s.Assert().True(due[0].NextAttemptAt.After(time.Now()))
+
+	// claimed delivery is not taken again within lease
+	claimed, err := s.delivery.Claim(s.ctx, 10, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Empty(claimed)
+
+	delivery := due[0]
+	delivery.Attempts = 1
+	delivery.LastStatusCode = ptr(500)
+	delivery.LastError = ptr("unexpected response status 500")
+	delivery.NextAttemptAt = time.Now().Add(time.Hour)
+	s.Assert().NoError(s.delivery.UpdateStatus(s.ctx, delivery))
+
+	due, err = s.delivery.Claim(s.ctx, 10, time.Minute)
+	s.Assert().NoError(err)
+	s.Assert().Empty(due)
+
+	log, err := s.delivery.FindByWebhook(s.ctx, webhookId, 10)
+	s.Assert().NoError(err)
+	s.Assert().Len(log, 1)
+	s.Assert().Equal(1, log[0].Attempts)
+	s.Assert().Equal(ptr(500), log[0].LastStatusCode)
+
+	// deliveries are removed with webhook
+	s.Assert().NoError(s.webhook.Delete(s.ctx, webhookId))
+
+	_, err = s.delivery.FindById(s.ctx, delivery.Id)
+	s.Assert().Equal(pgerrs.ErrNotFound, err)
+}
@@ -3,5 +3,7 @@ package pgerrs
 import "errors"
 
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrReferenced    = errors.New("referenced by other rows")
 )
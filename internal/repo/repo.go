@@ -8,21 +8,180 @@ import (
 	"time"
 )
 
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Subscription interface {
-	Create(ctx context.Context, s dbmodel.Subscription) error
+	Create(ctx context.Context, s dbmodel.Subscription) (int, error)
 	FindById(ctx context.Context, id int) (dbmodel.Subscription, error)
-	FindAll(ctx context.Context) ([]dbmodel.Subscription, error)
-	FindPrice(ctx context.Context, service, userId string, start, end time.Time) (int, error)
+	FindAll(ctx context.Context, f dbmodel.SubscriptionFilter) ([]dbmodel.Subscription, error)
+	FindActive(ctx context.Context, userId string, date time.Time) ([]dbmodel.Subscription, error)
+	FindPrice(ctx context.Context, f dbmodel.SubscriptionFilter, start, end time.Time) (dbmodel.Price, error)
+	FindUserPrices(ctx context.Context, userIds []string, start, end time.Time) (map[string]dbmodel.Price, error)
+	FindCharges(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.SubscriptionCharge, error)
 	Update(ctx context.Context, s dbmodel.Subscription) error
+	Cancel(ctx context.Context, s dbmodel.Subscription) error
+	Uncancel(ctx context.Context, id int) error
+	SetTags(ctx context.Context, subscriptionId int, tags []string) error
+	Delete(ctx context.Context, id int) error
+}
+
+type Share interface {
+	Set(ctx context.Context, split dbmodel.Split) error
+	FindBySubscription(ctx context.Context, subscriptionId int) (dbmodel.Split, error)
+	FindDebts(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.ShareDebt, error)
+	Delete(ctx context.Context, subscriptionId int) error
+}
+
+type Service interface {
+	Create(ctx context.Context, s dbmodel.Service) (int, error)
+	FindById(ctx context.Context, id int) (dbmodel.Service, error)
+	FindByName(ctx context.Context, name string) (dbmodel.Service, error)
+	FindAll(ctx context.Context) ([]dbmodel.Service, error)
+	Update(ctx context.Context, s dbmodel.Service) error
+	Delete(ctx context.Context, id int) error
+}
+
+type Organization interface {
+	Create(ctx context.Context, o dbmodel.Organization) (int, error)
+	FindById(ctx context.Context, id int) (dbmodel.Organization, error)
+	FindAll(ctx context.Context) ([]dbmodel.Organization, error)
+}
+
+type User interface {
+	Create(ctx context.Context, u dbmodel.User) (string, error)
+	Ensure(ctx context.Context, id string) error
+	FindById(ctx context.Context, id string) (dbmodel.User, error)
+	FindAll(ctx context.Context) ([]dbmodel.User, error)
+	Update(ctx context.Context, u dbmodel.User) error
+	Delete(ctx context.Context, id string) error
+}
+
+type Category interface {
+	Create(ctx context.Context, c dbmodel.Category) (int, error)
+	FindById(ctx context.Context, id int) (dbmodel.Category, error)
+	FindAll(ctx context.Context) ([]dbmodel.Category, error)
+	Update(ctx context.Context, c dbmodel.Category) error
+	Delete(ctx context.Context, id int) error
+}
+
+type Outbox interface {
+	Create(ctx context.Context, e dbmodel.OutboxEvent) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]dbmodel.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, reason string, nextAttempt time.Time) error
+}
+
+type Webhook interface {
+	Create(ctx context.Context, w dbmodel.Webhook) (int, error)
+	FindById(ctx context.Context, id int) (dbmodel.Webhook, error)
+	FindAll(ctx context.Context) ([]dbmodel.Webhook, error)
+	FindByEvent(ctx context.Context, eventType string) ([]dbmodel.Webhook, error)
+	Update(ctx context.Context, w dbmodel.Webhook) error
 	Delete(ctx context.Context, id int) error
 }
 
+type WebhookDelivery interface {
+	Create(ctx context.Context, d dbmodel.WebhookDelivery) error
+	FindById(ctx context.Context, id int64) (dbmodel.WebhookDelivery, error)
+	FindByWebhook(ctx context.Context, webhookId int, limit int) ([]dbmodel.WebhookDelivery, error)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]dbmodel.WebhookDelivery, error)
+	UpdateStatus(ctx context.Context, d dbmodel.WebhookDelivery) error
+}
+
+type Reminder interface {
+	FindSettings(ctx context.Context, userId string) (dbmodel.ReminderSettings, error)
+	FindAllSettings(ctx context.Context) ([]dbmodel.ReminderSettings, error)
+	UpsertSettings(ctx context.Context, s dbmodel.ReminderSettings) error
+	Create(ctx context.Context, r dbmodel.Reminder) (bool, error)
+	Claim(ctx context.Context, r dbmodel.Reminder, lease time.Duration) (int64, error)
+	MarkSent(ctx context.Context, id int64) error
+}
+
+type Budget interface {
+	Create(ctx context.Context, b dbmodel.Budget) (int, error)
+	FindById(ctx context.Context, id int) (dbmodel.Budget, error)
+	FindAll(ctx context.Context, userId string) ([]dbmodel.Budget, error)
+	Update(ctx context.Context, b dbmodel.Budget) error
+	Delete(ctx context.Context, id int) error
+	CreateAlert(ctx context.Context, a dbmodel.BudgetAlert) (bool, error)
+}
+
+type PriceChange interface {
+	Create(ctx context.Context, c dbmodel.PriceChange) (int, error)
+	FindBySubscriptions(ctx context.Context, subscriptionIds []int) ([]dbmodel.PriceChange, error)
+	FindDue(ctx context.Context, date time.Time) ([]dbmodel.PriceChange, error)
+	MarkApplied(ctx context.Context, subscriptionId int, date time.Time) error
+	Delete(ctx context.Context, subscriptionId, id int) error
+}
+
+type Pause interface {
+	Create(ctx context.Context, p dbmodel.Pause) (int, error)
+	Update(ctx context.Context, p dbmodel.Pause) error
+	Delete(ctx context.Context, subscriptionId, id int) error
+}
+
+type Charge interface {
+	Create(ctx context.Context, charges []dbmodel.Charge) (int, error)
+	FindById(ctx context.Context, id int64) (dbmodel.Charge, error)
+	FindAll(ctx context.Context, f dbmodel.ChargeFilter) ([]dbmodel.Charge, error)
+	UpdateStatus(ctx context.Context, id int64, from, to string) error
+	FindLastBillingDates(ctx context.Context, subscriptionIds []int) (map[int]time.Time, error)
+	FindAmount(ctx context.Context, f dbmodel.SubscriptionFilter, start, end time.Time) (dbmodel.Price, error)
+}
+
+type Anomaly interface {
+	FindPrices(ctx context.Context, date time.Time) ([]dbmodel.SubscriptionPrice, error)
+	Create(ctx context.Context, a dbmodel.PriceAnomaly) (bool, error)
+	FindAll(ctx context.Context, userId string) ([]dbmodel.PriceAnomaly, error)
+}
+
+type Analytics interface {
+	RecurringSpend(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.RecurringSpend, error)
+	Movements(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.Movement, error)
+	ServiceStats(ctx context.Context, userId string, start, end time.Time, limit int) ([]dbmodel.ServiceStats, error)
+	CategoryStats(ctx context.Context, userId string, start, end time.Time) ([]dbmodel.CategoryStats, error)
+}
+
 type Repositories struct {
+	Transactor
+	Organization
+	User
 	Subscription
+	Share
+	Service
+	Category
+	Outbox
+	Webhook
+	WebhookDelivery
+	Reminder
+	Budget
+	PriceChange
+	Pause
+	Charge
+	Anomaly
+	Analytics
 }
 
 func NewRepositories(pg *postgres.Postgres) *Repositories {
 	return &Repositories{
-		Subscription: pgdb.NewSubscriptionRepo(pg),
+		Transactor:      pg,
+		Organization:    pgdb.NewOrganizationRepo(pg),
+		User:            pgdb.NewUserRepo(pg),
+		Subscription:    pgdb.NewSubscriptionRepo(pg),
+		Share:           pgdb.NewShareRepo(pg),
+		Service:         pgdb.NewServiceRepo(pg),
+		Category:        pgdb.NewCategoryRepo(pg),
+		Outbox:          pgdb.NewOutboxRepo(pg),
+		Webhook:         pgdb.NewWebhookRepo(pg),
+		WebhookDelivery: pgdb.NewWebhookDeliveryRepo(pg),
+		Reminder:        pgdb.NewReminderRepo(pg),
+		Budget:          pgdb.NewBudgetRepo(pg),
+		PriceChange:     pgdb.NewPriceChangeRepo(pg),
+		Pause:           pgdb.NewPauseRepo(pg),
+		Charge:          pgdb.NewChargeRepo(pg),
+		Anomaly:         pgdb.NewAnomalyRepo(pg),
+		Analytics:       pgdb.NewAnalyticsRepo(pg),
 	}
 }
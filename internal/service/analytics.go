@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"strings"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+)
+
+type analyticsService struct {
+	analytics repo.Analytics
+	category  repo.Category
+}
+
+func newAnalyticsService(analytics repo.Analytics, category repo.Category) *analyticsService {
+	return &analyticsService{
+		analytics: analytics,
+		category:  category,
+	}
+}
+
+func (s *analyticsService) RecurringSpend(ctx context.Context, input AnalyticsInput) ([]RecurringSpendOutput, error) {
+	spend, err := s.analytics.RecurringSpend(ctx, input.UserId, monthStart(input.StartDate), monthStart(input.EndDate))
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("analytics/RecurringSpend error find recurring spend in database")
+		return nil, err
+	}
+	result := make([]RecurringSpendOutput, 0, len(spend))
+	for _, m := range spend {
+		result = append(result, RecurringSpendOutput{
+			Month:  formatMonth(m.Month),
+			Active: m.Active,
+			Amount: m.Amount,
+		})
+	}
+	return result, nil
+}
+
+// Growth returns new and churned subscriptions per month, net change of month is their difference
+func (s *analyticsService) Growth(ctx context.Context, input AnalyticsInput) ([]GrowthOutput, error) {
+	movements, err := s.analytics.Movements(ctx, input.UserId, monthStart(input.StartDate), monthStart(input.EndDate))
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("analytics/Growth error find movements in database")
+		return nil, err
+	}
+
+	result := make([]GrowthOutput, 0, len(movements))
+	for _, m := range movements {
+		result = append(result, GrowthOutput{
+			Month:   formatMonth(m.Month),
+			New:     m.New,
+			Churned: m.Churned,
+			Net:     m.New - m.Churned,
+		})
+	}
+	return result, nil
+}
+
+func (s *analyticsService) Services(ctx context.Context, input AnalyticsInput, limit int) ([]ServiceStatsOutput, error) {
+	stats, err := s.analytics.ServiceStats(ctx, input.UserId, monthStart(input.StartDate), monthStart(input.EndDate), limit)
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("analytics/Services error find service stats in database")
+		return nil, err
+	}
+	result := make([]ServiceStatsOutput, 0, len(stats))
+	for _, st := range stats {
+		result = append(result, ServiceStatsOutput{
+			ServiceName:   st.ServiceName,
+			Subscriptions: st.Subscriptions,
+			AvgPrice:      st.AvgPrice,
+			Spend:         st.Spend,
+		})
+	}
+	return result, nil
+}
+
+// Categories returns spend per category ordered by total spend. Spend of subcategory is added to total spend
+// of all its parents, so parents without own subscriptions are reported as well
+func (s *analyticsService) Categories(ctx context.Context, input AnalyticsInput) ([]CategoryStatsOutput, error) {
+	stats, err := s.analytics.CategoryStats(ctx, input.UserId, monthStart(input.StartDate), monthStart(input.EndDate))
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("analytics/Categories error find category stats in database")
+		return nil, err
+	}
+	categories, err := s.category.FindAll(ctx)
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("analytics/Categories error find categories in database")
+		return nil, err
+	}
+
+	byId := make(map[int]dbmodel.Category, len(categories))
+	for _, c := range categories {
+		byId[c.Id] = c
+	}
+
+	outputs := make(map[int]*CategoryStatsOutput)
+	output := func(id int) *CategoryStatsOutput {
+		if o, ok := outputs[id]; ok {
+			return o
+		}
+		c := byId[id]
+		o := &CategoryStatsOutput{CategoryId: ptr(id), Name: c.Name, ParentId: c.ParentId}
+		outputs[id] = o
+		return o
+	}
+
+	result := make([]CategoryStatsOutput, 0, len(stats))
+
+	for _, st := range stats {
+		if st.CategoryId == nil {
+			result = append(result, CategoryStatsOutput{
+				Subscriptions: st.Subscriptions,
+				Spend:         st.Spend,
+				TotalSpend:    st.Spend,
+			})
+			continue
+		}
+		o := output(*st.CategoryId)
+		o.Subscriptions = st.Subscriptions
+		o.Spend = st.Spend
+
+		// categories is a tree, the limit only protects from endless loop on inconsistent data
+		for id, depth := st.CategoryId, 0; id != nil && depth <= len(categories); depth++ {
+			p := output(*id)
+			p.TotalSpend += st.Spend
+			id = p.ParentId
+		}
+	}
+	for _, o := range outputs {
+		result = append(result, *o)
+	}
+	slices.SortFunc(result, func(a, b CategoryStatsOutput) int {
+		if a.TotalSpend != b.TotalSpend {
+			return b.TotalSpend - a.TotalSpend
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result, nil
+}
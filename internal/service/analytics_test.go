@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsService_Growth(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input AnalyticsInput
+	}
+
+	type mockBehaviour func(analytics *repomocks.MockAnalytics, a args)
+
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  []GrowthOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				input: AnalyticsInput{
+					UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+					StartDate: july,
+					EndDate:   august,
+				},
+			},
+			mockBehaviour: func(analytics *repomocks.MockAnalytics, a args) {
+				analytics.EXPECT().Movements(a.ctx, a.input.UserId, july, august).Return([]dbmodel.Movement{
+					{Month: july, New: 3, Churned: 1},
+					{Month: august, New: 0, Churned: 2},
+				}, nil)
+			},
+			expectOutput: []GrowthOutput{
+				{Month: "07-2025", New: 3, Churned: 1, Net: 2},
+				{Month: "08-2025", New: 0, Churned: 2, Net: -2},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: context.Background(),
+				input: AnalyticsInput{
+					StartDate: july,
+					EndDate:   august,
+				},
+			},
+			mockBehaviour: func(analytics *repomocks.MockAnalytics, a args) {
+				analytics.EXPECT().Movements(a.ctx, "", july, august).Return(nil, errors.New("some error"))
+			},
+			expectOutput: nil,
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			analytics := repomocks.NewMockAnalytics(ctrl)
+			tc.mockBehaviour(analytics, tc.args)
+
+			s := newAnalyticsService(analytics, nil)
+
+			output, err := s.Growth(tc.args.ctx, tc.args.input)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+func TestAnalyticsService_RecurringSpend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	analytics := repomocks.NewMockAnalytics(ctrl)
+	analytics.EXPECT().RecurringSpend(gomock.Any(), "", july, july).Return([]dbmodel.RecurringSpend{
+		{Month: july, Active: 2, Amount: 500},
+	}, nil)
+
+	s := newAnalyticsService(analytics, nil)
+
+	output, err := s.RecurringSpend(context.Background(), AnalyticsInput{StartDate: july, EndDate: july})
+	assert.NoError(t, err)
+	assert.Equal(t, []RecurringSpendOutput{{Month: "07-2025", Active: 2, Amount: 500}}, output)
+}
+
+func TestAnalyticsService_Categories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	analytics := repomocks.NewMockAnalytics(ctrl)
+	analytics.EXPECT().CategoryStats(gomock.Any(), "", july, july).Return([]dbmodel.CategoryStats{
+		{CategoryId: ptr(1), Subscriptions: 1, Spend: 300},
+		{CategoryId: ptr(2), Subscriptions: 2, Spend: 1000},
+		{CategoryId: ptr(3), Subscriptions: 1, Spend: 500},
+		{CategoryId: nil, Subscriptions: 1, Spend: 200},
+	}, nil)
+
+	category := repomocks.NewMockCategory(ctrl)
+	category.EXPECT().FindAll(gomock.Any()).Return([]dbmodel.Category{
+		{Id: 1, Name: "entertainment"},
+		{Id: 2, Name: "streaming", ParentId: ptr(1)},
+		{Id: 3, Name: "music", ParentId: ptr(1)},
+		{Id: 4, Name: "cloud"},
+	}, nil)
+
+	s := newAnalyticsService(analytics, category)
+
+	output, err := s.Categories(context.Background(), AnalyticsInput{StartDate: july, EndDate: july})
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryStatsOutput{
+		{CategoryId: ptr(1), Name: "entertainment", Subscriptions: 1, Spend: 300, TotalSpend: 1800},
+		{CategoryId: ptr(2), Name: "streaming", ParentId: ptr(1), Subscriptions: 2, Spend: 1000, TotalSpend: 1000},
+		{CategoryId: ptr(3), Name: "music", ParentId: ptr(1), Subscriptions: 1, Spend: 500, TotalSpend: 500},
+		{Subscriptions: 1, Spend: 200, TotalSpend: 200},
+	}, output)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+const (
+	defaultAnomalyThreshold = 20
+
+	// minAnomalyPeers is the least number of subscriptions of service median price is computed from
+	minAnomalyPeers = 3
+)
+
+type anomalyService struct {
+	tx        repo.Transactor
+	anomaly   repo.Anomaly
+	outbox    repo.Outbox
+	threshold int
+}
+
+func newAnomalyService(tx repo.Transactor, anomaly repo.Anomaly, outbox repo.Outbox, threshold int) *anomalyService {
+	if threshold <= 0 {
+		threshold = defaultAnomalyThreshold
+	}
+	return &anomalyService{
+		tx:        tx,
+		anomaly:   anomaly,
+		outbox:    outbox,
+		threshold: threshold,
+	}
+}
+
+// Detect compares price of every subscription active on date with its previous price and with median price
+// of the same service, billing period and currency among subscriptions of organization. Prices above them by more
+// than threshold percent are recorded and subscription.price_anomaly event is emitted for every new anomaly.
+// Returns number of emitted events
+func (s *anomalyService) Detect(ctx context.Context, date time.Time) (int, error) {
+	prices, err := s.anomaly.FindPrices(ctx, truncateToDay(date))
+	if err != nil {
+		log.Err(err).Msg("anomaly/Detect error find prices in database")
+		return 0, err
+	}
+
+	var anomalies []dbmodel.PriceAnomaly
+
+	medians := medianPrices(prices)
+	for _, p := range prices {
+		if previous, ok := previousPrice(p); ok {
+			anomalies = s.appendAnomaly(anomalies, p, dbmodel.AnomalyIncrease, previous)
+		}
+		if median, ok := medians[newPeerKey(p)]; ok {
+			anomalies = s.appendAnomaly(anomalies, p, dbmodel.AnomalyOutlier, median)
+		}
+	}
+
+	var detected int
+
+	for _, a := range anomalies {
+		// anomaly belongs to organization of subscription
+		created, err := s.create(tenant.WithOrganization(ctx, a.OrganizationId), a)
+		if err != nil {
+			// other anomalies are still recorded, failed one will be retried on next run
+			log.Err(err).Int("subscription_id", a.SubscriptionId).Str("kind", a.Kind).Msg("anomaly/Detect error create anomaly in database")
+			continue
+		}
+		if created {
+			detected++
+		}
+	}
+	log.Info().Int("detected", detected).Time("date", date).Msg("anomaly/Detect detect price anomalies")
+	return detected, nil
+}
+
+func (s *anomalyService) FindAll(ctx context.Context, userId string) ([]AnomalyOutput, error) {
+	anomalies, err := s.anomaly.FindAll(ctx, userId)
+	if err != nil {
+		log.Err(err).Str("user_id", userId).Msg("anomaly/FindAll error find anomalies in database")
+		return nil, err
+	}
+	result := make([]AnomalyOutput, 0, len(anomalies))
+	for _, a := range anomalies {
+		result = append(result, newAnomalyOutput(a))
+	}
+	return result, nil
+}
+
+// appendAnomaly appends anomaly of kind if price exceeds reference by more than threshold percent
+func (s *anomalyService) appendAnomaly(anomalies []dbmodel.PriceAnomaly, p dbmodel.SubscriptionPrice, kind string, reference int) []dbmodel.PriceAnomaly {
+	if reference <= 0 || (p.Price-reference)*100 <= reference*s.threshold {
+		return anomalies
+	}
+	return append(anomalies, dbmodel.PriceAnomaly{
+		SubscriptionId: p.SubscriptionId,
+		ServiceName:    p.ServiceName,
+		UserId:         p.UserId,
+		Kind:           kind,
+		Price:          p.Price,
+		ReferencePrice: reference,
+		Percent:        (p.Price - reference) * 100 / reference,
+		OrganizationId: p.OrganizationId,
+	})
+}
+
+func (s *anomalyService) create(ctx context.Context, a dbmodel.PriceAnomaly) (bool, error) {
+	var created bool
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.anomaly.Create(ctx, a)
+		if err != nil || !created {
+			return err
+		}
+		return s.outbox.Create(ctx, newAnomalyEvent(a))
+	})
+	return created, err
+}
+
+// previousPrice returns the last price of subscription history different from current one
+func previousPrice(p dbmodel.SubscriptionPrice) (int, bool) {
+	for i := len(p.History) - 1; i >= 0; i-- {
+		if p.History[i] != p.Price {
+			return p.History[i], true
+		}
+	}
+	return 0, false
+}
+
+// peerKey groups subscriptions with comparable prices. Catalog service belongs to one organization
+type peerKey struct {
+	serviceId     int
+	billingPeriod string
+	currency      string
+}
+
+func newPeerKey(p dbmodel.SubscriptionPrice) peerKey {
+	return peerKey{serviceId: p.ServiceId, billingPeriod: p.BillingPeriod, currency: p.Currency}
+}
+
+// medianPrices returns median price of every group of at least minAnomalyPeers subscriptions
+func medianPrices(prices []dbmodel.SubscriptionPrice) map[peerKey]int {
+	groups := make(map[peerKey][]int)
+	for _, p := range prices {
+		key := newPeerKey(p)
+		groups[key] = append(groups[key], p.Price)
+	}
+
+	result := make(map[peerKey]int)
+	for key, group := range groups {
+		if len(group) < minAnomalyPeers {
+			continue
+		}
+		slices.Sort(group)
+		mid := len(group) / 2
+		if len(group)%2 == 0 {
+			result[key] = (group[mid-1] + group[mid]) / 2
+		} else {
+			result[key] = group[mid]
+		}
+	}
+	return result
+}
+
+type anomalyPayload struct {
+	SubscriptionId int    `json:"subscription_id"`
+	UserId         string `json:"user_id"`
+	ServiceName    string `json:"service_name"`
+	Kind           string `json:"kind"`
+	Price          int    `json:"price"`
+	ReferencePrice int    `json:"reference_price"`
+	Percent        int    `json:"percent"`
+}
+
+func newAnomalyEvent(a dbmodel.PriceAnomaly) dbmodel.OutboxEvent {
+	payload, _ := json.Marshal(anomalyPayload{
+		SubscriptionId: a.SubscriptionId,
+		UserId:         a.UserId,
+		ServiceName:    a.ServiceName,
+		Kind:           a.Kind,
+		Price:          a.Price,
+		ReferencePrice: a.ReferencePrice,
+		Percent:        a.Percent,
+	})
+	return dbmodel.OutboxEvent{
+		EventType:   dbmodel.EventSubscriptionAnomaly,
+		AggregateId: a.SubscriptionId,
+		Payload:     payload,
+	}
+}
+
+func newAnomalyOutput(a dbmodel.PriceAnomaly) AnomalyOutput {
+	return AnomalyOutput{
+		Id:             a.Id,
+		SubscriptionId: a.SubscriptionId,
+		ServiceName:    a.ServiceName,
+		UserId:         a.UserId,
+		Kind:           a.Kind,
+		Price:          a.Price,
+		ReferencePrice: a.ReferencePrice,
+		Percent:        a.Percent,
+		DetectedAt:     a.DetectedAt,
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+func TestAnomalyService_Detect(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, anomaly *repomocks.MockAnomaly, outbox *repomocks.MockOutbox, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	day := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+	// anomalies are recorded within organization of subscription
+	orgCtx := tenant.WithOrganization(context.Background(), 2)
+
+	price := func(id, serviceId, price int, history ...int) dbmodel.SubscriptionPrice {
+		return dbmodel.SubscriptionPrice{
+			SubscriptionId: id,
+			ServiceId:      serviceId,
+			ServiceName:    "Netflix",
+			UserId:         userId,
+			BillingPeriod:  dbmodel.BillingMonthly,
+			Price:          price,
+			Currency:       "RUB",
+			History:        history,
+			OrganizationId: 2,
+		}
+	}
+	prices := []dbmodel.SubscriptionPrice{
+		// raised by 25% and above median 700 of service by 42%
+		price(1, 1, 1000, 800, 1000),
+		price(2, 1, 700, 700),
+		// raised by 7% only
+		price(3, 1, 700, 650, 700),
+		// raised by 66%, service has too few subscriptions for median
+		price(4, 2, 500, 300, 500, 500),
+	}
+	increase := dbmodel.PriceAnomaly{
+		SubscriptionId: 1, ServiceName: "Netflix", UserId: userId, Kind: dbmodel.AnomalyIncrease,
+		Price: 1000, ReferencePrice: 800, Percent: 25, OrganizationId: 2,
+	}
+	outlier := dbmodel.PriceAnomaly{
+		SubscriptionId: 1, ServiceName: "Netflix", UserId: userId, Kind: dbmodel.AnomalyOutlier,
+		Price: 1000, ReferencePrice: 700, Percent: 42, OrganizationId: 2,
+	}
+	recorded := dbmodel.PriceAnomaly{
+		SubscriptionId: 4, ServiceName: "Netflix", UserId: userId, Kind: dbmodel.AnomalyIncrease,
+		Price: 500, ReferencePrice: 300, Percent: 66, OrganizationId: 2,
+	}
+
+	testCases := []struct {
+		testName       string
+		args           args
+		mockBehaviour  mockBehaviour
+		expectDetected int
+		expectErr      error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:  context.Background(),
+				date: day.Add(15 * time.Hour),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, anomaly *repomocks.MockAnomaly, outbox *repomocks.MockOutbox, a args) {
+				anomaly.EXPECT().FindPrices(a.ctx, day).Return(prices, nil)
+
+				runInTransaction(tx)
+				anomaly.EXPECT().Create(orgCtx, increase).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newAnomalyEvent(increase)).Return(nil)
+
+				runInTransaction(tx)
+				anomaly.EXPECT().Create(orgCtx, outlier).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newAnomalyEvent(outlier)).Return(nil)
+
+				// already recorded anomaly emits no event
+				runInTransaction(tx)
+				anomaly.EXPECT().Create(orgCtx, recorded).Return(false, nil)
+			},
+			expectDetected: 2,
+			expectErr:      nil,
+		},
+		{
+			testName: "create error",
+			args: args{
+				ctx:  context.Background(),
+				date: day,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, anomaly *repomocks.MockAnomaly, outbox *repomocks.MockOutbox, a args) {
+				anomaly.EXPECT().FindPrices(a.ctx, day).Return(prices[3:], nil)
+				runInTransaction(tx)
+				anomaly.EXPECT().Create(orgCtx, recorded).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newAnomalyEvent(recorded)).Return(errors.New("some error"))
+			},
+			expectDetected: 0,
+			expectErr:      nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:  context.Background(),
+				date: day,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, anomaly *repomocks.MockAnomaly, outbox *repomocks.MockOutbox, a args) {
+				anomaly.EXPECT().FindPrices(a.ctx, day).Return(nil, errors.New("some error"))
+			},
+			expectDetected: 0,
+			expectErr:      errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			anomaly := repomocks.NewMockAnomaly(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, anomaly, outbox, tc.args)
+
+			s := newAnomalyService(tx, anomaly, outbox, 20)
+
+			detected, err := s.Detect(tc.args.ctx, tc.args.date)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectDetected, detected)
+		})
+	}
+}
+
+func TestAnomalyService_FindAll(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	detectedAt := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+
+	anomaly := repomocks.NewMockAnomaly(ctrl)
+	anomaly.EXPECT().FindAll(gomock.Any(), userId).Return([]dbmodel.PriceAnomaly{
+		{
+			Id: 1, SubscriptionId: 2, ServiceName: "Netflix", UserId: userId, Kind: dbmodel.AnomalyIncrease,
+			Price: 1000, ReferencePrice: 800, Percent: 25, DetectedAt: detectedAt, OrganizationId: 1,
+		},
+	}, nil)
+	anomaly.EXPECT().FindAll(gomock.Any(), "").Return(nil, errors.New("some error"))
+
+	s := newAnomalyService(nil, anomaly, nil, 0)
+
+	output, err := s.FindAll(context.Background(), userId)
+	assert.NoError(t, err)
+	assert.Equal(t, []AnomalyOutput{
+		{
+			Id: 1, SubscriptionId: 2, ServiceName: "Netflix", UserId: userId, Kind: dbmodel.AnomalyIncrease,
+			Price: 1000, ReferencePrice: 800, Percent: 25, DetectedAt: detectedAt,
+		},
+	}, output)
+
+	_, err = s.FindAll(context.Background(), "")
+	assert.Error(t, err)
+}
+
+func TestMedianPrices(t *testing.T) {
+	price := func(serviceId, price int, period string) dbmodel.SubscriptionPrice {
+		return dbmodel.SubscriptionPrice{ServiceId: serviceId, Price: price, BillingPeriod: period, Currency: "RUB"}
+	}
+	medians := medianPrices([]dbmodel.SubscriptionPrice{
+		price(1, 400, dbmodel.BillingMonthly),
+		price(1, 100, dbmodel.BillingMonthly),
+		price(1, 300, dbmodel.BillingMonthly),
+		price(1, 200, dbmodel.BillingMonthly),
+		// yearly prices are not compared with monthly ones
+		price(1, 2400, dbmodel.BillingYearly),
+		price(2, 100, dbmodel.BillingMonthly),
+	})
+	assert.Equal(t, map[peerKey]int{
+		{serviceId: 1, billingPeriod: dbmodel.BillingMonthly, currency: "RUB"}: 250,
+	}, medians)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"slices"
+	"subscription_service/internal/model/dbmodel"
+	"time"
+)
+
+// addMonths adds n months to t. Unlike time.AddDate day of month is clamped to the last day of result month,
+// so Jan 31 + 1 month is Feb 28 (29), not Mar 3
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+func periodMonths(period string) int {
+	if billingPeriod(period) == dbmodel.BillingYearly {
+		return 12
+	}
+	return 1
+}
+
+// billingDate returns date of n-th (from zero) charge of subscription
+func billingDate(sub dbmodel.Subscription, n int) time.Time {
+	return addMonths(sub.StartDate, n*periodMonths(sub.BillingPeriod))
+}
+
+// nextBillingDate returns first charge date of subscription which is not before date, charges in paused months
+// are skipped. ok is false if subscription ends before that charge
+func nextBillingDate(sub dbmodel.Subscription, date time.Time) (time.Time, bool) {
+	step := periodMonths(sub.BillingPeriod)
+
+	n := 0
+	if date.After(sub.StartDate) {
+		n = max(monthsBetween(sub.StartDate, date)/step-1, 0)
+	}
+	next := billingDate(sub, n)
+	for next.Before(date) || paused(sub, next) {
+		if sub.EndDate != nil && next.After(*sub.EndDate) || pausedIndefinitely(sub, next) {
+			return time.Time{}, false
+		}
+		n++
+		next = billingDate(sub, n)
+	}
+
+	if sub.EndDate != nil && next.After(*sub.EndDate) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// monthStart returns first day of month of t
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// monthEnd returns last day of month of t
+func monthEnd(t time.Time) time.Time {
+	return monthStart(t).AddDate(0, 1, -1)
+}
+
+// monthsBetween returns number of whole calendar months from a to b, days are ignored
+func monthsBetween(a, b time.Time) int {
+	return (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+}
+
+// anniversary returns billing anniversary of subscription in month of date: day of start clamped to the month
+func anniversary(sub dbmodel.Subscription, date time.Time) time.Time {
+	return addMonths(sub.StartDate, monthsBetween(sub.StartDate, date))
+}
+
+// activeIn reports whether anniversary of subscription in month is within subscription and is not paused
+func activeIn(sub dbmodel.Subscription, month time.Time) bool {
+	if monthsBetween(sub.StartDate, month) < 0 {
+		return false
+	}
+	day := anniversary(sub, month)
+	return (sub.EndDate == nil || !day.After(*sub.EndDate)) && !paused(sub, day)
+}
+
+// chargedIn reports whether subscription is charged in month: on its anniversary in the month of billing period
+func chargedIn(sub dbmodel.Subscription, month time.Time) bool {
+	return activeIn(sub, month) && monthsBetween(sub.StartDate, month)%periodMonths(sub.BillingPeriod) == 0
+}
+
+// paused reports whether date is within one of subscription pauses
+func paused(sub dbmodel.Subscription, date time.Time) bool {
+	return slices.ContainsFunc(sub.Pauses, func(p dbmodel.Pause) bool {
+		return pauseCovers(p, date)
+	})
+}
+
+// pauseCovers reports whether date is within pause, both ends are inclusive
+func pauseCovers(p dbmodel.Pause, date time.Time) bool {
+	return !date.Before(p.StartDate) && (p.EndDate == nil || !date.After(*p.EndDate))
+}
+
+// pausedIndefinitely reports whether subscription is paused from date until it is resumed
+func pausedIndefinitely(sub dbmodel.Subscription, date time.Time) bool {
+	for _, p := range sub.Pauses {
+		if p.EndDate == nil && !date.Before(p.StartDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// trialCharges returns number of charges within trial of subscription. Trial ending before start has no charges
+func trialCharges(sub dbmodel.Subscription) int {
+	if sub.TrialEndDate == nil {
+		return 0
+	}
+	n := 0
+	for !billingDate(sub, n).After(*sub.TrialEndDate) {
+		n++
+	}
+	return n
+}
+
+// introEndDate returns date of the first charge at regular price
+func introEndDate(sub dbmodel.Subscription) time.Time {
+	return billingDate(sub, trialCharges(sub)+sub.IntroPeriods)
+}
+
+// inTrial reports whether date is within free trial of subscription
+func inTrial(sub dbmodel.Subscription, date time.Time) bool {
+	return sub.TrialEndDate != nil && !date.After(*sub.TrialEndDate)
+}
+
+// priceOn returns price of subscription charged for billing period containing date: nothing within trial,
+// intro price in intro periods and regular price after them
+func priceOn(sub dbmodel.Subscription, date time.Time) int {
+	if inTrial(sub, date) {
+		return 0
+	}
+	if sub.IntroPrice != nil && date.Before(introEndDate(sub)) {
+		return *sub.IntroPrice
+	}
+	return sub.Price
+}
+
+// discounted reports whether charge on date is within discount of subscription
+func discounted(sub dbmodel.Subscription, date time.Time) bool {
+	if sub.DiscountType == nil {
+		return false
+	}
+	start := sub.StartDate
+	if sub.DiscountStartDate != nil {
+		start = *sub.DiscountStartDate
+	}
+	return !date.Before(start) && (sub.DiscountMonths == 0 || date.Before(addMonths(start, sub.DiscountMonths)))
+}
+
+// chargeOn returns amount of subscription charged for billing period containing date: price on date reduced
+// by discount, with tax included in it or added on top. Rounding matches subscription_gross in database
+func chargeOn(sub dbmodel.Subscription, date time.Time) dbmodel.Price {
+	price := priceOn(sub, date)
+
+	var discount int
+	if discounted(sub, date) {
+		if *sub.DiscountType == dbmodel.DiscountPercent {
+			discount = price * sub.DiscountValue / 100
+		} else {
+			discount = min(sub.DiscountValue, price)
+		}
+	}
+
+	gross := price - discount
+	tax := gross * sub.TaxRate / 100
+	if sub.TaxInclusive {
+		tax = gross * sub.TaxRate / (100 + sub.TaxRate)
+	} else {
+		gross += tax
+	}
+	return dbmodel.Price{Gross: gross, Net: gross - tax, Tax: tax, Discount: discount}
+}
+
+// monthCharge returns gross amount of subscription charge in month, ok is false if subscription is not charged in month
+func monthCharge(sub dbmodel.Subscription, month time.Time) (int, bool) {
+	if !chargedIn(sub, month) {
+		return 0, false
+	}
+	n := monthsBetween(sub.StartDate, month) / periodMonths(sub.BillingPeriod)
+	return chargeOn(sub, billingDate(sub, n)).Gross, true
+}
@@ -0,0 +1,434 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/model/dbmodel"
+	"testing"
+	"time"
+)
+
+func TestAddMonths(t *testing.T) {
+	testCases := []struct {
+		testName string
+		t        time.Time
+		n        int
+		expect   time.Time
+	}{
+		{
+			testName: "first day of month",
+			t:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			n:        1,
+			expect:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			testName: "end of month is clamped",
+			t:        time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			n:        1,
+			expect:   time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			testName: "leap year",
+			t:        time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			n:        1,
+			expect:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			testName: "next year",
+			t:        time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC),
+			n:        14,
+			expect:   time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, addMonths(tc.t, tc.n))
+		})
+	}
+}
+
+func TestNextBillingDate(t *testing.T) {
+	testCases := []struct {
+		testName string
+		sub      dbmodel.Subscription
+		date     time.Time
+		expect   time.Time
+		expectOk bool
+	}{
+		{
+			testName: "monthly",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: dbmodel.BillingMonthly,
+			},
+			date:     time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			expect:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			testName: "billing day itself",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: dbmodel.BillingMonthly,
+			},
+			date:     time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			expect:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			testName: "not started yet",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: dbmodel.BillingMonthly,
+			},
+			date:     time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			expect:   time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			testName: "yearly",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: dbmodel.BillingYearly,
+			},
+			date:     time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			expect:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			testName: "last month is billed",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:       ptr(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)),
+				BillingPeriod: dbmodel.BillingMonthly,
+			},
+			date:     time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			expect:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			testName: "paused months are skipped",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: dbmodel.BillingMonthly,
+				Pauses: []dbmodel.Pause{
+					{StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))},
+				},
+			},
+			date:     time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			expect:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			testName: "paused until resume",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BillingPeriod: dbmodel.BillingMonthly,
+				Pauses:        []dbmodel.Pause{{StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)}},
+			},
+			date:     time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			expectOk: false,
+		},
+		{
+			testName: "ended",
+			sub: dbmodel.Subscription{
+				StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:       ptr(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)),
+				BillingPeriod: dbmodel.BillingMonthly,
+			},
+			date:     time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			expectOk: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			actual, ok := nextBillingDate(tc.sub, tc.date)
+
+			assert.Equal(t, tc.expectOk, ok)
+			assert.Equal(t, tc.expect, actual)
+		})
+	}
+}
+
+func TestChargedIn(t *testing.T) {
+	end := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	monthly := dbmodel.Subscription{
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   &end,
+	}
+	yearly := dbmodel.Subscription{
+		StartDate:     time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingYearly,
+	}
+
+	testCases := []struct {
+		testName string
+		sub      dbmodel.Subscription
+		month    time.Time
+		expect   bool
+	}{
+		{
+			testName: "before start",
+			sub:      monthly,
+			month:    time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			expect:   false,
+		},
+		{
+			testName: "start month",
+			sub:      monthly,
+			month:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			expect:   true,
+		},
+		{
+			testName: "end month is included",
+			sub:      monthly,
+			month:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			expect:   true,
+		},
+		{
+			testName: "after end",
+			sub:      monthly,
+			month:    time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			expect:   false,
+		},
+		{
+			testName: "paused month",
+			sub: dbmodel.Subscription{
+				StartDate: monthly.StartDate,
+				Pauses:    []dbmodel.Pause{{StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))}},
+			},
+			month:  time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			expect: false,
+		},
+		{
+			testName: "month after pause",
+			sub: dbmodel.Subscription{
+				StartDate: monthly.StartDate,
+				Pauses:    []dbmodel.Pause{{StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))}},
+			},
+			month:  time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		{
+			testName: "yearly anniversary",
+			sub:      yearly,
+			month:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			expect:   true,
+		},
+		{
+			testName: "yearly between charges",
+			sub:      yearly,
+			month:    time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			expect:   false,
+		},
+		{
+			testName: "end before anniversary",
+			sub: dbmodel.Subscription{
+				StartDate: time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+				EndDate:   ptr(time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)),
+			},
+			month:  time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			expect: false,
+		},
+		{
+			testName: "end on anniversary",
+			sub: dbmodel.Subscription{
+				StartDate: time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+				EndDate:   ptr(time.Date(2025, 10, 25, 0, 0, 0, 0, time.UTC)),
+			},
+			month:  time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		{
+			testName: "pause ends before anniversary",
+			sub: dbmodel.Subscription{
+				StartDate: time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+				Pauses:    []dbmodel.Pause{{StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC))}},
+			},
+			month:  time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		{
+			testName: "clamped anniversary",
+			sub: dbmodel.Subscription{
+				StartDate: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+				EndDate:   ptr(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)),
+			},
+			month:  time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, chargedIn(tc.sub, tc.month))
+		})
+	}
+}
+
+func TestPriceOn(t *testing.T) {
+	trial := dbmodel.Subscription{
+		Price:        1000,
+		StartDate:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		TrialEndDate: ptr(time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC)),
+		IntroPrice:   ptr(500),
+		IntroPeriods: 2,
+	}
+	yearly := dbmodel.Subscription{
+		Price:         12000,
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingYearly,
+		IntroPrice:    ptr(6000),
+		IntroPeriods:  1,
+	}
+
+	testCases := []struct {
+		testName string
+		sub      dbmodel.Subscription
+		date     time.Time
+		expect   int
+	}{
+		{
+			testName: "first charge within trial",
+			sub:      trial,
+			date:     time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			expect:   0,
+		},
+		{
+			testName: "last day of trial",
+			sub:      trial,
+			date:     time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC),
+			expect:   0,
+		},
+		{
+			testName: "first intro period after trial",
+			sub:      trial,
+			date:     time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+			expect:   500,
+		},
+		{
+			testName: "last intro period",
+			sub:      trial,
+			date:     time.Date(2025, 4, 14, 0, 0, 0, 0, time.UTC),
+			expect:   500,
+		},
+		{
+			testName: "regular price after intro",
+			sub:      trial,
+			date:     time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC),
+			expect:   1000,
+		},
+		{
+			testName: "yearly intro without trial",
+			sub:      yearly,
+			date:     time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+			expect:   6000,
+		},
+		{
+			testName: "yearly regular price",
+			sub:      yearly,
+			date:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			expect:   12000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, priceOn(tc.sub, tc.date))
+		})
+	}
+}
+
+func TestChargeOn(t *testing.T) {
+	exclusive := dbmodel.Subscription{
+		Price:     1000,
+		StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		TaxRate:   20,
+	}
+	inclusive := dbmodel.Subscription{
+		Price:        1200,
+		StartDate:    time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		TaxRate:      20,
+		TaxInclusive: true,
+	}
+	coupon := dbmodel.Subscription{
+		Price:             1000,
+		StartDate:         time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		TaxRate:           20,
+		DiscountType:      ptr(dbmodel.DiscountPercent),
+		DiscountValue:     25,
+		DiscountStartDate: ptr(time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)),
+		DiscountMonths:    3,
+	}
+	fixed := dbmodel.Subscription{
+		Price:         1000,
+		StartDate:     time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		IntroPrice:    ptr(100),
+		IntroPeriods:  1,
+		DiscountType:  ptr(dbmodel.DiscountFixed),
+		DiscountValue: 300,
+	}
+
+	testCases := []struct {
+		testName string
+		sub      dbmodel.Subscription
+		date     time.Time
+		expect   dbmodel.Price
+	}{
+		{
+			testName: "without tax and discount",
+			sub:      dbmodel.Subscription{Price: 1000, StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+			date:     time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Gross: 1000, Net: 1000},
+		},
+		{
+			testName: "tax on top of price",
+			sub:      exclusive,
+			date:     time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Gross: 1200, Net: 1000, Tax: 200},
+		},
+		{
+			testName: "tax included in price",
+			sub:      inclusive,
+			date:     time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Gross: 1200, Net: 1000, Tax: 200},
+		},
+		{
+			testName: "before discount",
+			sub:      coupon,
+			date:     time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Gross: 1200, Net: 1000, Tax: 200},
+		},
+		{
+			testName: "tax on discounted price",
+			sub:      coupon,
+			date:     time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Gross: 900, Net: 750, Tax: 150, Discount: 250},
+		},
+		{
+			testName: "discount is over",
+			sub:      coupon,
+			date:     time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Gross: 1200, Net: 1000, Tax: 200},
+		},
+		{
+			testName: "fixed discount above intro price",
+			sub:      fixed,
+			date:     time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Discount: 100},
+		},
+		{
+			testName: "fixed discount forever",
+			sub:      fixed,
+			date:     time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC),
+			expect:   dbmodel.Price{Gross: 700, Net: 700, Discount: 300},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, chargeOn(tc.sub, tc.date))
+		})
+	}
+}
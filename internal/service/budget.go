@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+var defaultBudgetThresholds = []int{80, 100}
+
+type budgetService struct {
+	tx       repo.Transactor
+	budget   repo.Budget
+	sub      repo.Subscription
+	service  repo.Service
+	category repo.Category
+	outbox   repo.Outbox
+	charge   repo.Charge
+}
+
+func newBudgetService(
+	tx repo.Transactor,
+	budget repo.Budget,
+	sub repo.Subscription,
+	service repo.Service,
+	category repo.Category,
+	outbox repo.Outbox,
+	charge repo.Charge,
+) *budgetService {
+	return &budgetService{
+		tx:       tx,
+		budget:   budget,
+		sub:      sub,
+		service:  service,
+		category: category,
+		outbox:   outbox,
+		charge:   charge,
+	}
+}
+
+func (s *budgetService) Create(ctx context.Context, input BudgetInput) (int, error) {
+	if err := s.checkCategory(ctx, input.CategoryId); err != nil {
+		return 0, err
+	}
+	id, err := s.budget.Create(ctx, dbmodel.Budget{
+		UserId:      input.UserId,
+		ServiceName: input.ServiceName,
+		CategoryId:  input.CategoryId,
+		Amount:      input.Amount,
+		Thresholds:  normalizeThresholds(input.Thresholds),
+	})
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("budget/Create error create budget in database")
+		return 0, err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("budget/Create create new budget in database")
+	return id, nil
+}
+
+func (s *budgetService) FindById(ctx context.Context, id int) (BudgetOutput, error) {
+	b, err := s.findById(ctx, id)
+	if err != nil {
+		return BudgetOutput{}, err
+	}
+	return newBudgetOutput(b), nil
+}
+
+func (s *budgetService) FindAll(ctx context.Context, userId string) ([]BudgetOutput, error) {
+	budgets, err := s.budget.FindAll(ctx, userId)
+	if err != nil {
+		log.Err(err).Str("user_id", userId).Msg("budget/FindAll error find budgets in database")
+		return nil, err
+	}
+	result := make([]BudgetOutput, 0, len(budgets))
+	for _, b := range budgets {
+		result = append(result, newBudgetOutput(b))
+	}
+	return result, nil
+}
+
+func (s *budgetService) Update(ctx context.Context, id int, input BudgetInput) error {
+	if err := s.checkCategory(ctx, input.CategoryId); err != nil {
+		return err
+	}
+	err := s.budget.Update(ctx, dbmodel.Budget{
+		Id:          id,
+		UserId:      input.UserId,
+		ServiceName: input.ServiceName,
+		CategoryId:  input.CategoryId,
+		Amount:      input.Amount,
+		Thresholds:  normalizeThresholds(input.Thresholds),
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrBudgetNotFound
+		}
+		log.Err(err).Int("id", id).Msg("budget/Update error update budget in database")
+		return err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("budget/Update update budget in database")
+	return nil
+}
+
+func (s *budgetService) Delete(ctx context.Context, id int) error {
+	if err := s.budget.Delete(ctx, id); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrBudgetNotFound
+		}
+		log.Err(err).Int("id", id).Msg("budget/Delete error delete budget in database")
+		return err
+	}
+	log.Info().Int("id", id).Msg("budget/Delete delete budget in database")
+	return nil
+}
+
+// Evaluate compares budget with spend of every month from start to end inclusive.
+// Spend of month is computed the same way as subscription price for one month interval from source
+func (s *budgetService) Evaluate(ctx context.Context, id int, start, end time.Time, source string) ([]BudgetMonthOutput, error) {
+	b, err := s.findById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []BudgetMonthOutput
+
+	for month := monthStart(start); !month.After(end); month = month.AddDate(0, 1, 0) {
+		spent, err := s.spent(ctx, b, month, source)
+		if err != nil {
+			log.Err(err).Int("id", id).Time("month", month).Msg("budget/Evaluate error find spend in database")
+			return nil, err
+		}
+		result = append(result, newBudgetMonthOutput(b, month, spent))
+	}
+	return result, nil
+}
+
+// Check evaluates all budgets for current month of date and forecast for the next one and emits
+// budget.threshold_crossed event for every newly crossed threshold. Returns number of emitted events
+func (s *budgetService) Check(ctx context.Context, date time.Time) (int, error) {
+	budgets, err := s.budget.FindAll(ctx, "")
+	if err != nil {
+		log.Err(err).Msg("budget/Check error find budgets in database")
+		return 0, err
+	}
+	current := monthStart(date)
+
+	var alerts int
+
+	for _, b := range budgets {
+		// budget is evaluated within its organization: catalog lookup and events are scoped to it
+		ctx := tenant.WithOrganization(ctx, b.OrganizationId)
+
+		for _, month := range []time.Time{current, current.AddDate(0, 1, 0)} {
+			n, err := s.check(ctx, b, month, month.After(current))
+			if err != nil {
+				// other budgets are still checked, failed one will be retried on next run
+				log.Err(err).Int("id", b.Id).Time("month", month).Msg("budget/Check error check budget")
+				continue
+			}
+			alerts += n
+		}
+	}
+	log.Info().Int("alerts", alerts).Time("month", current).Msg("budget/Check check budgets")
+	return alerts, nil
+}
+
+func (s *budgetService) check(ctx context.Context, b dbmodel.Budget, month time.Time, forecast bool) (int, error) {
+	spent, err := s.spent(ctx, b, month, PriceSourceSubscriptions)
+	if err != nil {
+		return 0, err
+	}
+	crossed := crossedThresholds(b, spent)
+	if len(crossed) == 0 {
+		return 0, nil
+	}
+
+	var alerts int
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		alerts = 0
+		for _, threshold := range crossed {
+			created, err := s.budget.CreateAlert(ctx, dbmodel.BudgetAlert{BudgetId: b.Id, Month: month, Threshold: threshold})
+			if err != nil {
+				return err
+			}
+			if !created {
+				continue
+			}
+			if err = s.outbox.Create(ctx, newBudgetAlertEvent(b, month, spent, threshold, forecast)); err != nil {
+				return err
+			}
+			alerts++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return alerts, nil
+}
+
+// spent returns spend of budget subscriptions in month. Service of budget is resolved through catalog aliases,
+// category of budget includes its subcategories
+func (s *budgetService) spent(ctx context.Context, b dbmodel.Budget, month time.Time, source string) (int, error) {
+	var serviceId int
+
+	if b.ServiceName != nil {
+		id, ok, err := findServiceId(ctx, s.service, *b.ServiceName)
+		if err != nil || !ok {
+			return 0, err
+		}
+		serviceId = id
+	}
+	filter := dbmodel.SubscriptionFilter{UserId: b.UserId, ServiceId: serviceId}
+	if b.CategoryId != nil {
+		filter.CategoryId = *b.CategoryId
+	}
+
+	// budget limits amount actually paid, with tax and after discount
+	var (
+		price dbmodel.Price
+		err   error
+	)
+	if source == PriceSourceLedger {
+		price, err = s.charge.FindAmount(ctx, filter, month, monthEnd(month))
+	} else {
+		price, err = s.sub.FindPrice(ctx, filter, month, monthEnd(month))
+	}
+	return price.Gross, err
+}
+
+func (s *budgetService) checkCategory(ctx context.Context, categoryId *int) error {
+	if categoryId == nil {
+		return nil
+	}
+	_, err := s.category.FindById(ctx, *categoryId)
+	if errors.Is(err, pgerrs.ErrNotFound) {
+		return ErrCategoryNotFound
+	}
+	if err != nil {
+		log.Err(err).Int("category_id", *categoryId).Msg("budget/checkCategory error find category in database")
+	}
+	return err
+}
+
+func (s *budgetService) findById(ctx context.Context, id int) (dbmodel.Budget, error) {
+	b, err := s.budget.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return dbmodel.Budget{}, ErrBudgetNotFound
+		}
+		log.Err(err).Int("id", id).Msg("budget/FindById error find budget in database")
+		return dbmodel.Budget{}, err
+	}
+	return b, nil
+}
+
+// crossedThresholds returns thresholds reached by spent, in ascending order
+func crossedThresholds(b dbmodel.Budget, spent int) []int {
+	var result []int
+	for _, t := range b.Thresholds {
+		if spent*100 >= b.Amount*t {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func normalizeThresholds(thresholds []int) []int {
+	if len(thresholds) == 0 {
+		return slices.Clone(defaultBudgetThresholds)
+	}
+	result := slices.Clone(thresholds)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+type budgetAlertPayload struct {
+	BudgetId    int     `json:"budget_id"`
+	UserId      string  `json:"user_id"`
+	ServiceName *string `json:"service_name"`
+	CategoryId  *int    `json:"category_id"`
+	Month       string  `json:"month"`
+	Amount      int     `json:"amount"`
+	Spent       int     `json:"spent"`
+	Threshold   int     `json:"threshold"`
+	Forecast    bool    `json:"forecast"`
+}
+
+func newBudgetAlertEvent(b dbmodel.Budget, month time.Time, spent, threshold int, forecast bool) dbmodel.OutboxEvent {
+	payload, _ := json.Marshal(budgetAlertPayload{
+		BudgetId:    b.Id,
+		UserId:      b.UserId,
+		ServiceName: b.ServiceName,
+		CategoryId:  b.CategoryId,
+		Month:       formatMonth(month),
+		Amount:      b.Amount,
+		Spent:       spent,
+		Threshold:   threshold,
+		Forecast:    forecast,
+	})
+	return dbmodel.OutboxEvent{
+		EventType:   dbmodel.EventBudgetThreshold,
+		AggregateId: b.Id,
+		Payload:     payload,
+	}
+}
+
+func newBudgetOutput(b dbmodel.Budget) BudgetOutput {
+	return BudgetOutput{
+		Id:          b.Id,
+		UserId:      b.UserId,
+		ServiceName: b.ServiceName,
+		CategoryId:  b.CategoryId,
+		Amount:      b.Amount,
+		Thresholds:  b.Thresholds,
+		CreatedAt:   b.CreatedAt,
+	}
+}
+
+func newBudgetMonthOutput(b dbmodel.Budget, month time.Time, spent int) BudgetMonthOutput {
+	crossed := crossedThresholds(b, spent)
+	if crossed == nil {
+		crossed = []int{}
+	}
+	return BudgetMonthOutput{
+		Month:             formatMonth(month),
+		Amount:            b.Amount,
+		Spent:             spent,
+		Percent:           spent * 100 / b.Amount,
+		Remaining:         b.Amount - spent,
+		CrossedThresholds: crossed,
+	}
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+func TestBudgetService_Create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input BudgetInput
+	}
+
+	type mockBehaviour func(budget *repomocks.MockBudget, category *repomocks.MockCategory, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				input: BudgetInput{UserId: userId, Amount: 1000},
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, category *repomocks.MockCategory, a args) {
+				budget.EXPECT().Create(a.ctx, dbmodel.Budget{
+					UserId:     userId,
+					Amount:     1000,
+					Thresholds: []int{80, 100},
+				}).Return(1, nil)
+			},
+			expectOutput: 1,
+			expectErr:    nil,
+		},
+		{
+			testName: "budget of category",
+			args: args{
+				ctx:   context.Background(),
+				input: BudgetInput{UserId: userId, CategoryId: ptr(5), Amount: 1000, Thresholds: []int{100}},
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, category *repomocks.MockCategory, a args) {
+				category.EXPECT().FindById(a.ctx, 5).Return(dbmodel.Category{Id: 5, Name: "Music"}, nil)
+				budget.EXPECT().Create(a.ctx, dbmodel.Budget{
+					UserId:     userId,
+					CategoryId: ptr(5),
+					Amount:     1000,
+					Thresholds: []int{100},
+				}).Return(2, nil)
+			},
+			expectOutput: 2,
+			expectErr:    nil,
+		},
+		{
+			testName: "category not found",
+			args: args{
+				ctx:   context.Background(),
+				input: BudgetInput{UserId: userId, CategoryId: ptr(6), Amount: 1000},
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, category *repomocks.MockCategory, a args) {
+				category.EXPECT().FindById(a.ctx, 6).Return(dbmodel.Category{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: 0,
+			expectErr:    ErrCategoryNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			budget := repomocks.NewMockBudget(ctrl)
+			category := repomocks.NewMockCategory(ctrl)
+			tc.mockBehaviour(budget, category, tc.args)
+
+			s := newBudgetService(nil, budget, nil, nil, category, nil, nil)
+
+			output, err := s.Create(tc.args.ctx, tc.args.input)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+func TestBudgetService_Evaluate(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     int
+		start  time.Time
+		end    time.Time
+		source string
+	}
+
+	type mockBehaviour func(budget *repomocks.MockBudget, sub *repomocks.MockSubscription, service *repomocks.MockService, charge *repomocks.MockCharge, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  []BudgetMonthOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				start: july,
+				end:   august,
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, sub *repomocks.MockSubscription, service *repomocks.MockService, charge *repomocks.MockCharge, a args) {
+				budget.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Budget{
+					Id:         1,
+					UserId:     userId,
+					Amount:     1000,
+					Thresholds: []int{80, 100},
+				}, nil)
+				sub.EXPECT().FindPrice(a.ctx, dbmodel.SubscriptionFilter{UserId: userId}, july, monthEnd(july)).Return(dbmodel.Price{Gross: 850, Net: 850}, nil)
+				sub.EXPECT().FindPrice(a.ctx, dbmodel.SubscriptionFilter{UserId: userId}, august, monthEnd(august)).Return(dbmodel.Price{Gross: 1200, Net: 1200}, nil)
+			},
+			expectOutput: []BudgetMonthOutput{
+				{Month: "07-2025", Amount: 1000, Spent: 850, Percent: 85, Remaining: 150, CrossedThresholds: []int{80}},
+				{Month: "08-2025", Amount: 1000, Spent: 1200, Percent: 120, Remaining: -200, CrossedThresholds: []int{80, 100}},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "spend from ledger",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				start:  july,
+				end:    july,
+				source: PriceSourceLedger,
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, sub *repomocks.MockSubscription, service *repomocks.MockService, charge *repomocks.MockCharge, a args) {
+				budget.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Budget{
+					Id:         1,
+					UserId:     userId,
+					Amount:     1000,
+					Thresholds: []int{80, 100},
+				}, nil)
+				charge.EXPECT().FindAmount(a.ctx, dbmodel.SubscriptionFilter{UserId: userId}, july, monthEnd(july)).Return(dbmodel.Price{Gross: 500, Net: 500}, nil)
+			},
+			expectOutput: []BudgetMonthOutput{
+				{Month: "07-2025", Amount: 1000, Spent: 500, Percent: 50, Remaining: 500, CrossedThresholds: []int{}},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "budget of service",
+			args: args{
+				ctx:   context.Background(),
+				id:    2,
+				start: july,
+				end:   july,
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, sub *repomocks.MockSubscription, service *repomocks.MockService, charge *repomocks.MockCharge, a args) {
+				budget.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Budget{
+					Id:          2,
+					UserId:      userId,
+					ServiceName: ptr("Yandex Plus"),
+					Amount:      500,
+					Thresholds:  []int{100},
+				}, nil)
+				service.EXPECT().FindByName(a.ctx, "Yandex Plus").Return(dbmodel.Service{Id: 3, Name: "Yandex Plus"}, nil)
+				sub.EXPECT().FindPrice(a.ctx, dbmodel.SubscriptionFilter{UserId: userId, ServiceId: 3}, july, monthEnd(july)).Return(dbmodel.Price{Gross: 400, Net: 400}, nil)
+			},
+			expectOutput: []BudgetMonthOutput{
+				{Month: "07-2025", Amount: 500, Spent: 400, Percent: 80, Remaining: 100, CrossedThresholds: []int{}},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "budget of category",
+			args: args{
+				ctx:   context.Background(),
+				id:    4,
+				start: july,
+				end:   july,
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, sub *repomocks.MockSubscription, service *repomocks.MockService, charge *repomocks.MockCharge, a args) {
+				budget.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Budget{
+					Id:         4,
+					UserId:     userId,
+					CategoryId: ptr(5),
+					Amount:     1000,
+					Thresholds: []int{100},
+				}, nil)
+				sub.EXPECT().FindPrice(a.ctx, dbmodel.SubscriptionFilter{UserId: userId, CategoryId: 5}, july, monthEnd(july)).Return(dbmodel.Price{Gross: 700, Net: 700}, nil)
+			},
+			expectOutput: []BudgetMonthOutput{
+				{Month: "07-2025", Amount: 1000, Spent: 700, Percent: 70, Remaining: 300, CrossedThresholds: []int{}},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "budget of unknown service",
+			args: args{
+				ctx:   context.Background(),
+				id:    2,
+				start: july,
+				end:   july,
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, sub *repomocks.MockSubscription, service *repomocks.MockService, charge *repomocks.MockCharge, a args) {
+				budget.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Budget{
+					Id:          2,
+					UserId:      userId,
+					ServiceName: ptr("Okko"),
+					Amount:      500,
+					Thresholds:  []int{100},
+				}, nil)
+				service.EXPECT().FindByName(a.ctx, "Okko").Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: []BudgetMonthOutput{
+				{Month: "07-2025", Amount: 500, Spent: 0, Percent: 0, Remaining: 500, CrossedThresholds: []int{}},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "budget not found",
+			args: args{
+				ctx:   context.Background(),
+				id:    3,
+				start: july,
+				end:   july,
+			},
+			mockBehaviour: func(budget *repomocks.MockBudget, sub *repomocks.MockSubscription, service *repomocks.MockService, charge *repomocks.MockCharge, a args) {
+				budget.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Budget{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: nil,
+			expectErr:    ErrBudgetNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			budget := repomocks.NewMockBudget(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			service := repomocks.NewMockService(ctrl)
+			charge := repomocks.NewMockCharge(ctrl)
+			tc.mockBehaviour(budget, sub, service, charge, tc.args)
+
+			s := newBudgetService(nil, budget, sub, service, nil, nil, charge)
+
+			output, err := s.Evaluate(tc.args.ctx, tc.args.id, tc.args.start, tc.args.end, tc.args.source)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+func TestBudgetService_Check(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, budget *repomocks.MockBudget, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	b := dbmodel.Budget{
+		Id:         1,
+		UserId:     userId,
+		Amount:     1000,
+		Thresholds: []int{80, 100},
+
+		OrganizationId: 2,
+	}
+	// budget is checked within its organization
+	orgCtx := tenant.WithOrganization(context.Background(), 2)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectAlerts  int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, budget *repomocks.MockBudget, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				budget.EXPECT().FindAll(a.ctx, "").Return([]dbmodel.Budget{b}, nil)
+
+				sub.EXPECT().FindPrice(orgCtx, dbmodel.SubscriptionFilter{UserId: userId}, july, monthEnd(july)).Return(dbmodel.Price{Gross: 850, Net: 850}, nil)
+				runInTransaction(tx)
+				budget.EXPECT().CreateAlert(orgCtx, dbmodel.BudgetAlert{BudgetId: 1, Month: july, Threshold: 80}).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newBudgetAlertEvent(b, july, 850, 80, false)).Return(nil)
+
+				sub.EXPECT().FindPrice(orgCtx, dbmodel.SubscriptionFilter{UserId: userId}, august, monthEnd(august)).Return(dbmodel.Price{Gross: 1000, Net: 1000}, nil)
+				runInTransaction(tx)
+				budget.EXPECT().CreateAlert(orgCtx, dbmodel.BudgetAlert{BudgetId: 1, Month: august, Threshold: 80}).Return(false, nil)
+				budget.EXPECT().CreateAlert(orgCtx, dbmodel.BudgetAlert{BudgetId: 1, Month: august, Threshold: 100}).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newBudgetAlertEvent(b, august, 1000, 100, true)).Return(nil)
+			},
+			expectAlerts: 2,
+			expectErr:    nil,
+		},
+		{
+			testName: "under thresholds and forecast error",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, budget *repomocks.MockBudget, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				budget.EXPECT().FindAll(a.ctx, "").Return([]dbmodel.Budget{b}, nil)
+				sub.EXPECT().FindPrice(orgCtx, dbmodel.SubscriptionFilter{UserId: userId}, july, monthEnd(july)).Return(dbmodel.Price{Gross: 100, Net: 100}, nil)
+				sub.EXPECT().FindPrice(orgCtx, dbmodel.SubscriptionFilter{UserId: userId}, august, monthEnd(august)).Return(dbmodel.Price{}, errors.New("some error"))
+			},
+			expectAlerts: 0,
+			expectErr:    nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, budget *repomocks.MockBudget, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				budget.EXPECT().FindAll(a.ctx, "").Return(nil, errors.New("some error"))
+			},
+			expectAlerts: 0,
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			budget := repomocks.NewMockBudget(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, budget, sub, outbox, tc.args)
+
+			s := newBudgetService(tx, budget, sub, nil, nil, outbox, nil)
+
+			alerts, err := s.Check(tc.args.ctx, tc.args.date)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectAlerts, alerts)
+		})
+	}
+}
+
+func TestNormalizeThresholds(t *testing.T) {
+	assert.Equal(t, []int{80, 100}, normalizeThresholds(nil))
+	assert.Equal(t, []int{50, 90, 100}, normalizeThresholds([]int{100, 50, 90, 50}))
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"strconv"
+	"strings"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/ical"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+const (
+	calendarProdId    = "-//subscription_service//renewals//EN"
+	calendarAlarmDays = 3
+)
+
+type calendarService struct {
+	user   repo.User
+	sub    repo.Subscription
+	secret []byte
+}
+
+func newCalendarService(user repo.User, subscription repo.Subscription, secret string) *calendarService {
+	return &calendarService{
+		user:   user,
+		sub:    subscription,
+		secret: []byte(secret),
+	}
+}
+
+// Token is issued only for user of organization of caller. It has form "<organization id>.<signature>",
+// so feed is read in that organization without tenant header. Calendar without secret is disabled
+func (s *calendarService) Token(ctx context.Context, userId string) (string, error) {
+	if len(s.secret) == 0 {
+		return "", ErrCalendarDisabled
+	}
+	if _, err := s.user.FindById(ctx, userId); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return "", ErrUserNotFound
+		}
+		log.Err(err).Str("user_id", userId).Msg("calendar/Token error find user in database")
+		return "", err
+	}
+
+	org := tenant.OrganizationOrDefault(ctx)
+	return strconv.Itoa(org) + "." + s.sign(org, userId), nil
+}
+
+func (s *calendarService) sign(org int, userId string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strconv.Itoa(org) + ":" + userId))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *calendarService) Feed(ctx context.Context, userId, token string) ([]byte, error) {
+	if len(s.secret) == 0 {
+		// empty key would sign tokens anyone can forge
+		return nil, ErrCalendarDisabled
+	}
+	prefix, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCalendarToken
+	}
+	org, err := strconv.Atoi(prefix)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(org, userId))) {
+		return nil, ErrInvalidCalendarToken
+	}
+	ctx = tenant.WithOrganization(ctx, org)
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	subscriptions, err := s.sub.FindActive(ctx, userId, month)
+	if err != nil {
+		log.Err(err).Str("user_id", userId).Msg("calendar/Feed error find active subscriptions in database")
+		return nil, err
+	}
+
+	cal := ical.Calendar{
+		ProdId: calendarProdId,
+		Name:   "Subscription renewals",
+		Events: make([]ical.Event, 0, len(subscriptions)),
+	}
+	for _, sub := range subscriptions {
+		until, exDates := recurrenceBounds(sub)
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         fmt.Sprintf("subscription-%d@subscription_service", sub.Id),
+			Summary:     fmt.Sprintf("%s renewal", sub.ServiceName),
+			Description: fmt.Sprintf("%s subscription renews for %d (%s)", sub.ServiceName, sub.Price, billingPeriod(sub.BillingPeriod)),
+			Start:       sub.StartDate,
+			RRule:       ical.Recurrence(recurrenceFreq(sub.BillingPeriod), until, recurrenceDays(sub)...),
+			ExDates:     exDates,
+			AlarmDays:   calendarAlarmDays,
+		})
+	}
+	return cal.Marshal(now), nil
+}
+
+func recurrenceFreq(period string) string {
+	if billingPeriod(period) == dbmodel.BillingYearly {
+		return "YEARLY"
+	}
+	return "MONTHLY"
+}
+
+// recurrenceDays returns rule parts moving charge to the last day of months shorter than start day, like billingDate.
+// Without them calendar skips such months
+func recurrenceDays(sub dbmodel.Subscription) []string {
+	day := sub.StartDate.Day()
+	if billingPeriod(sub.BillingPeriod) == dbmodel.BillingYearly {
+		if sub.StartDate.Month() == time.February && day == 29 {
+			return []string{"BYMONTH=2", "BYMONTHDAY=-1"}
+		}
+		return nil
+	}
+	if day <= 28 {
+		return nil
+	}
+	days := make([]string, 0, day-27)
+	for d := 28; d <= day; d++ {
+		days = append(days, strconv.Itoa(d))
+	}
+	// the last of days existing in month
+	return []string{"BYMONTHDAY=" + strings.Join(days, ","), "BYSETPOS=-1"}
+}
+
+// recurrenceBounds returns last day of charges of subscription and charges skipped within its pauses.
+// Pause until resume ends recurrence the day before it starts
+func recurrenceBounds(sub dbmodel.Subscription) (*time.Time, []time.Time) {
+	until := sub.EndDate
+	var last time.Time
+
+	for _, p := range sub.Pauses {
+		if p.EndDate == nil {
+			if end := p.StartDate.AddDate(0, 0, -1); until == nil || end.Before(*until) {
+				until = &end
+			}
+		} else if p.EndDate.After(last) {
+			last = *p.EndDate
+		}
+	}
+
+	var exDates []time.Time
+
+	for n := 0; ; n++ {
+		date := billingDate(sub, n)
+		if date.After(last) || until != nil && date.After(*until) {
+			break
+		}
+		if paused(sub, date) {
+			exDates = append(exDates, date)
+		}
+	}
+	return until, exDates
+}
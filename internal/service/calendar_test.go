@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+func TestCalendarService_Token(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+	}
+
+	type mockBehaviour func(user *repomocks.MockUser, a args)
+
+	const secret = "secret"
+
+	userId := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+	orgCtx := tenant.WithOrganization(context.Background(), 2)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectToken   string
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    orgCtx,
+				userId: userId,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: a.userId}, nil)
+			},
+			expectToken: "2." + newCalendarService(nil, nil, secret).sign(2, userId),
+			expectErr:   nil,
+		},
+		{
+			testName: "user of another organization",
+			args: args{
+				ctx:    orgCtx,
+				userId: userId,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrUserNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    orgCtx,
+				userId: userId,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{}, errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			user := repomocks.NewMockUser(ctrl)
+			tc.mockBehaviour(user, tc.args)
+
+			s := newCalendarService(user, nil, secret)
+
+			actual, err := s.Token(tc.args.ctx, tc.args.userId)
+
+			assert.Equal(t, tc.expectToken, actual)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestCalendarService_Disabled(t *testing.T) {
+	s := newCalendarService(nil, nil, "")
+
+	token, err := s.Token(context.Background(), "6114696a-d069-4fad-a3ed-f27c13651c3a")
+	assert.Equal(t, "", token)
+	assert.Equal(t, ErrCalendarDisabled, err)
+
+	// token signed with empty secret is not accepted
+	feed, err := s.Feed(context.Background(), "6114696a-d069-4fad-a3ed-f27c13651c3a", "1."+s.sign(1, "6114696a-d069-4fad-a3ed-f27c13651c3a"))
+	assert.Nil(t, feed)
+	assert.Equal(t, ErrCalendarDisabled, err)
+}
+
+func TestCalendarService_Feed(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		token  string
+	}
+
+	type mockBehaviour func(sub *repomocks.MockSubscription, a args)
+
+	const secret = "secret"
+
+	userId := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+	token := "2." + newCalendarService(nil, nil, secret).sign(2, userId)
+	orgCtx := tenant.WithOrganization(context.Background(), 2)
+
+	testCases := []struct {
+		testName       string
+		args           args
+		mockBehaviour  mockBehaviour
+		expectContains []string
+		expectErr      error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				token:  token,
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindActive(orgCtx, a.userId, gomock.Any()).Return([]dbmodel.Subscription{
+					{
+						Id:            1,
+						ServiceName:   "Yandex",
+						Price:         400,
+						UserId:        a.userId,
+						StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						EndDate:       nil,
+						BillingPeriod: dbmodel.BillingMonthly,
+					},
+					{
+						Id:            2,
+						ServiceName:   "Google",
+						Price:         5000,
+						UserId:        a.userId,
+						StartDate:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+						EndDate:       ptr(time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)),
+						BillingPeriod: dbmodel.BillingYearly,
+					},
+				}, nil)
+			},
+			expectContains: []string{
+				"BEGIN:VCALENDAR\r\n",
+				"UID:subscription-1@subscription_service\r\n",
+				"DTSTART;VALUE=DATE:20250101\r\n",
+				"RRULE:FREQ=MONTHLY\r\n",
+				"SUMMARY:Yandex renewal\r\n",
+				"UID:subscription-2@subscription_service\r\n",
+				"DTSTART;VALUE=DATE:20240301\r\n",
+				"RRULE:FREQ=YEARLY;UNTIL=20270301\r\n",
+				"END:VCALENDAR\r\n",
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "paused",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				token:  token,
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindActive(orgCtx, a.userId, gomock.Any()).Return([]dbmodel.Subscription{
+					{
+						Id:            1,
+						ServiceName:   "Yandex",
+						Price:         400,
+						UserId:        a.userId,
+						StartDate:     time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+						BillingPeriod: dbmodel.BillingMonthly,
+						Pauses: []dbmodel.Pause{
+							{StartDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC))},
+							{StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+						},
+					},
+				}, nil)
+			},
+			expectContains: []string{
+				"RRULE:FREQ=MONTHLY;UNTIL=20250831\r\n",
+				"EXDATE;VALUE=DATE:20250315,20250415\r\n",
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "end of month",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				token:  token,
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindActive(orgCtx, a.userId, gomock.Any()).Return([]dbmodel.Subscription{
+					{
+						Id:            1,
+						ServiceName:   "Yandex",
+						Price:         400,
+						UserId:        a.userId,
+						StartDate:     time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+						BillingPeriod: dbmodel.BillingMonthly,
+					},
+					{
+						Id:            2,
+						ServiceName:   "Spotify",
+						Price:         300,
+						UserId:        a.userId,
+						StartDate:     time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC),
+						BillingPeriod: dbmodel.BillingMonthly,
+					},
+					{
+						Id:            3,
+						ServiceName:   "Google",
+						Price:         5000,
+						UserId:        a.userId,
+						StartDate:     time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+						BillingPeriod: dbmodel.BillingYearly,
+					},
+				}, nil)
+			},
+			expectContains: []string{
+				"DTSTART;VALUE=DATE:20250131\r\n",
+				"RRULE:FREQ=MONTHLY;BYMONTHDAY=28,29,30,31;BYSETPOS=-1\r\n",
+				"DTSTART;VALUE=DATE:20250130\r\n",
+				"RRULE:FREQ=MONTHLY;BYMONTHDAY=28,29,30;BYSETPOS=-1\r\n",
+				"DTSTART;VALUE=DATE:20240229\r\n",
+				"RRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1\r\n",
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "invalid token",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				token:  "foobar",
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {},
+			expectErr:     ErrInvalidCalendarToken,
+		},
+		{
+			testName: "token of another organization",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				token:  "3" + token[1:],
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {},
+			expectErr:     ErrInvalidCalendarToken,
+		},
+		{
+			testName: "token of another user",
+			args: args{
+				ctx:    context.Background(),
+				userId: "2234696a-d069-4fad-a3ed-f27c13651c3a",
+				token:  token,
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {},
+			expectErr:     ErrInvalidCalendarToken,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				token:  token,
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindActive(orgCtx, a.userId, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := repomocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			s := newCalendarService(nil, sub, secret)
+
+			actual, err := s.Feed(tc.args.ctx, tc.args.userId, tc.args.token)
+
+			assert.Equal(t, tc.expectErr, err)
+			for _, line := range tc.expectContains {
+				assert.Contains(t, string(actual), line)
+			}
+		})
+	}
+}
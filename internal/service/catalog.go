@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"strings"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+)
+
+type catalogService struct {
+	service repo.Service
+}
+
+func newCatalogService(service repo.Service) *catalogService {
+	return &catalogService{
+		service: service,
+	}
+}
+
+func (s *catalogService) Create(ctx context.Context, input ServiceInput) (int, error) {
+	svc := newServiceModel(input)
+	if err := s.checkNames(ctx, 0, svc); err != nil {
+		return 0, err
+	}
+
+	id, err := s.service.Create(ctx, svc)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrAlreadyExists) {
+			return 0, ErrServiceAlreadyExists
+		}
+		log.Err(err).Interface("input", input).Msg("catalog/Create error create service in database")
+		return 0, err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("catalog/Create create new service in database")
+	return id, nil
+}
+
+func (s *catalogService) FindById(ctx context.Context, id int) (ServiceOutput, error) {
+	svc, err := s.service.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ServiceOutput{}, ErrServiceNotFound
+		}
+		log.Err(err).Int("id", id).Msg("catalog/FindById error find service in database")
+		return ServiceOutput{}, err
+	}
+	return newServiceOutput(svc), nil
+}
+
+func (s *catalogService) FindAll(ctx context.Context) ([]ServiceOutput, error) {
+	services, err := s.service.FindAll(ctx)
+	if err != nil {
+		log.Err(err).Msg("catalog/FindAll error find all services in database")
+		return nil, err
+	}
+	result := make([]ServiceOutput, 0, len(services))
+	for _, svc := range services {
+		result = append(result, newServiceOutput(svc))
+	}
+	return result, nil
+}
+
+func (s *catalogService) Update(ctx context.Context, id int, input ServiceInput) error {
+	svc := newServiceModel(input)
+	svc.Id = id
+
+	if err := s.checkNames(ctx, id, svc); err != nil {
+		return err
+	}
+
+	if err := s.service.Update(ctx, svc); err != nil {
+		switch {
+		case errors.Is(err, pgerrs.ErrNotFound):
+			return ErrServiceNotFound
+		case errors.Is(err, pgerrs.ErrAlreadyExists):
+			return ErrServiceAlreadyExists
+		}
+		log.Err(err).Int("id", id).Msg("catalog/Update error update service in database")
+		return err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("catalog/Update update service in database")
+	return nil
+}
+
+func (s *catalogService) Delete(ctx context.Context, id int) error {
+	if err := s.service.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, pgerrs.ErrNotFound):
+			return ErrServiceNotFound
+		case errors.Is(err, pgerrs.ErrReferenced):
+			return ErrServiceInUse
+		}
+		log.Err(err).Int("id", id).Msg("catalog/Delete error delete service in database")
+		return err
+	}
+	log.Info().Int("id", id).Msg("catalog/Delete delete service in database")
+	return nil
+}
+
+// checkNames makes sure name and aliases of service do not resolve to another service
+func (s *catalogService) checkNames(ctx context.Context, id int, svc dbmodel.Service) error {
+	for _, name := range append([]string{svc.Name}, svc.Aliases...) {
+		found, err := s.service.FindByName(ctx, name)
+		if err != nil {
+			if errors.Is(err, pgerrs.ErrNotFound) {
+				continue
+			}
+			log.Err(err).Str("name", name).Msg("catalog/checkNames error find service by name in database")
+			return err
+		}
+		if found.Id != id {
+			return ErrServiceAlreadyExists
+		}
+	}
+	return nil
+}
+
+// findServiceId resolves service name or alias to catalog id. ok is false for unknown names
+func findServiceId(ctx context.Context, services repo.Service, name string) (int, bool, error) {
+	svc, err := services.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return svc.Id, true, nil
+}
+
+// normalizeName trims name and collapses repeated spaces, so catalog lookups are not affected by formatting
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func newServiceModel(input ServiceInput) dbmodel.Service {
+	aliases := make([]string, 0, len(input.Aliases))
+	for _, a := range input.Aliases {
+		aliases = append(aliases, normalizeName(a))
+	}
+	return dbmodel.Service{
+		Name:         normalizeName(input.Name),
+		Aliases:      aliases,
+		Category:     input.Category,
+		DefaultPrice: input.DefaultPrice,
+		YearlyPrice:  input.YearlyPrice,
+		FamilyPrice:  input.FamilyPrice,
+		FamilySize:   input.FamilySize,
+		VendorUrl:    input.VendorUrl,
+		LogoUrl:      input.LogoUrl,
+	}
+}
+
+func newServiceOutput(svc dbmodel.Service) ServiceOutput {
+	aliases := svc.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return ServiceOutput{
+		Id:           svc.Id,
+		Name:         svc.Name,
+		Aliases:      aliases,
+		Category:     svc.Category,
+		DefaultPrice: svc.DefaultPrice,
+		YearlyPrice:  svc.YearlyPrice,
+		FamilyPrice:  svc.FamilyPrice,
+		FamilySize:   svc.FamilySize,
+		VendorUrl:    svc.VendorUrl,
+		LogoUrl:      svc.LogoUrl,
+		CreatedAt:    svc.CreatedAt,
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+)
+
+func TestCatalogService_Create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input ServiceInput
+	}
+
+	type mockBehaviour func(service *repomocks.MockService, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectId      int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				input: ServiceInput{
+					Name:     " Yandex  Plus ",
+					Aliases:  []string{"Яндекс Плюс"},
+					Category: ptr("music"),
+				},
+			},
+			mockBehaviour: func(service *repomocks.MockService, a args) {
+				service.EXPECT().FindByName(a.ctx, "Yandex Plus").Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+				service.EXPECT().FindByName(a.ctx, "Яндекс Плюс").Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+				service.EXPECT().Create(a.ctx, dbmodel.Service{
+					Name:     "Yandex Plus",
+					Aliases:  []string{"Яндекс Плюс"},
+					Category: ptr("music"),
+				}).Return(1, nil)
+			},
+			expectId:  1,
+			expectErr: nil,
+		},
+		{
+			testName: "alias belongs to another service",
+			args: args{
+				ctx: context.Background(),
+				input: ServiceInput{
+					Name:    "Kinopoisk",
+					Aliases: []string{"Yandex Plus"},
+				},
+			},
+			mockBehaviour: func(service *repomocks.MockService, a args) {
+				service.EXPECT().FindByName(a.ctx, "Kinopoisk").Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+				service.EXPECT().FindByName(a.ctx, "Yandex Plus").Return(dbmodel.Service{Id: 1, Name: "Yandex Plus"}, nil)
+			},
+			expectId:  0,
+			expectErr: ErrServiceAlreadyExists,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: context.Background(),
+				input: ServiceInput{
+					Name: "Kinopoisk",
+				},
+			},
+			mockBehaviour: func(service *repomocks.MockService, a args) {
+				service.EXPECT().FindByName(a.ctx, "Kinopoisk").Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+				service.EXPECT().Create(a.ctx, gomock.Any()).Return(0, errors.New("some error"))
+			},
+			expectId:  0,
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			service := repomocks.NewMockService(ctrl)
+			tc.mockBehaviour(service, tc.args)
+
+			s := newCatalogService(service)
+
+			id, err := s.Create(tc.args.ctx, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectId, id)
+		})
+	}
+}
+
+func TestCatalogService_Delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int
+	}
+
+	type mockBehaviour func(service *repomocks.MockService, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehaviour: func(service *repomocks.MockService, a args) {
+				service.EXPECT().Delete(a.ctx, a.id).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "service in use",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehaviour: func(service *repomocks.MockService, a args) {
+				service.EXPECT().Delete(a.ctx, a.id).Return(pgerrs.ErrReferenced)
+			},
+			expectErr: ErrServiceInUse,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+			},
+			mockBehaviour: func(service *repomocks.MockService, a args) {
+				service.EXPECT().Delete(a.ctx, a.id).Return(pgerrs.ErrNotFound)
+			},
+			expectErr: ErrServiceNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			service := repomocks.NewMockService(ctrl)
+			tc.mockBehaviour(service, tc.args)
+
+			s := newCatalogService(service)
+
+			err := s.Delete(tc.args.ctx, tc.args.id)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
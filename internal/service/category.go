@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+)
+
+type categoryService struct {
+	category repo.Category
+}
+
+func newCategoryService(category repo.Category) *categoryService {
+	return &categoryService{
+		category: category,
+	}
+}
+
+func (s *categoryService) Create(ctx context.Context, input CategoryInput) (int, error) {
+	if err := s.checkParent(ctx, 0, input.ParentId); err != nil {
+		return 0, err
+	}
+
+	id, err := s.category.Create(ctx, dbmodel.Category{
+		Name:     normalizeName(input.Name),
+		ParentId: input.ParentId,
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrAlreadyExists) {
+			return 0, ErrCategoryAlreadyExists
+		}
+		log.Err(err).Interface("input", input).Msg("category/Create error create category in database")
+		return 0, err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("category/Create create new category in database")
+	return id, nil
+}
+
+func (s *categoryService) FindById(ctx context.Context, id int) (CategoryOutput, error) {
+	c, err := s.category.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return CategoryOutput{}, ErrCategoryNotFound
+		}
+		log.Err(err).Int("id", id).Msg("category/FindById error find category in database")
+		return CategoryOutput{}, err
+	}
+	return newCategoryOutput(c), nil
+}
+
+func (s *categoryService) FindAll(ctx context.Context) ([]CategoryOutput, error) {
+	categories, err := s.category.FindAll(ctx)
+	if err != nil {
+		log.Err(err).Msg("category/FindAll error find all categories in database")
+		return nil, err
+	}
+	result := make([]CategoryOutput, 0, len(categories))
+	for _, c := range categories {
+		result = append(result, newCategoryOutput(c))
+	}
+	return result, nil
+}
+
+func (s *categoryService) Update(ctx context.Context, id int, input CategoryInput) error {
+	if err := s.checkParent(ctx, id, input.ParentId); err != nil {
+		return err
+	}
+
+	err := s.category.Update(ctx, dbmodel.Category{
+		Id:       id,
+		Name:     normalizeName(input.Name),
+		ParentId: input.ParentId,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgerrs.ErrNotFound):
+			return ErrCategoryNotFound
+		case errors.Is(err, pgerrs.ErrAlreadyExists):
+			return ErrCategoryAlreadyExists
+		}
+		log.Err(err).Int("id", id).Msg("category/Update error update category in database")
+		return err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("category/Update update category in database")
+	return nil
+}
+
+func (s *categoryService) Delete(ctx context.Context, id int) error {
+	if err := s.category.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, pgerrs.ErrNotFound):
+			return ErrCategoryNotFound
+		case errors.Is(err, pgerrs.ErrReferenced):
+			return ErrCategoryInUse
+		}
+		log.Err(err).Int("id", id).Msg("category/Delete error delete category in database")
+		return err
+	}
+	log.Info().Int("id", id).Msg("category/Delete delete category in database")
+	return nil
+}
+
+// checkParent makes sure parent exists and is not category id itself or one of its subcategories,
+// so categories stay a tree. Zero id is used for new categories
+func (s *categoryService) checkParent(ctx context.Context, id int, parentId *int) error {
+	for next := parentId; next != nil; {
+		if *next == id {
+			return ErrInvalidCategoryParent
+		}
+		parent, err := s.category.FindById(ctx, *next)
+		if err != nil {
+			if errors.Is(err, pgerrs.ErrNotFound) {
+				return ErrInvalidCategoryParent
+			}
+			log.Err(err).Int("id", *next).Msg("category/checkParent error find category in database")
+			return err
+		}
+		next = parent.ParentId
+	}
+	return nil
+}
+
+func newCategoryOutput(c dbmodel.Category) CategoryOutput {
+	return CategoryOutput{
+		Id:        c.Id,
+		Name:      c.Name,
+		ParentId:  c.ParentId,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+)
+
+func TestCategoryService_Create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input CategoryInput
+	}
+
+	type mockBehaviour func(category *repomocks.MockCategory, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectId      int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				input: CategoryInput{Name: " Streaming ", ParentId: ptr(1)},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {
+				category.EXPECT().FindById(a.ctx, 1).Return(dbmodel.Category{Id: 1, Name: "Entertainment"}, nil)
+				category.EXPECT().Create(a.ctx, dbmodel.Category{Name: "Streaming", ParentId: ptr(1)}).Return(2, nil)
+			},
+			expectId:  2,
+			expectErr: nil,
+		},
+		{
+			testName: "parent not found",
+			args: args{
+				ctx:   context.Background(),
+				input: CategoryInput{Name: "Streaming", ParentId: ptr(5)},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {
+				category.EXPECT().FindById(a.ctx, 5).Return(dbmodel.Category{}, pgerrs.ErrNotFound)
+			},
+			expectId:  0,
+			expectErr: ErrInvalidCategoryParent,
+		},
+		{
+			testName: "name is taken",
+			args: args{
+				ctx:   context.Background(),
+				input: CategoryInput{Name: "Cloud"},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {
+				category.EXPECT().Create(a.ctx, dbmodel.Category{Name: "Cloud"}).Return(0, pgerrs.ErrAlreadyExists)
+			},
+			expectId:  0,
+			expectErr: ErrCategoryAlreadyExists,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:   context.Background(),
+				input: CategoryInput{Name: "Cloud"},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {
+				category.EXPECT().Create(a.ctx, gomock.Any()).Return(0, errors.New("some error"))
+			},
+			expectId:  0,
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			category := repomocks.NewMockCategory(ctrl)
+			tc.mockBehaviour(category, tc.args)
+
+			s := newCategoryService(category)
+
+			id, err := s.Create(tc.args.ctx, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectId, id)
+		})
+	}
+}
+
+func TestCategoryService_Update(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input CategoryInput
+	}
+
+	type mockBehaviour func(category *repomocks.MockCategory, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				id:    3,
+				input: CategoryInput{Name: "Music", ParentId: ptr(2)},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {
+				category.EXPECT().FindById(a.ctx, 2).Return(dbmodel.Category{Id: 2, ParentId: ptr(1)}, nil)
+				category.EXPECT().FindById(a.ctx, 1).Return(dbmodel.Category{Id: 1}, nil)
+				category.EXPECT().Update(a.ctx, dbmodel.Category{Id: 3, Name: "Music", ParentId: ptr(2)}).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "parent is the category itself",
+			args: args{
+				ctx:   context.Background(),
+				id:    3,
+				input: CategoryInput{Name: "Music", ParentId: ptr(3)},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {},
+			expectErr:     ErrInvalidCategoryParent,
+		},
+		{
+			testName: "parent is subcategory",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CategoryInput{Name: "Entertainment", ParentId: ptr(3)},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {
+				category.EXPECT().FindById(a.ctx, 3).Return(dbmodel.Category{Id: 3, ParentId: ptr(2)}, nil)
+				category.EXPECT().FindById(a.ctx, 2).Return(dbmodel.Category{Id: 2, ParentId: ptr(1)}, nil)
+			},
+			expectErr: ErrInvalidCategoryParent,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx:   context.Background(),
+				id:    7,
+				input: CategoryInput{Name: "Cloud"},
+			},
+			mockBehaviour: func(category *repomocks.MockCategory, a args) {
+				category.EXPECT().Update(a.ctx, dbmodel.Category{Id: 7, Name: "Cloud"}).Return(pgerrs.ErrNotFound)
+			},
+			expectErr: ErrCategoryNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			category := repomocks.NewMockCategory(ctrl)
+			tc.mockBehaviour(category, tc.args)
+
+			s := newCategoryService(category)
+
+			err := s.Update(tc.args.ctx, tc.args.id, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestCategoryService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	category := repomocks.NewMockCategory(ctrl)
+	category.EXPECT().Delete(gomock.Any(), 1).Return(pgerrs.ErrReferenced)
+
+	s := newCategoryService(category)
+
+	assert.Equal(t, ErrCategoryInUse, s.Delete(context.Background(), 1))
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+// chargeTransitions are statuses charge can move to from its current one. Failed charge can be paid on retry,
+// refunded one is final
+var chargeTransitions = map[string][]string{
+	dbmodel.ChargePending: {dbmodel.ChargePaid, dbmodel.ChargeFailed},
+	dbmodel.ChargeFailed:  {dbmodel.ChargePaid},
+	dbmodel.ChargePaid:    {dbmodel.ChargeRefunded},
+}
+
+var chargeEvents = map[string]string{
+	dbmodel.ChargePaid:     dbmodel.EventChargePaid,
+	dbmodel.ChargeFailed:   dbmodel.EventChargeFailed,
+	dbmodel.ChargeRefunded: dbmodel.EventChargeRefunded,
+}
+
+// chargeBatchSize is number of subscriptions read at once by generation
+const chargeBatchSize = 500
+
+type chargeService struct {
+	tx      repo.Transactor
+	sub     repo.Subscription
+	charge  repo.Charge
+	outbox  repo.Outbox
+	horizon int // months ahead of today until can be
+	batch   int
+}
+
+func newChargeService(tx repo.Transactor, sub repo.Subscription, charge repo.Charge, outbox repo.Outbox, horizon int) *chargeService {
+	return &chargeService{
+		tx:      tx,
+		sub:     sub,
+		charge:  charge,
+		outbox:  outbox,
+		horizon: horizon,
+		batch:   chargeBatchSize,
+	}
+}
+
+// Generate records pending charge for every billing date of subscriptions up to until inclusive and returns number
+// of created charges. Zero until means current day of subscription user. Charges in free trial and paused periods
+// are not recorded. Generation continues after the last recorded charge of subscription, so it can be repeated and
+// charges before the last one are kept as is. Subscriptions are read by pages of batch size. Until more than horizon
+// months ahead of today is rejected, so ledger is not flooded with charges of open-ended subscriptions
+func (s *chargeService) Generate(ctx context.Context, until time.Time) (int, error) {
+	if until.After(time.Now().UTC().AddDate(0, s.horizon, 0)) {
+		return 0, ErrChargeBeyondHorizon
+	}
+
+	var created, afterId int
+
+	for {
+		subscriptions, err := s.sub.FindAll(ctx, dbmodel.SubscriptionFilter{AfterId: afterId, Limit: s.batch})
+		if err != nil {
+			log.Err(err).Int("after_id", afterId).Msg("charge/Generate error find subscriptions in database")
+			return created, err
+		}
+		if len(subscriptions) == 0 {
+			break
+		}
+
+		ids := make([]int, 0, len(subscriptions))
+		for _, sub := range subscriptions {
+			ids = append(ids, sub.Id)
+		}
+		lastDates, err := s.charge.FindLastBillingDates(ctx, ids)
+		if err != nil {
+			log.Err(err).Int("after_id", afterId).Msg("charge/Generate error find last billing dates in database")
+			return created, err
+		}
+
+		for _, sub := range subscriptions {
+			last := truncateToDay(until)
+			if until.IsZero() {
+				last = today(sub)
+			}
+			charges := subscriptionCharges(sub, lastDates[sub.Id], last)
+			if len(charges) == 0 {
+				continue
+			}
+
+			// charges belong to organization of subscription
+			n, err := s.charge.Create(tenant.WithOrganization(ctx, sub.OrganizationId), charges)
+			if err != nil {
+				// other subscriptions are still charged, failed one will be retried on next run
+				log.Err(err).Int("id", sub.Id).Msg("charge/Generate error create charges in database")
+				continue
+			}
+			created += n
+		}
+
+		if len(subscriptions) < s.batch {
+			break
+		}
+		afterId = subscriptions[len(subscriptions)-1].Id
+	}
+	log.Info().Int("created", created).Time("until", until).Msg("charge/Generate generate charges")
+	return created, nil
+}
+
+func (s *chargeService) FindById(ctx context.Context, id int64) (ChargeOutput, error) {
+	c, err := s.findById(ctx, id)
+	if err != nil {
+		return ChargeOutput{}, err
+	}
+	return newChargeOutput(c), nil
+}
+
+func (s *chargeService) FindAll(ctx context.Context, filter ChargeFilterInput) ([]ChargeOutput, error) {
+	charges, err := s.charge.FindAll(ctx, dbmodel.ChargeFilter{
+		UserId:         filter.UserId,
+		SubscriptionId: filter.SubscriptionId,
+		Status:         filter.Status,
+		Start:          filter.StartDate,
+		End:            filter.EndDate,
+	})
+	if err != nil {
+		log.Err(err).Interface("filter", filter).Msg("charge/FindAll error find charges in database")
+		return nil, err
+	}
+	result := make([]ChargeOutput, 0, len(charges))
+	for _, c := range charges {
+		result = append(result, newChargeOutput(c))
+	}
+	return result, nil
+}
+
+// SetStatus moves charge to status allowed by chargeTransitions and emits event of new status
+func (s *chargeService) SetStatus(ctx context.Context, id int64, status string) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.findById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(chargeTransitions[c.Status], status) {
+			return ErrInvalidChargeStatus
+		}
+
+		if err = s.charge.UpdateStatus(ctx, id, c.Status, status); err != nil {
+			if errors.Is(err, pgerrs.ErrNotFound) {
+				// status was changed concurrently
+				return ErrInvalidChargeStatus
+			}
+			return err
+		}
+		c.Status = status
+
+		return s.outbox.Create(ctx, newChargeEvent(c))
+	})
+	if err != nil {
+		if errors.Is(err, ErrChargeNotFound) || errors.Is(err, ErrInvalidChargeStatus) {
+			return err
+		}
+		log.Err(err).Int64("id", id).Str("status", status).Msg("charge/SetStatus error update charge status in database")
+		return err
+	}
+	log.Info().Int64("id", id).Str("status", status).Msg("charge/SetStatus update charge status in database")
+	return nil
+}
+
+func (s *chargeService) findById(ctx context.Context, id int64) (dbmodel.Charge, error) {
+	c, err := s.charge.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return dbmodel.Charge{}, ErrChargeNotFound
+		}
+		log.Err(err).Int64("id", id).Msg("charge/FindById error find charge in database")
+		return dbmodel.Charge{}, err
+	}
+	return c, nil
+}
+
+// subscriptionCharges returns charges of subscription billed after date of already recorded one, zero if there is
+// none, up to last day inclusive
+func subscriptionCharges(sub dbmodel.Subscription, after, last time.Time) []dbmodel.Charge {
+	if sub.EndDate != nil && sub.EndDate.Before(last) {
+		last = *sub.EndDate
+	}
+
+	// billing dates up to after are already charged
+	n := 0
+	if !after.IsZero() {
+		n = max(monthsBetween(sub.StartDate, after)/periodMonths(sub.BillingPeriod), 0)
+		for !billingDate(sub, n).After(after) {
+			n++
+		}
+	}
+
+	var result []dbmodel.Charge
+
+	for ; ; n++ {
+		date := billingDate(sub, n)
+		if date.After(last) || pausedIndefinitely(sub, date) {
+			break
+		}
+		if paused(sub, date) || inTrial(sub, date) {
+			continue
+		}
+		price := chargeOn(sub, date)
+		result = append(result, dbmodel.Charge{
+			SubscriptionId: sub.Id,
+			UserId:         sub.UserId,
+			BillingDate:    date,
+			Amount:         price.Gross,
+			Tax:            price.Tax,
+			Discount:       price.Discount,
+		})
+	}
+	return result
+}
+
+type chargePayload struct {
+	ChargeId       int64  `json:"charge_id"`
+	SubscriptionId int    `json:"subscription_id"`
+	ServiceName    string `json:"service_name"`
+	UserId         string `json:"user_id"`
+	BillingDate    string `json:"billing_date"`
+	Amount         int    `json:"amount"`
+	Tax            int    `json:"tax"`
+	Discount       int    `json:"discount"`
+	Status         string `json:"status"`
+}
+
+func newChargeEvent(c dbmodel.Charge) dbmodel.OutboxEvent {
+	payload, _ := json.Marshal(chargePayload{
+		ChargeId:       c.Id,
+		SubscriptionId: c.SubscriptionId,
+		ServiceName:    c.ServiceName,
+		UserId:         c.UserId,
+		BillingDate:    formatDate(c.BillingDate),
+		Amount:         c.Amount,
+		Tax:            c.Tax,
+		Discount:       c.Discount,
+		Status:         c.Status,
+	})
+	return dbmodel.OutboxEvent{
+		EventType:   chargeEvents[c.Status],
+		AggregateId: c.SubscriptionId,
+		Payload:     payload,
+	}
+}
+
+func newChargeOutput(c dbmodel.Charge) ChargeOutput {
+	return ChargeOutput{
+		Id:             c.Id,
+		SubscriptionId: c.SubscriptionId,
+		ServiceName:    c.ServiceName,
+		UserId:         c.UserId,
+		BillingDate:    formatDate(c.BillingDate),
+		Amount:         c.Amount,
+		Tax:            c.Tax,
+		Discount:       c.Discount,
+		Status:         c.Status,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+func TestChargeService_Generate(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		until time.Time
+	}
+
+	type mockBehaviour func(sub *repomocks.MockSubscription, charge *repomocks.MockCharge, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	// monthly subscription on the last day of month with free trial and paused April
+	monthly := func() dbmodel.Subscription {
+		return dbmodel.Subscription{
+			Id:            1,
+			Price:         300,
+			UserId:        userId,
+			StartDate:     date(2025, 1, 31),
+			BillingPeriod: dbmodel.BillingMonthly,
+			TrialEndDate:  ptr(date(2025, 2, 15)),
+			Pauses:        []dbmodel.Pause{{Id: 1, StartDate: date(2025, 4, 1), EndDate: ptr(date(2025, 4, 30))}},
+
+			OrganizationId: 2,
+		}
+	}
+	// yearly subscription ended before its second charge
+	yearly := func() dbmodel.Subscription {
+		return dbmodel.Subscription{
+			Id:            2,
+			Price:         1200,
+			UserId:        userId,
+			StartDate:     date(2024, 6, 10),
+			EndDate:       ptr(date(2025, 3, 1)),
+			BillingPeriod: dbmodel.BillingYearly,
+
+			OrganizationId: 1,
+		}
+	}
+	// subscription starting after generation horizon
+	future := func() dbmodel.Subscription {
+		return dbmodel.Subscription{
+			Id:        3,
+			Price:     500,
+			UserId:    userId,
+			StartDate: date(2025, 7, 1),
+
+			OrganizationId: 1,
+		}
+	}
+	// charges belong to organization of subscription
+	orgCtx := tenant.WithOrganization(context.Background(), 2)
+	defaultCtx := tenant.WithOrganization(context.Background(), 1)
+	today := truncateToDay(time.Now().UTC())
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectCreated int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				until: date(2025, 5, 31),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, charge *repomocks.MockCharge, a args) {
+				// subscriptions are read by pages of two
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{Limit: 2}).Return([]dbmodel.Subscription{monthly(), yearly()}, nil)
+				charge.EXPECT().FindLastBillingDates(a.ctx, []int{1, 2}).Return(map[int]time.Time{}, nil)
+				charge.EXPECT().Create(orgCtx, []dbmodel.Charge{
+					{SubscriptionId: 1, UserId: userId, BillingDate: date(2025, 2, 28), Amount: 300},
+					{SubscriptionId: 1, UserId: userId, BillingDate: date(2025, 3, 31), Amount: 300},
+					{SubscriptionId: 1, UserId: userId, BillingDate: date(2025, 5, 31), Amount: 300},
+				}).Return(2, nil)
+				charge.EXPECT().Create(defaultCtx, []dbmodel.Charge{
+					{SubscriptionId: 2, UserId: userId, BillingDate: date(2024, 6, 10), Amount: 1200},
+				}).Return(1, nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{AfterId: 2, Limit: 2}).Return([]dbmodel.Subscription{future()}, nil)
+				charge.EXPECT().FindLastBillingDates(a.ctx, []int{3}).Return(nil, nil)
+			},
+			expectCreated: 3,
+			expectErr:     nil,
+		},
+		{
+			testName: "after last recorded charge",
+			args: args{
+				ctx:   context.Background(),
+				until: date(2025, 5, 31),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, charge *repomocks.MockCharge, a args) {
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{Limit: 2}).Return([]dbmodel.Subscription{monthly(), yearly()}, nil)
+				charge.EXPECT().FindLastBillingDates(a.ctx, []int{1, 2}).Return(map[int]time.Time{
+					1: date(2025, 3, 31),
+					2: date(2024, 6, 10),
+				}, nil)
+				charge.EXPECT().Create(orgCtx, []dbmodel.Charge{
+					{SubscriptionId: 1, UserId: userId, BillingDate: date(2025, 5, 31), Amount: 300},
+				}).Return(1, nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{AfterId: 2, Limit: 2}).Return(nil, nil)
+			},
+			expectCreated: 1,
+			expectErr:     nil,
+		},
+		{
+			testName: "current day of user by default",
+			args: args{
+				ctx: context.Background(),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, charge *repomocks.MockCharge, a args) {
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{Limit: 2}).Return([]dbmodel.Subscription{
+					{Id: 4, Price: 400, UserId: userId, StartDate: today, Timezone: dbmodel.DefaultTimezone, OrganizationId: 1},
+				}, nil)
+				charge.EXPECT().FindLastBillingDates(a.ctx, []int{4}).Return(nil, nil)
+				charge.EXPECT().Create(defaultCtx, []dbmodel.Charge{
+					{SubscriptionId: 4, UserId: userId, BillingDate: today, Amount: 400},
+				}).Return(0, nil)
+			},
+			expectCreated: 0,
+			expectErr:     nil,
+		},
+		{
+			testName: "until beyond horizon",
+			args: args{
+				ctx:   context.Background(),
+				until: today.AddDate(1, 1, 0),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, charge *repomocks.MockCharge, a args) {},
+			expectCreated: 0,
+			expectErr:     ErrChargeBeyondHorizon,
+		},
+		{
+			testName: "error of one subscription",
+			args: args{
+				ctx:   context.Background(),
+				until: date(2025, 5, 31),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, charge *repomocks.MockCharge, a args) {
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{Limit: 2}).Return([]dbmodel.Subscription{monthly(), yearly()}, nil)
+				charge.EXPECT().FindLastBillingDates(a.ctx, []int{1, 2}).Return(nil, nil)
+				charge.EXPECT().Create(orgCtx, gomock.Any()).Return(0, errors.New("some error"))
+				charge.EXPECT().Create(defaultCtx, gomock.Any()).Return(1, nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{AfterId: 2, Limit: 2}).Return(nil, nil)
+			},
+			expectCreated: 1,
+			expectErr:     nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:   context.Background(),
+				until: date(2025, 5, 31),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, charge *repomocks.MockCharge, a args) {
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{Limit: 2}).Return(nil, errors.New("some error"))
+			},
+			expectCreated: 0,
+			expectErr:     errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := repomocks.NewMockSubscription(ctrl)
+			charge := repomocks.NewMockCharge(ctrl)
+			tc.mockBehaviour(sub, charge, tc.args)
+
+			s := newChargeService(nil, sub, charge, nil, 12)
+			s.batch = 2
+
+			created, err := s.Generate(tc.args.ctx, tc.args.until)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectCreated, created)
+		})
+	}
+}
+
+func TestChargeService_SetStatus(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     int64
+		status string
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, charge *repomocks.MockCharge, outbox *repomocks.MockOutbox, a args)
+
+	newCharge := func(status string) dbmodel.Charge {
+		return dbmodel.Charge{
+			Id:             1,
+			SubscriptionId: 3,
+			ServiceName:    "Yandex Plus",
+			UserId:         "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			BillingDate:    time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC),
+			Amount:         400,
+			Status:         status,
+		}
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "pay pending charge",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: dbmodel.ChargePaid,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, charge *repomocks.MockCharge, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				charge.EXPECT().FindById(a.ctx, a.id).Return(newCharge(dbmodel.ChargePending), nil)
+				charge.EXPECT().UpdateStatus(a.ctx, a.id, dbmodel.ChargePending, dbmodel.ChargePaid).Return(nil)
+				outbox.EXPECT().Create(a.ctx, newChargeEvent(newCharge(dbmodel.ChargePaid))).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "refund paid charge",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: dbmodel.ChargeRefunded,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, charge *repomocks.MockCharge, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				charge.EXPECT().FindById(a.ctx, a.id).Return(newCharge(dbmodel.ChargePaid), nil)
+				charge.EXPECT().UpdateStatus(a.ctx, a.id, dbmodel.ChargePaid, dbmodel.ChargeRefunded).Return(nil)
+				outbox.EXPECT().Create(a.ctx, newChargeEvent(newCharge(dbmodel.ChargeRefunded))).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "refund pending charge",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: dbmodel.ChargeRefunded,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, charge *repomocks.MockCharge, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				charge.EXPECT().FindById(a.ctx, a.id).Return(newCharge(dbmodel.ChargePending), nil)
+			},
+			expectErr: ErrInvalidChargeStatus,
+		},
+		{
+			testName: "status changed concurrently",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: dbmodel.ChargeFailed,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, charge *repomocks.MockCharge, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				charge.EXPECT().FindById(a.ctx, a.id).Return(newCharge(dbmodel.ChargePending), nil)
+				charge.EXPECT().UpdateStatus(a.ctx, a.id, dbmodel.ChargePending, dbmodel.ChargeFailed).Return(pgerrs.ErrNotFound)
+			},
+			expectErr: ErrInvalidChargeStatus,
+		},
+		{
+			testName: "charge not found",
+			args: args{
+				ctx:    context.Background(),
+				id:     2,
+				status: dbmodel.ChargePaid,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, charge *repomocks.MockCharge, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				charge.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Charge{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrChargeNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: dbmodel.ChargePaid,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, charge *repomocks.MockCharge, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				charge.EXPECT().FindById(a.ctx, a.id).Return(newCharge(dbmodel.ChargeFailed), nil)
+				charge.EXPECT().UpdateStatus(a.ctx, a.id, dbmodel.ChargeFailed, dbmodel.ChargePaid).Return(nil)
+				outbox.EXPECT().Create(a.ctx, gomock.Any()).Return(errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			charge := repomocks.NewMockCharge(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, charge, outbox, tc.args)
+
+			s := newChargeService(tx, nil, charge, outbox, 12)
+
+			err := s.SetStatus(tc.args.ctx, tc.args.id, tc.args.status)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
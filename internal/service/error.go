@@ -3,5 +3,64 @@ package service
 import "errors"
 
 var (
-	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrOrganizationNotFound      = errors.New("organization not found")
+	ErrOrganizationAlreadyExists = errors.New("organization with this name already exists")
+
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists = errors.New("user with this email already exists")
+	ErrUserInUse         = errors.New("user has subscriptions")
+	ErrInvalidTimezone   = errors.New("invalid time zone")
+
+	ErrSubscriptionNotFound  = errors.New("subscription not found")
+	ErrInvalidCalendarToken  = errors.New("invalid calendar token")
+	ErrCalendarDisabled      = errors.New("calendar secret is not set")
+	ErrInvalidTrial          = errors.New("trial must end after subscription start and intro periods need intro price")
+	ErrInvalidDiscount       = errors.New("percent discount must not exceed 100 and discount must start within subscription")
+	ErrDuplicateSubscription = errors.New("user already has subscription to this service in this period")
+
+	ErrInvalidPause          = errors.New("pause must be within subscription and end after its start")
+	ErrSubscriptionPaused    = errors.New("subscription is already paused in this period")
+	ErrSubscriptionNotPaused = errors.New("subscription is not paused")
+
+	ErrInvalidCancellation      = errors.New("subscription can't end before current month or its start")
+	ErrSubscriptionEnded        = errors.New("subscription has already ended")
+	ErrSubscriptionNotCancelled = errors.New("subscription is not cancelled")
+
+	ErrShareNotFound = errors.New("subscription is not shared")
+	ErrInvalidShare  = errors.New("invalid shares for split rule")
+
+	ErrPriceChangeNotFound = errors.New("price change not found")
+	ErrInvalidPriceChange  = errors.New("price change must start after subscription start")
+
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrBudgetNotFound = errors.New("budget not found")
+
+	ErrChargeNotFound      = errors.New("charge not found")
+	ErrInvalidChargeStatus = errors.New("charge can't move to this status")
+	ErrChargeBeyondHorizon = errors.New("charges can't be generated so far ahead")
+
+	ErrServiceNotFound      = errors.New("service not found")
+	ErrServiceAlreadyExists = errors.New("service with this name or alias already exists")
+	ErrServiceInUse         = errors.New("service is used by subscriptions")
+
+	ErrCategoryNotFound      = errors.New("category not found")
+	ErrCategoryAlreadyExists = errors.New("category with this name already exists in parent category")
+	ErrCategoryInUse         = errors.New("category has subcategories")
+	ErrInvalidCategoryParent = errors.New("parent category does not exist or is a subcategory of category")
 )
+
+// DuplicateError lists subscriptions of the same user and service overlapping with created one.
+// It matches ErrDuplicateSubscription
+type DuplicateError struct {
+	Ids []int
+}
+
+func (e *DuplicateError) Error() string {
+	return ErrDuplicateSubscription.Error()
+}
+
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicateSubscription
+}
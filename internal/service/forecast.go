@@ -0,0 +1,234 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+type forecastService struct {
+	tx          repo.Transactor
+	sub         repo.Subscription
+	service     repo.Service
+	priceChange repo.PriceChange
+	outbox      repo.Outbox
+}
+
+func newForecastService(tx repo.Transactor, subscription repo.Subscription, service repo.Service, priceChange repo.PriceChange, outbox repo.Outbox) *forecastService {
+	return &forecastService{
+		tx:          tx,
+		sub:         subscription,
+		service:     service,
+		priceChange: priceChange,
+		outbox:      outbox,
+	}
+}
+
+// Forecast projects spend of every month from start month. Subscription is charged in months of its billing period
+// until end date, with the latest price change scheduled not after the month
+func (s *forecastService) Forecast(ctx context.Context, input ForecastInput) (ForecastOutput, error) {
+	start := monthStart(input.StartDate)
+
+	subscriptions, err := s.sub.FindActive(ctx, input.UserId, start)
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("forecast/Forecast error find active subscriptions in database")
+		return ForecastOutput{}, err
+	}
+	if input.ServiceName != "" {
+		serviceId, _, err := findServiceId(ctx, s.service, input.ServiceName)
+		if err != nil {
+			log.Err(err).Interface("input", input).Msg("forecast/Forecast error find service in database")
+			return ForecastOutput{}, err
+		}
+		subscriptions = slices.DeleteFunc(subscriptions, func(sub dbmodel.Subscription) bool {
+			return sub.ServiceId != serviceId
+		})
+	}
+
+	changes := make(map[int][]dbmodel.PriceChange)
+
+	if len(subscriptions) > 0 {
+		ids := make([]int, 0, len(subscriptions))
+		for _, sub := range subscriptions {
+			ids = append(ids, sub.Id)
+		}
+		priceChanges, err := s.priceChange.FindBySubscriptions(ctx, ids)
+		if err != nil {
+			log.Err(err).Interface("input", input).Msg("forecast/Forecast error find price changes in database")
+			return ForecastOutput{}, err
+		}
+		for _, c := range priceChanges {
+			changes[c.SubscriptionId] = append(changes[c.SubscriptionId], c)
+		}
+	}
+
+	output := ForecastOutput{
+		Months: make([]ForecastMonthOutput, 0, input.Months),
+	}
+	for i := range input.Months {
+		month := start.AddDate(0, i, 0)
+		byService := make(map[string]int)
+
+		for _, sub := range subscriptions {
+			// trial and intro prices take precedence over scheduled changes of regular price
+			sub.Price = priceAt(sub, changes[sub.Id], month)
+			if charge, ok := monthCharge(sub, month); ok {
+				byService[sub.ServiceName] += charge
+			}
+		}
+
+		m := ForecastMonthOutput{
+			Month:    formatMonth(month),
+			Services: make([]ForecastServiceOutput, 0, len(byService)),
+		}
+		for name, price := range byService {
+			m.Total += price
+			m.Services = append(m.Services, ForecastServiceOutput{ServiceName: name, Price: price})
+		}
+		slices.SortFunc(m.Services, func(a, b ForecastServiceOutput) int {
+			return cmp.Or(cmp.Compare(b.Price, a.Price), cmp.Compare(a.ServiceName, b.ServiceName))
+		})
+
+		output.Total += m.Total
+		output.Months = append(output.Months, m)
+	}
+	return output, nil
+}
+
+func (s *forecastService) SchedulePriceChange(ctx context.Context, subscriptionId int, input PriceChangeInput) (int, error) {
+	sub, err := s.sub.FindById(ctx, subscriptionId)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return 0, ErrSubscriptionNotFound
+		}
+		log.Err(err).Int("id", subscriptionId).Msg("forecast/SchedulePriceChange error find subscription in database")
+		return 0, err
+	}
+	if monthsBetween(sub.StartDate, input.StartDate) <= 0 {
+		return 0, ErrInvalidPriceChange
+	}
+
+	id, err := s.priceChange.Create(ctx, dbmodel.PriceChange{
+		SubscriptionId: subscriptionId,
+		Price:          input.Price,
+		StartDate:      monthStart(input.StartDate),
+	})
+	if err != nil {
+		log.Err(err).Int("id", subscriptionId).Interface("input", input).Msg("forecast/SchedulePriceChange error create price change in database")
+		return 0, err
+	}
+	log.Info().Int("id", id).Int("subscription_id", subscriptionId).Interface("input", input).Msg("forecast/SchedulePriceChange schedule price change")
+	return id, nil
+}
+
+func (s *forecastService) FindPriceChanges(ctx context.Context, subscriptionId int) ([]PriceChangeOutput, error) {
+	if _, err := s.sub.FindById(ctx, subscriptionId); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		log.Err(err).Int("id", subscriptionId).Msg("forecast/FindPriceChanges error find subscription in database")
+		return nil, err
+	}
+	changes, err := s.priceChange.FindBySubscriptions(ctx, []int{subscriptionId})
+	if err != nil {
+		log.Err(err).Int("id", subscriptionId).Msg("forecast/FindPriceChanges error find price changes in database")
+		return nil, err
+	}
+	result := make([]PriceChangeOutput, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, PriceChangeOutput{
+			Id:             c.Id,
+			SubscriptionId: c.SubscriptionId,
+			Price:          c.Price,
+			StartDate:      formatMonth(c.StartDate),
+			CreatedAt:      c.CreatedAt,
+			AppliedAt:      c.AppliedAt,
+		})
+	}
+	return result, nil
+}
+
+func (s *forecastService) DeletePriceChange(ctx context.Context, subscriptionId, id int) error {
+	if _, err := s.sub.FindById(ctx, subscriptionId); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		log.Err(err).Int("id", subscriptionId).Msg("forecast/DeletePriceChange error find subscription in database")
+		return err
+	}
+	if err := s.priceChange.Delete(ctx, subscriptionId, id); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrPriceChangeNotFound
+		}
+		log.Err(err).Int("id", id).Msg("forecast/DeletePriceChange error delete price change in database")
+		return err
+	}
+	log.Info().Int("id", id).Int("subscription_id", subscriptionId).Msg("forecast/DeletePriceChange delete price change")
+	return nil
+}
+
+// priceAt returns price of subscription in month. changes must be ordered by start date
+func priceAt(sub dbmodel.Subscription, changes []dbmodel.PriceChange, month time.Time) int {
+	price := sub.Price
+	for _, c := range changes {
+		if c.StartDate.After(month) {
+			break
+		}
+		price = c.Price
+	}
+	return price
+}
+
+// ApplyPriceChanges writes the latest scheduled price started not after date to subscription, so pricing, statements
+// and charges use it, and emits subscription.updated event. Returns number of updated subscriptions
+func (s *forecastService) ApplyPriceChanges(ctx context.Context, date time.Time) (int, error) {
+	date = truncateToDay(date)
+
+	changes, err := s.priceChange.FindDue(ctx, date)
+	if err != nil {
+		log.Err(err).Time("date", date).Msg("forecast/ApplyPriceChanges error find due price changes in database")
+		return 0, err
+	}
+
+	var applied int
+
+	for _, c := range changes {
+		if err = s.applyPriceChange(ctx, c, date); err != nil {
+			// other changes are still applied, failed one will be retried on next run
+			log.Err(err).Int("subscription_id", c.SubscriptionId).Int("id", c.Id).Msg("forecast/ApplyPriceChanges error apply price change in database")
+			continue
+		}
+		applied++
+	}
+	log.Info().Int("applied", applied).Time("date", date).Msg("forecast/ApplyPriceChanges apply price changes")
+	return applied, nil
+}
+
+func (s *forecastService) applyPriceChange(ctx context.Context, c dbmodel.PriceChange, date time.Time) error {
+	sub, err := s.sub.FindById(ctx, c.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	// event belongs to organization of subscription
+	ctx = tenant.WithOrganization(ctx, sub.OrganizationId)
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if sub.Price != c.Price {
+			sub.Price = c.Price
+			if err := s.sub.Update(ctx, sub); err != nil {
+				return err
+			}
+			if err := s.outbox.Create(ctx, newSubscriptionEvent(dbmodel.EventSubscriptionUpdated, sub)); err != nil {
+				return err
+			}
+		}
+		return s.priceChange.MarkApplied(ctx, c.SubscriptionId, date)
+	})
+}
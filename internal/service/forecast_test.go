@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+func TestForecastService_Forecast(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input ForecastInput
+	}
+
+	type mockBehaviour func(sub *repomocks.MockSubscription, service *repomocks.MockService, priceChange *repomocks.MockPriceChange, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	subscriptions := []dbmodel.Subscription{
+		{Id: 1, ServiceId: 1, ServiceName: "Yandex Plus", Price: 400, UserId: userId, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, ServiceId: 2, ServiceName: "Spotify", Price: 1200, UserId: userId, StartDate: time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: dbmodel.BillingYearly},
+		{Id: 3, ServiceId: 1, ServiceName: "Yandex Plus", Price: 300, UserId: userId, StartDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), EndDate: &end},
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  ForecastOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				input: ForecastInput{
+					UserId:    userId,
+					StartDate: time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+					Months:    3,
+				},
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, service *repomocks.MockService, priceChange *repomocks.MockPriceChange, a args) {
+				sub.EXPECT().FindActive(a.ctx, userId, july).Return(subscriptions, nil)
+				priceChange.EXPECT().FindBySubscriptions(a.ctx, []int{1, 2, 3}).Return([]dbmodel.PriceChange{
+					{SubscriptionId: 1, Price: 500, StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+				}, nil)
+			},
+			expectOutput: ForecastOutput{
+				Total: 3100,
+				Months: []ForecastMonthOutput{
+					{
+						Month: "07-2025",
+						Total: 700,
+						Services: []ForecastServiceOutput{
+							{ServiceName: "Yandex Plus", Price: 700},
+						},
+					},
+					{
+						Month: "08-2025",
+						Total: 1900,
+						Services: []ForecastServiceOutput{
+							{ServiceName: "Spotify", Price: 1200},
+							{ServiceName: "Yandex Plus", Price: 700},
+						},
+					},
+					{
+						Month: "09-2025",
+						Total: 500,
+						Services: []ForecastServiceOutput{
+							{ServiceName: "Yandex Plus", Price: 500},
+						},
+					},
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "filter by service",
+			args: args{
+				ctx: context.Background(),
+				input: ForecastInput{
+					ServiceName: "Netflix",
+					StartDate:   july,
+					Months:      1,
+				},
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, service *repomocks.MockService, priceChange *repomocks.MockPriceChange, a args) {
+				sub.EXPECT().FindActive(a.ctx, "", july).Return(slices.Clone(subscriptions), nil)
+				service.EXPECT().FindByName(a.ctx, "Netflix").Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: ForecastOutput{
+				Months: []ForecastMonthOutput{
+					{Month: "07-2025", Services: []ForecastServiceOutput{}},
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "filter by service alias",
+			args: args{
+				ctx: context.Background(),
+				input: ForecastInput{
+					ServiceName: "yandex plus",
+					StartDate:   july,
+					Months:      1,
+				},
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, service *repomocks.MockService, priceChange *repomocks.MockPriceChange, a args) {
+				sub.EXPECT().FindActive(a.ctx, "", july).Return(slices.Clone(subscriptions), nil)
+				service.EXPECT().FindByName(a.ctx, "yandex plus").Return(dbmodel.Service{Id: 1, Name: "Yandex Plus"}, nil)
+				priceChange.EXPECT().FindBySubscriptions(a.ctx, []int{1, 3}).Return(nil, nil)
+			},
+			expectOutput: ForecastOutput{
+				Total: 700,
+				Months: []ForecastMonthOutput{
+					{
+						Month: "07-2025",
+						Total: 700,
+						Services: []ForecastServiceOutput{
+							{ServiceName: "Yandex Plus", Price: 700},
+						},
+					},
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: context.Background(),
+				input: ForecastInput{
+					StartDate: july,
+					Months:    1,
+				},
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, service *repomocks.MockService, priceChange *repomocks.MockPriceChange, a args) {
+				sub.EXPECT().FindActive(a.ctx, "", july).Return(nil, errors.New("some error"))
+			},
+			expectOutput: ForecastOutput{},
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := repomocks.NewMockSubscription(ctrl)
+			service := repomocks.NewMockService(ctrl)
+			priceChange := repomocks.NewMockPriceChange(ctrl)
+			tc.mockBehaviour(sub, service, priceChange, tc.args)
+
+			s := newForecastService(nil, sub, service, priceChange, nil)
+
+			output, err := s.Forecast(tc.args.ctx, tc.args.input)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+func TestForecastService_SchedulePriceChange(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input PriceChangeInput
+	}
+
+	type mockBehaviour func(sub *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args)
+
+	sub := dbmodel.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserId:      "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectId      int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: PriceChangeInput{Price: 500, StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(s *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args) {
+				s.EXPECT().FindById(a.ctx, a.id).Return(sub, nil)
+				priceChange.EXPECT().Create(a.ctx, dbmodel.PriceChange{
+					SubscriptionId: 1,
+					Price:          500,
+					StartDate:      time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(4, nil)
+			},
+			expectId:  4,
+			expectErr: nil,
+		},
+		{
+			testName: "change in start month",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: PriceChangeInput{Price: 500, StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(s *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args) {
+				s.EXPECT().FindById(a.ctx, a.id).Return(sub, nil)
+			},
+			expectId:  0,
+			expectErr: ErrInvalidPriceChange,
+		},
+		{
+			testName: "subscription not found",
+			args: args{
+				ctx:   context.Background(),
+				id:    2,
+				input: PriceChangeInput{Price: 500, StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(s *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args) {
+				s.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
+			},
+			expectId:  0,
+			expectErr: ErrSubscriptionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := repomocks.NewMockSubscription(ctrl)
+			priceChange := repomocks.NewMockPriceChange(ctrl)
+			tc.mockBehaviour(sub, priceChange, tc.args)
+
+			s := newForecastService(nil, sub, nil, priceChange, nil)
+
+			id, err := s.SchedulePriceChange(tc.args.ctx, tc.args.id, tc.args.input)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectId, id)
+		})
+	}
+}
+
+func TestForecastService_DeletePriceChange(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		id       int
+		changeId int
+	}
+
+	type mockBehaviour func(sub *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:      context.Background(),
+				id:       1,
+				changeId: 4,
+			},
+			mockBehaviour: func(s *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args) {
+				s.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{Id: 1}, nil)
+				priceChange.EXPECT().Delete(a.ctx, a.id, a.changeId).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "subscription of another organization",
+			args: args{
+				ctx:      context.Background(),
+				id:       2,
+				changeId: 4,
+			},
+			mockBehaviour: func(s *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args) {
+				s.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrSubscriptionNotFound,
+		},
+		{
+			testName: "price change not found",
+			args: args{
+				ctx:      context.Background(),
+				id:       1,
+				changeId: 5,
+			},
+			mockBehaviour: func(s *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, a args) {
+				s.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{Id: 1}, nil)
+				priceChange.EXPECT().Delete(a.ctx, a.id, a.changeId).Return(pgerrs.ErrNotFound)
+			},
+			expectErr: ErrPriceChangeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := repomocks.NewMockSubscription(ctrl)
+			priceChange := repomocks.NewMockPriceChange(ctrl)
+			tc.mockBehaviour(sub, priceChange, tc.args)
+
+			s := newForecastService(nil, sub, nil, priceChange, nil)
+
+			err := s.DeletePriceChange(tc.args.ctx, tc.args.id, tc.args.changeId)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestForecastService_ApplyPriceChanges(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, outbox *repomocks.MockOutbox, a args)
+
+	september := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)
+
+	yandex := dbmodel.Subscription{Id: 1, Price: 400, StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), OrganizationId: 2}
+	raised := yandex
+	raised.Price = 500
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectApplied int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:  context.Background(),
+				date: today.Add(10 * time.Hour),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, outbox *repomocks.MockOutbox, a args) {
+				orgCtx := tenant.WithOrganization(a.ctx, 2)
+
+				priceChange.EXPECT().FindDue(a.ctx, today).Return([]dbmodel.PriceChange{
+					{Id: 4, SubscriptionId: 1, Price: 500, StartDate: september},
+					{Id: 5, SubscriptionId: 2, Price: 300, StartDate: september},
+					{Id: 6, SubscriptionId: 3, Price: 200, StartDate: september},
+				}, nil)
+
+				sub.EXPECT().FindById(a.ctx, 1).Return(yandex, nil)
+				runInTransaction(tx)
+				sub.EXPECT().Update(orgCtx, raised).Return(nil)
+				outbox.EXPECT().Create(orgCtx, newSubscriptionEvent(dbmodel.EventSubscriptionUpdated, raised)).Return(nil)
+				priceChange.EXPECT().MarkApplied(orgCtx, 1, today).Return(nil)
+
+				// the same price is only marked applied
+				sub.EXPECT().FindById(a.ctx, 2).Return(dbmodel.Subscription{Id: 2, Price: 300, OrganizationId: 2}, nil)
+				runInTransaction(tx)
+				priceChange.EXPECT().MarkApplied(orgCtx, 2, today).Return(nil)
+
+				// failed change is retried on next run
+				sub.EXPECT().FindById(a.ctx, 3).Return(dbmodel.Subscription{}, errors.New("some error"))
+			},
+			expectApplied: 2,
+			expectErr:     nil,
+		},
+		{
+			testName: "repo error",
+			args: args{
+				ctx:  context.Background(),
+				date: today,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, priceChange *repomocks.MockPriceChange, outbox *repomocks.MockOutbox, a args) {
+				priceChange.EXPECT().FindDue(a.ctx, today).Return(nil, errors.New("some error"))
+			},
+			expectApplied: 0,
+			expectErr:     errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			priceChange := repomocks.NewMockPriceChange(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, sub, priceChange, outbox, tc.args)
+
+			s := newForecastService(tx, sub, nil, priceChange, outbox)
+
+			applied, err := s.ApplyPriceChanges(tc.args.ctx, tc.args.date)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectApplied, applied)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+)
+
+type organizationService struct {
+	organization repo.Organization
+}
+
+func newOrganizationService(organization repo.Organization) *organizationService {
+	return &organizationService{
+		organization: organization,
+	}
+}
+
+func (s *organizationService) Create(ctx context.Context, input OrganizationInput) (int, error) {
+	id, err := s.organization.Create(ctx, dbmodel.Organization{
+		Name: normalizeName(input.Name),
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrAlreadyExists) {
+			return 0, ErrOrganizationAlreadyExists
+		}
+		log.Err(err).Interface("input", input).Msg("organization/Create error create organization in database")
+		return 0, err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("organization/Create create new organization in database")
+	return id, nil
+}
+
+func (s *organizationService) FindById(ctx context.Context, id int) (OrganizationOutput, error) {
+	o, err := s.organization.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return OrganizationOutput{}, ErrOrganizationNotFound
+		}
+		log.Err(err).Int("id", id).Msg("organization/FindById error find organization in database")
+		return OrganizationOutput{}, err
+	}
+	return newOrganizationOutput(o), nil
+}
+
+func (s *organizationService) FindAll(ctx context.Context) ([]OrganizationOutput, error) {
+	organizations, err := s.organization.FindAll(ctx)
+	if err != nil {
+		log.Err(err).Msg("organization/FindAll error find all organizations in database")
+		return nil, err
+	}
+	result := make([]OrganizationOutput, 0, len(organizations))
+	for _, o := range organizations {
+		result = append(result, newOrganizationOutput(o))
+	}
+	return result, nil
+}
+
+func newOrganizationOutput(o dbmodel.Organization) OrganizationOutput {
+	return OrganizationOutput{
+		Id:        o.Id,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt,
+	}
+}
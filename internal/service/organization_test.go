@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+	"time"
+)
+
+func TestOrganizationService_Create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input OrganizationInput
+	}
+
+	type mockBehaviour func(organization *repomocks.MockOrganization, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectId      int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				input: OrganizationInput{Name: "  Acme   Inc "},
+			},
+			mockBehaviour: func(organization *repomocks.MockOrganization, a args) {
+				organization.EXPECT().Create(a.ctx, dbmodel.Organization{Name: "Acme Inc"}).Return(2, nil)
+			},
+			expectId:  2,
+			expectErr: nil,
+		},
+		{
+			testName: "name is taken",
+			args: args{
+				ctx:   context.Background(),
+				input: OrganizationInput{Name: "default"},
+			},
+			mockBehaviour: func(organization *repomocks.MockOrganization, a args) {
+				organization.EXPECT().Create(a.ctx, dbmodel.Organization{Name: "default"}).Return(0, pgerrs.ErrAlreadyExists)
+			},
+			expectId:  0,
+			expectErr: ErrOrganizationAlreadyExists,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:   context.Background(),
+				input: OrganizationInput{Name: "Acme"},
+			},
+			mockBehaviour: func(organization *repomocks.MockOrganization, a args) {
+				organization.EXPECT().Create(a.ctx, gomock.Any()).Return(0, errors.New("some error"))
+			},
+			expectId:  0,
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			organization := repomocks.NewMockOrganization(ctrl)
+			tc.mockBehaviour(organization, tc.args)
+
+			s := newOrganizationService(organization)
+
+			id, err := s.Create(tc.args.ctx, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectId, id)
+		})
+	}
+}
+
+func TestOrganizationService_FindById(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int
+	}
+
+	type mockBehaviour func(organization *repomocks.MockOrganization, a args)
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  OrganizationOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+			},
+			mockBehaviour: func(organization *repomocks.MockOrganization, a args) {
+				organization.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Organization{Id: 2, Name: "Acme", CreatedAt: createdAt}, nil)
+			},
+			expectOutput: OrganizationOutput{Id: 2, Name: "Acme", CreatedAt: createdAt},
+			expectErr:    nil,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: context.Background(),
+				id:  5,
+			},
+			mockBehaviour: func(organization *repomocks.MockOrganization, a args) {
+				organization.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Organization{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: OrganizationOutput{},
+			expectErr:    ErrOrganizationNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			organization := repomocks.NewMockOrganization(ctrl)
+			tc.mockBehaviour(organization, tc.args)
+
+			s := newOrganizationService(organization)
+
+			output, err := s.FindById(tc.args.ctx, tc.args.id)
+
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/rs/zerolog/log"
+	"strconv"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/pkg/publisher"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+const (
+	outboxBaseRetryDelay = time.Second
+	outboxMaxRetryDelay  = 10 * time.Minute
+	outboxLease          = 5 * time.Minute // time batch of events is claimed for while it is published
+)
+
+// Event is a message published to downstream systems for every subscription change. Event is delivered at least
+// once: it is published again if relay failed to mark it published, so consumers deduplicate events by Id, which
+// is also the Key of published message
+type Event struct {
+	Id         int64           `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+
+	OrganizationId int `json:"organization_id"`
+}
+
+type outboxService struct {
+	outbox    repo.Outbox
+	publisher publisher.Publisher
+}
+
+func newOutboxService(outbox repo.Outbox, publisher publisher.Publisher) *outboxService {
+	return &outboxService{
+		outbox:    outbox,
+		publisher: publisher,
+	}
+}
+
+// Relay publishes batch of pending events and returns its size. Events are claimed for the time of publishing
+// the whole batch, so no transaction or lock is held while publisher sends them. Event is marked as published only
+// after publisher accepted it, so delivery is at-least-once: consumers should deduplicate by event id
+func (s *outboxService) Relay(ctx context.Context, limit int) (int, error) {
+	events, err := s.outbox.Claim(ctx, limit, outboxLease)
+	if err != nil {
+		log.Err(err).Msg("outbox/Relay error claim events in database")
+		return 0, err
+	}
+
+	for i, e := range events {
+		data, _ := json.Marshal(Event{
+			Id:         e.Id,
+			Type:       e.EventType,
+			OccurredAt: e.CreatedAt,
+			Data:       e.Payload,
+
+			OrganizationId: e.OrganizationId,
+		})
+		// webhooks of event organization only are notified
+		err = s.publisher.Publish(tenant.WithOrganization(ctx, e.OrganizationId), publisher.Message{
+			Subject: e.EventType,
+			Key:     strconv.FormatInt(e.Id, 10),
+			Data:    data,
+		})
+		if err != nil {
+			log.Warn().Err(err).Int64("id", e.Id).Int("attempts", e.Attempts).Msg("outbox/Relay error publish event")
+
+			err = s.outbox.MarkFailed(ctx, e.Id, err.Error(), time.Now().Add(backoff(e.Attempts, outboxBaseRetryDelay, outboxMaxRetryDelay)))
+		} else {
+			err = s.outbox.MarkPublished(ctx, e.Id)
+		}
+		if err != nil {
+			// event is published again after its lease ends
+			log.Err(err).Int64("id", e.Id).Msg("outbox/Relay error update event in database")
+			return i, err
+		}
+	}
+	return len(events), nil
+}
+
+// backoff is exponential retry delay: base, 2*base, 4*base, ... but not more than maxDelay
+func backoff(attempts int, base, maxDelay time.Duration) time.Duration {
+	if attempts >= 32 {
+		return maxDelay
+	}
+	return min(base<<attempts, maxDelay)
+}
+
+func newSubscriptionEvent(eventType string, sub dbmodel.Subscription) dbmodel.OutboxEvent {
+	payload, _ := json.Marshal(newSubscriptionOutput(sub))
+	return dbmodel.OutboxEvent{
+		EventType:   eventType,
+		AggregateId: sub.Id,
+		Payload:     payload,
+	}
+}
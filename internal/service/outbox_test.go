@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/publishermocks"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/pkg/publisher"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+func TestOutboxService_Relay(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		limit int
+	}
+
+	type mockBehaviour func(outbox *repomocks.MockOutbox, pub *publishermocks.MockPublisher, a args)
+
+	createdAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []dbmodel.OutboxEvent{
+		{
+			Id:          1,
+			EventType:   dbmodel.EventSubscriptionCreated,
+			AggregateId: 10,
+			Payload:     []byte(`{"id":10}`),
+			CreatedAt:   createdAt,
+
+			OrganizationId: 1,
+		},
+		{
+			Id:          2,
+			EventType:   dbmodel.EventSubscriptionDeleted,
+			AggregateId: 10,
+			Payload:     []byte(`{"id":10}`),
+			CreatedAt:   createdAt,
+			Attempts:    3,
+
+			OrganizationId: 2,
+		},
+	}
+	// events are published within their organizations
+	org1Ctx := tenant.WithOrganization(context.Background(), 1)
+	org2Ctx := tenant.WithOrganization(context.Background(), 2)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				limit: 10,
+			},
+			mockBehaviour: func(outbox *repomocks.MockOutbox, pub *publishermocks.MockPublisher, a args) {
+				outbox.EXPECT().Claim(a.ctx, a.limit, outboxLease).Return(events, nil)
+
+				pub.EXPECT().Publish(org1Ctx, publisher.Message{
+					Subject: dbmodel.EventSubscriptionCreated,
+					Key:     "1",
+					Data:    []byte(`{"id":1,"type":"subscription.created","occurred_at":"2025-07-01T12:00:00Z","data":{"id":10},"organization_id":1}`),
+				}).Return(nil)
+				outbox.EXPECT().MarkPublished(a.ctx, int64(1)).Return(nil)
+
+				pub.EXPECT().Publish(org2Ctx, publisher.Message{
+					Subject: dbmodel.EventSubscriptionDeleted,
+					Key:     "2",
+					Data:    []byte(`{"id":2,"type":"subscription.deleted","occurred_at":"2025-07-01T12:00:00Z","data":{"id":10},"organization_id":2}`),
+				}).Return(nil)
+				outbox.EXPECT().MarkPublished(a.ctx, int64(2)).Return(nil)
+			},
+			expectOutput: 2,
+			expectErr:    nil,
+		},
+		{
+			testName: "publish error schedules retry",
+			args: args{
+				ctx:   context.Background(),
+				limit: 10,
+			},
+			mockBehaviour: func(outbox *repomocks.MockOutbox, pub *publishermocks.MockPublisher, a args) {
+				outbox.EXPECT().Claim(a.ctx, a.limit, outboxLease).Return(events, nil)
+
+				pub.EXPECT().Publish(org1Ctx, gomock.Any()).Return(errors.New("broker is down"))
+				outbox.EXPECT().MarkFailed(a.ctx, int64(1), "broker is down", gomock.Any()).Return(nil)
+
+				pub.EXPECT().Publish(org2Ctx, gomock.Any()).Return(nil)
+				outbox.EXPECT().MarkPublished(a.ctx, int64(2)).Return(nil)
+			},
+			expectOutput: 2,
+			expectErr:    nil,
+		},
+		{
+			testName: "update error",
+			args: args{
+				ctx:   context.Background(),
+				limit: 10,
+			},
+			mockBehaviour: func(outbox *repomocks.MockOutbox, pub *publishermocks.MockPublisher, a args) {
+				outbox.EXPECT().Claim(a.ctx, a.limit, outboxLease).Return(events, nil)
+
+				// the rest of batch is published again after lease ends
+				pub.EXPECT().Publish(org1Ctx, gomock.Any()).Return(nil)
+				outbox.EXPECT().MarkPublished(a.ctx, int64(1)).Return(errors.New("some error"))
+			},
+			expectOutput: 0,
+			expectErr:    errors.New("some error"),
+		},
+		{
+			testName: "no pending events",
+			args: args{
+				ctx:   context.Background(),
+				limit: 10,
+			},
+			mockBehaviour: func(outbox *repomocks.MockOutbox, pub *publishermocks.MockPublisher, a args) {
+				outbox.EXPECT().Claim(a.ctx, a.limit, outboxLease).Return(nil, nil)
+			},
+			expectOutput: 0,
+			expectErr:    nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:   context.Background(),
+				limit: 10,
+			},
+			mockBehaviour: func(outbox *repomocks.MockOutbox, pub *publishermocks.MockPublisher, a args) {
+				outbox.EXPECT().Claim(a.ctx, a.limit, outboxLease).Return(nil, errors.New("some error"))
+			},
+			expectOutput: 0,
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			outbox := repomocks.NewMockOutbox(ctrl)
+			pub := publishermocks.NewMockPublisher(ctrl)
+			tc.mockBehaviour(outbox, pub, tc.args)
+
+			s := newOutboxService(outbox, pub)
+
+			actual, err := s.Relay(tc.args.ctx, tc.args.limit)
+
+			assert.Equal(t, tc.expectOutput, actual)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(0, time.Second, time.Minute))
+	assert.Equal(t, 8*time.Second, backoff(3, time.Second, time.Minute))
+	assert.Equal(t, time.Minute, backoff(10, time.Second, time.Minute))
+	assert.Equal(t, time.Minute, backoff(100, time.Second, time.Minute))
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/notifier"
+	"subscription_service/pkg/tenant"
+	"time"
+)
+
+// reminderLease is time reminder is claimed for while notification is sent
+const reminderLease = 5 * time.Minute
+
+type reminderService struct {
+	tx              repo.Transactor
+	user            repo.User
+	sub             repo.Subscription
+	reminder        repo.Reminder
+	outbox          repo.Outbox
+	notifier        notifier.Notifier
+	defaultLeadDays int
+}
+
+func newReminderService(
+	tx repo.Transactor,
+	user repo.User,
+	sub repo.Subscription,
+	reminder repo.Reminder,
+	outbox repo.Outbox,
+	notifier notifier.Notifier,
+	defaultLeadDays int,
+) *reminderService {
+	return &reminderService{
+		tx:              tx,
+		user:            user,
+		sub:             sub,
+		reminder:        reminder,
+		outbox:          outbox,
+		notifier:        notifier,
+		defaultLeadDays: defaultLeadDays,
+	}
+}
+
+func (s *reminderService) FindSettings(ctx context.Context, userId string) (ReminderSettingsOutput, error) {
+	settings, err := s.reminder.FindSettings(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ReminderSettingsOutput{UserId: userId, LeadDays: s.defaultLeadDays}, nil
+		}
+		log.Err(err).Str("user_id", userId).Msg("reminder/FindSettings error find reminder settings in database")
+		return ReminderSettingsOutput{}, err
+	}
+	return ReminderSettingsOutput{
+		UserId:   settings.UserId,
+		LeadDays: settings.LeadDays,
+		Email:    settings.Email,
+	}, nil
+}
+
+// UpdateSettings sets reminder settings of user of organization of ctx, so users of other organizations can't be
+// reminded to email given here
+func (s *reminderService) UpdateSettings(ctx context.Context, userId string, input ReminderSettingsInput) error {
+	if _, err := s.user.FindById(ctx, userId); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		log.Err(err).Str("user_id", userId).Msg("reminder/UpdateSettings error find user in database")
+		return err
+	}
+	err := s.reminder.UpsertSettings(ctx, dbmodel.ReminderSettings{
+		UserId:   userId,
+		LeadDays: input.LeadDays,
+		Email:    input.Email,
+	})
+	if err != nil {
+		log.Err(err).Str("user_id", userId).Msg("reminder/UpdateSettings error update reminder settings in database")
+		return err
+	}
+	log.Info().Str("user_id", userId).Interface("input", input).Msg("reminder/UpdateSettings update reminder settings in database")
+	return nil
+}
+
+// Run sends reminders about charges and trial endings within users lead time from date and returns number
+// of sent reminders. Every reminder is recorded with its event once, its notification is sent until it succeeds.
+// Charges within free trial are not reminded, trial ending is reminded instead
+func (s *reminderService) Run(ctx context.Context, date time.Time) (int, error) {
+	day := truncateToDay(date)
+
+	settings, err := s.reminder.FindAllSettings(ctx)
+	if err != nil {
+		log.Err(err).Msg("reminder/Run error find reminder settings in database")
+		return 0, err
+	}
+	byUser := make(map[string]dbmodel.ReminderSettings, len(settings))
+	for _, st := range settings {
+		byUser[st.UserId] = st
+	}
+
+	subscriptions, err := s.sub.FindActive(ctx, "", day)
+	if err != nil {
+		log.Err(err).Msg("reminder/Run error find active subscriptions in database")
+		return 0, err
+	}
+
+	var sent int
+
+	for _, sub := range subscriptions {
+		st, ok := byUser[sub.UserId]
+		if !ok {
+			st = dbmodel.ReminderSettings{UserId: sub.UserId, LeadDays: s.defaultLeadDays}
+		}
+		last := day.AddDate(0, 0, st.LeadDays)
+
+		if end := sub.TrialEndDate; end != nil && !end.Before(day) && !end.After(last) {
+			first := billingDate(sub, trialCharges(sub))
+			reminder := dbmodel.Reminder{SubscriptionId: sub.Id, Kind: dbmodel.ReminderTrialEnd, BillingDate: *end}
+			if s.send(ctx, sub, reminder, newTrialEndEvent(sub, first), newTrialEndMessage(sub, st, first)) {
+				sent++
+			}
+		}
+
+		next, ok := nextBillingDate(sub, day)
+		if !ok || next.After(last) || inTrial(sub, next) {
+			continue
+		}
+		reminder := dbmodel.Reminder{SubscriptionId: sub.Id, Kind: dbmodel.ReminderRenewal, BillingDate: next}
+		if s.send(ctx, sub, reminder, newRenewalDueEvent(sub, next), newRenewalMessage(sub, st, next)) {
+			sent++
+		}
+	}
+	log.Info().Int("sent", sent).Time("date", day).Msg("reminder/Run send reminders")
+	return sent, nil
+}
+
+// send records reminder with its event and claims it in one transaction, then notifies user outside of it and
+// marks reminder sent. It reports whether reminder was sent now. Reminder which failed to be sent is not marked
+// and is sent again by the run after its lease ends, so notification is delivered at least once
+func (s *reminderService) send(
+	ctx context.Context,
+	sub dbmodel.Subscription,
+	reminder dbmodel.Reminder,
+	event dbmodel.OutboxEvent,
+	msg notifier.Message,
+) bool {
+	// events of subscription belong to its organization
+	ctx = tenant.WithOrganization(ctx, sub.OrganizationId)
+
+	var id int64
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		created, err := s.reminder.Create(ctx, reminder)
+		if err != nil {
+			return err
+		}
+		if created {
+			if err = s.outbox.Create(ctx, event); err != nil {
+				return err
+			}
+		}
+		id, err = s.reminder.Claim(ctx, reminder, reminderLease)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, pgerrs.ErrNotFound) {
+			// other reminders are still sent, failed one will be retried on next run
+			log.Err(err).Int("id", sub.Id).Str("kind", reminder.Kind).Msg("reminder/Run error claim reminder in database")
+		}
+		return false
+	}
+
+	err = s.notifier.Notify(ctx, msg)
+	if errors.Is(err, notifier.ErrNoRecipient) {
+		log.Debug().Int("id", sub.Id).Msg("reminder/Run user has no notification recipient")
+	} else if err != nil {
+		log.Err(err).Int("id", sub.Id).Str("kind", reminder.Kind).Msg("reminder/Run error send reminder")
+		return false
+	}
+
+	if err = s.reminder.MarkSent(ctx, id); err != nil {
+		log.Err(err).Int64("reminder_id", id).Msg("reminder/Run error mark reminder sent in database")
+		return false
+	}
+	return true
+}
+
+type renewalDuePayload struct {
+	SubscriptionOutput
+	BillingDate string `json:"billing_date"`
+}
+
+func newRenewalDueEvent(sub dbmodel.Subscription, billingDate time.Time) dbmodel.OutboxEvent {
+	payload, _ := json.Marshal(renewalDuePayload{
+		SubscriptionOutput: newSubscriptionOutput(sub),
+		BillingDate:        billingDate.Format(time.DateOnly),
+	})
+	return dbmodel.OutboxEvent{
+		EventType:   dbmodel.EventSubscriptionRenewal,
+		AggregateId: sub.Id,
+		Payload:     payload,
+	}
+}
+
+func newRenewalMessage(sub dbmodel.Subscription, st dbmodel.ReminderSettings, billingDate time.Time) notifier.Message {
+	msg := notifier.Message{
+		Subject: fmt.Sprintf("Subscription renewal: %s", sub.ServiceName),
+		Body: fmt.Sprintf(
+			"Your %s subscription renews on %s for %d (%s).\nCancel it before this date if you don't need it anymore.",
+			sub.ServiceName, billingDate.Format(time.DateOnly), chargeOn(sub, billingDate).Gross, billingPeriod(sub.BillingPeriod),
+		),
+	}
+	if st.Email != nil {
+		msg.To = *st.Email
+	}
+	return msg
+}
+
+type trialEndPayload struct {
+	SubscriptionOutput
+	FirstBillingDate string `json:"first_billing_date"`
+	FirstPrice       int    `json:"first_price"`
+}
+
+func newTrialEndEvent(sub dbmodel.Subscription, firstBillingDate time.Time) dbmodel.OutboxEvent {
+	payload, _ := json.Marshal(trialEndPayload{
+		SubscriptionOutput: newSubscriptionOutput(sub),
+		FirstBillingDate:   firstBillingDate.Format(time.DateOnly),
+		FirstPrice:         chargeOn(sub, firstBillingDate).Gross,
+	})
+	return dbmodel.OutboxEvent{
+		EventType:   dbmodel.EventSubscriptionTrialEnd,
+		AggregateId: sub.Id,
+		Payload:     payload,
+	}
+}
+
+func newTrialEndMessage(sub dbmodel.Subscription, st dbmodel.ReminderSettings, firstBillingDate time.Time) notifier.Message {
+	msg := notifier.Message{
+		Subject: fmt.Sprintf("Trial ending: %s", sub.ServiceName),
+		Body: fmt.Sprintf(
+			"Your %s free trial ends on %s, the first charge is on %s for %d (%s).\nCancel it before this date if you don't need it anymore.",
+			sub.ServiceName, sub.TrialEndDate.Format(time.DateOnly), firstBillingDate.Format(time.DateOnly),
+			chargeOn(sub, firstBillingDate).Gross, billingPeriod(sub.BillingPeriod),
+		),
+	}
+	if st.Email != nil {
+		msg.To = *st.Email
+	}
+	return msg
+}
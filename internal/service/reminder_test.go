@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/notifiermocks"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/notifier"
+	"subscription_service/pkg/tenant"
+	"testing"
+	"time"
+)
+
+func TestReminderService_Run(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+
+	type mockBehaviour func(
+		tx *repomocks.MockTransactor,
+		sub *repomocks.MockSubscription,
+		reminder *repomocks.MockReminder,
+		outbox *repomocks.MockOutbox,
+		ntf *notifiermocks.MockNotifier,
+		a args,
+	)
+
+	email := "user@example.com"
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	monthly := dbmodel.Subscription{
+		Id:          1,
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserId:      userId,
+		StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+
+		OrganizationId: 2,
+	}
+	yearly := dbmodel.Subscription{
+		Id:            2,
+		ServiceName:   "Spotify",
+		Price:         1200,
+		UserId:        userId,
+		StartDate:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingYearly,
+
+		OrganizationId: 2,
+	}
+	trial := dbmodel.Subscription{
+		Id:           3,
+		ServiceName:  "Kinopoisk",
+		Price:        300,
+		UserId:       userId,
+		StartDate:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		TrialEndDate: ptr(time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)),
+		IntroPrice:   ptr(100),
+		IntroPeriods: 2,
+
+		OrganizationId: 2,
+	}
+	// reminder of subscription is sent within its organization
+	orgCtx := tenant.WithOrganization(context.Background(), 2)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectSent    int
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 29, 10, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+				billingDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+				reminder.EXPECT().FindAllSettings(a.ctx).Return([]dbmodel.ReminderSettings{
+					{UserId: userId, LeadDays: 5, Email: &email},
+				}, nil)
+				sub.EXPECT().FindActive(a.ctx, "", day).Return([]dbmodel.Subscription{monthly, yearly}, nil)
+
+				runInTransaction(tx)
+				reminder.EXPECT().Create(orgCtx, dbmodel.Reminder{SubscriptionId: 1, Kind: dbmodel.ReminderRenewal, BillingDate: billingDate}).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newRenewalDueEvent(monthly, billingDate)).Return(nil)
+				reminder.EXPECT().Claim(orgCtx, dbmodel.Reminder{SubscriptionId: 1, Kind: dbmodel.ReminderRenewal, BillingDate: billingDate}, reminderLease).Return(int64(7), nil)
+				ntf.EXPECT().Notify(orgCtx, newRenewalMessage(monthly, dbmodel.ReminderSettings{UserId: userId, LeadDays: 5, Email: &email}, billingDate)).Return(nil)
+				reminder.EXPECT().MarkSent(orgCtx, int64(7)).Return(nil)
+			},
+			expectSent: 1,
+			expectErr:  nil,
+		},
+		{
+			testName: "trial ending",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				st := dbmodel.ReminderSettings{UserId: userId, LeadDays: 5, Email: &email}
+				trialEnd := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+				billingDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+				reminder.EXPECT().FindAllSettings(a.ctx).Return([]dbmodel.ReminderSettings{st}, nil)
+				sub.EXPECT().FindActive(a.ctx, "", a.date).Return([]dbmodel.Subscription{trial}, nil)
+
+				runInTransaction(tx)
+				runInTransaction(tx)
+				reminder.EXPECT().Create(orgCtx, dbmodel.Reminder{SubscriptionId: 3, Kind: dbmodel.ReminderTrialEnd, BillingDate: trialEnd}).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newTrialEndEvent(trial, billingDate)).Return(nil)
+				reminder.EXPECT().Claim(orgCtx, dbmodel.Reminder{SubscriptionId: 3, Kind: dbmodel.ReminderTrialEnd, BillingDate: trialEnd}, reminderLease).Return(int64(7), nil)
+				ntf.EXPECT().Notify(orgCtx, newTrialEndMessage(trial, st, billingDate)).Return(nil)
+				reminder.EXPECT().MarkSent(orgCtx, int64(7)).Return(nil)
+
+				reminder.EXPECT().Create(orgCtx, dbmodel.Reminder{SubscriptionId: 3, Kind: dbmodel.ReminderRenewal, BillingDate: billingDate}).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, newRenewalDueEvent(trial, billingDate)).Return(nil)
+				reminder.EXPECT().Claim(orgCtx, dbmodel.Reminder{SubscriptionId: 3, Kind: dbmodel.ReminderRenewal, BillingDate: billingDate}, reminderLease).Return(int64(8), nil)
+				ntf.EXPECT().Notify(orgCtx, newRenewalMessage(trial, st, billingDate)).Return(nil)
+				reminder.EXPECT().MarkSent(orgCtx, int64(8)).Return(nil)
+			},
+			expectSent: 2,
+			expectErr:  nil,
+		},
+		{
+			testName: "charge within trial",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				longTrial := trial
+				longTrial.TrialEndDate = ptr(time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC))
+
+				reminder.EXPECT().FindAllSettings(a.ctx).Return(nil, nil)
+				sub.EXPECT().FindActive(a.ctx, "", a.date).Return([]dbmodel.Subscription{longTrial}, nil)
+			},
+			expectSent: 0,
+			expectErr:  nil,
+		},
+		{
+			testName: "default lead days",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 27, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				reminder.EXPECT().FindAllSettings(a.ctx).Return(nil, nil)
+				sub.EXPECT().FindActive(a.ctx, "", a.date).Return([]dbmodel.Subscription{monthly}, nil)
+			},
+			expectSent: 0,
+			expectErr:  nil,
+		},
+		{
+			testName: "already reminded",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				reminder.EXPECT().FindAllSettings(a.ctx).Return(nil, nil)
+				sub.EXPECT().FindActive(a.ctx, "", a.date).Return([]dbmodel.Subscription{monthly}, nil)
+
+				runInTransaction(tx)
+				reminder.EXPECT().Create(orgCtx, gomock.Any()).Return(false, nil)
+				reminder.EXPECT().Claim(orgCtx, gomock.Any(), reminderLease).Return(int64(0), pgerrs.ErrNotFound)
+			},
+			expectSent: 0,
+			expectErr:  nil,
+		},
+		{
+			testName: "not sent by previous run",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				reminder.EXPECT().FindAllSettings(a.ctx).Return(nil, nil)
+				sub.EXPECT().FindActive(a.ctx, "", a.date).Return([]dbmodel.Subscription{monthly}, nil)
+
+				// event was emitted when reminder was recorded
+				runInTransaction(tx)
+				reminder.EXPECT().Create(orgCtx, gomock.Any()).Return(false, nil)
+				reminder.EXPECT().Claim(orgCtx, gomock.Any(), reminderLease).Return(int64(7), nil)
+				ntf.EXPECT().Notify(orgCtx, gomock.Any()).Return(nil)
+				reminder.EXPECT().MarkSent(orgCtx, int64(7)).Return(nil)
+			},
+			expectSent: 1,
+			expectErr:  nil,
+		},
+		{
+			testName: "user without email",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				reminder.EXPECT().FindAllSettings(a.ctx).Return(nil, nil)
+				sub.EXPECT().FindActive(a.ctx, "", a.date).Return([]dbmodel.Subscription{monthly}, nil)
+
+				runInTransaction(tx)
+				reminder.EXPECT().Create(orgCtx, gomock.Any()).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, gomock.Any()).Return(nil)
+				reminder.EXPECT().Claim(orgCtx, gomock.Any(), reminderLease).Return(int64(7), nil)
+				ntf.EXPECT().Notify(orgCtx, gomock.Any()).Return(notifier.ErrNoRecipient)
+				reminder.EXPECT().MarkSent(orgCtx, int64(7)).Return(nil)
+			},
+			expectSent: 1,
+			expectErr:  nil,
+		},
+		{
+			testName: "notify error",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				reminder.EXPECT().FindAllSettings(a.ctx).Return(nil, nil)
+				sub.EXPECT().FindActive(a.ctx, "", a.date).Return([]dbmodel.Subscription{monthly}, nil)
+
+				runInTransaction(tx)
+				reminder.EXPECT().Create(orgCtx, gomock.Any()).Return(true, nil)
+				outbox.EXPECT().Create(orgCtx, gomock.Any()).Return(nil)
+				reminder.EXPECT().Claim(orgCtx, gomock.Any(), reminderLease).Return(int64(7), nil)
+				// reminder stays not sent until its lease ends
+				ntf.EXPECT().Notify(orgCtx, gomock.Any()).Return(errors.New("some error"))
+			},
+			expectSent: 0,
+			expectErr:  nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:  context.Background(),
+				date: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, reminder *repomocks.MockReminder, outbox *repomocks.MockOutbox, ntf *notifiermocks.MockNotifier, a args) {
+				reminder.EXPECT().FindAllSettings(a.ctx).Return(nil, errors.New("some error"))
+			},
+			expectSent: 0,
+			expectErr:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			reminder := repomocks.NewMockReminder(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			ntf := notifiermocks.NewMockNotifier(ctrl)
+			tc.mockBehaviour(tx, sub, reminder, outbox, ntf, tc.args)
+
+			s := newReminderService(tx, nil, sub, reminder, outbox, ntf, 3)
+
+			sent, err := s.Run(tc.args.ctx, tc.args.date)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectSent, sent)
+		})
+	}
+}
+
+func TestReminderService_FindSettings(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+	}
+
+	type mockBehaviour func(reminder *repomocks.MockReminder, a args)
+
+	email := "user@example.com"
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  ReminderSettingsOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    context.Background(),
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			},
+			mockBehaviour: func(reminder *repomocks.MockReminder, a args) {
+				reminder.EXPECT().FindSettings(a.ctx, a.userId).Return(dbmodel.ReminderSettings{
+					UserId:   a.userId,
+					LeadDays: 7,
+					Email:    &email,
+				}, nil)
+			},
+			expectOutput: ReminderSettingsOutput{
+				UserId:   "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+				LeadDays: 7,
+				Email:    &email,
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "default settings",
+			args: args{
+				ctx:    context.Background(),
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			},
+			mockBehaviour: func(reminder *repomocks.MockReminder, a args) {
+				reminder.EXPECT().FindSettings(a.ctx, a.userId).Return(dbmodel.ReminderSettings{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: ReminderSettingsOutput{
+				UserId:   "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+				LeadDays: 3,
+			},
+			expectErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			reminder := repomocks.NewMockReminder(ctrl)
+			tc.mockBehaviour(reminder, tc.args)
+
+			s := newReminderService(nil, nil, nil, reminder, nil, nil, 3)
+
+			output, err := s.FindSettings(tc.args.ctx, tc.args.userId)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+func TestReminderService_UpdateSettings(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		input  ReminderSettingsInput
+	}
+
+	type mockBehaviour func(user *repomocks.MockUser, reminder *repomocks.MockReminder, a args)
+
+	email := "user@example.com"
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    tenant.WithOrganization(context.Background(), 2),
+				userId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+				input:  ReminderSettingsInput{LeadDays: 5, Email: &email},
+			},
+			mockBehaviour: func(user *repomocks.MockUser, reminder *repomocks.MockReminder, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: a.userId}, nil)
+				reminder.EXPECT().UpsertSettings(a.ctx, dbmodel.ReminderSettings{UserId: a.userId, LeadDays: 5, Email: &email}).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			// user of another organization is not found within organization of ctx
+			testName: "user of another organization",
+			args: args{
+				ctx:    tenant.WithOrganization(context.Background(), 2),
+				userId: "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				input:  ReminderSettingsInput{LeadDays: 5, Email: &email},
+			},
+			mockBehaviour: func(user *repomocks.MockUser, reminder *repomocks.MockReminder, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			user := repomocks.NewMockUser(ctrl)
+			reminder := repomocks.NewMockReminder(ctrl)
+			tc.mockBehaviour(user, reminder, tc.args)
+
+			s := newReminderService(nil, user, nil, reminder, nil, nil, 3)
+
+			err := s.UpdateSettings(tc.args.ctx, tc.args.userId, tc.args.input)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+const (
+	recommendationDuplicate = "duplicate"
+	recommendationUnused    = "unused"
+	recommendationFamily    = "family"
+	recommendationAnnual    = "annual"
+
+	// unusedDays is the least number of days since last use subscription is considered unused after
+	unusedDays = 90
+)
+
+type savingsService struct {
+	user    repo.User
+	sub     repo.Subscription
+	service repo.Service
+}
+
+func newSavingsService(user repo.User, sub repo.Subscription, service repo.Service) *savingsService {
+	return &savingsService{
+		user:    user,
+		sub:     sub,
+		service: service,
+	}
+}
+
+// Recommend suggests savings on subscriptions of user running on date, today in users time zone without it.
+// Subscriptions are checked for duplicates, long unused ones, family plan with other users of organization
+// subscribed to the same service and yearly plan from catalog, in this order. Every subscription gets
+// at most one recommendation, so savings are not counted twice
+func (s *savingsService) Recommend(ctx context.Context, userId string, date *time.Time) (SavingsOutput, error) {
+	u, err := s.user.FindById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return SavingsOutput{}, ErrUserNotFound
+		}
+		log.Err(err).Str("user_id", userId).Msg("savings/Recommend error find user in database")
+		return SavingsOutput{}, err
+	}
+	day := truncateToDay(time.Now().In(userLocation(u)))
+	if date != nil {
+		day = truncateToDay(*date)
+	}
+
+	active, err := s.sub.FindActive(ctx, userId, day)
+	if err != nil {
+		log.Err(err).Str("user_id", userId).Msg("savings/Recommend error find active subscriptions in database")
+		return SavingsOutput{}, err
+	}
+	var subscriptions []dbmodel.Subscription
+	for _, sub := range active {
+		if running(sub, day) {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+
+	r := &recommender{day: day, covered: make(map[int]bool)}
+	r.duplicates(subscriptions)
+	r.unused(subscriptions)
+
+	catalog := make(map[int]dbmodel.Service)
+
+	for _, sub := range subscriptions {
+		if r.covered[sub.Id] {
+			continue
+		}
+		svc, ok := catalog[sub.ServiceId]
+		if !ok {
+			if svc, err = s.service.FindById(ctx, sub.ServiceId); err != nil {
+				log.Err(err).Int("service_id", sub.ServiceId).Msg("savings/Recommend error find service in database")
+				return SavingsOutput{}, err
+			}
+			catalog[sub.ServiceId] = svc
+		}
+		if svc.FamilyPrice != nil && svc.FamilySize != nil {
+			peers, err := s.sub.FindAll(ctx, dbmodel.SubscriptionFilter{ServiceId: sub.ServiceId})
+			if err != nil {
+				log.Err(err).Int("service_id", sub.ServiceId).Msg("savings/Recommend error find subscriptions in database")
+				return SavingsOutput{}, err
+			}
+			if r.family(sub, svc, peers) {
+				continue
+			}
+		}
+		r.annual(sub, svc)
+	}
+
+	output := SavingsOutput{
+		UserId:          u.Id,
+		Currency:        u.Currency,
+		Date:            formatDate(day),
+		Recommendations: r.recommendations,
+	}
+	if output.Recommendations == nil {
+		output.Recommendations = []RecommendationOutput{}
+	}
+	slices.SortStableFunc(output.Recommendations, func(a, b RecommendationOutput) int {
+		return cmp.Compare(b.MonthlySavings, a.MonthlySavings)
+	})
+	for _, rec := range output.Recommendations {
+		output.MonthlySavings += rec.MonthlySavings
+	}
+	return output, nil
+}
+
+// recommender collects recommendations for subscriptions of user, covered subscriptions already have one
+type recommender struct {
+	day             time.Time
+	covered         map[int]bool
+	recommendations []RecommendationOutput
+}
+
+// duplicates suggests to keep the most expensive of running subscriptions of the same service
+// and to cancel the others
+func (r *recommender) duplicates(subscriptions []dbmodel.Subscription) {
+	groups := make(map[int][]dbmodel.Subscription)
+	for _, sub := range subscriptions {
+		groups[sub.ServiceId] = append(groups[sub.ServiceId], sub)
+	}
+
+	for _, sub := range subscriptions {
+		group := groups[sub.ServiceId]
+		if len(group) < 2 || group[0].Id != sub.Id {
+			continue
+		}
+		kept := slices.MaxFunc(group, func(a, b dbmodel.Subscription) int {
+			return cmp.Or(cmp.Compare(monthlyCost(a, r.day), monthlyCost(b, r.day)), cmp.Compare(b.Id, a.Id))
+		})
+
+		rec := RecommendationOutput{
+			Kind:            recommendationDuplicate,
+			ServiceId:       sub.ServiceId,
+			ServiceName:     sub.ServiceName,
+			SubscriptionIds: []int{},
+		}
+		for _, d := range group {
+			if d.Id == kept.Id {
+				continue
+			}
+			rec.SubscriptionIds = append(rec.SubscriptionIds, d.Id)
+			rec.MonthlySavings += monthlyCost(d, r.day)
+			r.covered[d.Id] = true
+		}
+		if len(rec.SubscriptionIds) > 0 {
+			r.recommendations = append(r.recommendations, rec)
+		}
+	}
+}
+
+// unused suggests to cancel subscriptions not used for unusedDays
+func (r *recommender) unused(subscriptions []dbmodel.Subscription) {
+	for _, sub := range subscriptions {
+		if r.covered[sub.Id] || sub.LastUsedDate == nil || r.day.Before(sub.LastUsedDate.AddDate(0, 0, unusedDays)) {
+			continue
+		}
+		r.covered[sub.Id] = true
+		r.recommendations = append(r.recommendations, RecommendationOutput{
+			Kind:            recommendationUnused,
+			ServiceId:       sub.ServiceId,
+			ServiceName:     sub.ServiceName,
+			SubscriptionIds: []int{sub.Id},
+			MonthlySavings:  monthlyCost(sub, r.day),
+		})
+	}
+}
+
+// family suggests to replace subscription and the ones of other users of the same service with family plan
+// if user pays less with it. Members are taken in order of their subscriptions up to family size
+func (r *recommender) family(sub dbmodel.Subscription, svc dbmodel.Service, peers []dbmodel.Subscription) bool {
+	rec := RecommendationOutput{
+		Kind:            recommendationFamily,
+		ServiceId:       sub.ServiceId,
+		ServiceName:     sub.ServiceName,
+		SubscriptionIds: []int{sub.Id},
+		UserIds:         []string{sub.UserId},
+	}
+	total := monthlyCost(sub, r.day)
+
+	for _, p := range peers {
+		if len(rec.UserIds) == *svc.FamilySize {
+			break
+		}
+		if slices.Contains(rec.UserIds, p.UserId) || !running(p, r.day) {
+			continue
+		}
+		rec.SubscriptionIds = append(rec.SubscriptionIds, p.Id)
+		rec.UserIds = append(rec.UserIds, p.UserId)
+		total += monthlyCost(p, r.day)
+	}
+	if len(rec.UserIds) < 2 || total <= *svc.FamilyPrice {
+		return false
+	}
+
+	rec.MonthlySavings = monthlyCost(sub, r.day) - *svc.FamilyPrice/len(rec.UserIds)
+	if rec.MonthlySavings <= 0 {
+		return false
+	}
+	r.covered[sub.Id] = true
+	r.recommendations = append(r.recommendations, rec)
+	return true
+}
+
+// annual suggests to switch monthly subscription to yearly plan of catalog if it is cheaper
+func (r *recommender) annual(sub dbmodel.Subscription, svc dbmodel.Service) {
+	if billingPeriod(sub.BillingPeriod) != dbmodel.BillingMonthly || svc.YearlyPrice == nil || sub.Price*12 <= *svc.YearlyPrice {
+		return
+	}
+	r.covered[sub.Id] = true
+	r.recommendations = append(r.recommendations, RecommendationOutput{
+		Kind:            recommendationAnnual,
+		ServiceId:       sub.ServiceId,
+		ServiceName:     sub.ServiceName,
+		SubscriptionIds: []int{sub.Id},
+		MonthlySavings:  (sub.Price*12 - *svc.YearlyPrice) / 12,
+	})
+}
+
+// running reports whether subscription is started, not ended and not paused on day
+func running(sub dbmodel.Subscription, day time.Time) bool {
+	return subscriptionStatus(sub, day) == statusActive
+}
+
+// monthlyCost is amount charged for subscription on day per month, yearly price counts as 1/12
+func monthlyCost(sub dbmodel.Subscription, day time.Time) int {
+	return chargeOn(sub, day).Gross / periodMonths(sub.BillingPeriod)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+	"time"
+)
+
+func TestSavingsService_Recommend(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		date   *time.Time
+	}
+
+	type mockBehaviour func(user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	otherId := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+	thirdId := "4c2f3e0b-7f0a-4b43-9d0c-0a1f5b0f2c11"
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	day := date(2025, 7, 15)
+	start := date(2025, 1, 10)
+
+	subscriptions := []dbmodel.Subscription{
+		// duplicates, the most expensive one is kept
+		{Id: 1, ServiceId: 1, ServiceName: "Yandex Plus", Price: 300, UserId: userId, StartDate: start},
+		{Id: 2, ServiceId: 1, ServiceName: "Yandex Plus", Price: 400, UserId: userId, StartDate: start},
+		// not used for more than 90 days
+		{Id: 3, ServiceId: 2, ServiceName: "Okko", Price: 2400, UserId: userId, StartDate: start, BillingPeriod: dbmodel.BillingYearly, LastUsedDate: ptr(date(2025, 4, 1))},
+		// cheaper as yearly plan
+		{Id: 4, ServiceId: 3, ServiceName: "Kinopoisk", Price: 500, UserId: userId, StartDate: start, BillingPeriod: dbmodel.BillingMonthly, LastUsedDate: ptr(date(2025, 7, 1))},
+		// family plan with other users
+		{Id: 5, ServiceId: 4, ServiceName: "Spotify", Price: 600, UserId: userId, StartDate: start},
+		// not started yet
+		{Id: 6, ServiceId: 5, ServiceName: "Netflix", Price: 1000, UserId: userId, StartDate: date(2025, 8, 1)},
+	}
+	spotify := []dbmodel.Subscription{
+		subscriptions[4],
+		{Id: 7, ServiceId: 4, ServiceName: "Spotify", Price: 600, UserId: otherId, StartDate: start},
+		{Id: 8, ServiceId: 4, ServiceName: "Spotify", Price: 600, UserId: otherId, StartDate: start},
+		// ended
+		{Id: 9, ServiceId: 4, ServiceName: "Spotify", Price: 600, UserId: thirdId, StartDate: start, EndDate: ptr(date(2025, 6, 30))},
+		{Id: 10, ServiceId: 4, ServiceName: "Spotify", Price: 600, UserId: thirdId, StartDate: start},
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  SavingsOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				date:   &day,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: userId, Currency: "RUB"}, nil)
+				sub.EXPECT().FindActive(a.ctx, a.userId, day).Return(subscriptions, nil)
+				service.EXPECT().FindById(a.ctx, 1).Return(dbmodel.Service{Id: 1}, nil)
+				service.EXPECT().FindById(a.ctx, 3).Return(dbmodel.Service{Id: 3, YearlyPrice: ptr(4800)}, nil)
+				service.EXPECT().FindById(a.ctx, 4).Return(dbmodel.Service{Id: 4, FamilyPrice: ptr(900), FamilySize: ptr(3), YearlyPrice: ptr(6000)}, nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{ServiceId: 4}).Return(spotify, nil)
+			},
+			expectOutput: SavingsOutput{
+				UserId:         userId,
+				Currency:       "RUB",
+				Date:           "2025-07-15",
+				MonthlySavings: 300 + 300 + 200 + 100,
+				Recommendations: []RecommendationOutput{
+					{Kind: recommendationDuplicate, ServiceId: 1, ServiceName: "Yandex Plus", SubscriptionIds: []int{1}, MonthlySavings: 300},
+					{
+						Kind: recommendationFamily, ServiceId: 4, ServiceName: "Spotify", SubscriptionIds: []int{5, 7, 10},
+						UserIds: []string{userId, otherId, thirdId}, MonthlySavings: 300,
+					},
+					{Kind: recommendationUnused, ServiceId: 2, ServiceName: "Okko", SubscriptionIds: []int{3}, MonthlySavings: 200},
+					{Kind: recommendationAnnual, ServiceId: 3, ServiceName: "Kinopoisk", SubscriptionIds: []int{4}, MonthlySavings: 100},
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "family plan is more expensive",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				date:   &day,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: userId, Currency: "RUB"}, nil)
+				sub.EXPECT().FindActive(a.ctx, a.userId, day).Return(subscriptions[4:5], nil)
+				service.EXPECT().FindById(a.ctx, 4).Return(dbmodel.Service{Id: 4, FamilyPrice: ptr(1500), FamilySize: ptr(2), YearlyPrice: ptr(6000)}, nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{ServiceId: 4}).Return(spotify, nil)
+			},
+			expectOutput: SavingsOutput{
+				UserId:         userId,
+				Currency:       "RUB",
+				Date:           "2025-07-15",
+				MonthlySavings: 100,
+				Recommendations: []RecommendationOutput{
+					{Kind: recommendationAnnual, ServiceId: 4, ServiceName: "Spotify", SubscriptionIds: []int{5}, MonthlySavings: 100},
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "no recommendations",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				date:   &day,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: userId, Currency: "RUB"}, nil)
+				sub.EXPECT().FindActive(a.ctx, a.userId, day).Return(nil, nil)
+			},
+			expectOutput: SavingsOutput{
+				UserId:          userId,
+				Currency:        "RUB",
+				Date:            "2025-07-15",
+				Recommendations: []RecommendationOutput{},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "user not found",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				date:   &day,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: SavingsOutput{},
+			expectErr:    ErrUserNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				date:   &day,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: userId, Currency: "RUB"}, nil)
+				sub.EXPECT().FindActive(a.ctx, a.userId, day).Return(subscriptions[3:4], nil)
+				service.EXPECT().FindById(a.ctx, 3).Return(dbmodel.Service{}, errors.New("some error"))
+			},
+			expectOutput: SavingsOutput{},
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			user := repomocks.NewMockUser(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			service := repomocks.NewMockService(ctrl)
+			tc.mockBehaviour(user, sub, service, tc.args)
+
+			s := newSavingsService(user, sub, service)
+
+			output, err := s.Recommend(tc.args.ctx, tc.args.userId, tc.args.date)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
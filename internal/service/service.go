@@ -3,54 +3,748 @@ package service
 import (
 	"context"
 	"subscription_service/internal/repo"
+	"subscription_service/pkg/notifier"
+	"subscription_service/pkg/publisher"
 	"time"
 )
 
+type (
+	OrganizationInput struct {
+		Name string
+	}
+
+	OrganizationOutput struct {
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+type Organization interface {
+	Create(ctx context.Context, input OrganizationInput) (int, error)
+	FindById(ctx context.Context, id int) (OrganizationOutput, error)
+	FindAll(ctx context.Context) ([]OrganizationOutput, error)
+}
+
+type (
+	UserInput struct {
+		Email       *string
+		DisplayName *string
+		Timezone    string // UTC when empty
+		Currency    string // RUB when empty
+	}
+
+	UserOutput struct {
+		Id          string    `json:"id"`
+		Email       *string   `json:"email"`
+		DisplayName *string   `json:"display_name"`
+		Timezone    string    `json:"timezone"`
+		Currency    string    `json:"currency"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	// UserSummaryOutput describes subscriptions of user on a day.
+	// MonthlySpend counts yearly subscriptions as 1/12 of price, MonthCharges is sum of charges in current month
+	UserSummaryOutput struct {
+		UserId              string            `json:"user_id"`
+		Currency            string            `json:"currency"`
+		ActiveSubscriptions int               `json:"active_subscriptions"`
+		MonthlySpend        int               `json:"monthly_spend"`
+		MonthCharges        int               `json:"month_charges"`
+		NextCharge          *UserChargeOutput `json:"next_charge"`
+	}
+
+	UserChargeOutput struct {
+		SubscriptionId int    `json:"subscription_id"`
+		ServiceName    string `json:"service_name"`
+		Price          int    `json:"price"`
+		Date           string `json:"date"`
+	}
+)
+
+type User interface {
+	Create(ctx context.Context, input UserInput) (string, error)
+	FindById(ctx context.Context, id string) (UserOutput, error)
+	FindAll(ctx context.Context) ([]UserOutput, error)
+	Update(ctx context.Context, id string, input UserInput) error
+	Delete(ctx context.Context, id string) error
+	Subscriptions(ctx context.Context, id string) ([]SubscriptionOutput, error)
+	Summary(ctx context.Context, id string, date *time.Time) (UserSummaryOutput, error)
+}
+
 type (
 	SubscriptionInput struct {
-		ServiceName string
-		Price       int
-		UserId      string
-		StartDate   time.Time
-		EndDate     *time.Time
+		ServiceId     int    // catalog service, ServiceName is used when zero
+		ServiceName   string // name or alias, unknown names are added to catalog
+		Price         int
+		UserId        string     // unknown users are created with default settings
+		StartDate     time.Time  // day of the first charge, billing anniversaries are aligned to it
+		EndDate       *time.Time // inclusive last day
+		BillingPeriod string
+		CategoryId    *int
+		Tags          []string
+		TrialEndDate  *time.Time // charges up to this date are free
+		IntroPrice    *int       // price of the first IntroPeriods charges after trial
+		IntroPeriods  int
+		TaxRate       int  // percent
+		TaxInclusive  bool // tax is included in price, otherwise it is added on top
+		Discount      *DiscountInput
+		LastUsedDate  *time.Time // the last day user used subscription
+
+		// AllowDuplicate creates subscription even if user has another one of the same service in its period
+		AllowDuplicate bool
+	}
+
+	// DiscountInput reduces charges by Value percent or by fixed Value from StartDate (start of subscription if nil)
+	// for Months months, forever if it is zero
+	DiscountInput struct {
+		Type      string
+		Value     int
+		StartDate *time.Time
+		Months    int
 	}
 
 	SubscriptionOutput struct {
-		Id          int     `json:"id"`
-		ServiceName string  `json:"service_name"`
-		Price       int     `json:"price"`
-		UserId      string  `json:"user_id"`
-		StartDate   string  `json:"start_date"`
-		EndDate     *string `json:"end_date"`
+		Id            int      `json:"id"`
+		ServiceId     int      `json:"service_id"`
+		ServiceName   string   `json:"service_name"`
+		Price         int      `json:"price"`
+		UserId        string   `json:"user_id"`
+		StartDate     string   `json:"start_date"`
+		EndDate       *string  `json:"end_date"`
+		BillingPeriod string   `json:"billing_period"`
+		CategoryId    *int     `json:"category_id"`
+		Tags          []string `json:"tags"`
+		TrialEndDate  *string  `json:"trial_end_date,omitempty"`
+		IntroPrice    *int     `json:"intro_price,omitempty"`
+		IntroPeriods  int      `json:"intro_periods,omitempty"`
+
+		TaxRate      int             `json:"tax_rate,omitempty"`
+		TaxInclusive bool            `json:"tax_inclusive,omitempty"`
+		Discount     *DiscountOutput `json:"discount,omitempty"`
+		LastUsedDate *string         `json:"last_used_date,omitempty"`
+
+		// Status is active, paused, cancelled (ended) or scheduled (not started) on current date of user.
+		// Dates are yyyy-mm-dd, CancelledAt is in time zone of user
+		Status string        `json:"status"`
+		Pauses []PauseOutput `json:"pauses,omitempty"`
+
+		CancelReason *string    `json:"cancel_reason,omitempty"`
+		CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+	}
+
+	// CancelInput chooses the last day of subscription by When, Date is used with CancelAtDate
+	CancelInput struct {
+		When   string
+		Date   *time.Time
+		Reason *string
+	}
+
+	// PauseInput is pause from StartDate to inclusive EndDate, without EndDate it lasts until resume
+	PauseInput struct {
+		StartDate time.Time
+		EndDate   *time.Time
+	}
+
+	// ResumeInput is day from which subscription is charged again, today of user if Date is nil
+	ResumeInput struct {
+		Date *time.Time
+	}
+
+	PauseOutput struct {
+		StartDate string  `json:"start_date"`
+		EndDate   *string `json:"end_date"`
+	}
+
+	DiscountOutput struct {
+		Type      string `json:"type"`
+		Value     int    `json:"value"`
+		StartDate string `json:"start_date"`
+		Months    int    `json:"months,omitempty"`
+	}
+
+	// SubscriptionFilterInput narrows subscriptions, zero fields are not applied
+	SubscriptionFilterInput struct {
+		CategoryId int // includes subcategories
+		Tag        string
+
+		// UserIds and ServiceIds match any of them, nil is not applied and empty matches nothing
+		UserIds    []string
+		ServiceIds []int
+
+		// AfterId and Limit page subscriptions ordered by id, zero values are not applied
+		AfterId int
+		Limit   int
 	}
 
 	PriceInput struct {
 		ServiceName string
 		UserId      string
+		CategoryId  int
+		Tag         string
 		StartDate   time.Time
 		EndDate     time.Time
+		Source      string // PriceSourceSubscriptions if empty
+	}
+
+	// DuplicateOutput is group of subscriptions of one user to one catalog service with overlapping periods
+	DuplicateOutput struct {
+		UserId          string `json:"user_id"`
+		ServiceId       int    `json:"service_id"`
+		ServiceName     string `json:"service_name"`
+		SubscriptionIds []int  `json:"subscription_ids"`
 	}
+
+	// PriceOutput is amount paid for subscriptions: Gross with tax, Net without it. Discount is already subtracted
+	PriceOutput struct {
+		Gross    int `json:"gross"`
+		Net      int `json:"net"`
+		Tax      int `json:"tax"`
+		Discount int `json:"discount"`
+	}
+)
+
+// Sources of prices and spend: computed from subscriptions or summed from charge ledger.
+// Ledger counts pending and paid charges billed within interval
+const (
+	PriceSourceSubscriptions = "subscriptions"
+	PriceSourceLedger        = "ledger"
 )
 
 type Subscription interface {
 	Create(ctx context.Context, input SubscriptionInput) error
 	FindById(ctx context.Context, id int) (SubscriptionOutput, error)
-	FindAll(ctx context.Context) ([]SubscriptionOutput, error)
-	FindPrice(ctx context.Context, input PriceInput) (int, error)
+	FindAll(ctx context.Context, filter SubscriptionFilterInput) ([]SubscriptionOutput, error)
+	FindPrice(ctx context.Context, input PriceInput) (PriceOutput, error)
+	FindUserPrices(ctx context.Context, userIds []string, start, end time.Time) (map[string]PriceOutput, error)
+	FindDuplicates(ctx context.Context, userId string) ([]DuplicateOutput, error)
 	Update(ctx context.Context, id int, input SubscriptionInput) error
 	Delete(ctx context.Context, id int) error
+	Pause(ctx context.Context, id int, input PauseInput) error
+	Resume(ctx context.Context, id int, input ResumeInput) error
+	Cancel(ctx context.Context, id int, input CancelInput) error
+	Uncancel(ctx context.Context, id int) error
+}
+
+// Cancellation options, subscription is active on its end date inclusive
+const (
+	CancelImmediately = "immediately"   // today is the last day
+	CancelEndOfPeriod = "end_of_period" // the last day of current billing period
+	CancelAtDate      = "date"          // the given day
+)
+
+type (
+	ShareInput struct {
+		Rule    string
+		Members []ShareMemberInput
+	}
+
+	// ShareMemberInput is share of one member: Percent for percentage rule, Amount for fixed rule
+	ShareMemberInput struct {
+		UserId  string
+		Percent *int
+		Amount  *int
+	}
+
+	ShareOutput struct {
+		SubscriptionId int                 `json:"subscription_id"`
+		Rule           string              `json:"rule"`
+		OwnerId        string              `json:"owner_id"`
+		OwnerCost      int                 `json:"owner_cost"`
+		Members        []ShareMemberOutput `json:"members"`
+	}
+
+	ShareMemberOutput struct {
+		UserId  string `json:"user_id"`
+		Percent *int   `json:"percent"`
+		Amount  *int   `json:"amount"`
+		Cost    int    `json:"cost"`
+	}
+
+	SettleUpInput struct {
+		UserId    string
+		StartDate time.Time
+		EndDate   time.Time
+	}
+
+	SettleUpMonthOutput struct {
+		Month string       `json:"month"`
+		Debts []DebtOutput `json:"debts"`
+	}
+
+	// DebtOutput is amount user From owes user To, debts of two users to each other are netted
+	DebtOutput struct {
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Amount int    `json:"amount"`
+	}
+)
+
+type Share interface {
+	Set(ctx context.Context, subscriptionId int, input ShareInput) error
+	Find(ctx context.Context, subscriptionId int) (ShareOutput, error)
+	Delete(ctx context.Context, subscriptionId int) error
+	SettleUp(ctx context.Context, input SettleUpInput) ([]SettleUpMonthOutput, error)
+}
+
+type (
+	ForecastInput struct {
+		UserId      string
+		ServiceName string
+		StartDate   time.Time
+		Months      int
+	}
+
+	ForecastOutput struct {
+		Total  int                   `json:"total"`
+		Months []ForecastMonthOutput `json:"months"`
+	}
+
+	ForecastMonthOutput struct {
+		Month    string                  `json:"month"`
+		Total    int                     `json:"total"`
+		Services []ForecastServiceOutput `json:"services"`
+	}
+
+	ForecastServiceOutput struct {
+		ServiceName string `json:"service_name"`
+		Price       int    `json:"price"`
+	}
+
+	PriceChangeInput struct {
+		Price     int
+		StartDate time.Time
+	}
+
+	PriceChangeOutput struct {
+		Id             int        `json:"id"`
+		SubscriptionId int        `json:"subscription_id"`
+		Price          int        `json:"price"`
+		StartDate      string     `json:"start_date"`
+		CreatedAt      time.Time  `json:"created_at"`
+		AppliedAt      *time.Time `json:"applied_at,omitempty"` // time price was written to subscription
+	}
+)
+
+type Forecast interface {
+	Forecast(ctx context.Context, input ForecastInput) (ForecastOutput, error)
+	SchedulePriceChange(ctx context.Context, subscriptionId int, input PriceChangeInput) (int, error)
+	FindPriceChanges(ctx context.Context, subscriptionId int) ([]PriceChangeOutput, error)
+	DeletePriceChange(ctx context.Context, subscriptionId, id int) error
+	ApplyPriceChanges(ctx context.Context, date time.Time) (int, error)
+}
+
+type (
+	AnalyticsInput struct {
+		UserId    string
+		StartDate time.Time
+		EndDate   time.Time
+	}
+
+	RecurringSpendOutput struct {
+		Month  string `json:"month"`
+		Active int    `json:"active"`
+		Amount int    `json:"amount"`
+	}
+
+	GrowthOutput struct {
+		Month   string `json:"month"`
+		New     int    `json:"new"`
+		Churned int    `json:"churned"`
+		Net     int    `json:"net"`
+	}
+
+	ServiceStatsOutput struct {
+		ServiceName   string `json:"service_name"`
+		Subscriptions int    `json:"subscriptions"`
+		AvgPrice      int    `json:"avg_price"`
+		Spend         int    `json:"spend"`
+	}
+
+	// CategoryStatsOutput is spend of category: Spend of its own subscriptions and TotalSpend with subcategories.
+	// Uncategorized subscriptions have nil CategoryId
+	CategoryStatsOutput struct {
+		CategoryId    *int   `json:"category_id"`
+		Name          string `json:"name"`
+		ParentId      *int   `json:"parent_id"`
+		Subscriptions int    `json:"subscriptions"`
+		Spend         int    `json:"spend"`
+		TotalSpend    int    `json:"total_spend"`
+	}
+)
+
+type Analytics interface {
+	RecurringSpend(ctx context.Context, input AnalyticsInput) ([]RecurringSpendOutput, error)
+	Growth(ctx context.Context, input AnalyticsInput) ([]GrowthOutput, error)
+	Services(ctx context.Context, input AnalyticsInput, limit int) ([]ServiceStatsOutput, error)
+	Categories(ctx context.Context, input AnalyticsInput) ([]CategoryStatsOutput, error)
+}
+
+type (
+	ServiceInput struct {
+		Name         string
+		Aliases      []string
+		Category     *string
+		DefaultPrice *int
+		YearlyPrice  *int
+		FamilyPrice  *int
+		FamilySize   *int
+		VendorUrl    *string
+		LogoUrl      *string
+	}
+
+	ServiceOutput struct {
+		Id           int       `json:"id"`
+		Name         string    `json:"name"`
+		Aliases      []string  `json:"aliases"`
+		Category     *string   `json:"category"`
+		DefaultPrice *int      `json:"default_price"`
+		YearlyPrice  *int      `json:"yearly_price"`
+		FamilyPrice  *int      `json:"family_price"`
+		FamilySize   *int      `json:"family_size"`
+		VendorUrl    *string   `json:"vendor_url"`
+		LogoUrl      *string   `json:"logo_url"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+)
+
+type Catalog interface {
+	Create(ctx context.Context, input ServiceInput) (int, error)
+	FindById(ctx context.Context, id int) (ServiceOutput, error)
+	FindAll(ctx context.Context) ([]ServiceOutput, error)
+	Update(ctx context.Context, id int, input ServiceInput) error
+	Delete(ctx context.Context, id int) error
+}
+
+type (
+	CategoryInput struct {
+		Name     string
+		ParentId *int
+	}
+
+	CategoryOutput struct {
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		ParentId  *int      `json:"parent_id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+type Category interface {
+	Create(ctx context.Context, input CategoryInput) (int, error)
+	FindById(ctx context.Context, id int) (CategoryOutput, error)
+	FindAll(ctx context.Context) ([]CategoryOutput, error)
+	Update(ctx context.Context, id int, input CategoryInput) error
+	Delete(ctx context.Context, id int) error
+}
+
+type Calendar interface {
+	Token(ctx context.Context, userId string) (string, error)
+	Feed(ctx context.Context, userId, token string) ([]byte, error)
+}
+
+type (
+	WebhookInput struct {
+		Url    string
+		Secret string
+		Events []string
+	}
+
+	WebhookOutput struct {
+		Id        int       `json:"id"`
+		Url       string    `json:"url"`
+		Events    []string  `json:"events"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	WebhookDeliveryOutput struct {
+		Id             int64      `json:"id"`
+		EventId        int64      `json:"event_id"`
+		EventType      string     `json:"event_type"`
+		Status         string     `json:"status"`
+		Attempts       int        `json:"attempts"`
+		LastStatusCode *int       `json:"last_status_code"`
+		LastError      *string    `json:"last_error"`
+		NextAttemptAt  *time.Time `json:"next_attempt_at"`
+		CreatedAt      time.Time  `json:"created_at"`
+		DeliveredAt    *time.Time `json:"delivered_at"`
+	}
+)
+
+type Webhook interface {
+	Create(ctx context.Context, input WebhookInput) (int, error)
+	FindById(ctx context.Context, id int) (WebhookOutput, error)
+	FindAll(ctx context.Context) ([]WebhookOutput, error)
+	Update(ctx context.Context, id int, input WebhookInput) error
+	Delete(ctx context.Context, id int) error
+	FindDeliveries(ctx context.Context, webhookId int) ([]WebhookDeliveryOutput, error)
+	Redeliver(ctx context.Context, webhookId int, deliveryId int64) error
+	Dispatch(ctx context.Context, limit int) (int, error)
+}
+
+type (
+	ReminderSettingsInput struct {
+		LeadDays int
+		Email    *string
+	}
+
+	ReminderSettingsOutput struct {
+		UserId   string  `json:"user_id"`
+		LeadDays int     `json:"lead_days"`
+		Email    *string `json:"email"`
+	}
+)
+
+type Reminder interface {
+	FindSettings(ctx context.Context, userId string) (ReminderSettingsOutput, error)
+	UpdateSettings(ctx context.Context, userId string, input ReminderSettingsInput) error
+	Run(ctx context.Context, date time.Time) (int, error)
+}
+
+type (
+	BudgetInput struct {
+		UserId      string
+		ServiceName *string
+		CategoryId  *int
+		Amount      int
+		Thresholds  []int
+	}
+
+	BudgetOutput struct {
+		Id          int       `json:"id"`
+		UserId      string    `json:"user_id"`
+		ServiceName *string   `json:"service_name"`
+		CategoryId  *int      `json:"category_id"`
+		Amount      int       `json:"amount"`
+		Thresholds  []int     `json:"thresholds"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	BudgetMonthOutput struct {
+		Month             string `json:"month"`
+		Amount            int    `json:"amount"`
+		Spent             int    `json:"spent"`
+		Percent           int    `json:"percent"`
+		Remaining         int    `json:"remaining"`
+		CrossedThresholds []int  `json:"crossed_thresholds"`
+	}
+)
+
+type Budget interface {
+	Create(ctx context.Context, input BudgetInput) (int, error)
+	FindById(ctx context.Context, id int) (BudgetOutput, error)
+	FindAll(ctx context.Context, userId string) ([]BudgetOutput, error)
+	Update(ctx context.Context, id int, input BudgetInput) error
+	Delete(ctx context.Context, id int) error
+	Evaluate(ctx context.Context, id int, start, end time.Time, source string) ([]BudgetMonthOutput, error)
+	Check(ctx context.Context, date time.Time) (int, error)
+}
+
+type (
+	ChargeFilterInput struct {
+		UserId         string
+		SubscriptionId int
+		Status         string
+		StartDate      *time.Time
+		EndDate        *time.Time
+	}
+
+	ChargeOutput struct {
+		Id             int64     `json:"id"`
+		SubscriptionId int       `json:"subscription_id"`
+		ServiceName    string    `json:"service_name"`
+		UserId         string    `json:"user_id"`
+		BillingDate    string    `json:"billing_date"`
+		Amount         int       `json:"amount"` // gross with tax
+		Tax            int       `json:"tax"`
+		Discount       int       `json:"discount"`
+		Status         string    `json:"status"`
+		CreatedAt      time.Time `json:"created_at"`
+		UpdatedAt      time.Time `json:"updated_at"`
+	}
+)
+
+type (
+	// StatementOutput lists charges of subscriptions user paid for in month. Amount of shared subscription charge
+	// is part of price paid by user, totals are grouped by currency of subscription owners
+	StatementOutput struct {
+		UserId string                 `json:"user_id"`
+		Month  string                 `json:"month"`
+		Items  []StatementItemOutput  `json:"items"`
+		Totals []StatementTotalOutput `json:"totals"`
+	}
+
+	StatementItemOutput struct {
+		SubscriptionId int    `json:"subscription_id"`
+		ServiceName    string `json:"service_name"`
+		BillingPeriod  string `json:"billing_period"`
+		BillingDate    string `json:"billing_date"`
+		PeriodEnd      string `json:"period_end"`
+		Price          int    `json:"price"`
+		Amount         int    `json:"amount"`
+		Currency       string `json:"currency"`
+		Shared         bool   `json:"shared"`
+
+		Proration *StatementProrationOutput `json:"proration,omitempty"` // nil if subscription covers the whole period
+	}
+
+	// StatementProrationOutput is part of billing period covered by subscription. Amount of item is still charged
+	// in full, prorated amount is informational
+	StatementProrationOutput struct {
+		CoveredStart string `json:"covered_start"`
+		CoveredEnd   string `json:"covered_end"`
+		CoveredDays  int    `json:"covered_days"`
+		PeriodDays   int    `json:"period_days"`
+		Amount       int    `json:"amount"` // part of amount for covered days
+	}
+
+	StatementTotalOutput struct {
+		Currency string `json:"currency"`
+		Amount   int    `json:"amount"`
+	}
+)
+
+type Statement interface {
+	Find(ctx context.Context, userId string, month time.Time) (StatementOutput, error)
+	HTML(ctx context.Context, userId string, month time.Time) ([]byte, error)
+	PDF(ctx context.Context, userId string, month time.Time) ([]byte, error)
+}
+
+type Charge interface {
+	Generate(ctx context.Context, until time.Time) (int, error)
+	FindById(ctx context.Context, id int64) (ChargeOutput, error)
+	FindAll(ctx context.Context, filter ChargeFilterInput) ([]ChargeOutput, error)
+	SetStatus(ctx context.Context, id int64, status string) error
+}
+
+type (
+	// AnomalyOutput is price of subscription above ReferencePrice by Percent: previous price of subscription
+	// for increase and median price of the same service among users for outlier
+	AnomalyOutput struct {
+		Id             int       `json:"id"`
+		SubscriptionId int       `json:"subscription_id"`
+		ServiceName    string    `json:"service_name"`
+		UserId         string    `json:"user_id"`
+		Kind           string    `json:"kind"`
+		Price          int       `json:"price"`
+		ReferencePrice int       `json:"reference_price"`
+		Percent        int       `json:"percent"`
+		DetectedAt     time.Time `json:"detected_at"`
+	}
+)
+
+type Anomaly interface {
+	Detect(ctx context.Context, date time.Time) (int, error)
+	FindAll(ctx context.Context, userId string) ([]AnomalyOutput, error)
+}
+
+type (
+	// SavingsOutput lists recommendations for subscriptions of user running on Date, MonthlySavings is their sum.
+	// Amounts are monthly, yearly prices count as 1/12
+	SavingsOutput struct {
+		UserId          string                 `json:"user_id"`
+		Currency        string                 `json:"currency"`
+		Date            string                 `json:"date"`
+		MonthlySavings  int                    `json:"monthly_savings"`
+		Recommendations []RecommendationOutput `json:"recommendations"`
+	}
+
+	// RecommendationOutput is one way to save. SubscriptionIds are duplicates or unused subscriptions to cancel,
+	// monthly subscription to switch to yearly plan or subscriptions of UserIds to replace with one family plan.
+	// MonthlySavings of family plan is part saved by user
+	RecommendationOutput struct {
+		Kind            string   `json:"kind"`
+		ServiceId       int      `json:"service_id"`
+		ServiceName     string   `json:"service_name"`
+		SubscriptionIds []int    `json:"subscription_ids"`
+		UserIds         []string `json:"user_ids,omitempty"`
+		MonthlySavings  int      `json:"monthly_savings"`
+	}
+)
+
+type Savings interface {
+	Recommend(ctx context.Context, userId string, date *time.Time) (SavingsOutput, error)
+}
+
+type Outbox interface {
+	Relay(ctx context.Context, limit int) (int, error)
 }
 
 type Services struct {
+	Organization Organization
+	User         User
 	Subscription Subscription
+	Share        Share
+	Catalog      Catalog
+	Category     Category
+	Calendar     Calendar
+	Forecast     Forecast
+	Analytics    Analytics
+	Outbox       Outbox
+	Webhook      Webhook
+	Reminder     Reminder
+	Budget       Budget
+	Charge       Charge
+	Statement    Statement
+	Anomaly      Anomaly
+	Savings      Savings
 }
 
 type ServicesDependencies struct {
-	Repos *repo.Repositories
+	Repos            *repo.Repositories
+	Publisher        publisher.Publisher
+	Notifier         notifier.Notifier
+	CalendarSecret   string
+	ReminderLeadDays int
+	AnomalyThreshold int // percent of price increase reported as anomaly
+	ChargeHorizon    int // months ahead of today charges can be generated up to
 }
 
 func NewServices(d *ServicesDependencies) *Services {
+	webhook := newWebhookService(d.Repos.Webhook, d.Repos.WebhookDelivery)
+
 	return &Services{
-		Subscription: newSubscriptionService(d.Repos.Subscription),
+		Organization: newOrganizationService(d.Repos.Organization),
+		User:         newUserService(d.Repos.User, d.Repos.Subscription),
+		Subscription: newSubscriptionService(
+			d.Repos.Transactor,
+			d.Repos.User,
+			d.Repos.Subscription,
+			d.Repos.Service,
+			d.Repos.Category,
+			d.Repos.Outbox,
+			d.Repos.Pause,
+			d.Repos.Charge,
+		),
+		Share:     newShareService(d.Repos.Transactor, d.Repos.User, d.Repos.Subscription, d.Repos.Share),
+		Catalog:   newCatalogService(d.Repos.Service),
+		Category:  newCategoryService(d.Repos.Category),
+		Calendar:  newCalendarService(d.Repos.User, d.Repos.Subscription, d.CalendarSecret),
+		Forecast:  newForecastService(d.Repos.Transactor, d.Repos.Subscription, d.Repos.Service, d.Repos.PriceChange, d.Repos.Outbox),
+		Analytics: newAnalyticsService(d.Repos.Analytics, d.Repos.Category),
+		Outbox:    newOutboxService(d.Repos.Outbox, publisher.NewMulti(d.Publisher, webhook)),
+		Webhook:   webhook,
+		Reminder: newReminderService(
+			d.Repos.Transactor,
+			d.Repos.User,
+			d.Repos.Subscription,
+			d.Repos.Reminder,
+			d.Repos.Outbox,
+			d.Notifier,
+			d.ReminderLeadDays,
+		),
+		Budget: newBudgetService(
+			d.Repos.Transactor,
+			d.Repos.Budget,
+			d.Repos.Subscription,
+			d.Repos.Service,
+			d.Repos.Category,
+			d.Repos.Outbox,
+			d.Repos.Charge,
+		),
+		Charge:    newChargeService(d.Repos.Transactor, d.Repos.Subscription, d.Repos.Charge, d.Repos.Outbox, d.ChargeHorizon),
+		Statement: newStatementService(d.Repos.User, d.Repos.Subscription),
+		Anomaly:   newAnomalyService(d.Repos.Transactor, d.Repos.Anomaly, d.Repos.Outbox, d.AnomalyThreshold),
+		Savings:   newSavingsService(d.Repos.User, d.Repos.Subscription, d.Repos.Service),
 	}
 }
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"strings"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+type shareService struct {
+	tx    repo.Transactor
+	user  repo.User
+	sub   repo.Subscription
+	share repo.Share
+}
+
+func newShareService(tx repo.Transactor, user repo.User, subscription repo.Subscription, share repo.Share) *shareService {
+	return &shareService{
+		tx:    tx,
+		user:  user,
+		sub:   subscription,
+		share: share,
+	}
+}
+
+// Set shares subscription with members replacing previous shares. Unknown members are created with default settings
+func (s *shareService) Set(ctx context.Context, subscriptionId int, input ShareInput) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		sub, err := s.sub.FindById(ctx, subscriptionId)
+		if err != nil {
+			return err
+		}
+		split, err := newSplit(sub, input)
+		if err != nil {
+			return err
+		}
+		for _, sh := range split.Shares {
+			if err = ensureUser(ctx, s.user, sh.UserId); err != nil {
+				return err
+			}
+		}
+		return s.share.Set(ctx, split)
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		if errors.Is(err, ErrInvalidShare) || errors.Is(err, ErrUserNotFound) {
+			return err
+		}
+		log.Err(err).Int("subscription_id", subscriptionId).Interface("input", input).Msg("share/Set error set shares in database")
+		return err
+	}
+	log.Info().Int("subscription_id", subscriptionId).Interface("input", input).Msg("share/Set set shares of subscription in database")
+	return nil
+}
+
+func (s *shareService) Find(ctx context.Context, subscriptionId int) (ShareOutput, error) {
+	sub, err := s.sub.FindById(ctx, subscriptionId)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ShareOutput{}, ErrSubscriptionNotFound
+		}
+		log.Err(err).Int("subscription_id", subscriptionId).Msg("share/Find error find subscription in database")
+		return ShareOutput{}, err
+	}
+	split, err := s.share.FindBySubscription(ctx, subscriptionId)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ShareOutput{}, ErrShareNotFound
+		}
+		log.Err(err).Int("subscription_id", subscriptionId).Msg("share/Find error find shares in database")
+		return ShareOutput{}, err
+	}
+
+	output := ShareOutput{
+		SubscriptionId: subscriptionId,
+		Rule:           split.Rule,
+		OwnerId:        sub.UserId,
+		OwnerCost:      split.OwnerCost,
+		Members:        make([]ShareMemberOutput, 0, len(split.Shares)),
+	}
+	for _, sh := range split.Shares {
+		output.Members = append(output.Members, ShareMemberOutput{
+			UserId:  sh.UserId,
+			Percent: sh.Percent,
+			Amount:  sh.Amount,
+			Cost:    sh.Cost,
+		})
+	}
+	return output, nil
+}
+
+func (s *shareService) Delete(ctx context.Context, subscriptionId int) error {
+	if err := s.share.Delete(ctx, subscriptionId); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrShareNotFound
+		}
+		log.Err(err).Int("subscription_id", subscriptionId).Msg("share/Delete error delete shares in database")
+		return err
+	}
+	log.Info().Int("subscription_id", subscriptionId).Msg("share/Delete delete shares of subscription in database")
+	return nil
+}
+
+// SettleUp returns who owes whom in every month of interval: every charge of shared subscription
+// makes members owe their share to owner
+func (s *shareService) SettleUp(ctx context.Context, input SettleUpInput) ([]SettleUpMonthOutput, error) {
+	start, end := monthStart(input.StartDate), monthStart(input.EndDate)
+
+	debts, err := s.share.FindDebts(ctx, input.UserId, start, monthEnd(end))
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("share/SettleUp error find debts in database")
+		return nil, err
+	}
+
+	result := make([]SettleUpMonthOutput, 0, monthsBetween(start, end)+1)
+	for month := start; !month.After(end); month = addMonths(month, 1) {
+		result = append(result, SettleUpMonthOutput{
+			Month: formatMonth(month),
+			Debts: settleDebts(debts, month),
+		})
+	}
+	return result, nil
+}
+
+// settleDebts nets debts charged in month between every pair of users
+func settleDebts(debts []dbmodel.ShareDebt, month time.Time) []DebtOutput {
+	type pair struct{ a, b string }
+
+	// positive balance is debt of a to b
+	balance := make(map[pair]int)
+	for _, d := range debts {
+		charge, ok := monthCharge(dbmodel.Subscription{
+			Price:         d.Price,
+			StartDate:     d.StartDate,
+			EndDate:       d.EndDate,
+			BillingPeriod: d.BillingPeriod,
+			TrialEndDate:  d.TrialEndDate,
+			IntroPrice:    d.IntroPrice,
+			IntroPeriods:  d.IntroPeriods,
+			Pauses:        d.Pauses,
+
+			TaxRate:           d.TaxRate,
+			TaxInclusive:      d.TaxInclusive,
+			DiscountType:      d.DiscountType,
+			DiscountValue:     d.DiscountValue,
+			DiscountStartDate: d.DiscountStartDate,
+			DiscountMonths:    d.DiscountMonths,
+		}, month)
+		if !ok || d.Price == 0 {
+			continue
+		}
+		amount := d.Amount * charge / d.Price
+
+		if d.UserId < d.OwnerId {
+			balance[pair{d.UserId, d.OwnerId}] += amount
+		} else {
+			balance[pair{d.OwnerId, d.UserId}] -= amount
+		}
+	}
+
+	result := make([]DebtOutput, 0, len(balance))
+	for p, amount := range balance {
+		switch {
+		case amount > 0:
+			result = append(result, DebtOutput{From: p.a, To: p.b, Amount: amount})
+		case amount < 0:
+			result = append(result, DebtOutput{From: p.b, To: p.a, Amount: -amount})
+		}
+	}
+	slices.SortFunc(result, func(a, b DebtOutput) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To, b.To)
+	})
+	return result
+}
+
+// newSplit checks shares of input against split rule and price of subscription.
+// Members must be distinct users other than owner, their shares can't exceed 100% or price
+func newSplit(sub dbmodel.Subscription, input ShareInput) (dbmodel.Split, error) {
+	split := dbmodel.Split{
+		SubscriptionId: sub.Id,
+		Rule:           input.Rule,
+		Shares:         make([]dbmodel.Share, 0, len(input.Members)),
+	}
+	if len(input.Members) == 0 {
+		return dbmodel.Split{}, ErrInvalidShare
+	}
+
+	var total int
+
+	for _, m := range input.Members {
+		if m.UserId == sub.UserId || slices.ContainsFunc(split.Shares, func(sh dbmodel.Share) bool { return sh.UserId == m.UserId }) {
+			return dbmodel.Split{}, ErrInvalidShare
+		}
+		sh := dbmodel.Share{UserId: m.UserId}
+
+		switch input.Rule {
+		case dbmodel.SplitEqual:
+		case dbmodel.SplitPercentage:
+			if m.Percent == nil || *m.Percent <= 0 {
+				return dbmodel.Split{}, ErrInvalidShare
+			}
+			sh.Percent = m.Percent
+			total += *m.Percent
+		case dbmodel.SplitFixed:
+			if m.Amount == nil || *m.Amount <= 0 {
+				return dbmodel.Split{}, ErrInvalidShare
+			}
+			sh.Amount = m.Amount
+			total += *m.Amount
+		default:
+			return dbmodel.Split{}, ErrInvalidShare
+		}
+		split.Shares = append(split.Shares, sh)
+	}
+	if input.Rule == dbmodel.SplitPercentage && total > 100 || input.Rule == dbmodel.SplitFixed && total > sub.Price {
+		return dbmodel.Split{}, ErrInvalidShare
+	}
+	return split, nil
+}
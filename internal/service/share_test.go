@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+	"time"
+)
+
+func TestShareService_Set(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		subscriptionId int
+		input          ShareInput
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, share *repomocks.MockShare, a args)
+
+	owned := dbmodel.Subscription{
+		Id:            1,
+		ServiceName:   "Yandex Plus",
+		Price:         900,
+		UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "equal split",
+			args: args{
+				ctx:            context.Background(),
+				subscriptionId: 1,
+				input: ShareInput{
+					Rule: dbmodel.SplitEqual,
+					Members: []ShareMemberInput{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a", Percent: ptr(10)},
+						{UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba"},
+					},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, share *repomocks.MockShare, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.subscriptionId).Return(owned, nil)
+				user.EXPECT().Ensure(a.ctx, "2344696a-d069-4fad-a3ed-f27c13651c3a").Return(nil)
+				user.EXPECT().Ensure(a.ctx, "60601fee-2bf1-4721-ae6f-7636e79a0cba").Return(nil)
+				share.EXPECT().Set(a.ctx, dbmodel.Split{
+					SubscriptionId: 1,
+					Rule:           dbmodel.SplitEqual,
+					Shares: []dbmodel.Share{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a"},
+						{UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba"},
+					},
+				}).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "percentage over 100",
+			args: args{
+				ctx:            context.Background(),
+				subscriptionId: 1,
+				input: ShareInput{
+					Rule: dbmodel.SplitPercentage,
+					Members: []ShareMemberInput{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a", Percent: ptr(60)},
+						{UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba", Percent: ptr(50)},
+					},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, share *repomocks.MockShare, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.subscriptionId).Return(owned, nil)
+			},
+			expectErr: ErrInvalidShare,
+		},
+		{
+			testName: "fixed amounts over price",
+			args: args{
+				ctx:            context.Background(),
+				subscriptionId: 1,
+				input: ShareInput{
+					Rule: dbmodel.SplitFixed,
+					Members: []ShareMemberInput{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a", Amount: ptr(1000)},
+					},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, share *repomocks.MockShare, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.subscriptionId).Return(owned, nil)
+			},
+			expectErr: ErrInvalidShare,
+		},
+		{
+			testName: "owner is member",
+			args: args{
+				ctx:            context.Background(),
+				subscriptionId: 1,
+				input: ShareInput{
+					Rule: dbmodel.SplitEqual,
+					Members: []ShareMemberInput{
+						{UserId: "6114696a-d069-4fad-a3ed-f27c13651c3a"},
+					},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, share *repomocks.MockShare, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.subscriptionId).Return(owned, nil)
+			},
+			expectErr: ErrInvalidShare,
+		},
+		{
+			testName: "subscription not found",
+			args: args{
+				ctx:            context.Background(),
+				subscriptionId: 2,
+				input: ShareInput{
+					Rule: dbmodel.SplitEqual,
+					Members: []ShareMemberInput{
+						{UserId: "2344696a-d069-4fad-a3ed-f27c13651c3a"},
+					},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, share *repomocks.MockShare, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.subscriptionId).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrSubscriptionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			user := repomocks.NewMockUser(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			share := repomocks.NewMockShare(ctrl)
+			tc.mockBehaviour(tx, user, sub, share, tc.args)
+
+			s := newShareService(tx, user, sub, share)
+
+			err := s.Set(tc.args.ctx, tc.args.subscriptionId, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestShareService_SettleUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	share := repomocks.NewMockShare(ctrl)
+
+	ctx := context.Background()
+	input := SettleUpInput{
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	alice, bob := "6114696a-d069-4fad-a3ed-f27c13651c3a", "2344696a-d069-4fad-a3ed-f27c13651c3a"
+
+	share.EXPECT().FindDebts(ctx, "", input.StartDate, monthEnd(input.EndDate)).Return([]dbmodel.ShareDebt{
+		{
+			SubscriptionId: 1,
+			OwnerId:        alice,
+			UserId:         bob,
+			Amount:         300,
+			Price:          900,
+			StartDate:      time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:        ptr(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+			BillingPeriod:  dbmodel.BillingMonthly,
+		},
+		{
+			SubscriptionId: 2,
+			OwnerId:        bob,
+			UserId:         alice,
+			Amount:         600,
+			Price:          1200,
+			StartDate:      time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			BillingPeriod:  dbmodel.BillingYearly,
+		},
+		{
+			SubscriptionId: 3,
+			OwnerId:        alice,
+			UserId:         bob,
+			Amount:         100,
+			Price:          200,
+			StartDate:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			BillingPeriod:  dbmodel.BillingMonthly,
+			TrialEndDate:   ptr(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)),
+			IntroPrice:     ptr(100),
+			IntroPeriods:   1,
+		},
+	}, nil)
+
+	s := newShareService(nil, nil, nil, share)
+
+	output, err := s.SettleUp(ctx, input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SettleUpMonthOutput{
+		{Month: "01-2025", Debts: []DebtOutput{{From: bob, To: alice, Amount: 300}}},
+		{Month: "02-2025", Debts: []DebtOutput{{From: alice, To: bob, Amount: 250}}},
+		{Month: "03-2025", Debts: []DebtOutput{{From: bob, To: alice, Amount: 100}}},
+	}, output)
+}
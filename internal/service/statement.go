@@ -0,0 +1,205 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"html/template"
+	"slices"
+	"strings"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/pdf"
+	"time"
+)
+
+//go:embed templates/statement.html
+var statementHTML string
+
+var statementTemplate = template.Must(template.New("statement").Parse(statementHTML))
+
+type statementService struct {
+	user repo.User
+	sub  repo.Subscription
+}
+
+func newStatementService(user repo.User, subscription repo.Subscription) *statementService {
+	return &statementService{
+		user: user,
+		sub:  subscription,
+	}
+}
+
+// Find builds statement of user for month of date from the same prices as summed by subscription price, so total
+// of statement is the price of user subscriptions in month. Subscriptions within free trial are not listed
+func (s *statementService) Find(ctx context.Context, userId string, month time.Time) (StatementOutput, error) {
+	if _, err := s.user.FindById(ctx, userId); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return StatementOutput{}, ErrUserNotFound
+		}
+		log.Err(err).Str("user_id", userId).Msg("statement/Find error find user in database")
+		return StatementOutput{}, err
+	}
+	month = monthStart(month)
+
+	charges, err := s.sub.FindCharges(ctx, userId, month, monthEnd(month))
+	if err != nil {
+		log.Err(err).Str("user_id", userId).Time("month", month).Msg("statement/Find error find subscription charges in database")
+		return StatementOutput{}, err
+	}
+
+	output := StatementOutput{
+		UserId: userId,
+		Month:  formatMonth(month),
+		Items:  []StatementItemOutput{},
+		Totals: []StatementTotalOutput{},
+	}
+	totals := make(map[string]int)
+
+	for _, c := range charges {
+		day := lastActiveDay(c.Subscription, monthEnd(month))
+		if inTrial(c.Subscription, day) {
+			continue
+		}
+		item := newStatementItem(c, userId, day)
+		output.Items = append(output.Items, item)
+		totals[item.Currency] += item.Amount
+	}
+
+	for currency, amount := range totals {
+		output.Totals = append(output.Totals, StatementTotalOutput{Currency: currency, Amount: amount})
+	}
+	slices.SortFunc(output.Totals, func(a, b StatementTotalOutput) int {
+		return strings.Compare(a.Currency, b.Currency)
+	})
+	return output, nil
+}
+
+// HTML renders statement with html template
+func (s *statementService) HTML(ctx context.Context, userId string, month time.Time) ([]byte, error) {
+	statement, err := s.Find(ctx, userId, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err = statementTemplate.Execute(&buf, statement); err != nil {
+		log.Err(err).Str("user_id", userId).Msg("statement/HTML error render statement")
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PDF renders statement as plain text PDF document
+func (s *statementService) PDF(ctx context.Context, userId string, month time.Time) ([]byte, error) {
+	statement, err := s.Find(ctx, userId, month)
+	if err != nil {
+		return nil, err
+	}
+	return newStatementDocument(statement).Marshal(), nil
+}
+
+// newStatementItem returns statement line of subscription with billing period containing its last active day of month
+func newStatementItem(c dbmodel.SubscriptionCharge, userId string, day time.Time) StatementItemOutput {
+	sub := c.Subscription
+
+	n := monthsBetween(sub.StartDate, day) / periodMonths(sub.BillingPeriod)
+	if n > 0 && billingDate(sub, n).After(day) {
+		n--
+	}
+	date := billingDate(sub, n)
+
+	// period lasts until the day before the next charge
+	periodEnd := billingDate(sub, n+1).AddDate(0, 0, -1)
+	if sub.EndDate != nil && sub.EndDate.Before(periodEnd) {
+		periodEnd = *sub.EndDate
+	}
+
+	return StatementItemOutput{
+		SubscriptionId: sub.Id,
+		ServiceName:    sub.ServiceName,
+		BillingPeriod:  billingPeriod(sub.BillingPeriod),
+		BillingDate:    formatDate(date),
+		PeriodEnd:      formatDate(periodEnd),
+		Price:          c.Gross,
+		Amount:         c.Amount,
+		Currency:       c.Currency,
+		Shared:         sub.UserId != userId || c.Amount != c.Gross,
+		Proration:      newStatementProration(sub, date, billingDate(sub, n+1).AddDate(0, 0, -1), c.Amount),
+	}
+}
+
+// newStatementProration returns part of amount for days of billing period from date to last day covered by
+// subscription: after free trial, until end or cancellation and out of pauses. Nil if the whole period is covered
+func newStatementProration(sub dbmodel.Subscription, date, last time.Time, amount int) *StatementProrationOutput {
+	start, end := date, last
+	if sub.TrialEndDate != nil && !sub.TrialEndDate.Before(start) {
+		start = sub.TrialEndDate.AddDate(0, 0, 1)
+	}
+	if sub.EndDate != nil && sub.EndDate.Before(end) {
+		end = *sub.EndDate
+	}
+
+	var periodDays, coveredDays int
+
+	for day := date; !day.After(last); day = day.AddDate(0, 0, 1) {
+		periodDays++
+		if !day.Before(start) && !day.After(end) && !paused(sub, day) {
+			coveredDays++
+		}
+	}
+	if coveredDays == periodDays {
+		return nil
+	}
+	return &StatementProrationOutput{
+		CoveredStart: formatDate(start),
+		CoveredEnd:   formatDate(end),
+		CoveredDays:  coveredDays,
+		PeriodDays:   periodDays,
+		Amount:       amount * coveredDays / periodDays,
+	}
+}
+
+// lastActiveDay returns end of subscription if it ends before end of interval
+func lastActiveDay(sub dbmodel.Subscription, end time.Time) time.Time {
+	if sub.EndDate != nil && sub.EndDate.Before(end) {
+		return *sub.EndDate
+	}
+	return end
+}
+
+func newStatementDocument(statement StatementOutput) pdf.Document {
+	doc := pdf.Document{
+		Title: fmt.Sprintf("Statement %s", statement.Month),
+		Lines: []string{fmt.Sprintf("User %s", statement.UserId), ""},
+	}
+	if len(statement.Items) == 0 {
+		doc.Lines = append(doc.Lines, "No charges in this month.")
+		return doc
+	}
+
+	for _, item := range statement.Items {
+		name := item.ServiceName
+		if item.Shared {
+			name += " (shared)"
+		}
+		doc.Lines = append(doc.Lines,
+			fmt.Sprintf("%s  %s - %s, %s", name, item.BillingDate, item.PeriodEnd, item.BillingPeriod),
+			fmt.Sprintf("    price %d %s, paid %d %s", item.Price, item.Currency, item.Amount, item.Currency),
+		)
+		if p := item.Proration; p != nil {
+			doc.Lines = append(doc.Lines, fmt.Sprintf("    covered %s - %s, %d of %d days, prorated %d %s",
+				p.CoveredStart, p.CoveredEnd, p.CoveredDays, p.PeriodDays, p.Amount, item.Currency))
+		}
+	}
+	doc.Lines = append(doc.Lines, "")
+	for _, total := range statement.Totals {
+		doc.Lines = append(doc.Lines, fmt.Sprintf("Total %d %s", total.Amount, total.Currency))
+	}
+	return doc
+}
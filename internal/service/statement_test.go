@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+	"time"
+)
+
+func TestStatementService_Find(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+		month  time.Time
+	}
+
+	type mockBehaviour func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args)
+
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	ownerId := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	february := date(2025, 2, 1)
+
+	charges := func() []dbmodel.SubscriptionCharge {
+		return []dbmodel.SubscriptionCharge{
+			{
+				// anniversary is clamped to the end of February
+				Subscription: dbmodel.Subscription{Id: 1, ServiceName: "Yandex Plus", Price: 300, UserId: userId, StartDate: date(2025, 1, 31)},
+				Gross:        300,
+				Amount:       300,
+				Currency:     "RUB",
+			},
+			{
+				// shared with user by owner paying in another currency
+				Subscription: dbmodel.Subscription{Id: 2, ServiceName: "Netflix", Price: 1000, UserId: ownerId, StartDate: date(2024, 12, 5)},
+				Gross:        1000,
+				Amount:       250,
+				Currency:     "USD",
+			},
+			{
+				// free trial lasts the whole month
+				Subscription: dbmodel.Subscription{Id: 4, ServiceName: "Kinopoisk", Price: 400, UserId: userId, StartDate: date(2025, 2, 1), TrialEndDate: ptr(date(2025, 3, 14))},
+				Currency:     "RUB",
+			},
+			{
+				Subscription: dbmodel.Subscription{Id: 5, ServiceName: "Spotify", Price: 500, UserId: userId, StartDate: date(2025, 1, 15), IntroPrice: ptr(100), IntroPeriods: 2},
+				Gross:        100,
+				Amount:       100,
+				Currency:     "RUB",
+			},
+			{
+				// period ends with subscription
+				Subscription: dbmodel.Subscription{Id: 6, ServiceName: "VK Music", Price: 200, UserId: userId, StartDate: date(2024, 11, 10), EndDate: ptr(date(2025, 2, 20))},
+				Gross:        200,
+				Amount:       200,
+				Currency:     "RUB",
+			},
+			{
+				// week of period is paused
+				Subscription: dbmodel.Subscription{
+					Id: 7, ServiceName: "Okko", Price: 280, UserId: userId, StartDate: date(2025, 1, 3),
+					Pauses: []dbmodel.Pause{{StartDate: date(2025, 2, 10), EndDate: ptr(date(2025, 2, 16))}},
+				},
+				Gross:    280,
+				Amount:   280,
+				Currency: "RUB",
+			},
+		}
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  StatementOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				month:  date(2025, 2, 14),
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: userId}, nil)
+				sub.EXPECT().FindCharges(a.ctx, a.userId, february, date(2025, 2, 28)).Return(charges(), nil)
+			},
+			expectOutput: StatementOutput{
+				UserId: userId,
+				Month:  "02-2025",
+				Items: []StatementItemOutput{
+					{
+						SubscriptionId: 1, ServiceName: "Yandex Plus", BillingPeriod: dbmodel.BillingMonthly,
+						BillingDate: "2025-02-28", PeriodEnd: "2025-03-30", Price: 300, Amount: 300, Currency: "RUB",
+					},
+					{
+						SubscriptionId: 2, ServiceName: "Netflix", BillingPeriod: dbmodel.BillingMonthly,
+						BillingDate: "2025-02-05", PeriodEnd: "2025-03-04", Price: 1000, Amount: 250, Currency: "USD", Shared: true,
+					},
+					{
+						SubscriptionId: 5, ServiceName: "Spotify", BillingPeriod: dbmodel.BillingMonthly,
+						BillingDate: "2025-02-15", PeriodEnd: "2025-03-14", Price: 100, Amount: 100, Currency: "RUB",
+					},
+					{
+						SubscriptionId: 6, ServiceName: "VK Music", BillingPeriod: dbmodel.BillingMonthly,
+						BillingDate: "2025-02-10", PeriodEnd: "2025-02-20", Price: 200, Amount: 200, Currency: "RUB",
+						Proration: &StatementProrationOutput{
+							CoveredStart: "2025-02-10", CoveredEnd: "2025-02-20", CoveredDays: 11, PeriodDays: 28, Amount: 78,
+						},
+					},
+					{
+						SubscriptionId: 7, ServiceName: "Okko", BillingPeriod: dbmodel.BillingMonthly,
+						BillingDate: "2025-02-03", PeriodEnd: "2025-03-02", Price: 280, Amount: 280, Currency: "RUB",
+						Proration: &StatementProrationOutput{
+							CoveredStart: "2025-02-03", CoveredEnd: "2025-03-02", CoveredDays: 21, PeriodDays: 28, Amount: 210,
+						},
+					},
+				},
+				Totals: []StatementTotalOutput{
+					{Currency: "RUB", Amount: 880},
+					{Currency: "USD", Amount: 250},
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "no charges",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				month:  february,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: userId}, nil)
+				sub.EXPECT().FindCharges(a.ctx, a.userId, february, date(2025, 2, 28)).Return(nil, nil)
+			},
+			expectOutput: StatementOutput{
+				UserId: userId,
+				Month:  "02-2025",
+				Items:  []StatementItemOutput{},
+				Totals: []StatementTotalOutput{},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "user not found",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				month:  february,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{}, pgerrs.ErrNotFound)
+			},
+			expectOutput: StatementOutput{},
+			expectErr:    ErrUserNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				month:  february,
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args) {
+				user.EXPECT().FindById(a.ctx, a.userId).Return(dbmodel.User{Id: userId}, nil)
+				sub.EXPECT().FindCharges(a.ctx, a.userId, february, date(2025, 2, 28)).Return(nil, errors.New("some error"))
+			},
+			expectOutput: StatementOutput{},
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			user := repomocks.NewMockUser(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(user, sub, tc.args)
+
+			s := newStatementService(user, sub)
+
+			output, err := s.Find(tc.args.ctx, tc.args.userId, tc.args.month)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+func TestStatementService_Render(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	month := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+
+	user := repomocks.NewMockUser(ctrl)
+	sub := repomocks.NewMockSubscription(ctrl)
+	user.EXPECT().FindById(gomock.Any(), userId).Return(dbmodel.User{Id: userId}, nil).Times(2)
+	sub.EXPECT().FindCharges(gomock.Any(), userId, month, monthEnd(month)).Return([]dbmodel.SubscriptionCharge{
+		{
+			Subscription: dbmodel.Subscription{Id: 1, ServiceName: "<Yandex Plus>", Price: 300, UserId: userId, StartDate: month},
+			Gross:        300,
+			Amount:       300,
+			Currency:     "RUB",
+		},
+		{
+			Subscription: dbmodel.Subscription{Id: 2, ServiceName: "Okko", Price: 280, UserId: userId, StartDate: month, EndDate: ptr(time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC))},
+			Gross:        280,
+			Amount:       280,
+			Currency:     "RUB",
+		},
+	}, nil).Times(2)
+
+	s := newStatementService(user, sub)
+
+	html, err := s.HTML(context.Background(), userId, month)
+	assert.NoError(t, err)
+	assert.Contains(t, string(html), "<title>Statement 02-2025</title>")
+	assert.Contains(t, string(html), "&lt;Yandex Plus&gt;")
+	assert.Contains(t, string(html), "300 RUB")
+	assert.Contains(t, string(html), "covered 2025-02-01 &ndash; 2025-02-14, 14 of 28 days, prorated 140")
+
+	pdf, err := s.PDF(context.Background(), userId, month)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+	assert.Contains(t, string(pdf), "(<Yandex Plus>  2025-02-01 - 2025-02-28, monthly) Tj")
+	assert.Contains(t, string(pdf), "(    covered 2025-02-01 - 2025-02-14, 14 of 28 days, prorated 140 RUB) Tj")
+	assert.Contains(t, string(pdf), "(Total 580 RUB) Tj")
+}
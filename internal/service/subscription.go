@@ -4,31 +4,97 @@ import (
 	"context"
 	"errors"
 	"github.com/rs/zerolog/log"
+	"slices"
+	"strings"
 	"subscription_service/internal/model/dbmodel"
 	"subscription_service/internal/repo"
 	"subscription_service/internal/repo/pgerrs"
 	"time"
 )
 
+// Statuses of subscription on current date
+const (
+	statusActive    = "active"
+	statusPaused    = "paused"
+	statusCancelled = "cancelled"
+	statusScheduled = "scheduled"
+)
+
 type subscriptionService struct {
-	sub repo.Subscription
+	tx       repo.Transactor
+	user     repo.User
+	sub      repo.Subscription
+	service  repo.Service
+	category repo.Category
+	outbox   repo.Outbox
+	pause    repo.Pause
+	charge   repo.Charge
 }
 
-func newSubscriptionService(subscription repo.Subscription) *subscriptionService {
+func newSubscriptionService(
+	tx repo.Transactor,
+	user repo.User,
+	subscription repo.Subscription,
+	service repo.Service,
+	category repo.Category,
+	outbox repo.Outbox,
+	pause repo.Pause,
+	charge repo.Charge,
+) *subscriptionService {
 	return &subscriptionService{
-		sub: subscription,
+		tx:       tx,
+		user:     user,
+		sub:      subscription,
+		service:  service,
+		category: category,
+		outbox:   outbox,
+		pause:    pause,
+		charge:   charge,
 	}
 }
 
 func (s *subscriptionService) Create(ctx context.Context, input SubscriptionInput) error {
-	err := s.sub.Create(ctx, dbmodel.Subscription{
-		ServiceName: input.ServiceName,
-		Price:       input.Price,
-		UserId:      input.UserId,
-		StartDate:   input.StartDate,
-		EndDate:     input.EndDate,
+	sub, err := newSubscriptionModel(input)
+	if err != nil {
+		return err
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		svc, err := s.resolveService(ctx, input)
+		if err != nil {
+			return err
+		}
+		sub.ServiceId, sub.ServiceName = svc.Id, svc.Name
+
+		if err = s.checkCategory(ctx, sub.CategoryId); err != nil {
+			return err
+		}
+		if err = ensureUser(ctx, s.user, sub.UserId); err != nil {
+			return err
+		}
+		if !input.AllowDuplicate {
+			if err = s.checkDuplicates(ctx, sub); err != nil {
+				return err
+			}
+		}
+
+		id, err := s.sub.Create(ctx, sub)
+		if err != nil {
+			return err
+		}
+		sub.Id = id
+
+		if len(sub.Tags) > 0 {
+			if err = s.sub.SetTags(ctx, id, sub.Tags); err != nil {
+				return err
+			}
+		}
+		return s.outbox.Create(ctx, newSubscriptionEvent(dbmodel.EventSubscriptionCreated, sub))
 	})
 	if err != nil {
+		if errors.Is(err, ErrServiceNotFound) || errors.Is(err, ErrCategoryNotFound) || errors.Is(err, ErrUserNotFound) ||
+			errors.Is(err, ErrDuplicateSubscription) {
+			return err
+		}
 		log.Err(err).Interface("input", input).Msg("subscription/Create error create subscription in database")
 		return err
 	}
@@ -45,64 +111,187 @@ func (s *subscriptionService) FindById(ctx context.Context, id int) (Subscriptio
 		log.Err(err).Int("id", id).Msg("subscription/FindById error find subscription in database")
 		return SubscriptionOutput{}, err
 	}
-	output := SubscriptionOutput{
-		Id:          id,
-		ServiceName: sub.ServiceName,
-		Price:       sub.Price,
-		UserId:      sub.UserId,
-		StartDate:   formatDate(sub.StartDate),
-	}
-	if sub.EndDate != nil {
-		output.EndDate = ptr(formatDate(*sub.EndDate))
-	}
-	return output, nil
+	sub.Id = id
+	return newSubscriptionOutput(sub), nil
 }
 
-func (s *subscriptionService) FindAll(ctx context.Context) ([]SubscriptionOutput, error) {
-	subscriptions, err := s.sub.FindAll(ctx)
+func (s *subscriptionService) FindAll(ctx context.Context, filter SubscriptionFilterInput) ([]SubscriptionOutput, error) {
+	subscriptions, err := s.sub.FindAll(ctx, dbmodel.SubscriptionFilter{
+		CategoryId: filter.CategoryId,
+		Tag:        normalizeTag(filter.Tag),
+		UserIds:    filter.UserIds,
+		ServiceIds: filter.ServiceIds,
+		AfterId:    filter.AfterId,
+		Limit:      filter.Limit,
+	})
 	if err != nil {
-		log.Err(err).Msg("subscription/FindAll error find all subscriptions in database")
+		log.Err(err).Interface("filter", filter).Msg("subscription/FindAll error find all subscriptions in database")
 		return nil, err
 	}
 	result := make([]SubscriptionOutput, 0, len(subscriptions))
 	for _, sub := range subscriptions {
-		output := SubscriptionOutput{
-			Id:          sub.Id,
-			ServiceName: sub.ServiceName,
-			Price:       sub.Price,
-			UserId:      sub.UserId,
-			StartDate:   formatDate(sub.StartDate),
+		result = append(result, newSubscriptionOutput(sub))
+	}
+	return result, nil
+}
+
+func (s *subscriptionService) FindPrice(ctx context.Context, input PriceInput) (PriceOutput, error) {
+	var serviceId int
+
+	if input.ServiceName != "" {
+		id, ok, err := findServiceId(ctx, s.service, input.ServiceName)
+		if err != nil {
+			log.Err(err).Interface("input", input).Msg("subscription/FindPrice error find service in database")
+			return PriceOutput{}, err
 		}
-		if sub.EndDate != nil {
-			output.EndDate = ptr(formatDate(*sub.EndDate))
+		if !ok {
+			return PriceOutput{}, nil
+		}
+		serviceId = id
+	}
+
+	filter := dbmodel.SubscriptionFilter{
+		UserId:     input.UserId,
+		ServiceId:  serviceId,
+		CategoryId: input.CategoryId,
+		Tag:        normalizeTag(input.Tag),
+	}
+	// ledger has actual charges of interval instead of prices of subscriptions active in it
+	var (
+		price dbmodel.Price
+		err   error
+	)
+	if input.Source == PriceSourceLedger {
+		price, err = s.charge.FindAmount(ctx, filter, input.StartDate, input.EndDate)
+	} else {
+		price, err = s.sub.FindPrice(ctx, filter, input.StartDate, input.EndDate)
+	}
+	if err != nil {
+		log.Err(err).Interface("input", input).Msg("subscription/FindPrice error find total price in database")
+		return PriceOutput{}, err
+	}
+	return PriceOutput{
+		Gross:    price.Gross,
+		Net:      price.Net,
+		Tax:      price.Tax,
+		Discount: price.Discount,
+	}, nil
+}
+
+// FindUserPrices finds price paid by every of users like FindPrice with user filter, but with one query.
+// Users without subscriptions in interval have zero price
+func (s *subscriptionService) FindUserPrices(ctx context.Context, userIds []string, start, end time.Time) (map[string]PriceOutput, error) {
+	prices, err := s.sub.FindUserPrices(ctx, userIds, start, end)
+	if err != nil {
+		log.Err(err).Strs("user_ids", userIds).Msg("subscription/FindUserPrices error find prices in database")
+		return nil, err
+	}
+	result := make(map[string]PriceOutput, len(userIds))
+	for _, id := range userIds {
+		p := prices[id]
+		result[id] = PriceOutput{
+			Gross:    p.Gross,
+			Net:      p.Net,
+			Tax:      p.Tax,
+			Discount: p.Discount,
 		}
-		result = append(result, output)
 	}
 	return result, nil
 }
 
-func (s *subscriptionService) FindPrice(ctx context.Context, input PriceInput) (int, error) {
-	price, err := s.sub.FindPrice(ctx, input.ServiceName, input.UserId, input.StartDate, input.EndDate)
+// FindDuplicates groups subscriptions of user (all users if empty) to the same catalog service, so aliases
+// of service are matched, whose periods overlap. Subscriptions without overlapping ones are not listed
+func (s *subscriptionService) FindDuplicates(ctx context.Context, userId string) ([]DuplicateOutput, error) {
+	subscriptions, err := s.sub.FindAll(ctx, dbmodel.SubscriptionFilter{UserId: userId})
 	if err != nil {
-		log.Err(err).Interface("input", input).Msg("subscription/FindPrice error find total price in database")
-		return 0, err
+		log.Err(err).Str("user_id", userId).Msg("subscription/FindDuplicates error find subscriptions in database")
+		return nil, err
 	}
-	return price, nil
+	slices.SortStableFunc(subscriptions, func(a, b dbmodel.Subscription) int {
+		if c := strings.Compare(a.UserId, b.UserId); c != 0 {
+			return c
+		}
+		if a.ServiceId != b.ServiceId {
+			return a.ServiceId - b.ServiceId
+		}
+		return a.StartDate.Compare(b.StartDate)
+	})
+
+	result := make([]DuplicateOutput, 0)
+
+	// subscriptions sorted by start are in one group while they start before the latest end in group
+	for i := 0; i < len(subscriptions); {
+		first := subscriptions[i]
+		end := first.EndDate
+		group := DuplicateOutput{
+			UserId:          first.UserId,
+			ServiceId:       first.ServiceId,
+			ServiceName:     first.ServiceName,
+			SubscriptionIds: []int{first.Id},
+		}
+
+		j := i + 1
+		for ; j < len(subscriptions); j++ {
+			next := subscriptions[j]
+			if next.UserId != first.UserId || next.ServiceId != first.ServiceId || end != nil && next.StartDate.After(*end) {
+				break
+			}
+			if end != nil && (next.EndDate == nil || next.EndDate.After(*end)) {
+				end = next.EndDate
+			}
+			group.SubscriptionIds = append(group.SubscriptionIds, next.Id)
+		}
+		if len(group.SubscriptionIds) > 1 {
+			result = append(result, group)
+		}
+		i = j
+	}
+	return result, nil
 }
 
 func (s *subscriptionService) Update(ctx context.Context, id int, input SubscriptionInput) error {
-	err := s.sub.Update(ctx, dbmodel.Subscription{
-		Id:          id,
-		ServiceName: input.ServiceName,
-		Price:       input.Price,
-		UserId:      input.UserId,
-		StartDate:   input.StartDate,
-		EndDate:     input.EndDate,
+	sub, err := newSubscriptionModel(input)
+	if err != nil {
+		return err
+	}
+	sub.Id = id
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		old, err := s.sub.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		svc, err := s.resolveService(ctx, input)
+		if err != nil {
+			return err
+		}
+		sub.ServiceId, sub.ServiceName = svc.Id, svc.Name
+
+		if err = s.checkCategory(ctx, sub.CategoryId); err != nil {
+			return err
+		}
+		if err = ensureUser(ctx, s.user, sub.UserId); err != nil {
+			return err
+		}
+		if err = s.sub.Update(ctx, sub); err != nil {
+			return err
+		}
+		if err = s.sub.SetTags(ctx, id, sub.Tags); err != nil {
+			return err
+		}
+		eventType := dbmodel.EventSubscriptionUpdated
+		if old.EndDate == nil && sub.EndDate != nil {
+			eventType = dbmodel.EventSubscriptionCancelled
+		}
+		return s.outbox.Create(ctx, newSubscriptionEvent(eventType, sub))
 	})
 	if err != nil {
 		if errors.Is(err, pgerrs.ErrNotFound) {
 			return ErrSubscriptionNotFound
 		}
+		if errors.Is(err, ErrServiceNotFound) || errors.Is(err, ErrCategoryNotFound) || errors.Is(err, ErrUserNotFound) {
+			return err
+		}
 		log.Err(err).Interface("input", input).Msg("subscription/Update error update subscription in database")
 		return err
 	}
@@ -111,7 +300,18 @@ func (s *subscriptionService) Update(ctx context.Context, id int, input Subscrip
 }
 
 func (s *subscriptionService) Delete(ctx context.Context, id int) error {
-	if err := s.sub.Delete(ctx, id); err != nil {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		sub, err := s.sub.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		sub.Id = id
+		if err = s.sub.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.outbox.Create(ctx, newSubscriptionEvent(dbmodel.EventSubscriptionDeleted, sub))
+	})
+	if err != nil {
 		if errors.Is(err, pgerrs.ErrNotFound) {
 			return ErrSubscriptionNotFound
 		}
@@ -122,10 +322,395 @@ func (s *subscriptionService) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+// Pause stops charges of subscription from start date to inclusive end date. Open-ended pause lasts until Resume
+func (s *subscriptionService) Pause(ctx context.Context, id int, input PauseInput) error {
+	p := dbmodel.Pause{
+		SubscriptionId: id,
+		StartDate:      input.StartDate,
+		EndDate:        input.EndDate,
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		sub, err := s.sub.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if p.StartDate.Before(monthStart(sub.StartDate)) || p.EndDate != nil && p.EndDate.Before(p.StartDate) ||
+			sub.EndDate != nil && p.StartDate.After(*sub.EndDate) {
+			return ErrInvalidPause
+		}
+		for _, other := range sub.Pauses {
+			if pausesOverlap(p, other) {
+				return ErrSubscriptionPaused
+			}
+		}
+
+		if p.Id, err = s.pause.Create(ctx, p); err != nil {
+			return err
+		}
+		sub.Pauses = append(sub.Pauses, p)
+		slices.SortFunc(sub.Pauses, func(a, b dbmodel.Pause) int {
+			return a.StartDate.Compare(b.StartDate)
+		})
+		return s.outbox.Create(ctx, newSubscriptionEvent(dbmodel.EventSubscriptionPaused, sub))
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		if errors.Is(err, ErrInvalidPause) || errors.Is(err, ErrSubscriptionPaused) {
+			return err
+		}
+		log.Err(err).Int("id", id).Interface("input", input).Msg("subscription/Pause error pause subscription in database")
+		return err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("subscription/Pause pause subscription in database")
+	return nil
+}
+
+// Resume ends pause of subscription covering resume date, today of subscription user by default. Subscription
+// is charged again from this date. Pause starting on resume date is removed
+func (s *subscriptionService) Resume(ctx context.Context, id int, input ResumeInput) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		sub, err := s.sub.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		day := today(sub)
+		if input.Date != nil {
+			day = *input.Date
+		}
+		i := slices.IndexFunc(sub.Pauses, func(p dbmodel.Pause) bool {
+			return pauseCovers(p, day)
+		})
+		if i < 0 {
+			return ErrSubscriptionNotPaused
+		}
+
+		p := sub.Pauses[i]
+		if p.StartDate.Equal(day) {
+			if err = s.pause.Delete(ctx, id, p.Id); err != nil {
+				return err
+			}
+			sub.Pauses = slices.Delete(sub.Pauses, i, i+1)
+		} else {
+			p.EndDate = ptr(day.AddDate(0, 0, -1))
+			if err = s.pause.Update(ctx, p); err != nil {
+				return err
+			}
+			sub.Pauses[i] = p
+		}
+		return s.outbox.Create(ctx, newSubscriptionEvent(dbmodel.EventSubscriptionResumed, sub))
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		if errors.Is(err, ErrSubscriptionNotPaused) {
+			return err
+		}
+		log.Err(err).Int("id", id).Interface("input", input).Msg("subscription/Resume error resume subscription in database")
+		return err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("subscription/Resume resume subscription in database")
+	return nil
+}
+
+// Cancel sets end date of subscription to today of subscription user, to the last day of current billing period or
+// to the given date. Subscription with end date in the future is cancelled again with new end date
+func (s *subscriptionService) Cancel(ctx context.Context, id int, input CancelInput) error {
+	now := time.Now().UTC()
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		sub, err := s.sub.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		day := today(sub)
+		if sub.EndDate != nil && sub.EndDate.Before(day) {
+			return ErrSubscriptionEnded
+		}
+
+		end := day
+		switch input.When {
+		case CancelEndOfPeriod:
+			// period ends the day before the first charge after today, subscription not started yet ends with its first period
+			n := max(monthsBetween(sub.StartDate, day)/periodMonths(sub.BillingPeriod), 1)
+			for !billingDate(sub, n).After(day) {
+				n++
+			}
+			end = billingDate(sub, n).AddDate(0, 0, -1)
+		case CancelAtDate:
+			if input.Date == nil {
+				return ErrInvalidCancellation
+			}
+			end = *input.Date
+		}
+		if end.Before(day) || end.Before(sub.StartDate) {
+			return ErrInvalidCancellation
+		}
+
+		sub.EndDate, sub.CancelReason, sub.CancelledAt = &end, input.Reason, &now
+		if err = s.sub.Cancel(ctx, sub); err != nil {
+			return err
+		}
+		return s.outbox.Create(ctx, newSubscriptionEvent(dbmodel.EventSubscriptionCancelled, sub))
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		if errors.Is(err, ErrSubscriptionEnded) || errors.Is(err, ErrInvalidCancellation) {
+			return err
+		}
+		log.Err(err).Int("id", id).Interface("input", input).Msg("subscription/Cancel error cancel subscription in database")
+		return err
+	}
+	log.Info().Int("id", id).Interface("input", input).Msg("subscription/Cancel cancel subscription in database")
+	return nil
+}
+
+// Uncancel removes end date of cancelled subscription which is still active after today of subscription user.
+// End date set without cancellation is kept
+func (s *subscriptionService) Uncancel(ctx context.Context, id int) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		sub, err := s.sub.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if sub.CancelledAt == nil || sub.EndDate == nil {
+			return ErrSubscriptionNotCancelled
+		}
+		if !sub.EndDate.After(today(sub)) {
+			return ErrSubscriptionEnded
+		}
+
+		if err = s.sub.Uncancel(ctx, id); err != nil {
+			return err
+		}
+		sub.EndDate, sub.CancelReason, sub.CancelledAt = nil, nil, nil
+		return s.outbox.Create(ctx, newSubscriptionEvent(dbmodel.EventSubscriptionUncancelled, sub))
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		if errors.Is(err, ErrSubscriptionNotCancelled) || errors.Is(err, ErrSubscriptionEnded) {
+			return err
+		}
+		log.Err(err).Int("id", id).Msg("subscription/Uncancel error uncancel subscription in database")
+		return err
+	}
+	log.Info().Int("id", id).Msg("subscription/Uncancel uncancel subscription in database")
+	return nil
+}
+
+// resolveService returns catalog service of subscription: by id if it is set, otherwise by name or alias.
+// Unknown name is added to catalog, so clients may keep sending free text names
+func (s *subscriptionService) resolveService(ctx context.Context, input SubscriptionInput) (dbmodel.Service, error) {
+	if input.ServiceId != 0 {
+		svc, err := s.service.FindById(ctx, input.ServiceId)
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return dbmodel.Service{}, ErrServiceNotFound
+		}
+		return svc, err
+	}
+
+	svc, err := s.service.FindByName(ctx, input.ServiceName)
+	if !errors.Is(err, pgerrs.ErrNotFound) {
+		return svc, err
+	}
+	svc = dbmodel.Service{Name: normalizeName(input.ServiceName)}
+	svc.Id, err = s.service.Create(ctx, svc)
+	return svc, err
+}
+
+func (s *subscriptionService) checkCategory(ctx context.Context, categoryId *int) error {
+	if categoryId == nil {
+		return nil
+	}
+	_, err := s.category.FindById(ctx, *categoryId)
+	if errors.Is(err, pgerrs.ErrNotFound) {
+		return ErrCategoryNotFound
+	}
+	return err
+}
+
+// normalizeTags lowercases tags and removes empty and repeated ones
+func normalizeTags(tags []string) []string {
+	var result []string
+
+	for _, t := range tags {
+		if t = normalizeTag(t); t != "" && !slices.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(normalizeName(tag))
+}
+
+// newSubscriptionModel applies default billing period and checks trial and intro pricing
+func newSubscriptionModel(input SubscriptionInput) (dbmodel.Subscription, error) {
+	if input.TrialEndDate != nil && input.TrialEndDate.Before(input.StartDate) {
+		return dbmodel.Subscription{}, ErrInvalidTrial
+	}
+	if input.IntroPeriods > 0 && input.IntroPrice == nil {
+		return dbmodel.Subscription{}, ErrInvalidTrial
+	}
+	if d := input.Discount; d != nil {
+		if d.Type == dbmodel.DiscountPercent && d.Value > 100 ||
+			d.StartDate != nil && (d.StartDate.Before(input.StartDate) || input.EndDate != nil && d.StartDate.After(*input.EndDate)) {
+			return dbmodel.Subscription{}, ErrInvalidDiscount
+		}
+	}
+	sub := dbmodel.Subscription{
+		Price:         input.Price,
+		UserId:        input.UserId,
+		StartDate:     input.StartDate,
+		EndDate:       input.EndDate,
+		BillingPeriod: billingPeriod(input.BillingPeriod),
+		CategoryId:    input.CategoryId,
+		Tags:          normalizeTags(input.Tags),
+		TrialEndDate:  input.TrialEndDate,
+		IntroPrice:    input.IntroPrice,
+		IntroPeriods:  input.IntroPeriods,
+		TaxRate:       input.TaxRate,
+		TaxInclusive:  input.TaxInclusive,
+		LastUsedDate:  input.LastUsedDate,
+	}
+	// discount without start date applies from the first charge
+	if d := input.Discount; d != nil {
+		sub.DiscountType, sub.DiscountValue, sub.DiscountMonths = &d.Type, d.Value, d.Months
+		sub.DiscountStartDate = &input.StartDate
+		if d.StartDate != nil {
+			sub.DiscountStartDate = d.StartDate
+		}
+	}
+	return sub, nil
+}
+
+func newSubscriptionOutput(sub dbmodel.Subscription) SubscriptionOutput {
+	tags := sub.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	output := SubscriptionOutput{
+		Id:            sub.Id,
+		ServiceId:     sub.ServiceId,
+		ServiceName:   sub.ServiceName,
+		Price:         sub.Price,
+		UserId:        sub.UserId,
+		StartDate:     formatDate(sub.StartDate),
+		BillingPeriod: billingPeriod(sub.BillingPeriod),
+		CategoryId:    sub.CategoryId,
+		Tags:          tags,
+	}
+	if sub.EndDate != nil {
+		output.EndDate = ptr(formatDate(*sub.EndDate))
+	}
+	if sub.TrialEndDate != nil {
+		output.TrialEndDate = ptr(formatDate(*sub.TrialEndDate))
+	}
+	if sub.IntroPrice != nil {
+		output.IntroPrice, output.IntroPeriods = sub.IntroPrice, sub.IntroPeriods
+	}
+	if sub.TaxRate > 0 {
+		output.TaxRate, output.TaxInclusive = sub.TaxRate, sub.TaxInclusive
+	}
+	if sub.LastUsedDate != nil {
+		output.LastUsedDate = ptr(formatDate(*sub.LastUsedDate))
+	}
+	if sub.DiscountType != nil {
+		output.Discount = &DiscountOutput{
+			Type:   *sub.DiscountType,
+			Value:  sub.DiscountValue,
+			Months: sub.DiscountMonths,
+		}
+		if sub.DiscountStartDate != nil {
+			output.Discount.StartDate = formatDate(*sub.DiscountStartDate)
+		}
+	}
+	for _, p := range sub.Pauses {
+		pause := PauseOutput{StartDate: formatDate(p.StartDate)}
+		if p.EndDate != nil {
+			pause.EndDate = ptr(formatDate(*p.EndDate))
+		}
+		output.Pauses = append(output.Pauses, pause)
+	}
+	if sub.CancelledAt != nil {
+		output.CancelReason, output.CancelledAt = sub.CancelReason, ptr(sub.CancelledAt.In(location(sub.Timezone)))
+	}
+	output.Status = subscriptionStatus(sub, today(sub))
+	return output
+}
+
+// checkDuplicates returns DuplicateError if user has other subscriptions to service of sub overlapping with it
+func (s *subscriptionService) checkDuplicates(ctx context.Context, sub dbmodel.Subscription) error {
+	subscriptions, err := s.sub.FindAll(ctx, dbmodel.SubscriptionFilter{UserId: sub.UserId, ServiceId: sub.ServiceId})
+	if err != nil {
+		return err
+	}
+
+	var ids []int
+	for _, other := range subscriptions {
+		if overlap(sub, other) {
+			ids = append(ids, other.Id)
+		}
+	}
+	if len(ids) > 0 {
+		return &DuplicateError{Ids: ids}
+	}
+	return nil
+}
+
+// overlap reports whether periods of subscriptions have common days, subscription without end date never ends
+func overlap(a, b dbmodel.Subscription) bool {
+	return (b.EndDate == nil || !a.StartDate.After(*b.EndDate)) && (a.EndDate == nil || !b.StartDate.After(*a.EndDate))
+}
+
+// subscriptionStatus returns status of subscription on day
+func subscriptionStatus(sub dbmodel.Subscription, day time.Time) string {
+	switch {
+	case sub.EndDate != nil && sub.EndDate.Before(day):
+		return statusCancelled
+	case sub.StartDate.After(day):
+		return statusScheduled
+	case paused(sub, day):
+		return statusPaused
+	}
+	return statusActive
+}
+
+// pausesOverlap reports whether pauses have common days, open-ended pause lasts forever
+func pausesOverlap(a, b dbmodel.Pause) bool {
+	return (b.EndDate == nil || !a.StartDate.After(*b.EndDate)) && (a.EndDate == nil || !b.StartDate.After(*a.EndDate))
+}
+
 func formatDate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+func formatMonth(t time.Time) string {
 	return t.Format("01-2006")
 }
 
+// today returns current day in time zone of subscription user
+func today(sub dbmodel.Subscription) time.Time {
+	return truncateToDay(time.Now().In(location(sub.Timezone)))
+}
+
+// billingPeriod returns monthly period for subscriptions without explicit one
+func billingPeriod(period string) string {
+	if period == "" {
+		return dbmodel.BillingMonthly
+	}
+	return period
+}
+
 func ptr[T any](t T) *T {
 	return &t
 }
@@ -18,7 +18,7 @@ func TestSubscriptionService_Create(t *testing.T) {
 		input SubscriptionInput
 	}
 
-	type mockBehaviour func(sub *repomocks.MockSubscription, a args)
+	type mockBehaviour func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args)
 
 	testCases := []struct {
 		testName      string
@@ -38,17 +38,291 @@ func TestSubscriptionService_Create(t *testing.T) {
 					EndDate:     nil,
 				},
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				s := dbmodel.Subscription{
+					ServiceId:     3,
+					ServiceName:   "Yandex",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					EndDate:       a.input.EndDate,
+					BillingPeriod: dbmodel.BillingMonthly,
+				}
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 3}).Return(nil, nil)
+				sub.EXPECT().Create(a.ctx, s).Return(1, nil)
+
+				s.Id = 1
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionCreated, s)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "by service id",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceId: 3,
+					Price:     1000,
+					UserId:    "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindById(a.ctx, 3).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 3}).Return(nil, nil)
+				sub.EXPECT().Create(a.ctx, dbmodel.Subscription{
+					ServiceId:     3,
+					ServiceName:   "Yandex",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					BillingPeriod: dbmodel.BillingMonthly,
+				}).Return(1, nil)
+				outbox.EXPECT().Create(a.ctx, gomock.Any()).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "new service",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "  Kinopoisk   HD ",
+					Price:       400,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+				service.EXPECT().Create(a.ctx, dbmodel.Service{Name: "Kinopoisk HD"}).Return(5, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 5}).Return(nil, nil)
 				sub.EXPECT().Create(a.ctx, dbmodel.Subscription{
-					ServiceName: a.input.ServiceName,
-					Price:       a.input.Price,
-					UserId:      a.input.UserId,
-					StartDate:   a.input.StartDate,
-					EndDate:     a.input.EndDate,
-				}).Return(nil)
+					ServiceId:     5,
+					ServiceName:   "Kinopoisk HD",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					BillingPeriod: dbmodel.BillingMonthly,
+				}).Return(1, nil)
+				outbox.EXPECT().Create(a.ctx, gomock.Any()).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "with category and tags",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					CategoryId:  ptr(2),
+					Tags:        []string{"Family", " music ", "family", ""},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				s := dbmodel.Subscription{
+					ServiceId:     3,
+					ServiceName:   "Yandex",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					BillingPeriod: dbmodel.BillingMonthly,
+					CategoryId:    ptr(2),
+					Tags:          []string{"family", "music"},
+				}
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				category.EXPECT().FindById(a.ctx, 2).Return(dbmodel.Category{Id: 2, Name: "music"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 3}).Return(nil, nil)
+				sub.EXPECT().Create(a.ctx, s).Return(1, nil)
+				sub.EXPECT().SetTags(a.ctx, 1, []string{"family", "music"}).Return(nil)
+
+				s.Id = 1
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionCreated, s)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "with tax and discount from start",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					TaxRate:     20,
+					Discount:    &DiscountInput{Type: dbmodel.DiscountFixed, Value: 100, Months: 6},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				s := dbmodel.Subscription{
+					ServiceId:         3,
+					ServiceName:       "Yandex",
+					Price:             a.input.Price,
+					UserId:            a.input.UserId,
+					StartDate:         a.input.StartDate,
+					BillingPeriod:     dbmodel.BillingMonthly,
+					TaxRate:           20,
+					DiscountType:      ptr(dbmodel.DiscountFixed),
+					DiscountValue:     100,
+					DiscountStartDate: ptr(a.input.StartDate),
+					DiscountMonths:    6,
+				}
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 3}).Return(nil, nil)
+				sub.EXPECT().Create(a.ctx, s).Return(1, nil)
+
+				s.Id = 1
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionCreated, s)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "percent discount above 100",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Discount:    &DiscountInput{Type: dbmodel.DiscountPercent, Value: 150},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+			},
+			expectErr: ErrInvalidDiscount,
+		},
+		{
+			testName: "discount starts before subscription",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Discount:    &DiscountInput{Type: dbmodel.DiscountFixed, Value: 100, StartDate: ptr(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))},
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+			},
+			expectErr: ErrInvalidDiscount,
+		},
+		{
+			testName: "duplicate",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:     ptr(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 3}).Return([]dbmodel.Subscription{
+					{Id: 1, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))},
+					{Id: 2, StartDate: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)},
+					{Id: 3, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 4, StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+				}, nil)
+			},
+			expectErr: &DuplicateError{Ids: []int{2, 3}},
+		},
+		{
+			testName: "allowed duplicate",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName:    "Yandex",
+					Price:          1000,
+					UserId:         "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					AllowDuplicate: true,
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().Create(a.ctx, gomock.Any()).Return(2, nil)
+				outbox.EXPECT().Create(a.ctx, gomock.Any()).Return(nil)
 			},
 			expectErr: nil,
 		},
+		{
+			testName: "category not found",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					CategoryId:  ptr(5),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				category.EXPECT().FindById(a.ctx, 5).Return(dbmodel.Category{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrCategoryNotFound,
+		},
+		{
+			testName: "user of another organization",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(pgerrs.ErrNotFound)
+			},
+			expectErr: ErrUserNotFound,
+		},
+		{
+			testName: "service not found",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceId: 3,
+					Price:     1000,
+					UserId:    "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindById(a.ctx, 3).Return(dbmodel.Service{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrServiceNotFound,
+		},
 		{
 			testName: "unexpected error",
 			args: args{
@@ -61,14 +335,42 @@ func TestSubscriptionService_Create(t *testing.T) {
 					EndDate:     nil,
 				},
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 3}).Return(nil, nil)
 				sub.EXPECT().Create(a.ctx, dbmodel.Subscription{
-					ServiceName: a.input.ServiceName,
-					Price:       a.input.Price,
-					UserId:      a.input.UserId,
-					StartDate:   a.input.StartDate,
-					EndDate:     a.input.EndDate,
-				}).Return(errors.New("some error"))
+					ServiceId:     3,
+					ServiceName:   "Yandex",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					EndDate:       a.input.EndDate,
+					BillingPeriod: dbmodel.BillingMonthly,
+				}).Return(0, errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+		{
+			testName: "outbox error",
+			args: args{
+				ctx: context.Background(),
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:     nil,
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, category *repomocks.MockCategory, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: a.input.UserId, ServiceId: 3}).Return(nil, nil)
+				sub.EXPECT().Create(a.ctx, gomock.Any()).Return(1, nil)
+				outbox.EXPECT().Create(a.ctx, gomock.Any()).Return(errors.New("some error"))
 			},
 			expectErr: errors.New("some error"),
 		},
@@ -78,10 +380,15 @@ func TestSubscriptionService_Create(t *testing.T) {
 		t.Run(tc.testName, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
+			tx := repomocks.NewMockTransactor(ctrl)
+			user := repomocks.NewMockUser(ctrl)
 			sub := repomocks.NewMockSubscription(ctrl)
-			tc.mockBehaviour(sub, tc.args)
+			service := repomocks.NewMockService(ctrl)
+			category := repomocks.NewMockCategory(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, user, sub, service, category, outbox, tc.args)
 
-			s := newSubscriptionService(sub)
+			s := newSubscriptionService(tx, user, sub, service, category, outbox, nil, nil)
 
 			err := s.Create(tc.args.ctx, tc.args.input)
 
@@ -121,12 +428,49 @@ func TestSubscriptionService_FindById(t *testing.T) {
 				}, nil)
 			},
 			expectOutput: SubscriptionOutput{
-				Id:          1,
-				ServiceName: "Yandex",
-				Price:       1000,
-				UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
-				StartDate:   "01-2025",
-				EndDate:     nil,
+				Id:            1,
+				ServiceName:   "Yandex",
+				Price:         1000,
+				UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				StartDate:     "2025-01-01",
+				EndDate:       nil,
+				BillingPeriod: dbmodel.BillingMonthly,
+				Tags:          []string{},
+				Status:        "active",
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "paused",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{
+					ServiceName: "Yandex",
+					Price:       1000,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Pauses: []dbmodel.Pause{
+						{Id: 1, SubscriptionId: 1, StartDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC))},
+						{Id: 2, SubscriptionId: 1, StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+					},
+				}, nil)
+			},
+			expectOutput: SubscriptionOutput{
+				Id:            1,
+				ServiceName:   "Yandex",
+				Price:         1000,
+				UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				StartDate:     "2025-01-01",
+				BillingPeriod: dbmodel.BillingMonthly,
+				Tags:          []string{},
+				Status:        "paused",
+				Pauses: []PauseOutput{
+					{StartDate: "2025-03-01", EndDate: ptr("2025-04-30")},
+					{StartDate: "2025-07-01"},
+				},
 			},
 			expectErr: nil,
 		},
@@ -142,16 +486,19 @@ func TestSubscriptionService_FindById(t *testing.T) {
 					Price:       1000,
 					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
 					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-					EndDate:     ptr(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)),
+					EndDate:     ptr(time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)),
 				}, nil)
 			},
 			expectOutput: SubscriptionOutput{
-				Id:          1,
-				ServiceName: "Yandex",
-				Price:       1000,
-				UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
-				StartDate:   "01-2025",
-				EndDate:     ptr("05-2025"),
+				Id:            1,
+				ServiceName:   "Yandex",
+				Price:         1000,
+				UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				StartDate:     "2025-01-01",
+				EndDate:       ptr("2025-05-31"),
+				BillingPeriod: dbmodel.BillingMonthly,
+				Tags:          []string{},
+				Status:        "cancelled",
 			},
 			expectErr: nil,
 		},
@@ -186,7 +533,7 @@ func TestSubscriptionService_FindById(t *testing.T) {
 			sub := repomocks.NewMockSubscription(ctrl)
 			tc.mockBehaviour(sub, tc.args)
 
-			s := newSubscriptionService(sub)
+			s := newSubscriptionService(nil, nil, sub, nil, nil, nil, nil, nil)
 
 			actual, err := s.FindById(tc.args.ctx, tc.args.id)
 
@@ -203,7 +550,17 @@ func TestSubscriptionService_Update(t *testing.T) {
 		input SubscriptionInput
 	}
 
-	type mockBehaviour func(sub *repomocks.MockSubscription, a args)
+	type mockBehaviour func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, outbox *repomocks.MockOutbox, a args)
+
+	existing := dbmodel.Subscription{
+		ServiceId:     3,
+		ServiceName:   "Yandex",
+		Price:         800,
+		UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       nil,
+		BillingPeriod: dbmodel.BillingMonthly,
+	}
 
 	testCases := []struct {
 		testName      string
@@ -224,15 +581,58 @@ func TestSubscriptionService_Update(t *testing.T) {
 					EndDate:     nil,
 				},
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
-				sub.EXPECT().Update(a.ctx, dbmodel.Subscription{
-					Id:          a.id,
-					ServiceName: a.input.ServiceName,
-					Price:       a.input.Price,
-					UserId:      a.input.UserId,
-					StartDate:   a.input.StartDate,
-					EndDate:     a.input.EndDate,
-				}).Return(nil)
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, outbox *repomocks.MockOutbox, a args) {
+				s := dbmodel.Subscription{
+					Id:            a.id,
+					ServiceId:     3,
+					ServiceName:   "Yandex",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					EndDate:       a.input.EndDate,
+					BillingPeriod: dbmodel.BillingMonthly,
+				}
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().Update(a.ctx, s).Return(nil)
+				sub.EXPECT().SetTags(a.ctx, a.id, nil).Return(nil)
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionUpdated, s)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "cancel subscription",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+				input: SubscriptionInput{
+					ServiceName: "Yandex",
+					Price:       800,
+					UserId:      "6114696a-d069-4fad-a3ed-f27c13651c3a",
+					StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:     ptr(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, outbox *repomocks.MockOutbox, a args) {
+				s := dbmodel.Subscription{
+					Id:            a.id,
+					ServiceId:     3,
+					ServiceName:   "Yandex",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					EndDate:       a.input.EndDate,
+					BillingPeriod: dbmodel.BillingMonthly,
+				}
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
+				sub.EXPECT().Update(a.ctx, s).Return(nil)
+				sub.EXPECT().SetTags(a.ctx, a.id, nil).Return(nil)
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionCancelled, s)).Return(nil)
 			},
 			expectErr: nil,
 		},
@@ -249,15 +649,9 @@ func TestSubscriptionService_Update(t *testing.T) {
 					EndDate:     nil,
 				},
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
-				sub.EXPECT().Update(a.ctx, dbmodel.Subscription{
-					Id:          a.id,
-					ServiceName: a.input.ServiceName,
-					Price:       a.input.Price,
-					UserId:      a.input.UserId,
-					StartDate:   a.input.StartDate,
-					EndDate:     a.input.EndDate,
-				}).Return(pgerrs.ErrNotFound)
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
 			},
 			expectErr: ErrSubscriptionNotFound,
 		},
@@ -274,14 +668,20 @@ func TestSubscriptionService_Update(t *testing.T) {
 					EndDate:     nil,
 				},
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, service *repomocks.MockService, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+				service.EXPECT().FindByName(a.ctx, a.input.ServiceName).Return(dbmodel.Service{Id: 3, Name: "Yandex"}, nil)
+				user.EXPECT().Ensure(a.ctx, a.input.UserId).Return(nil)
 				sub.EXPECT().Update(a.ctx, dbmodel.Subscription{
-					Id:          a.id,
-					ServiceName: a.input.ServiceName,
-					Price:       a.input.Price,
-					UserId:      a.input.UserId,
-					StartDate:   a.input.StartDate,
-					EndDate:     a.input.EndDate,
+					Id:            a.id,
+					ServiceId:     3,
+					ServiceName:   "Yandex",
+					Price:         a.input.Price,
+					UserId:        a.input.UserId,
+					StartDate:     a.input.StartDate,
+					EndDate:       a.input.EndDate,
+					BillingPeriod: dbmodel.BillingMonthly,
 				}).Return(errors.New("some error"))
 			},
 			expectErr: errors.New("some error"),
@@ -292,10 +692,14 @@ func TestSubscriptionService_Update(t *testing.T) {
 		t.Run(tc.testName, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
+			tx := repomocks.NewMockTransactor(ctrl)
+			user := repomocks.NewMockUser(ctrl)
 			sub := repomocks.NewMockSubscription(ctrl)
-			tc.mockBehaviour(sub, tc.args)
+			service := repomocks.NewMockService(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, user, sub, service, outbox, tc.args)
 
-			s := newSubscriptionService(sub)
+			s := newSubscriptionService(tx, user, sub, service, nil, outbox, nil, nil)
 
 			err := s.Update(tc.args.ctx, tc.args.id, tc.args.input)
 
@@ -310,7 +714,16 @@ func TestSubscriptionService_Delete(t *testing.T) {
 		id  int
 	}
 
-	type mockBehaviour func(sub *repomocks.MockSubscription, a args)
+	type mockBehaviour func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args)
+
+	existing := dbmodel.Subscription{
+		ServiceName:   "Yandex",
+		Price:         800,
+		UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       nil,
+		BillingPeriod: dbmodel.BillingMonthly,
+	}
 
 	testCases := []struct {
 		testName      string
@@ -324,8 +737,14 @@ func TestSubscriptionService_Delete(t *testing.T) {
 				ctx: context.Background(),
 				id:  1,
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
 				sub.EXPECT().Delete(a.ctx, a.id).Return(nil)
+
+				deleted := existing
+				deleted.Id = a.id
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionDeleted, deleted)).Return(nil)
 			},
 			expectErr: nil,
 		},
@@ -335,8 +754,9 @@ func TestSubscriptionService_Delete(t *testing.T) {
 				ctx: context.Background(),
 				id:  2,
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
-				sub.EXPECT().Delete(a.ctx, a.id).Return(pgerrs.ErrNotFound)
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
 			},
 			expectErr: ErrSubscriptionNotFound,
 		},
@@ -346,7 +766,9 @@ func TestSubscriptionService_Delete(t *testing.T) {
 				ctx: context.Background(),
 				id:  1,
 			},
-			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+			mockBehaviour: func(tx *repomocks.MockTransactor, user *repomocks.MockUser, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
 				sub.EXPECT().Delete(a.ctx, a.id).Return(errors.New("some error"))
 			},
 			expectErr: errors.New("some error"),
@@ -357,10 +779,13 @@ func TestSubscriptionService_Delete(t *testing.T) {
 		t.Run(tc.testName, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
+			tx := repomocks.NewMockTransactor(ctrl)
+			user := repomocks.NewMockUser(ctrl)
 			sub := repomocks.NewMockSubscription(ctrl)
-			tc.mockBehaviour(sub, tc.args)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, user, sub, outbox, tc.args)
 
-			s := newSubscriptionService(sub)
+			s := newSubscriptionService(tx, user, sub, nil, nil, outbox, nil, nil)
 
 			err := s.Delete(tc.args.ctx, tc.args.id)
 
@@ -368,3 +793,722 @@ func TestSubscriptionService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriptionService_Pause(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input PauseInput
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args)
+
+	existing := dbmodel.Subscription{
+		Id:            1,
+		ServiceName:   "Yandex",
+		Price:         1000,
+		UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriod: dbmodel.BillingMonthly,
+		Pauses: []dbmodel.Pause{
+			{Id: 1, SubscriptionId: 1, StartDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), EndDate: ptr(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+				input: PauseInput{
+					StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   ptr(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				p := dbmodel.Pause{SubscriptionId: 1, StartDate: a.input.StartDate, EndDate: a.input.EndDate}
+
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+				pause.EXPECT().Create(a.ctx, p).Return(2, nil)
+
+				paused := existing
+				p.Id = 2
+				paused.Pauses = []dbmodel.Pause{existing.Pauses[0], p}
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionPaused, paused)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "overlapping pause",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: PauseInput{StartDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+			},
+			expectErr: ErrSubscriptionPaused,
+		},
+		{
+			testName: "pause before subscription start",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: PauseInput{StartDate: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+			},
+			expectErr: ErrInvalidPause,
+		},
+		{
+			testName: "end before start",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+				input: PauseInput{
+					StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+			},
+			expectErr: ErrInvalidPause,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx:   context.Background(),
+				id:    2,
+				input: PauseInput{StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrSubscriptionNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: PauseInput{StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+				pause.EXPECT().Create(a.ctx, gomock.Any()).Return(0, errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			pause := repomocks.NewMockPause(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, sub, pause, outbox, tc.args)
+
+			s := newSubscriptionService(tx, nil, sub, nil, nil, outbox, pause, nil)
+
+			err := s.Pause(tc.args.ctx, tc.args.id, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestSubscriptionService_Resume(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input ResumeInput
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args)
+
+	// pauses are changed in place, so every call returns new ones
+	existing := func() dbmodel.Subscription {
+		return dbmodel.Subscription{
+			Id:            1,
+			ServiceName:   "Yandex",
+			Price:         1000,
+			UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			BillingPeriod: dbmodel.BillingMonthly,
+			Pauses: []dbmodel.Pause{
+				{Id: 1, SubscriptionId: 1, StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: ResumeInput{Date: ptr(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				p := existing().Pauses[0]
+				p.EndDate = ptr(time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC))
+
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing(), nil)
+				pause.EXPECT().Update(a.ctx, p).Return(nil)
+
+				resumed := existing()
+				resumed.Pauses = []dbmodel.Pause{p}
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionResumed, resumed)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "resume in the first paused month",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: ResumeInput{Date: ptr(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing(), nil)
+				pause.EXPECT().Delete(a.ctx, a.id, 1).Return(nil)
+
+				resumed := existing()
+				resumed.Pauses = []dbmodel.Pause{}
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionResumed, resumed)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "not paused",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: ResumeInput{Date: ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing(), nil)
+			},
+			expectErr: ErrSubscriptionNotPaused,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, pause *repomocks.MockPause, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrSubscriptionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			pause := repomocks.NewMockPause(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, sub, pause, outbox, tc.args)
+
+			s := newSubscriptionService(tx, nil, sub, nil, nil, outbox, pause, nil)
+
+			err := s.Resume(tc.args.ctx, tc.args.id, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestSubscriptionService_Cancel(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    int
+		input CancelInput
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args)
+
+	day := truncateToDay(time.Now().UTC())
+	monthly := dbmodel.Subscription{
+		Id:            1,
+		ServiceName:   "Yandex",
+		Price:         1000,
+		UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+		StartDate:     day.AddDate(-1, 0, -10),
+		BillingPeriod: dbmodel.BillingMonthly,
+	}
+	// yearly subscription started 3 months ago is charged again in 9 months, its period ends the day before
+	yearly := monthly
+	yearly.StartDate, yearly.BillingPeriod = addMonths(day, -3), dbmodel.BillingYearly
+	// user far east of UTC may already live in the next day
+	kiritimati := monthly
+	kiritimati.Timezone = "Pacific/Kiritimati"
+
+	// cancelled expects cancellation of subscription with end date and reason
+	cancelled := func(sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, ctx context.Context, end time.Time, reason *string) {
+		sub.EXPECT().Cancel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s dbmodel.Subscription) error {
+			assert.Equal(t, end, *s.EndDate)
+			assert.Equal(t, reason, s.CancelReason)
+			assert.NotNil(t, s.CancelledAt)
+			return nil
+		})
+		outbox.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e dbmodel.OutboxEvent) error {
+			assert.Equal(t, dbmodel.EventSubscriptionCancelled, e.EventType)
+			return nil
+		})
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "immediately",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CancelInput{When: CancelImmediately, Reason: ptr("too expensive")},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(monthly, nil)
+				cancelled(sub, outbox, a.ctx, day, ptr("too expensive"))
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "end of yearly period",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CancelInput{When: CancelEndOfPeriod},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(yearly, nil)
+				cancelled(sub, outbox, a.ctx, addMonths(yearly.StartDate, 12).AddDate(0, 0, -1), nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "on date",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CancelInput{When: CancelAtDate, Date: ptr(day.AddDate(0, 2, 0))},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(monthly, nil)
+				cancelled(sub, outbox, a.ctx, day.AddDate(0, 2, 0), nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "date in the past",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CancelInput{When: CancelAtDate, Date: ptr(day.AddDate(0, 0, -1))},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(monthly, nil)
+			},
+			expectErr: ErrInvalidCancellation,
+		},
+		{
+			testName: "already ended",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CancelInput{When: CancelImmediately},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				ended := monthly
+				ended.EndDate = ptr(day.AddDate(0, 0, -1))
+
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(ended, nil)
+			},
+			expectErr: ErrSubscriptionEnded,
+		},
+		{
+			testName: "today of user",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CancelInput{When: CancelImmediately},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(kiritimati, nil)
+				cancelled(sub, outbox, a.ctx, today(kiritimati), nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx:   context.Background(),
+				id:    2,
+				input: CancelInput{When: CancelImmediately},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrSubscriptionNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:   context.Background(),
+				id:    1,
+				input: CancelInput{When: CancelImmediately},
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(monthly, nil)
+				sub.EXPECT().Cancel(a.ctx, gomock.Any()).Return(errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, sub, outbox, tc.args)
+
+			s := newSubscriptionService(tx, nil, sub, nil, nil, outbox, nil, nil)
+
+			err := s.Cancel(tc.args.ctx, tc.args.id, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestSubscriptionService_Uncancel(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  int
+	}
+
+	type mockBehaviour func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args)
+
+	day := truncateToDay(time.Now().UTC())
+	existing := dbmodel.Subscription{
+		Id:            1,
+		ServiceName:   "Yandex",
+		Price:         1000,
+		UserId:        "6114696a-d069-4fad-a3ed-f27c13651c3a",
+		StartDate:     day.AddDate(-1, 0, 0),
+		EndDate:       ptr(day.AddDate(0, 0, 1)),
+		BillingPeriod: dbmodel.BillingMonthly,
+		CancelReason:  ptr("too expensive"),
+		CancelledAt:   ptr(day),
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(existing, nil)
+				sub.EXPECT().Uncancel(a.ctx, a.id).Return(nil)
+
+				uncancelled := existing
+				uncancelled.EndDate, uncancelled.CancelReason, uncancelled.CancelledAt = nil, nil, nil
+				outbox.EXPECT().Create(a.ctx, newSubscriptionEvent(dbmodel.EventSubscriptionUncancelled, uncancelled)).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "not cancelled",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				open := existing
+				open.EndDate = nil
+
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(open, nil)
+			},
+			expectErr: ErrSubscriptionNotCancelled,
+		},
+		{
+			testName: "end date without cancellation",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				fixed := existing
+				fixed.CancelReason, fixed.CancelledAt = nil, nil
+
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(fixed, nil)
+			},
+			expectErr: ErrSubscriptionNotCancelled,
+		},
+		{
+			testName: "ends today",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				ending := existing
+				ending.EndDate = ptr(day)
+
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(ending, nil)
+			},
+			expectErr: ErrSubscriptionEnded,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+			},
+			mockBehaviour: func(tx *repomocks.MockTransactor, sub *repomocks.MockSubscription, outbox *repomocks.MockOutbox, a args) {
+				runInTransaction(tx)
+				sub.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.Subscription{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrSubscriptionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			tx := repomocks.NewMockTransactor(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			outbox := repomocks.NewMockOutbox(ctrl)
+			tc.mockBehaviour(tx, sub, outbox, tc.args)
+
+			s := newSubscriptionService(tx, nil, sub, nil, nil, outbox, nil, nil)
+
+			err := s.Uncancel(tc.args.ctx, tc.args.id)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+// runInTransaction makes transactor mock call function with the same context as real one does
+func runInTransaction(tx *repomocks.MockTransactor) {
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+}
+
+func TestSubscriptionService_FindDuplicates(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId string
+	}
+
+	type mockBehaviour func(sub *repomocks.MockSubscription, a args)
+
+	alice := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+	bob := "2344696a-d069-4fad-a3ed-f27c13651c3a"
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  []DuplicateOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{}).Return([]dbmodel.Subscription{
+					{Id: 1, ServiceId: 3, ServiceName: "Yandex Plus", UserId: alice, StartDate: date(2025, 1, 1), EndDate: ptr(date(2025, 3, 31))},
+					// chained by the first one, though it does not overlap with the third one
+					{Id: 2, ServiceId: 3, ServiceName: "Yandex Plus", UserId: alice, StartDate: date(2025, 3, 1), EndDate: ptr(date(2025, 5, 31))},
+					{Id: 3, ServiceId: 3, ServiceName: "Yandex Plus", UserId: alice, StartDate: date(2025, 2, 1), EndDate: ptr(date(2025, 2, 28))},
+					{Id: 4, ServiceId: 3, ServiceName: "Yandex Plus", UserId: alice, StartDate: date(2025, 6, 1)},
+					{Id: 5, ServiceId: 5, ServiceName: "Netflix", UserId: alice, StartDate: date(2025, 6, 1)},
+					{Id: 6, ServiceId: 3, ServiceName: "Yandex Plus", UserId: bob, StartDate: date(2025, 1, 1)},
+					{Id: 7, ServiceId: 3, ServiceName: "Yandex Plus", UserId: bob, StartDate: date(2026, 1, 1)},
+				}, nil)
+			},
+			expectOutput: []DuplicateOutput{
+				{UserId: bob, ServiceId: 3, ServiceName: "Yandex Plus", SubscriptionIds: []int{6, 7}},
+				{UserId: alice, ServiceId: 3, ServiceName: "Yandex Plus", SubscriptionIds: []int{1, 3, 2}},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "no duplicates",
+			args: args{
+				ctx:    context.Background(),
+				userId: alice,
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{UserId: alice}).Return([]dbmodel.Subscription{
+					{Id: 1, ServiceId: 3, UserId: alice, StartDate: date(2025, 1, 1), EndDate: ptr(date(2025, 3, 31))},
+					{Id: 2, ServiceId: 3, UserId: alice, StartDate: date(2025, 4, 1)},
+				}, nil)
+			},
+			expectOutput: []DuplicateOutput{},
+			expectErr:    nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: context.Background(),
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindAll(a.ctx, dbmodel.SubscriptionFilter{}).Return(nil, errors.New("some error"))
+			},
+			expectOutput: nil,
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := repomocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			s := newSubscriptionService(nil, nil, sub, nil, nil, nil, nil, nil)
+
+			output, err := s.FindDuplicates(tc.args.ctx, tc.args.userId)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+func TestSubscriptionService_FindUserPrices(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		userIds []string
+	}
+
+	type mockBehaviour func(sub *repomocks.MockSubscription, a args)
+
+	alice := "6114696a-d069-4fad-a3ed-f27c13651c3a"
+	bob := "2344696a-d069-4fad-a3ed-f27c13651c3a"
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  map[string]PriceOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:     context.Background(),
+				userIds: []string{alice, bob},
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindUserPrices(a.ctx, a.userIds, start, end).Return(map[string]dbmodel.Price{
+					alice: {Gross: 1200, Net: 1000, Tax: 200, Discount: 100},
+				}, nil)
+			},
+			// bob has no subscriptions in interval
+			expectOutput: map[string]PriceOutput{
+				alice: {Gross: 1200, Net: 1000, Tax: 200, Discount: 100},
+				bob:   {},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				userIds: []string{alice},
+			},
+			mockBehaviour: func(sub *repomocks.MockSubscription, a args) {
+				sub.EXPECT().FindUserPrices(a.ctx, a.userIds, start, end).Return(nil, errors.New("some error"))
+			},
+			expectOutput: nil,
+			expectErr:    errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sub := repomocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(sub, tc.args)
+
+			s := newSubscriptionService(nil, nil, sub, nil, nil, nil, nil, nil)
+
+			output, err := s.FindUserPrices(tc.args.ctx, tc.args.userIds, start, end)
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"time"
+)
+
+type userService struct {
+	user repo.User
+	sub  repo.Subscription
+}
+
+func newUserService(user repo.User, subscription repo.Subscription) *userService {
+	return &userService{
+		user: user,
+		sub:  subscription,
+	}
+}
+
+func (s *userService) Create(ctx context.Context, input UserInput) (string, error) {
+	u, err := newUserModel(input)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := s.user.Create(ctx, u)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrAlreadyExists) {
+			return "", ErrUserAlreadyExists
+		}
+		log.Err(err).Interface("input", input).Msg("user/Create error create user in database")
+		return "", err
+	}
+	log.Info().Str("id", id).Interface("input", input).Msg("user/Create create new user in database")
+	return id, nil
+}
+
+func (s *userService) FindById(ctx context.Context, id string) (UserOutput, error) {
+	u, err := s.findById(ctx, id)
+	if err != nil {
+		return UserOutput{}, err
+	}
+	return newUserOutput(u), nil
+}
+
+func (s *userService) FindAll(ctx context.Context) ([]UserOutput, error) {
+	users, err := s.user.FindAll(ctx)
+	if err != nil {
+		log.Err(err).Msg("user/FindAll error find all users in database")
+		return nil, err
+	}
+	result := make([]UserOutput, 0, len(users))
+	for _, u := range users {
+		result = append(result, newUserOutput(u))
+	}
+	return result, nil
+}
+
+func (s *userService) Update(ctx context.Context, id string, input UserInput) error {
+	u, err := newUserModel(input)
+	if err != nil {
+		return err
+	}
+	u.Id = id
+
+	if err = s.user.Update(ctx, u); err != nil {
+		switch {
+		case errors.Is(err, pgerrs.ErrNotFound):
+			return ErrUserNotFound
+		case errors.Is(err, pgerrs.ErrAlreadyExists):
+			return ErrUserAlreadyExists
+		}
+		log.Err(err).Str("id", id).Msg("user/Update error update user in database")
+		return err
+	}
+	log.Info().Str("id", id).Interface("input", input).Msg("user/Update update user in database")
+	return nil
+}
+
+func (s *userService) Delete(ctx context.Context, id string) error {
+	if err := s.user.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, pgerrs.ErrNotFound):
+			return ErrUserNotFound
+		case errors.Is(err, pgerrs.ErrReferenced):
+			return ErrUserInUse
+		}
+		log.Err(err).Str("id", id).Msg("user/Delete error delete user in database")
+		return err
+	}
+	log.Info().Str("id", id).Msg("user/Delete delete user in database")
+	return nil
+}
+
+func (s *userService) Subscriptions(ctx context.Context, id string) ([]SubscriptionOutput, error) {
+	if _, err := s.findById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.sub.FindAll(ctx, dbmodel.SubscriptionFilter{UserId: id})
+	if err != nil {
+		log.Err(err).Str("id", id).Msg("user/Subscriptions error find subscriptions in database")
+		return nil, err
+	}
+	result := make([]SubscriptionOutput, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		result = append(result, newSubscriptionOutput(sub))
+	}
+	return result, nil
+}
+
+// Summary returns subscriptions summary of user on date. Without date it is today in users time zone,
+// so month and next charge are the ones user sees in the calendar
+func (s *userService) Summary(ctx context.Context, id string, date *time.Time) (UserSummaryOutput, error) {
+	u, err := s.findById(ctx, id)
+	if err != nil {
+		return UserSummaryOutput{}, err
+	}
+	day := truncateToDay(time.Now().In(userLocation(u)))
+	if date != nil {
+		day = truncateToDay(*date)
+	}
+	month := monthStart(day)
+
+	subscriptions, err := s.sub.FindActive(ctx, id, month)
+	if err != nil {
+		log.Err(err).Str("id", id).Msg("user/Summary error find active subscriptions in database")
+		return UserSummaryOutput{}, err
+	}
+
+	output := UserSummaryOutput{
+		UserId:   u.Id,
+		Currency: u.Currency,
+	}
+	var nextDate time.Time
+
+	for _, sub := range subscriptions {
+		if !sub.StartDate.After(day) && !paused(sub, day) {
+			output.ActiveSubscriptions++
+			output.MonthlySpend += chargeOn(sub, day).Gross / periodMonths(sub.BillingPeriod)
+		}
+		if charge, ok := monthCharge(sub, month); ok {
+			output.MonthCharges += charge
+		}
+		next, ok := nextBillingDate(sub, day)
+		if !ok || output.NextCharge != nil && !next.Before(nextDate) {
+			continue
+		}
+		nextDate = next
+		output.NextCharge = &UserChargeOutput{
+			SubscriptionId: sub.Id,
+			ServiceName:    sub.ServiceName,
+			Price:          chargeOn(sub, next).Gross,
+			Date:           next.Format(time.DateOnly),
+		}
+	}
+	return output, nil
+}
+
+func (s *userService) findById(ctx context.Context, id string) (dbmodel.User, error) {
+	u, err := s.user.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return dbmodel.User{}, ErrUserNotFound
+		}
+		log.Err(err).Str("id", id).Msg("user/findById error find user in database")
+		return dbmodel.User{}, err
+	}
+	return u, nil
+}
+
+// ensureUser creates unknown user with default settings. User of another organization is reported as not found
+func ensureUser(ctx context.Context, user repo.User, id string) error {
+	if err := user.Ensure(ctx, id); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// userLocation returns time zone of user, UTC if it is unknown
+func userLocation(u dbmodel.User) *time.Location {
+	return location(u.Timezone)
+}
+
+// location loads time zone by name, UTC if it is unknown
+func location(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// newUserModel applies default settings and checks time zone
+func newUserModel(input UserInput) (dbmodel.User, error) {
+	u := dbmodel.User{
+		Email:       input.Email,
+		DisplayName: input.DisplayName,
+		Timezone:    input.Timezone,
+		Currency:    input.Currency,
+	}
+	if u.Timezone == "" {
+		u.Timezone = dbmodel.DefaultTimezone
+	}
+	if u.Currency == "" {
+		u.Currency = dbmodel.DefaultCurrency
+	}
+	if _, err := time.LoadLocation(u.Timezone); err != nil || u.Timezone == "Local" {
+		return dbmodel.User{}, ErrInvalidTimezone
+	}
+	return u, nil
+}
+
+func newUserOutput(u dbmodel.User) UserOutput {
+	return UserOutput{
+		Id:          u.Id,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		Timezone:    u.Timezone,
+		Currency:    u.Currency,
+		CreatedAt:   u.CreatedAt,
+	}
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"testing"
+	"time"
+)
+
+func TestUserService_Create(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input UserInput
+	}
+
+	type mockBehaviour func(user *repomocks.MockUser, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectId      string
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				input: UserInput{
+					Email:       ptr("user@example.com"),
+					DisplayName: ptr("User"),
+					Timezone:    "Europe/Moscow",
+					Currency:    "USD",
+				},
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().Create(a.ctx, dbmodel.User{
+					Email:       a.input.Email,
+					DisplayName: a.input.DisplayName,
+					Timezone:    "Europe/Moscow",
+					Currency:    "USD",
+				}).Return("6114696a-d069-4fad-a3ed-f27c13651c3a", nil)
+			},
+			expectId:  "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			expectErr: nil,
+		},
+		{
+			testName: "default settings",
+			args: args{
+				ctx:   context.Background(),
+				input: UserInput{},
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().Create(a.ctx, dbmodel.User{
+					Timezone: dbmodel.DefaultTimezone,
+					Currency: dbmodel.DefaultCurrency,
+				}).Return("6114696a-d069-4fad-a3ed-f27c13651c3a", nil)
+			},
+			expectId:  "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			expectErr: nil,
+		},
+		{
+			testName: "invalid timezone",
+			args: args{
+				ctx: context.Background(),
+				input: UserInput{
+					Timezone: "Mars/Olympus",
+				},
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {},
+			expectErr:     ErrInvalidTimezone,
+		},
+		{
+			testName: "email is taken",
+			args: args{
+				ctx: context.Background(),
+				input: UserInput{
+					Email: ptr("user@example.com"),
+				},
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().Create(a.ctx, gomock.Any()).Return("", pgerrs.ErrAlreadyExists)
+			},
+			expectErr: ErrUserAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			user := repomocks.NewMockUser(ctrl)
+			tc.mockBehaviour(user, tc.args)
+
+			s := newUserService(user, nil)
+
+			id, err := s.Create(tc.args.ctx, tc.args.input)
+
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectId, id)
+		})
+	}
+}
+
+func TestUserService_Delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+
+	type mockBehaviour func(user *repomocks.MockUser, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				id:  "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().Delete(a.ctx, a.id).Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "user has subscriptions",
+			args: args{
+				ctx: context.Background(),
+				id:  "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().Delete(a.ctx, a.id).Return(pgerrs.ErrReferenced)
+			},
+			expectErr: ErrUserInUse,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx: context.Background(),
+				id:  "6114696a-d069-4fad-a3ed-f27c13651c3a",
+			},
+			mockBehaviour: func(user *repomocks.MockUser, a args) {
+				user.EXPECT().Delete(a.ctx, a.id).Return(pgerrs.ErrNotFound)
+			},
+			expectErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			user := repomocks.NewMockUser(ctrl)
+			tc.mockBehaviour(user, tc.args)
+
+			s := newUserService(user, nil)
+
+			err := s.Delete(tc.args.ctx, tc.args.id)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestUserService_Summary(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		id   string
+		date *time.Time
+	}
+
+	type mockBehaviour func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args)
+
+	subscriptions := []dbmodel.Subscription{
+		{
+			Id:            1,
+			ServiceName:   "Yandex Plus",
+			Price:         1000,
+			StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			BillingPeriod: dbmodel.BillingMonthly,
+		},
+		{
+			Id:            2,
+			ServiceName:   "Kinopoisk",
+			Price:         1200,
+			StartDate:     time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			BillingPeriod: dbmodel.BillingYearly,
+		},
+		{
+			Id:            3,
+			ServiceName:   "Okko",
+			Price:         500,
+			StartDate:     time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+			BillingPeriod: dbmodel.BillingMonthly,
+		},
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectOutput  UserSummaryOutput
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:  context.Background(),
+				id:   "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				date: ptr(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args) {
+				user.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.User{
+					Id:       a.id,
+					Timezone: "Europe/Moscow",
+					Currency: "RUB",
+				}, nil)
+				sub.EXPECT().FindActive(a.ctx, a.id, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)).Return(subscriptions, nil)
+			},
+			expectOutput: UserSummaryOutput{
+				UserId:              "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				Currency:            "RUB",
+				ActiveSubscriptions: 2,
+				MonthlySpend:        1100,
+				MonthCharges:        2200,
+				NextCharge: &UserChargeOutput{
+					SubscriptionId: 1,
+					ServiceName:    "Yandex Plus",
+					Price:          1000,
+					Date:           "2025-04-01",
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "user not found",
+			args: args{
+				ctx:  context.Background(),
+				id:   "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				date: ptr(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args) {
+				user.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.User{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrUserNotFound,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx:  context.Background(),
+				id:   "6114696a-d069-4fad-a3ed-f27c13651c3a",
+				date: ptr(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			mockBehaviour: func(user *repomocks.MockUser, sub *repomocks.MockSubscription, a args) {
+				user.EXPECT().FindById(a.ctx, a.id).Return(dbmodel.User{Id: a.id, Timezone: "UTC"}, nil)
+				sub.EXPECT().FindActive(a.ctx, a.id, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)).Return(nil, errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			user := repomocks.NewMockUser(ctrl)
+			sub := repomocks.NewMockSubscription(ctrl)
+			tc.mockBehaviour(user, sub, tc.args)
+
+			s := newUserService(user, sub)
+
+			output, err := s.Summary(tc.args.ctx, tc.args.id, tc.args.date)
+
+			assert.Equal(t, tc.expectErr, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
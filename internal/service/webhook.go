@@ -0,0 +1,316 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"io"
+	"net/http"
+	"strconv"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/publisher"
+	"time"
+)
+
+const (
+	webhookTimeout         = 10 * time.Second
+	webhookMaxAttempts     = 10
+	webhookBaseRetryDelay  = 30 * time.Second
+	webhookMaxRetryDelay   = 6 * time.Hour
+	webhookDeliveriesLimit = 100
+	webhookMaxErrorLength  = 512
+	webhookLeaseMargin     = time.Minute
+
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookDelivery  = "X-Webhook-Delivery"
+)
+
+type webhookService struct {
+	webhook    repo.Webhook
+	delivery   repo.WebhookDelivery
+	httpClient *http.Client
+}
+
+func newWebhookService(webhook repo.Webhook, delivery repo.WebhookDelivery) *webhookService {
+	return &webhookService{
+		webhook:    webhook,
+		delivery:   delivery,
+		httpClient: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (s *webhookService) Create(ctx context.Context, input WebhookInput) (int, error) {
+	id, err := s.webhook.Create(ctx, dbmodel.Webhook{
+		Url:    input.Url,
+		Secret: input.Secret,
+		Events: input.Events,
+	})
+	if err != nil {
+		log.Err(err).Str("url", input.Url).Msg("webhook/Create error create webhook in database")
+		return 0, err
+	}
+	log.Info().Int("id", id).Str("url", input.Url).Msg("webhook/Create create new webhook in database")
+	return id, nil
+}
+
+func (s *webhookService) FindById(ctx context.Context, id int) (WebhookOutput, error) {
+	w, err := s.webhook.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return WebhookOutput{}, ErrWebhookNotFound
+		}
+		log.Err(err).Int("id", id).Msg("webhook/FindById error find webhook in database")
+		return WebhookOutput{}, err
+	}
+	return newWebhookOutput(w), nil
+}
+
+func (s *webhookService) FindAll(ctx context.Context) ([]WebhookOutput, error) {
+	webhooks, err := s.webhook.FindAll(ctx)
+	if err != nil {
+		log.Err(err).Msg("webhook/FindAll error find all webhooks in database")
+		return nil, err
+	}
+	result := make([]WebhookOutput, 0, len(webhooks))
+	for _, w := range webhooks {
+		result = append(result, newWebhookOutput(w))
+	}
+	return result, nil
+}
+
+func (s *webhookService) Update(ctx context.Context, id int, input WebhookInput) error {
+	err := s.webhook.Update(ctx, dbmodel.Webhook{
+		Id:     id,
+		Url:    input.Url,
+		Secret: input.Secret,
+		Events: input.Events,
+	})
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrWebhookNotFound
+		}
+		log.Err(err).Int("id", id).Msg("webhook/Update error update webhook in database")
+		return err
+	}
+	log.Info().Int("id", id).Msg("webhook/Update update webhook in database")
+	return nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, id int) error {
+	if err := s.webhook.Delete(ctx, id); err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrWebhookNotFound
+		}
+		log.Err(err).Int("id", id).Msg("webhook/Delete error delete webhook in database")
+		return err
+	}
+	log.Info().Int("id", id).Msg("webhook/Delete delete webhook in database")
+	return nil
+}
+
+func (s *webhookService) FindDeliveries(ctx context.Context, webhookId int) ([]WebhookDeliveryOutput, error) {
+	if _, err := s.FindById(ctx, webhookId); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.delivery.FindByWebhook(ctx, webhookId, webhookDeliveriesLimit)
+	if err != nil {
+		log.Err(err).Int("webhook_id", webhookId).Msg("webhook/FindDeliveries error find deliveries in database")
+		return nil, err
+	}
+	result := make([]WebhookDeliveryOutput, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, newWebhookDeliveryOutput(d))
+	}
+	return result, nil
+}
+
+// Redeliver schedules delivery for immediate sending with full number of attempts, dead deliveries included
+func (s *webhookService) Redeliver(ctx context.Context, webhookId int, deliveryId int64) error {
+	if _, err := s.FindById(ctx, webhookId); err != nil {
+		return err
+	}
+	d, err := s.delivery.FindById(ctx, deliveryId)
+	if err != nil {
+		if errors.Is(err, pgerrs.ErrNotFound) {
+			return ErrWebhookDeliveryNotFound
+		}
+		log.Err(err).Int64("id", deliveryId).Msg("webhook/Redeliver error find delivery in database")
+		return err
+	}
+	if d.WebhookId != webhookId {
+		return ErrWebhookDeliveryNotFound
+	}
+
+	d.Status = dbmodel.DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = time.Now()
+
+	if err = s.delivery.UpdateStatus(ctx, d); err != nil {
+		log.Err(err).Int64("id", deliveryId).Msg("webhook/Redeliver error update delivery in database")
+		return err
+	}
+	log.Info().Int64("id", deliveryId).Msg("webhook/Redeliver schedule delivery")
+	return nil
+}
+
+// Publish enqueues event to every webhook subscribed to it. Together with outbox relay it makes
+// webhooks one more consumer of subscription events
+func (s *webhookService) Publish(ctx context.Context, msg publisher.Message) error {
+	eventId, err := strconv.ParseInt(msg.Key, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid event id %q: %w", msg.Key, err)
+	}
+	webhooks, err := s.webhook.FindByEvent(ctx, msg.Subject)
+	if err != nil {
+		return err
+	}
+	for _, w := range webhooks {
+		err = s.delivery.Create(ctx, dbmodel.WebhookDelivery{
+			WebhookId: w.Id,
+			EventId:   eventId,
+			EventType: msg.Subject,
+			Payload:   msg.Data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *webhookService) Close() error {
+	return nil
+}
+
+// Dispatch sends batch of due deliveries and returns its size. Failed delivery is retried with exponential
+// backoff, after webhookMaxAttempts it is moved to dead state and can be sent again only manually.
+// Deliveries are claimed for the time of sending the whole batch, so no transaction or lock is held while
+// receivers respond, and result of every delivery is saved as soon as it is sent
+func (s *webhookService) Dispatch(ctx context.Context, limit int) (int, error) {
+	lease := time.Duration(limit)*webhookTimeout + webhookLeaseMargin
+
+	deliveries, err := s.delivery.Claim(ctx, limit, lease)
+	if err != nil {
+		log.Err(err).Msg("webhook/Dispatch error claim deliveries in database")
+		return 0, err
+	}
+	webhooks := make(map[int]dbmodel.Webhook)
+
+	for i, d := range deliveries {
+		w, ok := webhooks[d.WebhookId]
+		if !ok {
+			if w, err = s.webhook.FindById(ctx, d.WebhookId); err != nil {
+				log.Err(err).Int("webhook_id", d.WebhookId).Msg("webhook/Dispatch error find webhook in database")
+				return i, err
+			}
+			webhooks[d.WebhookId] = w
+		}
+
+		statusCode, sendErr := s.send(ctx, w, d)
+
+		d.Attempts++
+		d.LastStatusCode = statusCode
+		if sendErr == nil {
+			d.Status = dbmodel.DeliveryDelivered
+			d.LastError = nil
+			d.DeliveredAt = ptr(time.Now())
+		} else {
+			log.Warn().Err(sendErr).Int64("id", d.Id).Int("webhook_id", w.Id).Msg("webhook/Dispatch error send delivery")
+
+			d.LastError = ptr(truncate(sendErr.Error(), webhookMaxErrorLength))
+			d.NextAttemptAt = time.Now().Add(backoff(d.Attempts-1, webhookBaseRetryDelay, webhookMaxRetryDelay))
+			if d.Attempts >= webhookMaxAttempts {
+				d.Status = dbmodel.DeliveryDead
+			}
+		}
+		if err = s.delivery.UpdateStatus(ctx, d); err != nil {
+			log.Err(err).Int64("id", d.Id).Msg("webhook/Dispatch error update delivery in database")
+			return i, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+func (s *webhookService) send(ctx context.Context, w dbmodel.Webhook, d dbmodel.WebhookDelivery) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "subscription_service-webhook")
+	req.Header.Set(HeaderWebhookEvent, d.EventType)
+	req.Header.Set(HeaderWebhookDelivery, strconv.FormatInt(d.Id, 10))
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(HeaderWebhookSignature, "sha256="+SignWebhook(w.Secret, timestamp, d.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return &resp.StatusCode, nil
+}
+
+// SignWebhook returns hex encoded HMAC-SHA256 of "timestamp.payload". Receivers compute it with their copy
+// of the secret and compare with X-Webhook-Signature header
+func SignWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookOutput(w dbmodel.Webhook) WebhookOutput {
+	events := w.Events
+	if events == nil {
+		events = []string{}
+	}
+	return WebhookOutput{
+		Id:        w.Id,
+		Url:       w.Url,
+		Events:    events,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func newWebhookDeliveryOutput(d dbmodel.WebhookDelivery) WebhookDeliveryOutput {
+	output := WebhookDeliveryOutput{
+		Id:             d.Id,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.Status == dbmodel.DeliveryPending {
+		output.NextAttemptAt = &d.NextAttemptAt
+	}
+	return output
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
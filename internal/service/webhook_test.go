@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"subscription_service/internal/mocks/repomocks"
+	"subscription_service/internal/model/dbmodel"
+	"subscription_service/internal/repo/pgerrs"
+	"subscription_service/pkg/publisher"
+	"testing"
+	"time"
+)
+
+func TestWebhookService_Publish(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		msg publisher.Message
+	}
+
+	type mockBehaviour func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args)
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx: context.Background(),
+				msg: publisher.Message{Subject: dbmodel.EventSubscriptionCreated, Key: "7", Data: []byte(`{"id":7}`)},
+			},
+			mockBehaviour: func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args) {
+				webhook.EXPECT().FindByEvent(a.ctx, a.msg.Subject).Return([]dbmodel.Webhook{{Id: 1}, {Id: 2}}, nil)
+				for _, id := range []int{1, 2} {
+					delivery.EXPECT().Create(a.ctx, dbmodel.WebhookDelivery{
+						WebhookId: id,
+						EventId:   7,
+						EventType: a.msg.Subject,
+						Payload:   a.msg.Data,
+					}).Return(nil)
+				}
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "no subscribed webhooks",
+			args: args{
+				ctx: context.Background(),
+				msg: publisher.Message{Subject: dbmodel.EventSubscriptionCreated, Key: "7", Data: []byte(`{"id":7}`)},
+			},
+			mockBehaviour: func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args) {
+				webhook.EXPECT().FindByEvent(a.ctx, a.msg.Subject).Return(nil, nil)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "unexpected error",
+			args: args{
+				ctx: context.Background(),
+				msg: publisher.Message{Subject: dbmodel.EventSubscriptionCreated, Key: "7", Data: []byte(`{"id":7}`)},
+			},
+			mockBehaviour: func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args) {
+				webhook.EXPECT().FindByEvent(a.ctx, a.msg.Subject).Return(nil, errors.New("some error"))
+			},
+			expectErr: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			webhook := repomocks.NewMockWebhook(ctrl)
+			delivery := repomocks.NewMockWebhookDelivery(ctrl)
+			tc.mockBehaviour(webhook, delivery, tc.args)
+
+			s := newWebhookService(webhook, delivery)
+
+			err := s.Publish(tc.args.ctx, tc.args.msg)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestWebhookService_Dispatch(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	var (
+		statusCode = http.StatusOK
+		received   *http.Request
+		body       []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	hook := dbmodel.Webhook{Id: 1, Url: server.URL, Secret: secret}
+	due := dbmodel.WebhookDelivery{
+		Id:        5,
+		WebhookId: 1,
+		EventId:   7,
+		EventType: dbmodel.EventSubscriptionCreated,
+		Payload:   []byte(`{"id":7}`),
+		Status:    dbmodel.DeliveryPending,
+	}
+
+	testCases := []struct {
+		testName     string
+		statusCode   int
+		attempts     int
+		expectStatus string
+	}{
+		{
+			testName:     "delivered",
+			statusCode:   http.StatusNoContent,
+			expectStatus: dbmodel.DeliveryDelivered,
+		},
+		{
+			testName:     "failed delivery is retried",
+			statusCode:   http.StatusInternalServerError,
+			expectStatus: dbmodel.DeliveryPending,
+		},
+		{
+			testName:     "last attempt moves delivery to dead letter",
+			statusCode:   http.StatusBadGateway,
+			attempts:     webhookMaxAttempts - 1,
+			expectStatus: dbmodel.DeliveryDead,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			webhook := repomocks.NewMockWebhook(ctrl)
+			delivery := repomocks.NewMockWebhookDelivery(ctrl)
+
+			d := due
+			d.Attempts = tc.attempts
+
+			var updated dbmodel.WebhookDelivery
+
+			delivery.EXPECT().Claim(gomock.Any(), 10, 10*webhookTimeout+webhookLeaseMargin).Return([]dbmodel.WebhookDelivery{d}, nil)
+			webhook.EXPECT().FindById(gomock.Any(), 1).Return(hook, nil)
+			delivery.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, d dbmodel.WebhookDelivery) error {
+					updated = d
+					return nil
+				},
+			)
+
+			statusCode = tc.statusCode
+			s := newWebhookService(webhook, delivery)
+
+			n, err := s.Dispatch(context.Background(), 10)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, n)
+
+			assert.Equal(t, `{"id":7}`, string(body))
+			assert.Equal(t, dbmodel.EventSubscriptionCreated, received.Header.Get(HeaderWebhookEvent))
+			assert.Equal(t, "5", received.Header.Get(HeaderWebhookDelivery))
+			assert.Equal(t,
+				"sha256="+SignWebhook(secret, received.Header.Get(HeaderWebhookTimestamp), body),
+				received.Header.Get(HeaderWebhookSignature),
+			)
+
+			assert.Equal(t, tc.expectStatus, updated.Status)
+			assert.Equal(t, tc.attempts+1, updated.Attempts)
+			assert.Equal(t, &tc.statusCode, updated.LastStatusCode)
+			if tc.expectStatus == dbmodel.DeliveryDelivered {
+				assert.Nil(t, updated.LastError)
+				assert.NotNil(t, updated.DeliveredAt)
+			} else {
+				assert.NotNil(t, updated.LastError)
+				assert.True(t, updated.NextAttemptAt.After(time.Now()))
+			}
+		})
+	}
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		webhookId  int
+		deliveryId int64
+	}
+
+	type mockBehaviour func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args)
+
+	dead := dbmodel.WebhookDelivery{
+		Id:        5,
+		WebhookId: 1,
+		Status:    dbmodel.DeliveryDead,
+		Attempts:  webhookMaxAttempts,
+	}
+
+	testCases := []struct {
+		testName      string
+		args          args
+		mockBehaviour mockBehaviour
+		expectErr     error
+	}{
+		{
+			testName: "correct test",
+			args: args{
+				ctx:        context.Background(),
+				webhookId:  1,
+				deliveryId: 5,
+			},
+			mockBehaviour: func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args) {
+				webhook.EXPECT().FindById(a.ctx, a.webhookId).Return(dbmodel.Webhook{Id: a.webhookId}, nil)
+				delivery.EXPECT().FindById(a.ctx, a.deliveryId).Return(dead, nil)
+				delivery.EXPECT().UpdateStatus(a.ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, d dbmodel.WebhookDelivery) error {
+						assert.Equal(t, dbmodel.DeliveryPending, d.Status)
+						assert.Equal(t, 0, d.Attempts)
+						return nil
+					},
+				)
+			},
+			expectErr: nil,
+		},
+		{
+			testName: "delivery of another webhook",
+			args: args{
+				ctx:        context.Background(),
+				webhookId:  2,
+				deliveryId: 5,
+			},
+			mockBehaviour: func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args) {
+				webhook.EXPECT().FindById(a.ctx, a.webhookId).Return(dbmodel.Webhook{Id: a.webhookId}, nil)
+				delivery.EXPECT().FindById(a.ctx, a.deliveryId).Return(dead, nil)
+			},
+			expectErr: ErrWebhookDeliveryNotFound,
+		},
+		{
+			testName: "not found",
+			args: args{
+				ctx:        context.Background(),
+				webhookId:  1,
+				deliveryId: 6,
+			},
+			mockBehaviour: func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args) {
+				webhook.EXPECT().FindById(a.ctx, a.webhookId).Return(dbmodel.Webhook{Id: a.webhookId}, nil)
+				delivery.EXPECT().FindById(a.ctx, a.deliveryId).Return(dbmodel.WebhookDelivery{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrWebhookDeliveryNotFound,
+		},
+		{
+			testName: "webhook of another organization",
+			args: args{
+				ctx:        context.Background(),
+				webhookId:  1,
+				deliveryId: 5,
+			},
+			mockBehaviour: func(webhook *repomocks.MockWebhook, delivery *repomocks.MockWebhookDelivery, a args) {
+				webhook.EXPECT().FindById(a.ctx, a.webhookId).Return(dbmodel.Webhook{}, pgerrs.ErrNotFound)
+			},
+			expectErr: ErrWebhookNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			webhook := repomocks.NewMockWebhook(ctrl)
+			delivery := repomocks.NewMockWebhookDelivery(ctrl)
+			tc.mockBehaviour(webhook, delivery, tc.args)
+
+			s := newWebhookService(webhook, delivery)
+
+			err := s.Redeliver(tc.args.ctx, tc.args.webhookId, tc.args.deliveryId)
+
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
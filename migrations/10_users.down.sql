@@ -0,0 +1,4 @@
+alter table subscription
+    drop constraint if exists fk_subscription_user;
+
+drop table if exists users;
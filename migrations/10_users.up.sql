@@ -0,0 +1,23 @@
+create table if not exists users
+(
+    id           varchar primary key default gen_random_uuid()::varchar,
+    email        varchar,
+    display_name varchar,
+    timezone     varchar     not null default 'UTC',
+    currency     varchar(3)  not null default 'RUB',
+    created_at   timestamptz not null default now()
+);
+
+create unique index if not exists idx_users_email on users (lower(email));
+
+-- every user id seen so far becomes a user with default settings
+insert into users (id)
+select user_id from subscription
+union
+select user_id from budget
+union
+select user_id from reminder_settings
+on conflict do nothing;
+
+alter table subscription
+    add constraint fk_subscription_user foreign key (user_id) references users (id);
@@ -0,0 +1,5 @@
+drop view if exists subscription_cost;
+
+drop table if exists subscription_share;
+
+drop table if exists subscription_split;
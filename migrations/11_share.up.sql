@@ -0,0 +1,37 @@
+create table if not exists subscription_split
+(
+    subscription_id int primary key references subscription (id) on delete cascade,
+    rule            varchar not null
+);
+
+create table if not exists subscription_share
+(
+    subscription_id int     not null references subscription_split (subscription_id) on delete cascade,
+    user_id         varchar not null references users (id),
+    percent         int,
+    amount          int,
+    primary key (subscription_id, user_id)
+);
+
+create index if not exists idx_subscription_share_user on subscription_share (user_id);
+
+-- part of subscription price paid by every user: members of shared subscription pay their share,
+-- owner pays the rest. Not shared subscriptions are paid by owner in full
+create or replace view subscription_cost as
+with member as (select sh.subscription_id,
+                       sh.user_id,
+                       case sp.rule
+                           when 'equal' then s.price / (count(*) over (partition by sh.subscription_id) + 1)
+                           when 'percentage' then s.price * sh.percent / 100
+                           else sh.amount
+                           end as amount
+                from subscription_share sh
+                         join subscription_split sp on sp.subscription_id = sh.subscription_id
+                         join subscription s on s.id = sh.subscription_id)
+select subscription_id, user_id, amount
+from member
+union all
+select s.id,
+       s.user_id,
+       s.price - coalesce((select sum(m.amount) from member m where m.subscription_id = s.id), 0)::int
+from subscription s;
@@ -0,0 +1,25 @@
+drop index if exists idx_category_name;
+create unique index if not exists idx_category_name on category (coalesce(parent_id, 0), lower(name));
+
+drop index if exists idx_services_name;
+create unique index if not exists idx_services_name on services (lower(name));
+
+drop index if exists idx_users_email;
+create unique index if not exists idx_users_email on users (lower(email));
+
+alter table outbox
+    drop column if exists organization_id;
+alter table webhook
+    drop column if exists organization_id;
+alter table budget
+    drop column if exists organization_id;
+alter table category
+    drop column if exists organization_id;
+alter table services
+    drop column if exists organization_id;
+alter table subscription
+    drop column if exists organization_id;
+alter table users
+    drop column if exists organization_id;
+
+drop table if exists organization;
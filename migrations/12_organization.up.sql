@@ -0,0 +1,45 @@
+create table if not exists organization
+(
+    id         serial primary key,
+    name       varchar     not null,
+    created_at timestamptz not null default now()
+);
+
+create unique index if not exists idx_organization_name on organization (lower(name));
+
+-- existing data belongs to default organization
+insert into organization (id, name)
+values (1, 'default')
+on conflict do nothing;
+
+select setval('organization_id_seq', (select max(id) from organization));
+
+alter table users
+    add column if not exists organization_id int not null default 1 references organization (id);
+alter table subscription
+    add column if not exists organization_id int not null default 1 references organization (id);
+alter table services
+    add column if not exists organization_id int not null default 1 references organization (id);
+alter table category
+    add column if not exists organization_id int not null default 1 references organization (id);
+alter table budget
+    add column if not exists organization_id int not null default 1 references organization (id);
+alter table webhook
+    add column if not exists organization_id int not null default 1 references organization (id);
+alter table outbox
+    add column if not exists organization_id int not null default 1 references organization (id);
+
+create index if not exists idx_users_organization on users (organization_id);
+create index if not exists idx_subscription_organization on subscription (organization_id);
+create index if not exists idx_budget_organization on budget (organization_id);
+create index if not exists idx_webhook_organization on webhook (organization_id);
+
+-- names are unique within organization
+drop index if exists idx_users_email;
+create unique index if not exists idx_users_email on users (organization_id, lower(email));
+
+drop index if exists idx_services_name;
+create unique index if not exists idx_services_name on services (organization_id, lower(name));
+
+drop index if exists idx_category_name;
+create unique index if not exists idx_category_name on category (organization_id, coalesce(parent_id, 0), lower(name));
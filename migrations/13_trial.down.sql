@@ -0,0 +1,18 @@
+delete
+from reminder
+where kind <> 'renewal';
+
+alter table reminder
+    drop constraint if exists reminder_subscription_id_kind_billing_date_key;
+alter table reminder
+    add constraint reminder_subscription_id_billing_date_key unique (subscription_id, billing_date);
+alter table reminder
+    drop column if exists kind;
+
+drop function if exists subscription_price(subscription, date);
+drop function if exists subscription_intro_end(subscription);
+
+alter table subscription
+    drop column if exists intro_periods,
+    drop column if exists intro_price,
+    drop column if exists trial_end_date;
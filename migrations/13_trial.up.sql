@@ -0,0 +1,42 @@
+alter table subscription
+    add column if not exists trial_end_date date,
+    add column if not exists intro_price    int check (intro_price >= 0),
+    add column if not exists intro_periods  int not null default 0 check (intro_periods >= 0);
+
+-- first charge at regular price: charges on dates within trial are free, the next intro_periods charges cost intro_price
+create or replace function subscription_intro_end(s subscription) returns date
+    language sql
+    immutable
+as
+$$
+select (s.start_date + make_interval(months => step * (trial_charges + s.intro_periods)))::date
+from (select step,
+             case
+                 when s.trial_end_date is null or s.trial_end_date < s.start_date then 0
+                 else ((date_part('year', s.trial_end_date) - date_part('year', s.start_date)) * 12
+                           + date_part('month', s.trial_end_date) - date_part('month', s.start_date)
+                           - (date_part('day', s.trial_end_date) < date_part('day', s.start_date))::int)::int / step + 1
+                 end as trial_charges
+      from (select case s.billing_period when 'yearly' then 12 else 1 end as step) p) t
+$$;
+
+-- price of subscription charged for billing period containing day
+create or replace function subscription_price(s subscription, day date) returns int
+    language sql
+    immutable
+as
+$$
+select case
+           when s.trial_end_date is not null and day <= s.trial_end_date then 0
+           when s.intro_price is not null and day < subscription_intro_end(s) then s.intro_price
+           else s.price
+           end
+$$;
+
+-- trial ending and renewal reminders of the same date are sent independently
+alter table reminder
+    add column if not exists kind varchar not null default 'renewal';
+alter table reminder
+    drop constraint if exists reminder_subscription_id_billing_date_key;
+alter table reminder
+    add constraint reminder_subscription_id_kind_billing_date_key unique (subscription_id, kind, billing_date);
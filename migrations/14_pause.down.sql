@@ -0,0 +1,15 @@
+create or replace function subscription_price(s subscription, day date) returns int
+    language sql
+    immutable
+as
+$$
+select case
+           when s.trial_end_date is not null and day <= s.trial_end_date then 0
+           when s.intro_price is not null and day < subscription_intro_end(s) then s.intro_price
+           else s.price
+           end
+$$;
+
+drop function if exists subscription_paused(subscription, date);
+
+drop table if exists subscription_pause;
@@ -0,0 +1,37 @@
+-- months from start_date to end_date inclusive are not charged, end_date is null until subscription is resumed
+create table if not exists subscription_pause
+(
+    id              serial primary key,
+    subscription_id int       not null references subscription (id) on delete cascade,
+    start_date      date      not null,
+    end_date        date check (end_date >= start_date),
+    created_at      timestamp not null default now()
+);
+
+create index if not exists subscription_pause_subscription_id_idx on subscription_pause (subscription_id, start_date);
+
+create or replace function subscription_paused(s subscription, day date) returns boolean
+    language sql
+    stable
+as
+$$
+select exists(select 1
+              from subscription_pause p
+              where p.subscription_id = s.id
+                and p.start_date <= day
+                and (p.end_date is null or p.end_date >= date_trunc('month', day)::date))
+$$;
+
+-- paused months cost nothing like trial
+create or replace function subscription_price(s subscription, day date) returns int
+    language sql
+    stable
+as
+$$
+select case
+           when subscription_paused(s, day) then 0
+           when s.trial_end_date is not null and day <= s.trial_end_date then 0
+           when s.intro_price is not null and day < subscription_intro_end(s) then s.intro_price
+           else s.price
+           end
+$$;
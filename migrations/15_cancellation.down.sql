@@ -0,0 +1,3 @@
+alter table subscription
+    drop column if exists cancelled_at,
+    drop column if exists cancel_reason;
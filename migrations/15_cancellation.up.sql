@@ -0,0 +1,3 @@
+alter table subscription
+    add column if not exists cancel_reason varchar,
+    add column if not exists cancelled_at  timestamp;
@@ -0,0 +1,23 @@
+drop function if exists subscription_active(subscription, date);
+
+create or replace function subscription_paused(s subscription, day date) returns boolean
+    language sql
+    stable
+as
+$$
+select exists(select 1
+              from subscription_pause p
+              where p.subscription_id = s.id
+                and p.start_date <= day
+                and (p.end_date is null or p.end_date >= date_trunc('month', day)::date))
+$$;
+
+drop function if exists subscription_anniversary(subscription, date);
+
+update subscription_pause
+set start_date = date_trunc('month', start_date)::date,
+    end_date   = date_trunc('month', end_date)::date;
+update subscription
+set start_date     = date_trunc('month', start_date)::date,
+    end_date       = date_trunc('month', end_date)::date,
+    trial_end_date = date_trunc('month', trial_end_date)::date;
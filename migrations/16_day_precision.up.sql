@@ -0,0 +1,43 @@
+-- dates were stored as first days of months, inclusive ends now mean the last day of their month
+update subscription
+set end_date = (date_trunc('month', end_date) + interval '1 month - 1 day')::date
+where end_date is not null;
+update subscription
+set trial_end_date = (date_trunc('month', trial_end_date) + interval '1 month - 1 day')::date
+where trial_end_date is not null;
+update subscription_pause
+set end_date = (date_trunc('month', end_date) + interval '1 month - 1 day')::date
+where end_date is not null;
+
+-- billing anniversary of subscription in month of day, day of month is clamped like in start_date + interval
+create or replace function subscription_anniversary(s subscription, day date) returns date
+    language sql
+    immutable
+as
+$$
+select (s.start_date + make_interval(months => ((date_part('year', day) - date_part('year', s.start_date)) * 12
+    + date_part('month', day) - date_part('month', s.start_date))::int))::date
+$$;
+
+create or replace function subscription_paused(s subscription, day date) returns boolean
+    language sql
+    stable
+as
+$$
+select exists(select 1
+              from subscription_pause p
+              where p.subscription_id = s.id
+                and p.start_date <= day
+                and (p.end_date is null or p.end_date >= day))
+$$;
+
+-- subscription is active in month of day if its anniversary in the month is within subscription and not paused
+create or replace function subscription_active(s subscription, day date) returns boolean
+    language sql
+    stable
+as
+$$
+select date_trunc('month', s.start_date) <= date_trunc('month', day)
+           and (s.end_date is null or s.end_date >= subscription_anniversary(s, day))
+           and not subscription_paused(s, subscription_anniversary(s, day))
+$$;
@@ -0,0 +1,17 @@
+create table if not exists charge
+(
+    id              bigserial primary key,
+    subscription_id int         not null references subscription (id) on delete cascade,
+    user_id         varchar     not null,
+    billing_date    date        not null,
+    amount          int         not null,
+    status          varchar     not null default 'pending'
+        check (status in ('pending', 'paid', 'failed', 'refunded')),
+    created_at      timestamptz not null default now(),
+    updated_at      timestamptz not null default now(),
+    organization_id int         not null default 1 references organization (id),
+    unique (subscription_id, billing_date)
+);
+
+create index if not exists idx_charge_billing_date on charge (organization_id, billing_date);
+create index if not exists idx_charge_user on charge (user_id);
@@ -0,0 +1,15 @@
+alter table charge
+    drop column if exists discount,
+    drop column if exists tax;
+
+drop function if exists subscription_gross(subscription, date);
+drop function if exists subscription_tax(subscription, date);
+drop function if exists subscription_discount(subscription, date);
+
+alter table subscription
+    drop column if exists discount_months,
+    drop column if exists discount_start_date,
+    drop column if exists discount_value,
+    drop column if exists discount_type,
+    drop column if exists tax_inclusive,
+    drop column if exists tax_rate;
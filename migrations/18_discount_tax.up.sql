@@ -0,0 +1,50 @@
+-- tax is included in price or added on top of it, discount reduces charges from discount_start_date
+-- for discount_months months or forever when it is 0
+alter table subscription
+    add column if not exists tax_rate            int     not null default 0 check (tax_rate between 0 and 100),
+    add column if not exists tax_inclusive       boolean not null default false,
+    add column if not exists discount_type       varchar check (discount_type in ('percent', 'fixed')),
+    add column if not exists discount_value      int     not null default 0 check (discount_value >= 0),
+    add column if not exists discount_start_date date,
+    add column if not exists discount_months     int     not null default 0 check (discount_months >= 0);
+
+-- discount of subscription charged for billing period containing day, never more than its price
+create or replace function subscription_discount(s subscription, day date) returns int
+    language sql
+    stable
+as
+$$
+select case
+           when s.discount_type is null or day < s.discount_start_date then 0
+           when s.discount_months > 0
+               and day >= (s.discount_start_date + make_interval(months => s.discount_months))::date then 0
+           when s.discount_type = 'percent' then subscription_price(s, day) * s.discount_value / 100
+           else least(s.discount_value, subscription_price(s, day))
+           end
+$$;
+
+-- tax in discounted price of subscription charged for billing period containing day
+create or replace function subscription_tax(s subscription, day date) returns int
+    language sql
+    stable
+as
+$$
+select case
+           when s.tax_inclusive then (subscription_price(s, day) - subscription_discount(s, day)) * s.tax_rate / (100 + s.tax_rate)
+           else (subscription_price(s, day) - subscription_discount(s, day)) * s.tax_rate / 100
+           end
+$$;
+
+-- amount actually charged for billing period containing day: discounted price with tax
+create or replace function subscription_gross(s subscription, day date) returns int
+    language sql
+    stable
+as
+$$
+select subscription_price(s, day) - subscription_discount(s, day)
+           + case when s.tax_inclusive then 0 else subscription_tax(s, day) end
+$$;
+
+alter table charge
+    add column if not exists tax      int not null default 0,
+    add column if not exists discount int not null default 0;
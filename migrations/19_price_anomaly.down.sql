@@ -0,0 +1,6 @@
+drop table if exists price_anomaly;
+
+drop trigger if exists subscription_price_history on subscription;
+drop function if exists record_subscription_price();
+
+drop table if exists subscription_price_history;
@@ -0,0 +1,50 @@
+-- every list price subscription ever had, written by trigger on create and on change of price
+create table if not exists subscription_price_history
+(
+    id              serial primary key,
+    subscription_id int         not null references subscription (id) on delete cascade,
+    price           int         not null,
+    changed_at      timestamptz not null default now()
+);
+
+create index if not exists idx_subscription_price_history on subscription_price_history (subscription_id, changed_at);
+
+-- existing subscriptions start history with their current price
+insert into subscription_price_history (subscription_id, price)
+select id, price
+from subscription;
+
+create or replace function record_subscription_price() returns trigger
+    language plpgsql
+as
+$$
+begin
+    if tg_op = 'INSERT' or new.price <> old.price then
+        insert into subscription_price_history (subscription_id, price) values (new.id, new.price);
+    end if;
+    return new;
+end
+$$;
+
+drop trigger if exists subscription_price_history on subscription;
+create trigger subscription_price_history
+    after insert or update of price
+    on subscription
+    for each row
+execute function record_subscription_price();
+
+-- anomaly of price is recorded once, so event is emitted only when it is detected for the first time
+create table if not exists price_anomaly
+(
+    id              serial primary key,
+    subscription_id int         not null references subscription (id) on delete cascade,
+    kind            varchar     not null check (kind in ('increase', 'outlier')),
+    price           int         not null,
+    reference_price int         not null,
+    percent         int         not null,
+    detected_at     timestamptz not null default now(),
+    organization_id int         not null default 1 references organization (id),
+    unique (subscription_id, kind, price)
+);
+
+create index if not exists idx_price_anomaly_organization on price_anomaly (organization_id, detected_at);
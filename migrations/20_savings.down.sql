@@ -0,0 +1,7 @@
+alter table subscription
+    drop column if exists last_used_date;
+
+alter table services
+    drop column if exists family_size,
+    drop column if exists family_price,
+    drop column if exists yearly_price;
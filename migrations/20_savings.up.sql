@@ -0,0 +1,9 @@
+-- catalog prices of other plans: yearly plan and monthly family plan for up to family_size users
+alter table services
+    add column if not exists yearly_price int check (yearly_price >= 0),
+    add column if not exists family_price int check (family_price >= 0),
+    add column if not exists family_size  int check (family_size >= 2);
+
+-- the last day user reported using subscription
+alter table subscription
+    add column if not exists last_used_date date;
@@ -0,0 +1,19 @@
+-- tags of the same name are merged into the first of them
+update subscription_tag st
+set tag_id = first.id
+from tag t,
+     (select name, min(id) as id from tag group by name) first
+where t.id = st.tag_id
+  and first.name = t.name
+  and st.tag_id <> first.id;
+
+delete
+from tag t
+where t.id <> (select min(id) from tag where name = t.name);
+
+drop index if exists idx_tag_name;
+alter table tag
+    add constraint tag_name_key unique (name);
+
+alter table tag
+    drop column if exists organization_id;
@@ -0,0 +1,27 @@
+-- tags are names within organization, like categories and services
+alter table tag
+    add column if not exists organization_id int not null default 1 references organization (id);
+
+alter table tag
+    drop constraint if exists tag_name_key;
+create unique index if not exists idx_tag_name on tag (organization_id, name);
+
+-- tags shared by subscriptions of several organizations are copied to each of them
+insert into tag (organization_id, name)
+select distinct s.organization_id, t.name
+from subscription_tag st
+         join subscription s on s.id = st.subscription_id
+         join tag t on t.id = st.tag_id
+where s.organization_id <> t.organization_id
+on conflict do nothing;
+
+update subscription_tag st
+set tag_id = own.id
+from subscription s,
+     tag t,
+     tag own
+where s.id = st.subscription_id
+  and t.id = st.tag_id
+  and s.organization_id <> t.organization_id
+  and own.organization_id = s.organization_id
+  and own.name = t.name;
@@ -0,0 +1,2 @@
+alter table budget
+    drop column if exists category_id;
@@ -0,0 +1,5 @@
+-- budget for subscriptions of category and its subcategories, category can't be deleted while budget uses it
+alter table budget
+    add column if not exists category_id int references category (id);
+
+create index if not exists idx_budget_category on budget (category_id);
@@ -0,0 +1,25 @@
+-- fixed shares are checked against price only when split is set, price can be lowered later by update or by
+-- scheduled price change applied to subscription when its month starts: then shares are reduced proportionally
+-- to fit price and owner part is never negative
+create or replace view subscription_cost as
+with member as (select sh.subscription_id,
+                       sh.user_id,
+                       case sp.rule
+                           when 'equal' then s.price / (count(*) over (partition by sh.subscription_id) + 1)
+                           when 'percentage' then s.price * sh.percent / 100
+                           else case
+                                    when sum(sh.amount) over (partition by sh.subscription_id) > s.price
+                                        then sh.amount * s.price / sum(sh.amount) over (partition by sh.subscription_id)
+                                    else sh.amount
+                               end
+                           end as amount
+                from subscription_share sh
+                         join subscription_split sp on sp.subscription_id = sh.subscription_id
+                         join subscription s on s.id = sh.subscription_id)
+select subscription_id, user_id, amount
+from member
+union all
+select s.id,
+       s.user_id,
+       greatest(s.price - coalesce((select sum(m.amount) from member m where m.subscription_id = s.id), 0), 0)::int
+from subscription s;
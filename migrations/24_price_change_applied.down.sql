@@ -0,0 +1,4 @@
+drop index if exists idx_price_change_due;
+
+alter table price_change
+    drop column if exists applied_at;
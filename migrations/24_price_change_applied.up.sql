@@ -0,0 +1,5 @@
+-- scheduled price is written to subscription price when its month starts, applied changes keep time of it
+alter table price_change
+    add column if not exists applied_at timestamptz;
+
+create index if not exists idx_price_change_due on price_change (start_date) where applied_at is null;
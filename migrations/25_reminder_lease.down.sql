@@ -0,0 +1,8 @@
+delete from reminder where sent_at is null;
+
+alter table reminder
+    drop column if exists lease_until;
+
+alter table reminder
+    alter column sent_at set default now(),
+    alter column sent_at set not null;
@@ -0,0 +1,8 @@
+-- reminder is recorded before notification is sent: sent_at is set after it is sent, lease_until keeps concurrent runs
+-- from sending it meanwhile and lets the next run send it again if notification failed
+alter table reminder
+    alter column sent_at drop not null,
+    alter column sent_at drop default;
+
+alter table reminder
+    add column if not exists lease_until timestamptz;
@@ -0,0 +1,2 @@
+alter table subscription
+    drop column if exists billing_period;
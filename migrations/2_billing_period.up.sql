@@ -0,0 +1,2 @@
+alter table subscription
+    add column if not exists billing_period varchar not null default 'monthly';
@@ -0,0 +1,3 @@
+drop index if exists idx_outbox_pending;
+
+drop table if exists outbox;
@@ -0,0 +1,14 @@
+create table if not exists outbox
+(
+    id              bigserial primary key,
+    event_type      varchar     not null,
+    aggregate_id    int         not null,
+    payload         jsonb       not null,
+    created_at      timestamptz not null default now(),
+    published_at    timestamptz,
+    attempts        int         not null default 0,
+    last_error      varchar,
+    next_attempt_at timestamptz not null default now()
+);
+
+create index if not exists idx_outbox_pending on outbox (next_attempt_at, id) where published_at is null;
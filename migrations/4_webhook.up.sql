@@ -0,0 +1,27 @@
+create table if not exists webhook
+(
+    id         serial primary key,
+    url        varchar     not null,
+    secret     varchar     not null,
+    events     varchar[]   not null default '{}',
+    created_at timestamptz not null default now()
+);
+
+create table if not exists webhook_delivery
+(
+    id               bigserial primary key,
+    webhook_id       int         not null references webhook (id) on delete cascade,
+    event_id         bigint      not null,
+    event_type       varchar     not null,
+    payload          jsonb       not null,
+    status           varchar     not null default 'pending',
+    attempts         int         not null default 0,
+    last_status_code int,
+    last_error       varchar,
+    next_attempt_at  timestamptz not null default now(),
+    created_at       timestamptz not null default now(),
+    delivered_at     timestamptz,
+    unique (webhook_id, event_id)
+);
+
+create index if not exists idx_webhook_delivery_pending on webhook_delivery (next_attempt_at, id) where status = 'pending';
@@ -0,0 +1,3 @@
+drop table if exists reminder;
+
+drop table if exists reminder_settings;
@@ -0,0 +1,15 @@
+create table if not exists reminder_settings
+(
+    user_id   varchar primary key,
+    lead_days int not null,
+    email     varchar
+);
+
+create table if not exists reminder
+(
+    id              bigserial primary key,
+    subscription_id int         not null references subscription (id) on delete cascade,
+    billing_date    date        not null,
+    sent_at         timestamptz not null default now(),
+    unique (subscription_id, billing_date)
+);
@@ -0,0 +1,3 @@
+drop table if exists budget_alert;
+
+drop table if exists budget;
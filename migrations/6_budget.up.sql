@@ -0,0 +1,20 @@
+create table if not exists budget
+(
+    id           serial primary key,
+    user_id      varchar     not null,
+    service_name varchar,
+    amount       int         not null,
+    thresholds   int[]       not null default '{80,100}',
+    created_at   timestamptz not null default now()
+);
+
+create index if not exists idx_budget_user on budget (user_id);
+
+create table if not exists budget_alert
+(
+    budget_id  int         not null references budget (id) on delete cascade,
+    month      date        not null,
+    threshold  int         not null,
+    created_at timestamptz not null default now(),
+    primary key (budget_id, month, threshold)
+);
@@ -0,0 +1,9 @@
+create table if not exists price_change
+(
+    id              serial primary key,
+    subscription_id int         not null references subscription (id) on delete cascade,
+    price           int         not null,
+    start_date      date        not null,
+    created_at      timestamptz not null default now(),
+    unique (subscription_id, start_date)
+);
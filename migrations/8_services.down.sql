@@ -0,0 +1,15 @@
+alter table subscription
+    add column if not exists service_name varchar;
+
+update subscription s
+set service_name = sv.name
+from services sv
+where sv.id = s.service_id;
+
+alter table subscription
+    alter column service_name set not null;
+
+alter table subscription
+    drop column if exists service_id;
+
+drop table if exists services;
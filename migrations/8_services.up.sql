@@ -0,0 +1,40 @@
+create table if not exists services
+(
+    id            serial primary key,
+    name          varchar     not null,
+    aliases       varchar[]   not null default '{}',
+    category      varchar,
+    default_price int,
+    vendor_url    varchar,
+    logo_url      varchar,
+    created_at    timestamptz not null default now()
+);
+
+create unique index if not exists idx_services_name on services (lower(name));
+
+-- existing names are grouped by normalised form: case, surrounding and repeated spaces are ignored.
+-- the most used spelling becomes canonical name, the others become aliases
+insert into services (name, aliases)
+select mode() within group (order by regexp_replace(trim(service_name), '\s+', ' ', 'g')),
+       array_remove(
+               array_agg(distinct regexp_replace(trim(service_name), '\s+', ' ', 'g')),
+               mode() within group (order by regexp_replace(trim(service_name), '\s+', ' ', 'g'))
+       )
+from subscription
+group by lower(regexp_replace(trim(service_name), '\s+', ' ', 'g'));
+
+alter table subscription
+    add column if not exists service_id int references services (id);
+
+update subscription s
+set service_id = sv.id
+from services sv
+where lower(regexp_replace(trim(s.service_name), '\s+', ' ', 'g')) = lower(sv.name);
+
+alter table subscription
+    alter column service_id set not null;
+
+alter table subscription
+    drop column if exists service_name;
+
+create index if not exists idx_subscription_service on subscription (service_id);
@@ -0,0 +1,8 @@
+drop table if exists subscription_tag;
+
+drop table if exists tag;
+
+alter table subscription
+    drop column if exists category_id;
+
+drop table if exists category;
@@ -0,0 +1,30 @@
+create table if not exists category
+(
+    id         serial primary key,
+    name       varchar     not null,
+    parent_id  int references category (id),
+    created_at timestamptz not null default now()
+);
+
+-- names are unique among children of the same parent
+create unique index if not exists idx_category_name on category (coalesce(parent_id, 0), lower(name));
+
+alter table subscription
+    add column if not exists category_id int references category (id) on delete set null;
+
+create index if not exists idx_subscription_category on subscription (category_id);
+
+create table if not exists tag
+(
+    id   serial primary key,
+    name varchar not null unique
+);
+
+create table if not exists subscription_tag
+(
+    subscription_id int not null references subscription (id) on delete cascade,
+    tag_id          int not null references tag (id) on delete cascade,
+    primary key (subscription_id, tag_id)
+);
+
+create index if not exists idx_subscription_tag_tag on subscription_tag (tag_id);
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type (
+	// AnalyticsInput selects months from Start to End in mm-yyyy format, of all users if UserId is empty
+	AnalyticsInput struct {
+		UserId string
+		Start  string
+		End    string
+	}
+
+	RecurringSpend struct {
+		Month  string `json:"month"`
+		Active int    `json:"active"`
+		Amount int    `json:"amount"`
+	}
+
+	Growth struct {
+		Month   string `json:"month"`
+		New     int    `json:"new"`
+		Churned int    `json:"churned"`
+		Net     int    `json:"net"`
+	}
+
+	ServiceStats struct {
+		ServiceName   string `json:"service_name"`
+		Subscriptions int    `json:"subscriptions"`
+		AvgPrice      int    `json:"avg_price"`
+		Spend         int    `json:"spend"`
+	}
+
+	// CategoryStats is spend of category: Spend of its own subscriptions and TotalSpend with subcategories.
+	// Uncategorized subscriptions have nil CategoryId
+	CategoryStats struct {
+		CategoryId    *int   `json:"category_id"`
+		Name          string `json:"name"`
+		ParentId      *int   `json:"parent_id"`
+		Subscriptions int    `json:"subscriptions"`
+		Spend         int    `json:"spend"`
+		TotalSpend    int    `json:"total_spend"`
+	}
+)
+
+type AnalyticsClient struct {
+	c *Client
+}
+
+func (in AnalyticsInput) query() url.Values {
+	q := url.Values{}
+	setQuery(q, "user_id", in.UserId)
+	setQuery(q, "start", in.Start)
+	setQuery(q, "end", in.End)
+	return q
+}
+
+// RecurringSpend returns monthly recurring spend and number of active subscriptions per month
+func (a *AnalyticsClient) RecurringSpend(ctx context.Context, input AnalyticsInput) ([]RecurringSpend, error) {
+	var out []RecurringSpend
+	err := a.c.do(ctx, http.MethodGet, apiPrefix+"/analytics/mrr", input.query(), nil, &out)
+	return out, err
+}
+
+// Growth returns new and churned subscriptions per month
+func (a *AnalyticsClient) Growth(ctx context.Context, input AnalyticsInput) ([]Growth, error) {
+	var out []Growth
+	err := a.c.do(ctx, http.MethodGet, apiPrefix+"/analytics/growth", input.query(), nil, &out)
+	return out, err
+}
+
+// Services returns top limit services by spend, all of them if limit is zero
+func (a *AnalyticsClient) Services(ctx context.Context, input AnalyticsInput, limit int) ([]ServiceStats, error) {
+	q := input.query()
+	setQueryInt(q, "limit", limit)
+
+	var out []ServiceStats
+	err := a.c.do(ctx, http.MethodGet, apiPrefix+"/analytics/services", q, nil, &out)
+	return out, err
+}
+
+// Categories returns spend per category ordered by total spend
+func (a *AnalyticsClient) Categories(ctx context.Context, input AnalyticsInput) ([]CategoryStats, error) {
+	var out []CategoryStats
+	err := a.c.do(ctx, http.MethodGet, apiPrefix+"/analytics/categories", input.query(), nil, &out)
+	return out, err
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsClient(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	input := service.AnalyticsInput{
+		UserId:    userId,
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	interval := AnalyticsInput{UserId: userId, Start: "01-2025", End: "02-2025"}
+
+	runTestCases(t, []testCase{
+		{
+			testName: "recurring spend",
+			mockBehaviour: func(m *mocks) {
+				m.analytics.EXPECT().RecurringSpend(gomock.Any(), input).Return([]service.RecurringSpendOutput{
+					{Month: "01-2025", Active: 2, Amount: 800},
+					{Month: "02-2025", Active: 1, Amount: 400},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Analytics.RecurringSpend(ctx, interval)
+			},
+			expectOutput: []RecurringSpend{{Month: "01-2025", Active: 2, Amount: 800}, {Month: "02-2025", Active: 1, Amount: 400}},
+		},
+		{
+			testName: "growth",
+			mockBehaviour: func(m *mocks) {
+				m.analytics.EXPECT().Growth(gomock.Any(), input).Return([]service.GrowthOutput{{Month: "01-2025", New: 2, Net: 2}}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Analytics.Growth(ctx, interval)
+			},
+			expectOutput: []Growth{{Month: "01-2025", New: 2, Net: 2}},
+		},
+		{
+			testName: "services",
+			mockBehaviour: func(m *mocks) {
+				m.analytics.EXPECT().Services(gomock.Any(), input, 1).Return([]service.ServiceStatsOutput{
+					{ServiceName: "Okko", Subscriptions: 1, AvgPrice: 400, Spend: 800},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Analytics.Services(ctx, interval, 1)
+			},
+			expectOutput: []ServiceStats{{ServiceName: "Okko", Subscriptions: 1, AvgPrice: 400, Spend: 800}},
+		},
+		{
+			testName: "categories",
+			mockBehaviour: func(m *mocks) {
+				m.analytics.EXPECT().Categories(gomock.Any(), input).Return([]service.CategoryStatsOutput{
+					{Name: "Uncategorized", Subscriptions: 1, Spend: 800, TotalSpend: 800},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Analytics.Categories(ctx, interval)
+			},
+			expectOutput: []CategoryStats{{Name: "Uncategorized", Subscriptions: 1, Spend: 800, TotalSpend: 800}},
+		},
+	})
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"net/http"
+)
+
+// Authenticator adds credentials to request before it is sent
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthFunc adapts function to Authenticator, e.g. to fetch short-lived tokens
+type AuthFunc func(req *http.Request) error
+
+func (f AuthFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BearerToken sends token in Authorization header
+func BearerToken(token string) Authenticator {
+	return Header("Authorization", "Bearer "+token)
+}
+
+// Header sends value in header, e.g. API key
+func Header(name, value string) Authenticator {
+	return AuthFunc(func(req *http.Request) error {
+		req.Header.Set(name, value)
+		return nil
+	})
+}
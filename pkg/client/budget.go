@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type (
+	// BudgetInput limits monthly spend of user on all subscriptions, on service with ServiceName or on
+	// category with CategoryId including its subcategories. Thresholds are percents of Amount crossing of which is notified
+	BudgetInput struct {
+		UserId      string  `json:"user_id"`
+		ServiceName *string `json:"service_name,omitempty"`
+		CategoryId  *int    `json:"category_id,omitempty"`
+		Amount      int     `json:"amount"`
+		Thresholds  []int   `json:"thresholds,omitempty"`
+	}
+
+	Budget struct {
+		Id          int       `json:"id"`
+		UserId      string    `json:"user_id"`
+		ServiceName *string   `json:"service_name"`
+		CategoryId  *int      `json:"category_id"`
+		Amount      int       `json:"amount"`
+		Thresholds  []int     `json:"thresholds"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	// EvaluationInput selects months from Start to End in mm-yyyy format
+	EvaluationInput struct {
+		Start  string
+		End    string
+		Source string // PriceSourceSubscriptions if empty
+	}
+
+	BudgetMonth struct {
+		Month             string `json:"month"`
+		Amount            int    `json:"amount"`
+		Spent             int    `json:"spent"`
+		Percent           int    `json:"percent"`
+		Remaining         int    `json:"remaining"`
+		CrossedThresholds []int  `json:"crossed_thresholds"`
+	}
+)
+
+type BudgetClient struct {
+	c *Client
+}
+
+func budgetPath(id int) string {
+	return apiPrefix + "/budget/" + strconv.Itoa(id)
+}
+
+func (b *BudgetClient) Create(ctx context.Context, input BudgetInput) (int, error) {
+	var out idOutput[int]
+	err := b.c.do(ctx, http.MethodPost, apiPrefix+"/budget", nil, input, &out)
+	return out.Id, err
+}
+
+func (b *BudgetClient) FindById(ctx context.Context, id int) (Budget, error) {
+	var out Budget
+	err := b.c.do(ctx, http.MethodGet, budgetPath(id), nil, nil, &out)
+	return out, err
+}
+
+// FindAll returns budgets of user, of all users if userId is empty
+func (b *BudgetClient) FindAll(ctx context.Context, userId string) ([]Budget, error) {
+	q := url.Values{}
+	setQuery(q, "user_id", userId)
+
+	var out []Budget
+	err := b.c.do(ctx, http.MethodGet, apiPrefix+"/budget/all", q, nil, &out)
+	return out, err
+}
+
+func (b *BudgetClient) Update(ctx context.Context, id int, input BudgetInput) error {
+	return b.c.do(ctx, http.MethodPut, budgetPath(id), nil, input, nil)
+}
+
+func (b *BudgetClient) Delete(ctx context.Context, id int) error {
+	return b.c.do(ctx, http.MethodDelete, budgetPath(id), nil, nil, nil)
+}
+
+// Evaluate compares spend of every month with budget
+func (b *BudgetClient) Evaluate(ctx context.Context, id int, input EvaluationInput) ([]BudgetMonth, error) {
+	q := url.Values{}
+	setQuery(q, "start", input.Start)
+	setQuery(q, "end", input.End)
+	setQuery(q, "source", input.Source)
+
+	var out []BudgetMonth
+	err := b.c.do(ctx, http.MethodGet, budgetPath(id)+"/evaluation", q, nil, &out)
+	return out, err
+}
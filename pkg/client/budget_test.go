@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestBudgetClient(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	runTestCases(t, []testCase{
+		{
+			testName: "create",
+			mockBehaviour: func(m *mocks) {
+				m.budget.EXPECT().Create(gomock.Any(), service.BudgetInput{UserId: userId, Amount: 1000, Thresholds: []int{80, 100}}).Return(1, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Budgets.Create(ctx, BudgetInput{UserId: userId, Amount: 1000, Thresholds: []int{80, 100}})
+			},
+			expectOutput: 1,
+		},
+		{
+			testName: "find all",
+			mockBehaviour: func(m *mocks) {
+				m.budget.EXPECT().FindAll(gomock.Any(), userId).Return([]service.BudgetOutput{
+					{Id: 1, UserId: userId, Amount: 1000, Thresholds: []int{80, 100}, CreatedAt: createdAt},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Budgets.FindAll(ctx, userId)
+			},
+			expectOutput: []Budget{{Id: 1, UserId: userId, Amount: 1000, Thresholds: []int{80, 100}, CreatedAt: createdAt}},
+		},
+		{
+			testName: "evaluate",
+			mockBehaviour: func(m *mocks) {
+				july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+				m.budget.EXPECT().Evaluate(gomock.Any(), 1, july, july, service.PriceSourceLedger).Return([]service.BudgetMonthOutput{
+					{Month: "07-2025", Amount: 1000, Spent: 900, Percent: 90, Remaining: 100, CrossedThresholds: []int{80}},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Budgets.Evaluate(ctx, 1, EvaluationInput{Start: "07-2025", End: "07-2025", Source: PriceSourceLedger})
+			},
+			expectOutput: []BudgetMonth{{Month: "07-2025", Amount: 1000, Spent: 900, Percent: 90, Remaining: 100, CrossedThresholds: []int{80}}},
+		},
+		{
+			testName:      "invalid interval",
+			mockBehaviour: func(m *mocks) {},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Budgets.Evaluate(ctx, 1, EvaluationInput{Start: "2025-07-01"})
+			},
+			expectErr: ErrBadRequest,
+		},
+	})
+}
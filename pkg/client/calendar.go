@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type calendarLinkOutput struct {
+	Url string `json:"url"`
+}
+
+// CalendarLink returns tokenised url of user renewals calendar for subscribing from calendar apps
+func (s *SubscriptionClient) CalendarLink(ctx context.Context, userId string) (string, error) {
+	var out calendarLinkOutput
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/subscription/calendar/link", url.Values{"user_id": {userId}}, nil, &out)
+	return out.Url, err
+}
+
+// CalendarFeed returns iCalendar feed of user renewals, token is taken from calendar link
+func (s *SubscriptionClient) CalendarFeed(ctx context.Context, userId, token string) ([]byte, error) {
+	q := url.Values{"user_id": {userId}, "token": {token}}
+
+	var out []byte
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/subscription/calendar.ics", q, nil, &out)
+	return out, err
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	// ServiceInput is catalog service, FamilySize is required with FamilyPrice
+	ServiceInput struct {
+		Name         string   `json:"name"`
+		Aliases      []string `json:"aliases,omitempty"`
+		Category     *string  `json:"category,omitempty"`
+		DefaultPrice *int     `json:"default_price,omitempty"`
+		YearlyPrice  *int     `json:"yearly_price,omitempty"`
+		FamilyPrice  *int     `json:"family_price,omitempty"`
+		FamilySize   *int     `json:"family_size,omitempty"`
+		VendorUrl    *string  `json:"vendor_url,omitempty"`
+		LogoUrl      *string  `json:"logo_url,omitempty"`
+	}
+
+	Service struct {
+		Id           int       `json:"id"`
+		Name         string    `json:"name"`
+		Aliases      []string  `json:"aliases"`
+		Category     *string   `json:"category"`
+		DefaultPrice *int      `json:"default_price"`
+		YearlyPrice  *int      `json:"yearly_price"`
+		FamilyPrice  *int      `json:"family_price"`
+		FamilySize   *int      `json:"family_size"`
+		VendorUrl    *string   `json:"vendor_url"`
+		LogoUrl      *string   `json:"logo_url"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+)
+
+// ServiceClient manages catalog of subscription services
+type ServiceClient struct {
+	c *Client
+}
+
+func servicePath(id int) string {
+	return apiPrefix + "/service/" + strconv.Itoa(id)
+}
+
+func (s *ServiceClient) Create(ctx context.Context, input ServiceInput) (int, error) {
+	var out idOutput[int]
+	err := s.c.do(ctx, http.MethodPost, apiPrefix+"/service", nil, input, &out)
+	return out.Id, err
+}
+
+func (s *ServiceClient) FindById(ctx context.Context, id int) (Service, error) {
+	var out Service
+	err := s.c.do(ctx, http.MethodGet, servicePath(id), nil, nil, &out)
+	return out, err
+}
+
+func (s *ServiceClient) FindAll(ctx context.Context) ([]Service, error) {
+	var out []Service
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/service/all", nil, nil, &out)
+	return out, err
+}
+
+func (s *ServiceClient) Update(ctx context.Context, id int, input ServiceInput) error {
+	return s.c.do(ctx, http.MethodPut, servicePath(id), nil, input, nil)
+}
+
+func (s *ServiceClient) Delete(ctx context.Context, id int) error {
+	return s.c.do(ctx, http.MethodDelete, servicePath(id), nil, nil, nil)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestServiceClient(t *testing.T) {
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	runTestCases(t, []testCase{
+		{
+			testName: "create",
+			mockBehaviour: func(m *mocks) {
+				m.catalog.EXPECT().Create(gomock.Any(), service.ServiceInput{
+					Name: "Spotify", Aliases: []string{"spotify premium"}, FamilyPrice: ptr(900), FamilySize: ptr(6),
+				}).Return(4, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Services.Create(ctx, ServiceInput{
+					Name: "Spotify", Aliases: []string{"spotify premium"}, FamilyPrice: ptr(900), FamilySize: ptr(6),
+				})
+			},
+			expectOutput: 4,
+		},
+		{
+			testName: "find all",
+			mockBehaviour: func(m *mocks) {
+				m.catalog.EXPECT().FindAll(gomock.Any()).Return([]service.ServiceOutput{
+					{Id: 4, Name: "Spotify", Aliases: []string{}, YearlyPrice: ptr(6000), CreatedAt: createdAt},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Services.FindAll(ctx)
+			},
+			expectOutput: []Service{{Id: 4, Name: "Spotify", Aliases: []string{}, YearlyPrice: ptr(6000), CreatedAt: createdAt}},
+		},
+		{
+			testName: "service in use",
+			mockBehaviour: func(m *mocks) {
+				m.catalog.EXPECT().Delete(gomock.Any(), 4).Return(service.ErrServiceInUse)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Services.Delete(ctx, 4)
+			},
+			expectErr: ErrConflict,
+		},
+	})
+}
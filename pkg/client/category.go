@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	CategoryInput struct {
+		Name     string `json:"name"`
+		ParentId *int   `json:"parent_id,omitempty"`
+	}
+
+	Category struct {
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		ParentId  *int      `json:"parent_id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+type CategoryClient struct {
+	c *Client
+}
+
+func categoryPath(id int) string {
+	return apiPrefix + "/category/" + strconv.Itoa(id)
+}
+
+func (cc *CategoryClient) Create(ctx context.Context, input CategoryInput) (int, error) {
+	var out idOutput[int]
+	err := cc.c.do(ctx, http.MethodPost, apiPrefix+"/category", nil, input, &out)
+	return out.Id, err
+}
+
+func (cc *CategoryClient) FindById(ctx context.Context, id int) (Category, error) {
+	var out Category
+	err := cc.c.do(ctx, http.MethodGet, categoryPath(id), nil, nil, &out)
+	return out, err
+}
+
+func (cc *CategoryClient) FindAll(ctx context.Context) ([]Category, error) {
+	var out []Category
+	err := cc.c.do(ctx, http.MethodGet, apiPrefix+"/category/all", nil, nil, &out)
+	return out, err
+}
+
+func (cc *CategoryClient) Update(ctx context.Context, id int, input CategoryInput) error {
+	return cc.c.do(ctx, http.MethodPut, categoryPath(id), nil, input, nil)
+}
+
+func (cc *CategoryClient) Delete(ctx context.Context, id int) error {
+	return cc.c.do(ctx, http.MethodDelete, categoryPath(id), nil, nil, nil)
+}
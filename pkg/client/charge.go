@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Statuses of charge
+const (
+	ChargePending  = "pending"
+	ChargePaid     = "paid"
+	ChargeFailed   = "failed"
+	ChargeRefunded = "refunded"
+)
+
+type (
+	// ChargeFilter narrows charges, zero fields are not applied. Start and End are yyyy-mm-dd, End is inclusive
+	ChargeFilter struct {
+		UserId         string
+		SubscriptionId int
+		Status         string
+		Start          string
+		End            string
+	}
+
+	Charge struct {
+		Id             int64     `json:"id"`
+		SubscriptionId int       `json:"subscription_id"`
+		ServiceName    string    `json:"service_name"`
+		UserId         string    `json:"user_id"`
+		BillingDate    string    `json:"billing_date"`
+		Amount         int       `json:"amount"` // gross with tax
+		Tax            int       `json:"tax"`
+		Discount       int       `json:"discount"`
+		Status         string    `json:"status"`
+		CreatedAt      time.Time `json:"created_at"`
+		UpdatedAt      time.Time `json:"updated_at"`
+	}
+)
+
+type ChargeClient struct {
+	c *Client
+}
+
+type generateInput struct {
+	Until *string `json:"until,omitempty"`
+}
+
+type generateOutput struct {
+	Created int `json:"created"`
+}
+
+func chargePath(id int64) string {
+	return apiPrefix + "/charge/" + strconv.FormatInt(id, 10)
+}
+
+// Generate records pending charges of subscriptions up to until in yyyy-mm-dd format, current day of user
+// if it is empty, and returns number of created charges
+func (ch *ChargeClient) Generate(ctx context.Context, until string) (int, error) {
+	var input generateInput
+	if until != "" {
+		input.Until = &until
+	}
+
+	var out generateOutput
+	err := ch.c.do(ctx, http.MethodPost, apiPrefix+"/charge/generate", nil, input, &out)
+	return out.Created, err
+}
+
+func (ch *ChargeClient) FindById(ctx context.Context, id int64) (Charge, error) {
+	var out Charge
+	err := ch.c.do(ctx, http.MethodGet, chargePath(id), nil, nil, &out)
+	return out, err
+}
+
+func (ch *ChargeClient) FindAll(ctx context.Context, filter ChargeFilter) ([]Charge, error) {
+	q := url.Values{}
+	setQuery(q, "user_id", filter.UserId)
+	setQueryInt(q, "subscription_id", filter.SubscriptionId)
+	setQuery(q, "status", filter.Status)
+	setQuery(q, "start", filter.Start)
+	setQuery(q, "end", filter.End)
+
+	var out []Charge
+	err := ch.c.do(ctx, http.MethodGet, apiPrefix+"/charge/all", q, nil, &out)
+	return out, err
+}
+
+func (ch *ChargeClient) Pay(ctx context.Context, id int64) error {
+	return ch.c.do(ctx, http.MethodPost, chargePath(id)+"/pay", nil, nil, nil)
+}
+
+func (ch *ChargeClient) Fail(ctx context.Context, id int64) error {
+	return ch.c.do(ctx, http.MethodPost, chargePath(id)+"/fail", nil, nil, nil)
+}
+
+func (ch *ChargeClient) Refund(ctx context.Context, id int64) error {
+	return ch.c.do(ctx, http.MethodPost, chargePath(id)+"/refund", nil, nil, nil)
+}
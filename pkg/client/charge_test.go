@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestChargeClient(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	runTestCases(t, []testCase{
+		{
+			testName: "generate",
+			mockBehaviour: func(m *mocks) {
+				m.charge.EXPECT().Generate(gomock.Any(), date(2025, 7, 31)).Return(3, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Charges.Generate(ctx, "2025-07-31")
+			},
+			expectOutput: 3,
+		},
+		{
+			testName: "generate until today",
+			mockBehaviour: func(m *mocks) {
+				m.charge.EXPECT().Generate(gomock.Any(), time.Time{}).Return(0, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Charges.Generate(ctx, "")
+			},
+			expectOutput: 0,
+		},
+		{
+			testName: "find all",
+			mockBehaviour: func(m *mocks) {
+				m.charge.EXPECT().FindAll(gomock.Any(), service.ChargeFilterInput{
+					UserId: userId, SubscriptionId: 1, Status: ChargePending, StartDate: ptr(date(2025, 7, 1)), EndDate: ptr(date(2025, 7, 31)),
+				}).Return([]service.ChargeOutput{
+					{Id: 5, SubscriptionId: 1, ServiceName: "Okko", UserId: userId, BillingDate: "2025-07-01", Amount: 400, Status: ChargePending, CreatedAt: createdAt, UpdatedAt: createdAt},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Charges.FindAll(ctx, ChargeFilter{UserId: userId, SubscriptionId: 1, Status: ChargePending, Start: "2025-07-01", End: "2025-07-31"})
+			},
+			expectOutput: []Charge{
+				{Id: 5, SubscriptionId: 1, ServiceName: "Okko", UserId: userId, BillingDate: "2025-07-01", Amount: 400, Status: ChargePending, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+		{
+			testName: "pay",
+			mockBehaviour: func(m *mocks) {
+				m.charge.EXPECT().SetStatus(gomock.Any(), int64(5), ChargePaid).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Charges.Pay(ctx, 5)
+			},
+		},
+		{
+			testName: "refund not paid charge",
+			mockBehaviour: func(m *mocks) {
+				m.charge.EXPECT().SetStatus(gomock.Any(), int64(5), ChargeRefunded).Return(service.ErrInvalidChargeStatus)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Charges.Refund(ctx, 5)
+			},
+			expectErr: ErrConflict,
+		},
+	})
+}
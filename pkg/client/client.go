@@ -0,0 +1,222 @@
+// Package client is Go client of subscription service HTTP API.
+//
+//	c := client.New("http://localhost:8000", client.WithTenant(2), client.WithAuth(client.BearerToken(token)))
+//	id, err := c.Users.Create(ctx, client.UserInput{Timezone: "Europe/Moscow"})
+//
+// Idempotent requests failed with 5xx or 429 are retried with exponential backoff, POST requests only when server
+// did not process them (see RetryPolicy). API errors are returned as *Error
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	apiPrefix    = "/api/v1"
+	tenantHeader = "X-Tenant"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	auth       Authenticator
+	retry      RetryPolicy
+	tenant     int
+
+	Organizations *OrganizationClient
+	Users         *UserClient
+	Subscriptions *SubscriptionClient
+	Services      *ServiceClient
+	Categories    *CategoryClient
+	Webhooks      *WebhookClient
+	Reminders     *ReminderClient
+	Budgets       *BudgetClient
+	Charges       *ChargeClient
+	Analytics     *AnalyticsClient
+	Insights      *InsightsClient
+}
+
+type Option func(c *Client)
+
+// WithHTTPClient sets client requests are sent with, http.DefaultClient is used by default
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTenant sends requests on behalf of organization, default organization is used without it
+func WithTenant(organizationId int) Option {
+	return func(c *Client) {
+		c.tenant = organizationId
+	}
+}
+
+// WithAuth authenticates every request, including retried ones
+func WithAuth(auth Authenticator) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithRetry replaces DefaultRetryPolicy, RetryPolicy{} disables retries
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// New returns client of service running at baseURL, e.g. http://localhost:8000
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Organizations = &OrganizationClient{c: c}
+	c.Users = &UserClient{c: c}
+	c.Subscriptions = &SubscriptionClient{c: c}
+	c.Services = &ServiceClient{c: c}
+	c.Categories = &CategoryClient{c: c}
+	c.Webhooks = &WebhookClient{c: c}
+	c.Reminders = &ReminderClient{c: c}
+	c.Budgets = &BudgetClient{c: c}
+	c.Charges = &ChargeClient{c: c}
+	c.Analytics = &AnalyticsClient{c: c}
+	c.Insights = &InsightsClient{c: c}
+
+	return c
+}
+
+// Ping checks that service is available
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/ping", nil, nil, nil)
+}
+
+// idOutput is response of create endpoints
+type idOutput[T any] struct {
+	Id T `json:"id"`
+}
+
+// do sends request with JSON body and decodes JSON response into out. Raw response body is read into
+// out of type *[]byte. Request is retried according to retry policy of client
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, u, payload)
+		if err != nil {
+			return err
+		}
+		if attempt < c.retry.MaxRetries && c.retry.retryable(method, resp) {
+			wait := c.retry.backoff(attempt, resp.Header.Get("Retry-After"))
+			drain(resp)
+			if err = sleep(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+		return decode(resp, out)
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.tenant != 0 {
+		req.Header.Set(tenantHeader, strconv.Itoa(c.tenant))
+	}
+	if c.auth != nil {
+		if err = c.auth.Authenticate(req); err != nil {
+			return nil, fmt.Errorf("authenticate request: %w", err)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+func decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newError(resp)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if raw, ok := out.(*[]byte); ok {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+		*raw = b
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// drain reads the rest of response so connection is reused by retry
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
+
+// segment escapes string id in path
+func segment(s string) string {
+	return url.PathEscape(s)
+}
+
+// setQuery sets query parameter if value is not empty
+func setQuery(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+// setQueryInt sets query parameter if value is not zero
+func setQueryInt(q url.Values, key string, value int) {
+	if value != 0 {
+		q.Set(key, strconv.Itoa(value))
+	}
+}
@@ -0,0 +1,358 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	v1 "subscription_service/internal/controller/http/v1"
+	"subscription_service/internal/mocks/servicemocks"
+	"subscription_service/internal/service"
+	"subscription_service/pkg/tenant"
+	"subscription_service/pkg/validator"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mocks are services behind router of test server
+type mocks struct {
+	organization *servicemocks.MockOrganization
+	user         *servicemocks.MockUser
+	subscription *servicemocks.MockSubscription
+	share        *servicemocks.MockShare
+	forecast     *servicemocks.MockForecast
+	calendar     *servicemocks.MockCalendar
+	catalog      *servicemocks.MockCatalog
+	category     *servicemocks.MockCategory
+	webhook      *servicemocks.MockWebhook
+	reminder     *servicemocks.MockReminder
+	budget       *servicemocks.MockBudget
+	charge       *servicemocks.MockCharge
+	statement    *servicemocks.MockStatement
+	analytics    *servicemocks.MockAnalytics
+	anomaly      *servicemocks.MockAnomaly
+	savings      *servicemocks.MockSavings
+}
+
+// newTestClient returns client of test server running HTTP router of service with mocked services
+func newTestClient(t *testing.T, mockBehaviour func(m *mocks), opts ...Option) *Client {
+	ctrl := gomock.NewController(t)
+
+	m := &mocks{
+		organization: servicemocks.NewMockOrganization(ctrl),
+		user:         servicemocks.NewMockUser(ctrl),
+		subscription: servicemocks.NewMockSubscription(ctrl),
+		share:        servicemocks.NewMockShare(ctrl),
+		forecast:     servicemocks.NewMockForecast(ctrl),
+		calendar:     servicemocks.NewMockCalendar(ctrl),
+		catalog:      servicemocks.NewMockCatalog(ctrl),
+		category:     servicemocks.NewMockCategory(ctrl),
+		webhook:      servicemocks.NewMockWebhook(ctrl),
+		reminder:     servicemocks.NewMockReminder(ctrl),
+		budget:       servicemocks.NewMockBudget(ctrl),
+		charge:       servicemocks.NewMockCharge(ctrl),
+		statement:    servicemocks.NewMockStatement(ctrl),
+		analytics:    servicemocks.NewMockAnalytics(ctrl),
+		anomaly:      servicemocks.NewMockAnomaly(ctrl),
+		savings:      servicemocks.NewMockSavings(ctrl),
+	}
+	mockBehaviour(m)
+
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	v1.NewRouter(e, &service.Services{
+		Organization: m.organization,
+		User:         m.user,
+		Subscription: m.subscription,
+		Share:        m.share,
+		Forecast:     m.forecast,
+		Calendar:     m.calendar,
+		Catalog:      m.catalog,
+		Category:     m.category,
+		Webhook:      m.webhook,
+		Reminder:     m.reminder,
+		Budget:       m.budget,
+		Charge:       m.charge,
+		Statement:    m.statement,
+		Analytics:    m.analytics,
+		Anomaly:      m.anomaly,
+		Savings:      m.savings,
+	})
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL, append([]Option{WithRetry(RetryPolicy{})}, opts...)...)
+}
+
+// testCase calls client and compares its output with expected one
+type testCase struct {
+	testName      string
+	mockBehaviour func(m *mocks)
+	call          func(ctx context.Context, c *Client) (any, error)
+	expectOutput  any
+	expectErr     error
+}
+
+func runTestCases(t *testing.T, testCases []testCase) {
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			c := newTestClient(t, tc.mockBehaviour)
+
+			output, err := tc.call(context.Background(), c)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectOutput, output)
+		})
+	}
+}
+
+// inOrganization matches context bound to organization
+type inOrganization int
+
+func (m inOrganization) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	id, ok := tenant.Organization(ctx)
+	return ok && id == int(m)
+}
+
+func (m inOrganization) String() string {
+	return "context of organization " + strconv.Itoa(int(m))
+}
+
+func TestClient_tenant(t *testing.T) {
+	c := newTestClient(t, func(m *mocks) {
+		m.organization.EXPECT().FindById(gomock.Any(), 2).Return(service.OrganizationOutput{Id: 2}, nil)
+		m.category.EXPECT().Delete(inOrganization(2), 1).Return(nil)
+	}, WithTenant(2))
+
+	assert.NoError(t, c.Categories.Delete(context.Background(), 1))
+}
+
+func TestClient_errors(t *testing.T) {
+	c := newTestClient(t, func(m *mocks) {
+		m.category.EXPECT().FindById(gomock.Any(), 1).Return(service.CategoryOutput{}, service.ErrCategoryNotFound)
+		m.category.EXPECT().Delete(gomock.Any(), 1).Return(service.ErrCategoryInUse)
+	})
+
+	_, err := c.Categories.FindById(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	err = c.Categories.Delete(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	// invalid input is rejected by router
+	_, err = c.Categories.Create(context.Background(), CategoryInput{})
+	assert.ErrorIs(t, err, ErrBadRequest)
+}
+
+func TestClient_retry(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+	create := func(t *testing.T, c *Client) error {
+		id, err := c.Categories.Create(context.Background(), CategoryInput{Name: "Music"})
+		if err == nil {
+			assert.Equal(t, 3, id)
+		}
+		return err
+	}
+	update := func(t *testing.T, c *Client) error {
+		return c.Categories.Update(context.Background(), 3, CategoryInput{Name: "Music"})
+	}
+
+	testCases := []struct {
+		testName      string
+		call          func(t *testing.T, c *Client) error
+		policy        RetryPolicy
+		codes         []int
+		retryAfter    bool // Retry-After is sent with 503, it is always sent with 429
+		expectCalls   int
+		expectErrCode int
+	}{
+		{
+			testName:    "retried after server error and rate limit",
+			call:        update,
+			policy:      policy,
+			codes:       []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectCalls: 3,
+		},
+		{
+			testName:      "retries are exhausted",
+			call:          update,
+			policy:        policy,
+			codes:         []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expectCalls:   3,
+			expectErrCode: http.StatusBadGateway,
+		},
+		{
+			testName:      "client error is not retried",
+			call:          update,
+			policy:        policy,
+			codes:         []int{http.StatusConflict, http.StatusOK},
+			expectCalls:   1,
+			expectErrCode: http.StatusConflict,
+		},
+		{
+			testName:      "post is not retried after server error",
+			call:          create,
+			policy:        policy,
+			codes:         []int{http.StatusInternalServerError, http.StatusOK},
+			expectCalls:   1,
+			expectErrCode: http.StatusInternalServerError,
+		},
+		{
+			testName:      "post is not retried after unavailable without retry after",
+			call:          create,
+			policy:        policy,
+			codes:         []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectCalls:   1,
+			expectErrCode: http.StatusServiceUnavailable,
+		},
+		{
+			testName:    "post is retried after rate limit and unavailable with retry after",
+			call:        create,
+			policy:      policy,
+			codes:       []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			retryAfter:  true,
+			expectCalls: 3,
+		},
+		{
+			testName: "post is retried after server error if enabled",
+			call:     create,
+			policy: RetryPolicy{
+				MaxRetries:         2,
+				MinBackoff:         time.Millisecond,
+				MaxBackoff:         10 * time.Millisecond,
+				RetryNonIdempotent: true,
+			},
+			codes:       []int{http.StatusInternalServerError, http.StatusOK},
+			expectCalls: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			var calls atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// body is sent again with every retry
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `{"name":"Music"}`, string(body))
+
+				code := tc.codes[calls.Add(1)-1]
+				if code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable && tc.retryAfter {
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(`{"id":3}`))
+			}))
+			defer srv.Close()
+
+			err := tc.call(t, New(srv.URL, WithRetry(tc.policy)))
+			assert.Equal(t, tc.expectCalls, int(calls.Load()))
+			if tc.expectErrCode != 0 {
+				var apiErr *Error
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tc.expectErrCode, apiErr.StatusCode)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_retryCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithRetry(RetryPolicy{MaxRetries: 5, MinBackoff: time.Minute, MaxBackoff: time.Minute}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.Ping(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_auth(t *testing.T) {
+	testCases := []struct {
+		testName     string
+		auth         Authenticator
+		expectHeader string
+		expectValue  string
+		expectErr    bool
+	}{
+		{
+			testName:     "bearer token",
+			auth:         BearerToken("secret"),
+			expectHeader: "Authorization",
+			expectValue:  "Bearer secret",
+		},
+		{
+			testName:     "api key",
+			auth:         Header("X-Api-Key", "key"),
+			expectHeader: "X-Api-Key",
+			expectValue:  "key",
+		},
+		{
+			testName: "auth error",
+			auth: AuthFunc(func(req *http.Request) error {
+				return errors.New("no token")
+			}),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.expectValue, r.Header.Get(tc.expectHeader))
+				assert.Equal(t, "3", r.Header.Get("X-Tenant"))
+			}))
+			defer srv.Close()
+
+			c := New(srv.URL+"/", WithAuth(tc.auth), WithTenant(3))
+
+			err := c.Ping(context.Background())
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, expect := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		d := p.backoff(attempt, "")
+		assert.GreaterOrEqual(t, d, expect/2)
+		assert.LessOrEqual(t, d, expect)
+	}
+
+	assert.Equal(t, 2*time.Second, RetryPolicy{MaxBackoff: 5 * time.Second}.backoff(0, "2"))
+	// server can't make client wait longer than MaxBackoff
+	assert.Equal(t, time.Second, p.backoff(0, "30"))
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	ErrBadRequest = errors.New("bad request")
+	ErrForbidden  = errors.New("forbidden")
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+)
+
+// Error is response of API with 4xx or 5xx status. It matches ErrBadRequest, ErrForbidden, ErrNotFound
+// and ErrConflict with errors.Is
+type Error struct {
+	StatusCode int
+
+	// SubscriptionIds are existing subscriptions of the same service when created subscription is duplicate
+	SubscriptionIds []int
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("subscription service: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *Error) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return target == ErrBadRequest
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	default:
+		return false
+	}
+}
+
+type duplicateOutput struct {
+	SubscriptionIds []int `json:"subscription_ids"`
+}
+
+func newError(resp *http.Response) error {
+	e := &Error{StatusCode: resp.StatusCode}
+
+	if resp.StatusCode == http.StatusConflict {
+		var d duplicateOutput
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&d); err == nil {
+			e.SubscriptionIds = d.SubscriptionIds
+		}
+	}
+	return e
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type (
+	// ForecastInput selects subscriptions spend is projected for. Start is first month in mm-yyyy format,
+	// current month if empty. Months is 12 if zero
+	ForecastInput struct {
+		UserId      string
+		ServiceName string
+		Start       string
+		Months      int
+	}
+
+	Forecast struct {
+		Total  int             `json:"total"`
+		Months []ForecastMonth `json:"months"`
+	}
+
+	ForecastMonth struct {
+		Month    string            `json:"month"`
+		Total    int               `json:"total"`
+		Services []ForecastService `json:"services"`
+	}
+
+	ForecastService struct {
+		ServiceName string `json:"service_name"`
+		Price       int    `json:"price"`
+	}
+
+	// PriceChangeInput is new price of subscription starting from month in mm-yyyy format
+	PriceChangeInput struct {
+		Price     int    `json:"price"`
+		StartDate string `json:"start_date"`
+	}
+
+	PriceChange struct {
+		Id             int        `json:"id"`
+		SubscriptionId int        `json:"subscription_id"`
+		Price          int        `json:"price"`
+		StartDate      string     `json:"start_date"`
+		CreatedAt      time.Time  `json:"created_at"`
+		AppliedAt      *time.Time `json:"applied_at"`
+	}
+)
+
+// Forecast projects monthly spend of active subscriptions
+func (s *SubscriptionClient) Forecast(ctx context.Context, input ForecastInput) (Forecast, error) {
+	q := url.Values{}
+	setQuery(q, "user_id", input.UserId)
+	setQuery(q, "service_name", input.ServiceName)
+	setQuery(q, "start", input.Start)
+	setQueryInt(q, "months", input.Months)
+
+	var out Forecast
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/subscription/forecast", q, nil, &out)
+	return out, err
+}
+
+// SchedulePriceChange schedules new price of subscription and returns id of price change
+func (s *SubscriptionClient) SchedulePriceChange(ctx context.Context, id int, input PriceChangeInput) (int, error) {
+	var out idOutput[int]
+	err := s.c.do(ctx, http.MethodPost, subscriptionPath(id)+"/price_changes", nil, input, &out)
+	return out.Id, err
+}
+
+func (s *SubscriptionClient) FindPriceChanges(ctx context.Context, id int) ([]PriceChange, error) {
+	var out []PriceChange
+	err := s.c.do(ctx, http.MethodGet, subscriptionPath(id)+"/price_changes", nil, nil, &out)
+	return out, err
+}
+
+func (s *SubscriptionClient) DeletePriceChange(ctx context.Context, id, changeId int) error {
+	return s.c.do(ctx, http.MethodDelete, subscriptionPath(id)+"/price_changes/"+strconv.Itoa(changeId), nil, nil, nil)
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type (
+	// Anomaly is price of subscription above ReferencePrice by Percent: previous price of subscription
+	// for increase and median price of the same service among users for outlier
+	Anomaly struct {
+		Id             int       `json:"id"`
+		SubscriptionId int       `json:"subscription_id"`
+		ServiceName    string    `json:"service_name"`
+		UserId         string    `json:"user_id"`
+		Kind           string    `json:"kind"`
+		Price          int       `json:"price"`
+		ReferencePrice int       `json:"reference_price"`
+		Percent        int       `json:"percent"`
+		DetectedAt     time.Time `json:"detected_at"`
+	}
+
+	// Savings lists recommendations for subscriptions of user running on Date, MonthlySavings is their sum
+	Savings struct {
+		UserId          string           `json:"user_id"`
+		Currency        string           `json:"currency"`
+		Date            string           `json:"date"`
+		MonthlySavings  int              `json:"monthly_savings"`
+		Recommendations []Recommendation `json:"recommendations"`
+	}
+
+	// Recommendation is one way to save: duplicate, unused, family or annual
+	Recommendation struct {
+		Kind            string   `json:"kind"`
+		ServiceId       int      `json:"service_id"`
+		ServiceName     string   `json:"service_name"`
+		SubscriptionIds []int    `json:"subscription_ids"`
+		UserIds         []string `json:"user_ids,omitempty"`
+		MonthlySavings  int      `json:"monthly_savings"`
+	}
+)
+
+type InsightsClient struct {
+	c *Client
+}
+
+// Anomalies returns detected price anomalies of user, of all users if userId is empty, the latest first
+func (i *InsightsClient) Anomalies(ctx context.Context, userId string) ([]Anomaly, error) {
+	q := url.Values{}
+	setQuery(q, "user_id", userId)
+
+	var out []Anomaly
+	err := i.c.do(ctx, http.MethodGet, apiPrefix+"/insights/anomalies", q, nil, &out)
+	return out, err
+}
+
+// Savings suggests savings on subscriptions of user on date in yyyy-mm-dd format, today of user if it is empty
+func (i *InsightsClient) Savings(ctx context.Context, userId, date string) (Savings, error) {
+	q := url.Values{"user_id": {userId}}
+	setQuery(q, "date", date)
+
+	var out Savings
+	err := i.c.do(ctx, http.MethodGet, apiPrefix+"/insights/savings", q, nil, &out)
+	return out, err
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestInsightsClient(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	detectedAt := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	runTestCases(t, []testCase{
+		{
+			testName: "anomalies",
+			mockBehaviour: func(m *mocks) {
+				m.anomaly.EXPECT().FindAll(gomock.Any(), userId).Return([]service.AnomalyOutput{
+					{Id: 1, SubscriptionId: 2, ServiceName: "Netflix", UserId: userId, Kind: "increase", Price: 1000, ReferencePrice: 800, Percent: 25, DetectedAt: detectedAt},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Insights.Anomalies(ctx, userId)
+			},
+			expectOutput: []Anomaly{
+				{Id: 1, SubscriptionId: 2, ServiceName: "Netflix", UserId: userId, Kind: "increase", Price: 1000, ReferencePrice: 800, Percent: 25, DetectedAt: detectedAt},
+			},
+		},
+		{
+			testName: "savings",
+			mockBehaviour: func(m *mocks) {
+				m.savings.EXPECT().Recommend(gomock.Any(), userId, &detectedAt).Return(service.SavingsOutput{
+					UserId: userId, Currency: "RUB", Date: "2025-07-15", MonthlySavings: 100,
+					Recommendations: []service.RecommendationOutput{
+						{Kind: "annual", ServiceId: 3, ServiceName: "Kinopoisk", SubscriptionIds: []int{4}, MonthlySavings: 100},
+					},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Insights.Savings(ctx, userId, "2025-07-15")
+			},
+			expectOutput: Savings{
+				UserId: userId, Currency: "RUB", Date: "2025-07-15", MonthlySavings: 100,
+				Recommendations: []Recommendation{{Kind: "annual", ServiceId: 3, ServiceName: "Kinopoisk", SubscriptionIds: []int{4}, MonthlySavings: 100}},
+			},
+		},
+		{
+			testName: "user not found",
+			mockBehaviour: func(m *mocks) {
+				m.savings.EXPECT().Recommend(gomock.Any(), userId, nil).Return(service.SavingsOutput{}, service.ErrUserNotFound)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Insights.Savings(ctx, userId, "")
+			},
+			expectErr: ErrNotFound,
+		},
+	})
+}
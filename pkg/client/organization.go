@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	OrganizationInput struct {
+		Name string `json:"name"`
+	}
+
+	Organization struct {
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+// OrganizationClient manages organizations, its requests are not bound to tenant
+type OrganizationClient struct {
+	c *Client
+}
+
+func (o *OrganizationClient) Create(ctx context.Context, input OrganizationInput) (int, error) {
+	var out idOutput[int]
+	err := o.c.do(ctx, http.MethodPost, apiPrefix+"/organization", nil, input, &out)
+	return out.Id, err
+}
+
+func (o *OrganizationClient) FindById(ctx context.Context, id int) (Organization, error) {
+	var out Organization
+	err := o.c.do(ctx, http.MethodGet, apiPrefix+"/organization/"+strconv.Itoa(id), nil, nil, &out)
+	return out, err
+}
+
+func (o *OrganizationClient) FindAll(ctx context.Context) ([]Organization, error) {
+	var out []Organization
+	err := o.c.do(ctx, http.MethodGet, apiPrefix+"/organization/all", nil, nil, &out)
+	return out, err
+}
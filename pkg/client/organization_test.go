@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestOrganizationClient(t *testing.T) {
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	runTestCases(t, []testCase{
+		{
+			testName: "create",
+			mockBehaviour: func(m *mocks) {
+				m.organization.EXPECT().Create(gomock.Any(), service.OrganizationInput{Name: "Acme"}).Return(2, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Organizations.Create(ctx, OrganizationInput{Name: "Acme"})
+			},
+			expectOutput: 2,
+		},
+		{
+			testName: "find by id",
+			mockBehaviour: func(m *mocks) {
+				m.organization.EXPECT().FindById(gomock.Any(), 2).Return(service.OrganizationOutput{Id: 2, Name: "Acme", CreatedAt: createdAt}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Organizations.FindById(ctx, 2)
+			},
+			expectOutput: Organization{Id: 2, Name: "Acme", CreatedAt: createdAt},
+		},
+		{
+			testName: "organization name is taken",
+			mockBehaviour: func(m *mocks) {
+				m.organization.EXPECT().Create(gomock.Any(), service.OrganizationInput{Name: "default"}).Return(0, service.ErrOrganizationAlreadyExists)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Organizations.Create(ctx, OrganizationInput{Name: "default"})
+			},
+			expectErr: ErrConflict,
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// ReminderSettingsInput sends reminders to Email LeadDays days before renewal
+	ReminderSettingsInput struct {
+		LeadDays int     `json:"lead_days"`
+		Email    *string `json:"email,omitempty"`
+	}
+
+	ReminderSettings struct {
+		UserId   string  `json:"user_id"`
+		LeadDays int     `json:"lead_days"`
+		Email    *string `json:"email"`
+	}
+)
+
+type ReminderClient struct {
+	c *Client
+}
+
+func reminderPath(userId string) string {
+	return apiPrefix + "/reminder/settings/" + segment(userId)
+}
+
+func (r *ReminderClient) FindSettings(ctx context.Context, userId string) (ReminderSettings, error) {
+	var out ReminderSettings
+	err := r.c.do(ctx, http.MethodGet, reminderPath(userId), nil, nil, &out)
+	return out, err
+}
+
+func (r *ReminderClient) UpdateSettings(ctx context.Context, userId string, input ReminderSettingsInput) error {
+	return r.c.do(ctx, http.MethodPut, reminderPath(userId), nil, input, nil)
+}
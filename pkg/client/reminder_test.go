@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+)
+
+func TestReminderClient(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	runTestCases(t, []testCase{
+		{
+			testName: "find settings",
+			mockBehaviour: func(m *mocks) {
+				m.reminder.EXPECT().FindSettings(gomock.Any(), userId).Return(service.ReminderSettingsOutput{UserId: userId, LeadDays: 3}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Reminders.FindSettings(ctx, userId)
+			},
+			expectOutput: ReminderSettings{UserId: userId, LeadDays: 3},
+		},
+		{
+			// zero lead days are sent, not omitted as missing
+			testName: "update settings",
+			mockBehaviour: func(m *mocks) {
+				m.reminder.EXPECT().UpdateSettings(gomock.Any(), userId, service.ReminderSettingsInput{Email: ptr("user@example.com")}).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Reminders.UpdateSettings(ctx, userId, ReminderSettingsInput{Email: ptr("user@example.com")})
+			},
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries requests failed with 5xx or 429 status up to MaxRetries times. Delay before retry
+// doubles from MinBackoff up to MaxBackoff with random jitter, Retry-After header of response takes precedence.
+// Only idempotent requests (GET, PUT, DELETE) are retried on any of these statuses. POST requests could already be
+// processed by server, so they are retried only on 429 or 503 with Retry-After, unless RetryNonIdempotent is set
+type RetryPolicy struct {
+	MaxRetries         int
+	MinBackoff         time.Duration
+	MaxBackoff         time.Duration
+	RetryNonIdempotent bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 200 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+func (p RetryPolicy) retryable(method string, resp *http.Response) bool {
+	code := resp.StatusCode
+	if code != http.StatusTooManyRequests && code < http.StatusInternalServerError {
+		return false
+	}
+	if p.RetryNonIdempotent || idempotent(method) {
+		return true
+	}
+	// server rejected request without processing it
+	return code == http.StatusTooManyRequests ||
+		code == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != ""
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns delay before retry after attempt, starting from 0
+func (p RetryPolicy) backoff(attempt int, retryAfter string) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, p.MaxBackoff)
+	}
+	d := p.MaxBackoff
+	// shift is limited so delay does not overflow
+	if attempt < 32 && p.MinBackoff<<attempt < d {
+		d = p.MinBackoff << attempt
+	}
+	if d <= 0 {
+		return 0
+	}
+	// half of delay is random, so clients failed together do not retry together
+	return d/2 + rand.N(d/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
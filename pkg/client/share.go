@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Rules of splitting price of shared subscription
+const (
+	ShareEqual      = "equal"
+	SharePercentage = "percentage"
+	ShareFixed      = "fixed"
+)
+
+type (
+	ShareInput struct {
+		Rule    string             `json:"rule"`
+		Members []ShareMemberInput `json:"members"`
+	}
+
+	// ShareMemberInput is share of one member: Percent for percentage rule, Amount for fixed rule
+	ShareMemberInput struct {
+		UserId  string `json:"user_id"`
+		Percent *int   `json:"percent,omitempty"`
+		Amount  *int   `json:"amount,omitempty"`
+	}
+
+	Share struct {
+		SubscriptionId int           `json:"subscription_id"`
+		Rule           string        `json:"rule"`
+		OwnerId        string        `json:"owner_id"`
+		OwnerCost      int           `json:"owner_cost"`
+		Members        []ShareMember `json:"members"`
+	}
+
+	ShareMember struct {
+		UserId  string `json:"user_id"`
+		Percent *int   `json:"percent"`
+		Amount  *int   `json:"amount"`
+		Cost    int    `json:"cost"`
+	}
+
+	// SettleUpInput selects debts of months from Start to End in mm-yyyy format, of all users if UserId is empty
+	SettleUpInput struct {
+		UserId string
+		Start  string
+		End    string
+	}
+
+	SettleUpMonth struct {
+		Month string `json:"month"`
+		Debts []Debt `json:"debts"`
+	}
+
+	// Debt is amount user From owes user To, debts of two users to each other are netted
+	Debt struct {
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Amount int    `json:"amount"`
+	}
+)
+
+// SetShare shares subscription with members replacing previous shares
+func (s *SubscriptionClient) SetShare(ctx context.Context, id int, input ShareInput) error {
+	return s.c.do(ctx, http.MethodPut, subscriptionPath(id)+"/share", nil, input, nil)
+}
+
+func (s *SubscriptionClient) FindShare(ctx context.Context, id int) (Share, error) {
+	var out Share
+	err := s.c.do(ctx, http.MethodGet, subscriptionPath(id)+"/share", nil, nil, &out)
+	return out, err
+}
+
+// DeleteShare makes subscription not shared, owner pays full price again
+func (s *SubscriptionClient) DeleteShare(ctx context.Context, id int) error {
+	return s.c.do(ctx, http.MethodDelete, subscriptionPath(id)+"/share", nil, nil, nil)
+}
+
+// SettleUp returns who owes whom per month
+func (s *SubscriptionClient) SettleUp(ctx context.Context, input SettleUpInput) ([]SettleUpMonth, error) {
+	q := url.Values{}
+	setQuery(q, "user_id", input.UserId)
+	setQuery(q, "start", input.Start)
+	setQuery(q, "end", input.End)
+
+	var out []SettleUpMonth
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/subscription/settle", q, nil, &out)
+	return out, err
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Billing periods of subscription, monthly by default
+const (
+	BillingMonthly = "monthly"
+	BillingYearly  = "yearly"
+)
+
+// Moments subscription is cancelled at
+const (
+	CancelImmediately = "immediately"
+	CancelEndOfPeriod = "end_of_period"
+	CancelAtDate      = "date"
+)
+
+// Sources of prices and spend: computed from subscriptions or summed from charge ledger
+const (
+	PriceSourceSubscriptions = "subscriptions"
+	PriceSourceLedger        = "ledger"
+)
+
+type (
+	// SubscriptionInput sets service by ServiceId or ServiceName, unknown names are added to catalog.
+	// Dates are yyyy-mm-dd, EndDate is inclusive last day
+	SubscriptionInput struct {
+		ServiceId     int       `json:"service_id,omitempty"`
+		ServiceName   string    `json:"service_name,omitempty"`
+		Price         int       `json:"price"`
+		UserId        string    `json:"user_id"`
+		StartDate     string    `json:"start_date"`
+		EndDate       *string   `json:"end_date,omitempty"`
+		BillingPeriod string    `json:"billing_period,omitempty"`
+		CategoryId    *int      `json:"category_id,omitempty"`
+		Tags          []string  `json:"tags,omitempty"`
+		TrialEndDate  *string   `json:"trial_end_date,omitempty"`
+		IntroPrice    *int      `json:"intro_price,omitempty"`
+		IntroPeriods  int       `json:"intro_periods,omitempty"`
+		TaxRate       int       `json:"tax_rate,omitempty"`
+		TaxInclusive  bool      `json:"tax_inclusive,omitempty"`
+		Discount      *Discount `json:"discount,omitempty"`
+		LastUsedDate  *string   `json:"last_used_date,omitempty"`
+	}
+
+	// Discount reduces charges by Value percent or by fixed Value from StartDate (start of subscription if empty)
+	// for Months months, forever if it is zero
+	Discount struct {
+		Type      string `json:"type"`
+		Value     int    `json:"value"`
+		StartDate string `json:"start_date,omitempty"`
+		Months    int    `json:"months,omitempty"`
+	}
+
+	Subscription struct {
+		Id            int       `json:"id"`
+		ServiceId     int       `json:"service_id"`
+		ServiceName   string    `json:"service_name"`
+		Price         int       `json:"price"`
+		UserId        string    `json:"user_id"`
+		StartDate     string    `json:"start_date"`
+		EndDate       *string   `json:"end_date"`
+		BillingPeriod string    `json:"billing_period"`
+		CategoryId    *int      `json:"category_id"`
+		Tags          []string  `json:"tags"`
+		TrialEndDate  *string   `json:"trial_end_date,omitempty"`
+		IntroPrice    *int      `json:"intro_price,omitempty"`
+		IntroPeriods  int       `json:"intro_periods,omitempty"`
+		TaxRate       int       `json:"tax_rate,omitempty"`
+		TaxInclusive  bool      `json:"tax_inclusive,omitempty"`
+		Discount      *Discount `json:"discount,omitempty"`
+		LastUsedDate  *string   `json:"last_used_date,omitempty"`
+
+		// Status is active, paused, cancelled (ended) or scheduled (not started) on current date of user
+		Status string  `json:"status"`
+		Pauses []Pause `json:"pauses,omitempty"`
+
+		CancelReason *string    `json:"cancel_reason,omitempty"`
+		CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+	}
+
+	// Pause lasts from StartDate to inclusive EndDate, until resume without EndDate
+	Pause struct {
+		StartDate string  `json:"start_date"`
+		EndDate   *string `json:"end_date,omitempty"`
+	}
+
+	// ResumeInput is day from which subscription is charged again, today of user if Date is nil
+	ResumeInput struct {
+		Date *string `json:"date,omitempty"`
+	}
+
+	// CancelInput chooses the last day of subscription by When, Date is required with CancelAtDate
+	CancelInput struct {
+		When   string  `json:"when"`
+		Date   *string `json:"date,omitempty"`
+		Reason *string `json:"reason,omitempty"`
+	}
+
+	// SubscriptionFilter narrows subscriptions, zero fields are not applied
+	SubscriptionFilter struct {
+		CategoryId int // includes subcategories
+		Tag        string
+	}
+
+	// PriceInput selects subscriptions price is computed for. Start and End are yyyy-mm-dd, End is inclusive
+	PriceInput struct {
+		ServiceName string
+		UserId      string
+		CategoryId  int
+		Tag         string
+		Start       string
+		End         string
+		Source      string // PriceSourceSubscriptions if empty
+	}
+
+	// Price is amount paid for subscriptions: Gross with tax, Net without it. Discount is already subtracted
+	Price struct {
+		Gross    int `json:"gross"`
+		Net      int `json:"net"`
+		Tax      int `json:"tax"`
+		Discount int `json:"discount"`
+	}
+
+	// Duplicate is group of subscriptions of one user to one catalog service with overlapping periods
+	Duplicate struct {
+		UserId          string `json:"user_id"`
+		ServiceId       int    `json:"service_id"`
+		ServiceName     string `json:"service_name"`
+		SubscriptionIds []int  `json:"subscription_ids"`
+	}
+)
+
+type SubscriptionClient struct {
+	c *Client
+}
+
+func subscriptionPath(id int) string {
+	return apiPrefix + "/subscription/" + strconv.Itoa(id)
+}
+
+// Create creates subscription. Duplicate of another subscription of user is rejected with *Error
+// listing conflicting subscriptions, use CreateDuplicate to create it anyway
+func (s *SubscriptionClient) Create(ctx context.Context, input SubscriptionInput) error {
+	return s.c.do(ctx, http.MethodPost, apiPrefix+"/subscription", nil, input, nil)
+}
+
+// CreateDuplicate creates subscription even if user has another one of the same service in its period
+func (s *SubscriptionClient) CreateDuplicate(ctx context.Context, input SubscriptionInput) error {
+	return s.c.do(ctx, http.MethodPost, apiPrefix+"/subscription", url.Values{"allow_duplicate": {"true"}}, input, nil)
+}
+
+func (s *SubscriptionClient) FindById(ctx context.Context, id int) (Subscription, error) {
+	var out Subscription
+	err := s.c.do(ctx, http.MethodGet, subscriptionPath(id), nil, nil, &out)
+	return out, err
+}
+
+func (s *SubscriptionClient) FindAll(ctx context.Context, filter SubscriptionFilter) ([]Subscription, error) {
+	q := url.Values{}
+	setQueryInt(q, "category_id", filter.CategoryId)
+	setQuery(q, "tag", filter.Tag)
+
+	var out []Subscription
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/subscription/all", q, nil, &out)
+	return out, err
+}
+
+// FindPrice returns total price of subscriptions for time interval
+func (s *SubscriptionClient) FindPrice(ctx context.Context, input PriceInput) (Price, error) {
+	q := url.Values{}
+	setQuery(q, "service_name", input.ServiceName)
+	setQuery(q, "user_id", input.UserId)
+	setQueryInt(q, "category_id", input.CategoryId)
+	setQuery(q, "tag", input.Tag)
+	setQuery(q, "start", input.Start)
+	setQuery(q, "end", input.End)
+	setQuery(q, "source", input.Source)
+
+	var out Price
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/subscription/price", q, nil, &out)
+	return out, err
+}
+
+// FindDuplicates returns duplicate subscriptions of user, of all users if userId is empty
+func (s *SubscriptionClient) FindDuplicates(ctx context.Context, userId string) ([]Duplicate, error) {
+	q := url.Values{}
+	setQuery(q, "user_id", userId)
+
+	var out []Duplicate
+	err := s.c.do(ctx, http.MethodGet, apiPrefix+"/subscription/duplicates", q, nil, &out)
+	return out, err
+}
+
+func (s *SubscriptionClient) Update(ctx context.Context, id int, input SubscriptionInput) error {
+	return s.c.do(ctx, http.MethodPut, subscriptionPath(id), nil, input, nil)
+}
+
+func (s *SubscriptionClient) Delete(ctx context.Context, id int) error {
+	return s.c.do(ctx, http.MethodDelete, subscriptionPath(id), nil, nil, nil)
+}
+
+func (s *SubscriptionClient) Pause(ctx context.Context, id int, input Pause) error {
+	return s.c.do(ctx, http.MethodPost, subscriptionPath(id)+"/pause", nil, input, nil)
+}
+
+func (s *SubscriptionClient) Resume(ctx context.Context, id int, input ResumeInput) error {
+	return s.c.do(ctx, http.MethodPost, subscriptionPath(id)+"/resume", nil, input, nil)
+}
+
+func (s *SubscriptionClient) Cancel(ctx context.Context, id int, input CancelInput) error {
+	return s.c.do(ctx, http.MethodPost, subscriptionPath(id)+"/cancel", nil, input, nil)
+}
+
+func (s *SubscriptionClient) Uncancel(ctx context.Context, id int) error {
+	return s.c.do(ctx, http.MethodPost, subscriptionPath(id)+"/uncancel", nil, nil, nil)
+}
@@ -0,0 +1,337 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestSubscriptionClient(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	input := SubscriptionInput{
+		ServiceName:   "Yandex Plus",
+		Price:         400,
+		UserId:        userId,
+		StartDate:     "2025-07-01",
+		EndDate:       ptr("2025-12-31"),
+		BillingPeriod: BillingMonthly,
+		Tags:          []string{"music"},
+		Discount:      &Discount{Type: "percent", Value: 10, Months: 3},
+	}
+	serviceInput := service.SubscriptionInput{
+		ServiceName:   "Yandex Plus",
+		Price:         400,
+		UserId:        userId,
+		StartDate:     date(2025, 7, 1),
+		EndDate:       ptr(date(2025, 12, 31)),
+		BillingPeriod: BillingMonthly,
+		Tags:          []string{"music"},
+		Discount:      &service.DiscountInput{Type: "percent", Value: 10, Months: 3},
+	}
+	output := service.SubscriptionOutput{
+		Id:            1,
+		ServiceId:     2,
+		ServiceName:   "Yandex Plus",
+		Price:         400,
+		UserId:        userId,
+		StartDate:     "2025-07-01",
+		EndDate:       ptr("2025-12-31"),
+		BillingPeriod: BillingMonthly,
+		Tags:          []string{"music"},
+		Status:        "active",
+	}
+	subscription := Subscription{
+		Id:            1,
+		ServiceId:     2,
+		ServiceName:   "Yandex Plus",
+		Price:         400,
+		UserId:        userId,
+		StartDate:     "2025-07-01",
+		EndDate:       ptr("2025-12-31"),
+		BillingPeriod: BillingMonthly,
+		Tags:          []string{"music"},
+		Status:        "active",
+	}
+
+	runTestCases(t, []testCase{
+		{
+			testName: "create",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Create(gomock.Any(), serviceInput).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Create(ctx, input)
+			},
+		},
+		{
+			testName: "create duplicate",
+			mockBehaviour: func(m *mocks) {
+				allowed := serviceInput
+				allowed.AllowDuplicate = true
+				m.subscription.EXPECT().Create(gomock.Any(), allowed).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.CreateDuplicate(ctx, input)
+			},
+		},
+		{
+			testName: "find by id",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().FindById(gomock.Any(), 1).Return(output, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.FindById(ctx, 1)
+			},
+			expectOutput: subscription,
+		},
+		{
+			testName: "subscription not found",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().FindById(gomock.Any(), 1).Return(service.SubscriptionOutput{}, service.ErrSubscriptionNotFound)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.FindById(ctx, 1)
+			},
+			expectErr: ErrNotFound,
+		},
+		{
+			testName: "find all",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().FindAll(gomock.Any(), service.SubscriptionFilterInput{CategoryId: 3, Tag: "music"}).
+					Return([]service.SubscriptionOutput{output}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.FindAll(ctx, SubscriptionFilter{CategoryId: 3, Tag: "music"})
+			},
+			expectOutput: []Subscription{subscription},
+		},
+		{
+			testName: "find price",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().FindPrice(gomock.Any(), service.PriceInput{
+					ServiceName: "Yandex Plus",
+					UserId:      userId,
+					StartDate:   date(2025, 1, 1),
+					EndDate:     date(2025, 12, 31),
+					Source:      service.PriceSourceLedger,
+				}).Return(service.PriceOutput{Gross: 1200, Net: 1000, Tax: 200}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.FindPrice(ctx, PriceInput{
+					ServiceName: "Yandex Plus",
+					UserId:      userId,
+					Start:       "2025-01-01",
+					End:         "2025-12-31",
+					Source:      PriceSourceLedger,
+				})
+			},
+			expectOutput: Price{Gross: 1200, Net: 1000, Tax: 200},
+		},
+		{
+			testName: "find duplicates",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().FindDuplicates(gomock.Any(), userId).Return([]service.DuplicateOutput{
+					{UserId: userId, ServiceId: 2, ServiceName: "Yandex Plus", SubscriptionIds: []int{1, 4}},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.FindDuplicates(ctx, userId)
+			},
+			expectOutput: []Duplicate{{UserId: userId, ServiceId: 2, ServiceName: "Yandex Plus", SubscriptionIds: []int{1, 4}}},
+		},
+		{
+			testName: "update",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Update(gomock.Any(), 1, serviceInput).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Update(ctx, 1, input)
+			},
+		},
+		{
+			testName: "delete",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Delete(ctx, 1)
+			},
+		},
+		{
+			testName: "pause",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Pause(gomock.Any(), 1, service.PauseInput{StartDate: date(2025, 8, 1)}).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Pause(ctx, 1, Pause{StartDate: "2025-08-01"})
+			},
+		},
+		{
+			testName: "subscription already paused",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Pause(gomock.Any(), 1, service.PauseInput{StartDate: date(2025, 8, 1)}).Return(service.ErrSubscriptionPaused)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Pause(ctx, 1, Pause{StartDate: "2025-08-01"})
+			},
+			expectErr: ErrConflict,
+		},
+		{
+			testName: "resume",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Resume(gomock.Any(), 1, service.ResumeInput{}).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Resume(ctx, 1, ResumeInput{})
+			},
+		},
+		{
+			testName: "cancel",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Cancel(gomock.Any(), 1, service.CancelInput{
+					When:   service.CancelAtDate,
+					Date:   ptr(date(2025, 9, 30)),
+					Reason: ptr("too expensive"),
+				}).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Cancel(ctx, 1, CancelInput{When: CancelAtDate, Date: ptr("2025-09-30"), Reason: ptr("too expensive")})
+			},
+		},
+		{
+			testName: "uncancel",
+			mockBehaviour: func(m *mocks) {
+				m.subscription.EXPECT().Uncancel(gomock.Any(), 1).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.Uncancel(ctx, 1)
+			},
+		},
+		{
+			testName: "forecast",
+			mockBehaviour: func(m *mocks) {
+				m.forecast.EXPECT().Forecast(gomock.Any(), service.ForecastInput{UserId: userId, StartDate: date(2025, 7, 1), Months: 1}).
+					Return(service.ForecastOutput{
+						Total: 400,
+						Months: []service.ForecastMonthOutput{
+							{Month: "07-2025", Total: 400, Services: []service.ForecastServiceOutput{{ServiceName: "Yandex Plus", Price: 400}}},
+						},
+					}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.Forecast(ctx, ForecastInput{UserId: userId, Start: "07-2025", Months: 1})
+			},
+			expectOutput: Forecast{
+				Total:  400,
+				Months: []ForecastMonth{{Month: "07-2025", Total: 400, Services: []ForecastService{{ServiceName: "Yandex Plus", Price: 400}}}},
+			},
+		},
+		{
+			testName: "schedule price change",
+			mockBehaviour: func(m *mocks) {
+				m.forecast.EXPECT().SchedulePriceChange(gomock.Any(), 1, service.PriceChangeInput{Price: 500, StartDate: date(2025, 10, 1)}).Return(5, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.SchedulePriceChange(ctx, 1, PriceChangeInput{Price: 500, StartDate: "10-2025"})
+			},
+			expectOutput: 5,
+		},
+		{
+			testName: "delete price change",
+			mockBehaviour: func(m *mocks) {
+				m.forecast.EXPECT().DeletePriceChange(gomock.Any(), 1, 5).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.DeletePriceChange(ctx, 1, 5)
+			},
+		},
+		{
+			testName: "set share",
+			mockBehaviour: func(m *mocks) {
+				m.share.EXPECT().Set(gomock.Any(), 1, service.ShareInput{
+					Rule:    SharePercentage,
+					Members: []service.ShareMemberInput{{UserId: userId, Percent: ptr(30)}},
+				}).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Subscriptions.SetShare(ctx, 1, ShareInput{
+					Rule:    SharePercentage,
+					Members: []ShareMemberInput{{UserId: userId, Percent: ptr(30)}},
+				})
+			},
+		},
+		{
+			testName: "settle up",
+			mockBehaviour: func(m *mocks) {
+				m.share.EXPECT().SettleUp(gomock.Any(), service.SettleUpInput{StartDate: date(2025, 7, 1), EndDate: date(2025, 7, 1)}).
+					Return([]service.SettleUpMonthOutput{{Month: "07-2025", Debts: []service.DebtOutput{{From: userId, To: "owner", Amount: 120}}}}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.SettleUp(ctx, SettleUpInput{Start: "07-2025", End: "07-2025"})
+			},
+			expectOutput: []SettleUpMonth{{Month: "07-2025", Debts: []Debt{{From: userId, To: "owner", Amount: 120}}}},
+		},
+		{
+			testName: "calendar feed",
+			mockBehaviour: func(m *mocks) {
+				m.calendar.EXPECT().Feed(gomock.Any(), userId, "token").Return([]byte("BEGIN:VCALENDAR"), nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				feed, err := c.Subscriptions.CalendarFeed(ctx, userId, "token")
+				return string(feed), err
+			},
+			expectOutput: "BEGIN:VCALENDAR",
+		},
+		{
+			testName: "invalid calendar token",
+			mockBehaviour: func(m *mocks) {
+				m.calendar.EXPECT().Feed(gomock.Any(), userId, "token").Return(nil, service.ErrInvalidCalendarToken)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Subscriptions.CalendarFeed(ctx, userId, "token")
+			},
+			expectErr: ErrForbidden,
+		},
+	})
+}
+
+func TestSubscriptionClient_CreateDuplicate(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	c := newTestClient(t, func(m *mocks) {
+		m.subscription.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&service.DuplicateError{Ids: []int{3, 7}})
+	})
+
+	err := c.Subscriptions.Create(context.Background(), SubscriptionInput{ServiceId: 1, Price: 400, UserId: userId, StartDate: "2025-07-01"})
+	assert.ErrorIs(t, err, ErrConflict)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	assert.Equal(t, []int{3, 7}, apiErr.SubscriptionIds)
+}
+
+func TestSubscriptionClient_CalendarLink(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	c := newTestClient(t, func(m *mocks) {
+		m.calendar.EXPECT().Token(gomock.Any(), userId).Return("token", nil)
+	})
+
+	link, err := c.Subscriptions.CalendarLink(context.Background(), userId)
+	assert.NoError(t, err)
+	assert.Contains(t, link, "/api/v1/subscription/calendar.ics?token=token&user_id="+userId)
+}
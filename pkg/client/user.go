@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type (
+	// UserInput is settings of user, Timezone is UTC and Currency is RUB when empty
+	UserInput struct {
+		Email       *string `json:"email,omitempty"`
+		DisplayName *string `json:"display_name,omitempty"`
+		Timezone    string  `json:"timezone,omitempty"`
+		Currency    string  `json:"currency,omitempty"`
+	}
+
+	User struct {
+		Id          string    `json:"id"`
+		Email       *string   `json:"email"`
+		DisplayName *string   `json:"display_name"`
+		Timezone    string    `json:"timezone"`
+		Currency    string    `json:"currency"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	// UserSummary describes subscriptions of user on a day.
+	// MonthlySpend counts yearly subscriptions as 1/12 of price, MonthCharges is sum of charges in current month
+	UserSummary struct {
+		UserId              string      `json:"user_id"`
+		Currency            string      `json:"currency"`
+		ActiveSubscriptions int         `json:"active_subscriptions"`
+		MonthlySpend        int         `json:"monthly_spend"`
+		MonthCharges        int         `json:"month_charges"`
+		NextCharge          *UserCharge `json:"next_charge"`
+	}
+
+	UserCharge struct {
+		SubscriptionId int    `json:"subscription_id"`
+		ServiceName    string `json:"service_name"`
+		Price          int    `json:"price"`
+		Date           string `json:"date"`
+	}
+
+	// Statement lists charges of subscriptions user paid for in month. Amount of shared subscription charge
+	// is part of price paid by user, totals are grouped by currency of subscription owners
+	Statement struct {
+		UserId string           `json:"user_id"`
+		Month  string           `json:"month"`
+		Items  []StatementItem  `json:"items"`
+		Totals []StatementTotal `json:"totals"`
+	}
+
+	StatementItem struct {
+		SubscriptionId int    `json:"subscription_id"`
+		ServiceName    string `json:"service_name"`
+		BillingPeriod  string `json:"billing_period"`
+		BillingDate    string `json:"billing_date"`
+		PeriodEnd      string `json:"period_end"`
+		Price          int    `json:"price"`
+		Amount         int    `json:"amount"`
+		Currency       string `json:"currency"`
+		Shared         bool   `json:"shared"`
+
+		Proration *StatementProration `json:"proration"` // nil if subscription covers the whole period
+	}
+
+	// StatementProration is part of billing period covered by subscription, amount is informational
+	StatementProration struct {
+		CoveredStart string `json:"covered_start"`
+		CoveredEnd   string `json:"covered_end"`
+		CoveredDays  int    `json:"covered_days"`
+		PeriodDays   int    `json:"period_days"`
+		Amount       int    `json:"amount"`
+	}
+
+	StatementTotal struct {
+		Currency string `json:"currency"`
+		Amount   int    `json:"amount"`
+	}
+)
+
+type UserClient struct {
+	c *Client
+}
+
+func userPath(id string) string {
+	return apiPrefix + "/user/" + segment(id)
+}
+
+func (u *UserClient) Create(ctx context.Context, input UserInput) (string, error) {
+	var out idOutput[string]
+	err := u.c.do(ctx, http.MethodPost, apiPrefix+"/user", nil, input, &out)
+	return out.Id, err
+}
+
+func (u *UserClient) FindById(ctx context.Context, id string) (User, error) {
+	var out User
+	err := u.c.do(ctx, http.MethodGet, userPath(id), nil, nil, &out)
+	return out, err
+}
+
+func (u *UserClient) FindAll(ctx context.Context) ([]User, error) {
+	var out []User
+	err := u.c.do(ctx, http.MethodGet, apiPrefix+"/user/all", nil, nil, &out)
+	return out, err
+}
+
+func (u *UserClient) Update(ctx context.Context, id string, input UserInput) error {
+	return u.c.do(ctx, http.MethodPut, userPath(id), nil, input, nil)
+}
+
+func (u *UserClient) Delete(ctx context.Context, id string) error {
+	return u.c.do(ctx, http.MethodDelete, userPath(id), nil, nil, nil)
+}
+
+func (u *UserClient) Subscriptions(ctx context.Context, id string) ([]Subscription, error) {
+	var out []Subscription
+	err := u.c.do(ctx, http.MethodGet, userPath(id)+"/subscriptions", nil, nil, &out)
+	return out, err
+}
+
+// Summary describes subscriptions of user on date in yyyy-mm-dd format, today of user if it is empty
+func (u *UserClient) Summary(ctx context.Context, id, date string) (UserSummary, error) {
+	q := url.Values{}
+	setQuery(q, "date", date)
+
+	var out UserSummary
+	err := u.c.do(ctx, http.MethodGet, userPath(id)+"/summary", q, nil, &out)
+	return out, err
+}
+
+// Statement returns statement of user for month in yyyy-mm format
+func (u *UserClient) Statement(ctx context.Context, id, month string) (Statement, error) {
+	var out Statement
+	err := u.c.do(ctx, http.MethodGet, userPath(id)+"/statements/"+segment(month), nil, nil, &out)
+	return out, err
+}
+
+// StatementHTML returns statement rendered as html page
+func (u *UserClient) StatementHTML(ctx context.Context, id, month string) ([]byte, error) {
+	return u.renderStatement(ctx, id, month, "html")
+}
+
+// StatementPDF returns statement rendered as pdf document
+func (u *UserClient) StatementPDF(ctx context.Context, id, month string) ([]byte, error) {
+	return u.renderStatement(ctx, id, month, "pdf")
+}
+
+func (u *UserClient) renderStatement(ctx context.Context, id, month, format string) ([]byte, error) {
+	var out []byte
+	err := u.c.do(ctx, http.MethodGet, userPath(id)+"/statements/"+segment(month), url.Values{"format": {format}}, nil, &out)
+	return out, err
+}
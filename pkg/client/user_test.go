@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestUserClient(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	runTestCases(t, []testCase{
+		{
+			testName: "create",
+			mockBehaviour: func(m *mocks) {
+				m.user.EXPECT().Create(gomock.Any(), service.UserInput{Email: ptr("user@example.com"), Timezone: "Europe/Moscow"}).Return(userId, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Users.Create(ctx, UserInput{Email: ptr("user@example.com"), Timezone: "Europe/Moscow"})
+			},
+			expectOutput: userId,
+		},
+		{
+			testName: "find by id",
+			mockBehaviour: func(m *mocks) {
+				m.user.EXPECT().FindById(gomock.Any(), userId).Return(service.UserOutput{
+					Id: userId, Timezone: "UTC", Currency: "RUB", CreatedAt: createdAt,
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Users.FindById(ctx, userId)
+			},
+			expectOutput: User{Id: userId, Timezone: "UTC", Currency: "RUB", CreatedAt: createdAt},
+		},
+		{
+			testName: "user not found",
+			mockBehaviour: func(m *mocks) {
+				m.user.EXPECT().Delete(gomock.Any(), userId).Return(service.ErrUserNotFound)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Users.Delete(ctx, userId)
+			},
+			expectErr: ErrNotFound,
+		},
+		{
+			// id is escaped, so it is validated by router instead of matching another route
+			testName:      "invalid id",
+			mockBehaviour: func(m *mocks) {},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Users.FindById(ctx, "not/uuid")
+			},
+			expectErr: ErrBadRequest,
+		},
+		{
+			testName: "summary",
+			mockBehaviour: func(m *mocks) {
+				day := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+				m.user.EXPECT().Summary(gomock.Any(), userId, &day).Return(service.UserSummaryOutput{
+					UserId: userId, Currency: "RUB", ActiveSubscriptions: 1, MonthlySpend: 400,
+					NextCharge: &service.UserChargeOutput{SubscriptionId: 1, ServiceName: "Okko", Price: 400, Date: "2025-08-01"},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Users.Summary(ctx, userId, "2025-07-15")
+			},
+			expectOutput: UserSummary{
+				UserId: userId, Currency: "RUB", ActiveSubscriptions: 1, MonthlySpend: 400,
+				NextCharge: &UserCharge{SubscriptionId: 1, ServiceName: "Okko", Price: 400, Date: "2025-08-01"},
+			},
+		},
+		{
+			testName: "statement",
+			mockBehaviour: func(m *mocks) {
+				m.statement.EXPECT().Find(gomock.Any(), userId, july).Return(service.StatementOutput{
+					UserId: userId,
+					Month:  "2025-07",
+					Items:  []service.StatementItemOutput{{SubscriptionId: 1, ServiceName: "Okko", Amount: 400, Currency: "RUB"}},
+					Totals: []service.StatementTotalOutput{{Currency: "RUB", Amount: 400}},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Users.Statement(ctx, userId, "2025-07")
+			},
+			expectOutput: Statement{
+				UserId: userId,
+				Month:  "2025-07",
+				Items:  []StatementItem{{SubscriptionId: 1, ServiceName: "Okko", Amount: 400, Currency: "RUB"}},
+				Totals: []StatementTotal{{Currency: "RUB", Amount: 400}},
+			},
+		},
+		{
+			testName: "statement pdf",
+			mockBehaviour: func(m *mocks) {
+				m.statement.EXPECT().PDF(gomock.Any(), userId, july).Return([]byte("%PDF-1.4"), nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				pdf, err := c.Users.StatementPDF(ctx, userId, "2025-07")
+				return string(pdf), err
+			},
+			expectOutput: "%PDF-1.4",
+		},
+	})
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	// WebhookInput subscribes Url to Events, payloads are signed with Secret of at least 16 characters
+	WebhookInput struct {
+		Url    string   `json:"url"`
+		Secret string   `json:"secret"`
+		Events []string `json:"events,omitempty"`
+	}
+
+	Webhook struct {
+		Id        int       `json:"id"`
+		Url       string    `json:"url"`
+		Events    []string  `json:"events"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	WebhookDelivery struct {
+		Id             int64      `json:"id"`
+		EventId        int64      `json:"event_id"`
+		EventType      string     `json:"event_type"`
+		Status         string     `json:"status"`
+		Attempts       int        `json:"attempts"`
+		LastStatusCode *int       `json:"last_status_code"`
+		LastError      *string    `json:"last_error"`
+		NextAttemptAt  *time.Time `json:"next_attempt_at"`
+		CreatedAt      time.Time  `json:"created_at"`
+		DeliveredAt    *time.Time `json:"delivered_at"`
+	}
+)
+
+type WebhookClient struct {
+	c *Client
+}
+
+func webhookPath(id int) string {
+	return apiPrefix + "/webhook/" + strconv.Itoa(id)
+}
+
+func (w *WebhookClient) Create(ctx context.Context, input WebhookInput) (int, error) {
+	var out idOutput[int]
+	err := w.c.do(ctx, http.MethodPost, apiPrefix+"/webhook", nil, input, &out)
+	return out.Id, err
+}
+
+func (w *WebhookClient) FindById(ctx context.Context, id int) (Webhook, error) {
+	var out Webhook
+	err := w.c.do(ctx, http.MethodGet, webhookPath(id), nil, nil, &out)
+	return out, err
+}
+
+func (w *WebhookClient) FindAll(ctx context.Context) ([]Webhook, error) {
+	var out []Webhook
+	err := w.c.do(ctx, http.MethodGet, apiPrefix+"/webhook/all", nil, nil, &out)
+	return out, err
+}
+
+func (w *WebhookClient) Update(ctx context.Context, id int, input WebhookInput) error {
+	return w.c.do(ctx, http.MethodPut, webhookPath(id), nil, input, nil)
+}
+
+func (w *WebhookClient) Delete(ctx context.Context, id int) error {
+	return w.c.do(ctx, http.MethodDelete, webhookPath(id), nil, nil, nil)
+}
+
+func (w *WebhookClient) FindDeliveries(ctx context.Context, id int) ([]WebhookDelivery, error) {
+	var out []WebhookDelivery
+	err := w.c.do(ctx, http.MethodGet, webhookPath(id)+"/deliveries", nil, nil, &out)
+	return out, err
+}
+
+// Redeliver sends delivery of webhook again
+func (w *WebhookClient) Redeliver(ctx context.Context, id int, deliveryId int64) error {
+	path := webhookPath(id) + "/deliveries/" + strconv.FormatInt(deliveryId, 10) + "/redeliver"
+	return w.c.do(ctx, http.MethodPost, path, nil, nil, nil)
+}
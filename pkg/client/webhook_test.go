@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"subscription_service/internal/service"
+	"testing"
+	"time"
+)
+
+func TestWebhookClient(t *testing.T) {
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	runTestCases(t, []testCase{
+		{
+			testName: "create",
+			mockBehaviour: func(m *mocks) {
+				m.webhook.EXPECT().Create(gomock.Any(), service.WebhookInput{
+					Url: "https://example.com/hook", Secret: "0123456789abcdef", Events: []string{"charge.paid"},
+				}).Return(1, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Webhooks.Create(ctx, WebhookInput{
+					Url: "https://example.com/hook", Secret: "0123456789abcdef", Events: []string{"charge.paid"},
+				})
+			},
+			expectOutput: 1,
+		},
+		{
+			testName: "find deliveries",
+			mockBehaviour: func(m *mocks) {
+				m.webhook.EXPECT().FindDeliveries(gomock.Any(), 1).Return([]service.WebhookDeliveryOutput{
+					{Id: 10, EventId: 3, EventType: "charge.paid", Status: "failed", Attempts: 2, LastStatusCode: ptr(500), CreatedAt: createdAt},
+				}, nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.Webhooks.FindDeliveries(ctx, 1)
+			},
+			expectOutput: []WebhookDelivery{
+				{Id: 10, EventId: 3, EventType: "charge.paid", Status: "failed", Attempts: 2, LastStatusCode: ptr(500), CreatedAt: createdAt},
+			},
+		},
+		{
+			testName: "redeliver",
+			mockBehaviour: func(m *mocks) {
+				m.webhook.EXPECT().Redeliver(gomock.Any(), 1, int64(10)).Return(nil)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Webhooks.Redeliver(ctx, 1, 10)
+			},
+		},
+		{
+			testName: "delivery not found",
+			mockBehaviour: func(m *mocks) {
+				m.webhook.EXPECT().Redeliver(gomock.Any(), 1, int64(11)).Return(service.ErrWebhookDeliveryNotFound)
+			},
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return nil, c.Webhooks.Redeliver(ctx, 1, 11)
+			},
+			expectErr: ErrNotFound,
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+type Calendar struct {
+	ProdId string
+	Name   string
+	Events []Event
+}
+
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time // all-day event, only date part is used
+	RRule       string
+	ExDates     []time.Time // all-day occurrences excluded from recurrence
+	AlarmDays   int         // if > 0, display alarm this number of days before event
+}
+
+// Recurrence builds RRULE value. freq must be one of RFC 5545 FREQ values (MONTHLY, YEARLY, ...),
+// parts are other rule parts like BYMONTHDAY=-1
+func Recurrence(freq string, until *time.Time, parts ...string) string {
+	rule := "FREQ=" + freq
+	for _, p := range parts {
+		rule += ";" + p
+	}
+	if until != nil {
+		rule += ";UNTIL=" + until.Format(dateLayout)
+	}
+	return rule
+}
+
+// Marshal encodes calendar in RFC 5545 format. stamp is used as DTSTAMP for every event
+func (c Calendar) Marshal(stamp time.Time) []byte {
+	var buf bytes.Buffer
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+escape(c.ProdId))
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&buf, "X-WR-CALNAME:"+escape(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+escape(e.UID))
+		writeLine(&buf, "DTSTAMP:"+stamp.UTC().Format(dateTimeLayout))
+		writeLine(&buf, "DTSTART;VALUE=DATE:"+e.Start.Format(dateLayout))
+		writeLine(&buf, "DTEND;VALUE=DATE:"+e.Start.AddDate(0, 0, 1).Format(dateLayout))
+		if e.RRule != "" {
+			writeLine(&buf, "RRULE:"+e.RRule)
+		}
+		if len(e.ExDates) > 0 {
+			dates := make([]string, 0, len(e.ExDates))
+			for _, d := range e.ExDates {
+				dates = append(dates, d.Format(dateLayout))
+			}
+			writeLine(&buf, "EXDATE;VALUE=DATE:"+strings.Join(dates, ","))
+		}
+		writeLine(&buf, "SUMMARY:"+escape(e.Summary))
+		if e.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escape(e.Description))
+		}
+		writeLine(&buf, "TRANSP:TRANSPARENT")
+		if e.AlarmDays > 0 {
+			writeLine(&buf, "BEGIN:VALARM")
+			writeLine(&buf, "ACTION:DISPLAY")
+			writeLine(&buf, "DESCRIPTION:"+escape(e.Summary))
+			writeLine(&buf, fmt.Sprintf("TRIGGER:-P%dD", e.AlarmDays))
+			writeLine(&buf, "END:VALARM")
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// writeLine writes content line with CRLF ending, folding it to 75 octets without breaking utf-8 sequences
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // continuation line starts with a space
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
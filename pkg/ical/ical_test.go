@@ -0,0 +1,164 @@
+package ical
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestRecurrence(t *testing.T) {
+	until := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName string
+		freq     string
+		until    *time.Time
+		parts    []string
+		expect   string
+	}{
+		{
+			testName: "without until",
+			freq:     "MONTHLY",
+			until:    nil,
+			expect:   "FREQ=MONTHLY",
+		},
+		{
+			testName: "with until",
+			freq:     "YEARLY",
+			until:    &until,
+			expect:   "FREQ=YEARLY;UNTIL=20260331",
+		},
+		{
+			testName: "with parts",
+			freq:     "MONTHLY",
+			until:    &until,
+			parts:    []string{"BYMONTHDAY=28,29,30,31", "BYSETPOS=-1"},
+			expect:   "FREQ=MONTHLY;BYMONTHDAY=28,29,30,31;BYSETPOS=-1;UNTIL=20260331",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, Recurrence(tc.freq, tc.until, tc.parts...))
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	testCases := []struct {
+		testName string
+		input    string
+		expect   string
+	}{
+		{
+			testName: "plain text",
+			input:    "Yandex renewal",
+			expect:   "Yandex renewal",
+		},
+		{
+			testName: "comma and semicolon",
+			input:    "Music, video; books",
+			expect:   `Music\, video\; books`,
+		},
+		{
+			testName: "backslash",
+			input:    `C:\plans`,
+			expect:   `C:\\plans`,
+		},
+		{
+			testName: "newlines",
+			input:    "first\nsecond\r\nthird",
+			expect:   `first\nsecond\nthird`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.expect, escape(tc.input))
+		})
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	testCases := []struct {
+		testName string
+		line     string
+		expect   string
+	}{
+		{
+			testName: "short line",
+			line:     "SUMMARY:Yandex renewal",
+			expect:   "SUMMARY:Yandex renewal\r\n",
+		},
+		{
+			testName: "exactly 75 octets",
+			line:     strings.Repeat("a", 75),
+			expect:   strings.Repeat("a", 75) + "\r\n",
+		},
+		{
+			testName: "folded line",
+			line:     strings.Repeat("a", 75+74+10),
+			expect:   strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n " + strings.Repeat("a", 10) + "\r\n",
+		},
+		{
+			testName: "multibyte rune on fold boundary",
+			line:     strings.Repeat("a", 74) + "я" + "b",
+			expect:   strings.Repeat("a", 74) + "\r\n яb\r\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeLine(&buf, tc.line)
+			assert.Equal(t, tc.expect, buf.String())
+		})
+	}
+}
+
+func TestCalendar_Marshal(t *testing.T) {
+	until := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	cal := Calendar{
+		ProdId: "-//test//EN",
+		Name:   "Renewals",
+		Events: []Event{
+			{
+				UID:         "subscription-1@test",
+				Summary:     "Yandex, Plus renewal",
+				Description: strings.Repeat("Очень длинное описание; ", 10),
+				Start:       time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+				RRule:       Recurrence("MONTHLY", &until),
+				ExDates:     []time.Time{time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+				AlarmDays:   3,
+			},
+		},
+	}
+
+	out := string(cal.Marshal(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTAMP:20250101T120000Z\r\n",
+		"DTSTART;VALUE=DATE:20250115\r\n",
+		"DTEND;VALUE=DATE:20250116\r\n",
+		"RRULE:FREQ=MONTHLY;UNTIL=20261201\r\n",
+		"EXDATE;VALUE=DATE:20250315\r\n",
+		"SUMMARY:Yandex\\, Plus renewal\r\n",
+		"TRIGGER:-P3D\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		assert.Contains(t, out, line)
+	}
+
+	assert.True(t, strings.HasSuffix(out, "\r\n"))
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		assert.True(t, utf8.ValidString(line), line)
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	assert.Contains(t, unfolded, "DESCRIPTION:"+strings.Repeat(`Очень длинное описание\; `, 10)+"\r\n")
+}
@@ -0,0 +1,18 @@
+package notifier
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+)
+
+type logNotifier struct{}
+
+// NewLog returns notifier which only writes messages to application log
+func NewLog() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) Notify(_ context.Context, msg Message) error {
+	log.Info().Str("to", msg.To).Str("subject", msg.Subject).Str("body", msg.Body).Msg("notification")
+	return nil
+}
@@ -0,0 +1,18 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNoRecipient = errors.New("notification recipient is not set")
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,122 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"strings"
+	"testing"
+)
+
+type sinkMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// runSMTPSink starts minimal SMTP server which accepts every mail and sends it to returned channel
+func runSMTPSink(t *testing.T) (string, <-chan sinkMail) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	mails := make(chan sinkMail, 1)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, mails)
+		}
+	}()
+	return l.Addr().String(), mails
+}
+
+func serveSMTP(conn net.Conn, mails chan<- sinkMail) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+	var mail sinkMail
+
+	reply("220 sink ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 sink")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			mail.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			mail.to = append(mail.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			mail.data = data.String()
+			mails <- mail
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	addr, mails := runSMTPSink(t)
+	host, port, _ := net.SplitHostPort(addr)
+
+	n := NewSMTP(SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "noreply@example.com",
+	})
+
+	err := n.Notify(context.Background(), Message{
+		To:      "user@example.com",
+		Subject: "Yandex renewal",
+		Body:    "Yandex renews on 01.08.2025\nPrice: 400",
+	})
+	require.NoError(t, err)
+
+	mail := <-mails
+
+	assert.Equal(t, "noreply@example.com", mail.from)
+	assert.Equal(t, []string{"user@example.com"}, mail.to)
+	assert.Contains(t, mail.data, "To: user@example.com\r\n")
+	assert.Contains(t, mail.data, "Subject: Yandex renewal\r\n")
+	assert.Contains(t, mail.data, "Yandex renews on 01.08.2025\r\nPrice: 400\r\n")
+}
+
+func TestSMTPNotifier_NotifyWithoutRecipient(t *testing.T) {
+	n := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: "25", From: "noreply@example.com"})
+
+	err := n.Notify(context.Background(), Message{Subject: "Yandex renewal"})
+
+	assert.Equal(t, ErrNoRecipient, err)
+}
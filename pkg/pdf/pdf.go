@@ -0,0 +1,150 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page in points with text in Helvetica, one of the standard fonts every viewer has, so nothing is embedded
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 50
+	fontSize     = 10
+	titleSize    = 14
+	leading      = 14
+	linesPerPage = (pageHeight - 2*margin - leading) / leading
+)
+
+// Document is a plain text document. Standard font covers Latin-1 only: cyrillic is transliterated,
+// other characters are replaced with '?'
+type Document struct {
+	Title string
+	Lines []string
+}
+
+// Marshal encodes document in PDF 1.4 format. Title is printed at the top of the first page, lines follow
+// it and are split into pages
+func (d Document) Marshal() []byte {
+	pages := paginate(d.Lines)
+
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// objects: 1 catalog, 2 pages, 3 font, 4 info, then page and its content for every page
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	w.object(fmt.Sprintf("<< /Title (%s) /Producer (subscription_service) >>", encode(d.Title)))
+
+	for i, lines := range pages {
+		w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+2*i))
+
+		var title string
+		if i == 0 {
+			title = d.Title
+		}
+		content := pageContent(title, lines)
+		w.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	w.trailer()
+	return w.buf.Bytes()
+}
+
+// paginate splits lines into pages, the first page has room for title. Document always has at least one page
+func paginate(lines []string) [][]string {
+	pages := [][]string{nil}
+	room := linesPerPage - 2
+
+	for _, line := range lines {
+		if room == 0 {
+			pages = append(pages, nil)
+			room = linesPerPage
+		}
+		pages[len(pages)-1] = append(pages[len(pages)-1], line)
+		room--
+	}
+	return pages
+}
+
+func pageContent(title string, lines []string) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("BT\n")
+	fmt.Fprintf(&buf, "%d %d Td\n", margin, pageHeight-margin-leading)
+	fmt.Fprintf(&buf, "%d TL\n", leading)
+	if title != "" {
+		fmt.Fprintf(&buf, "/F1 %d Tf\n(%s) Tj T* T*\n", titleSize, encode(title))
+	}
+	fmt.Fprintf(&buf, "/F1 %d Tf\n", fontSize)
+	for _, line := range lines {
+		fmt.Fprintf(&buf, "(%s) Tj T*\n", encode(line))
+	}
+	buf.WriteString("ET")
+	return buf.Bytes()
+}
+
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+// object writes next indirect object, objects are numbered from 1 in order of writing
+func (w *writer) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+// trailer writes cross-reference table, offsets of entries are 10 digits and every entry is 20 bytes long
+func (w *writer) trailer() {
+	start := w.buf.Len()
+
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n", len(w.offsets)+1)
+	w.buf.WriteString("0000000000 65535 f \n")
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, start)
+}
+
+// encode converts text to WinAnsi string literal content
+func encode(s string) string {
+	var buf strings.Builder
+
+	for _, r := range s {
+		if t, ok := cyrillic[r]; ok {
+			buf.WriteString(t)
+			continue
+		}
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			buf.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			// Latin-1 supplement matches WinAnsi, written as octal escape to keep content ASCII
+			fmt.Fprintf(&buf, "\\%03o", r)
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
+
+var cyrillic = map[rune]string{
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Е': "E", 'Ё': "Yo", 'Ж': "Zh", 'З': "Z", 'И': "I", 'Й': "Y",
+	'К': "K", 'Л': "L", 'М': "M", 'Н': "N", 'О': "O", 'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'У': "U", 'Ф': "F",
+	'Х': "Kh", 'Ц': "Ts", 'Ч': "Ch", 'Ш': "Sh", 'Щ': "Shch", 'Ъ': "", 'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "Yu", 'Я': "Ya",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z", 'и': "i", 'й': "y",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
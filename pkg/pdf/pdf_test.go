@@ -0,0 +1,60 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+var startXrefRe = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+
+func TestDocument_Marshal(t *testing.T) {
+	doc := Document{
+		Title: "Statement 07-2025",
+		Lines: []string{"Yandex Plus (family)  400 RUB", "Яндекс Плюс  299 RUB", "Café  10 €"},
+	}
+
+	data := doc.Marshal()
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.Contains(t, string(data), "/Count 1")
+	assert.Contains(t, string(data), `(Statement 07-2025) Tj`)
+	assert.Contains(t, string(data), `(Yandex Plus \(family\)  400 RUB) Tj`)
+	assert.Contains(t, string(data), `(Yandeks Plyus  299 RUB) Tj`)
+	assert.Contains(t, string(data), `(Caf\351  10 ?) Tj`)
+
+	// every cross-reference entry points to its object
+	m := startXrefRe.FindSubmatch(data)
+	require.NotNil(t, m)
+	start, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(data[start:], []byte("xref\n0 7\n")))
+
+	entries := bytes.Split(data[start+len("xref\n0 7\n"):], []byte("\n"))[1:7]
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[:10]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestDocument_MarshalPages(t *testing.T) {
+	lines := make([]string, 2*linesPerPage)
+	for i := range lines {
+		lines[i] = strconv.Itoa(i)
+	}
+
+	data := Document{Title: "Long", Lines: lines}.Marshal()
+
+	assert.Contains(t, string(data), "/Kids [5 0 R 7 0 R 9 0 R] /Count 3")
+	assert.Equal(t, 1, bytes.Count(data, []byte("(Long) Tj")))
+}
+
+func TestDocument_MarshalEmpty(t *testing.T) {
+	data := Document{}.Marshal()
+
+	assert.Contains(t, string(data), "/Kids [5 0 R] /Count 1")
+}
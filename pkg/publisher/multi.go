@@ -0,0 +1,34 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+)
+
+type multiPublisher []Publisher
+
+// NewMulti returns publisher which sends every message to all publishers. Publish fails if any of them fails,
+// so message may be delivered to some publishers more than once after retry
+func NewMulti(publishers ...Publisher) Publisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, p := range m {
+		if err := p.Publish(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiPublisher) Close() error {
+	var errs []error
+	for _, p := range m {
+		if err := p.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
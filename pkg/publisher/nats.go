@@ -0,0 +1,41 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"github.com/nats-io/nats.go"
+)
+
+type natsPublisher struct {
+	nc *nats.Conn
+}
+
+// NewNats connects to NATS server. Message key is sent in Nats-Msg-Id header, so JetStream streams
+// deduplicate redelivered messages
+func NewNats(url string) (Publisher, error) {
+	nc, err := nats.Connect(url, nats.Name("subscription_service"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("error connect to nats, %w", err)
+	}
+	return &natsPublisher{nc: nc}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, msg Message) error {
+	m := nats.NewMsg(msg.Subject)
+	m.Data = msg.Data
+	if msg.Key != "" {
+		m.Header.Set(nats.MsgIdHdr, msg.Key)
+	}
+	if err := p.nc.PublishMsg(m); err != nil {
+		return err
+	}
+	// flush waits for server to process everything sent, so failed delivery is reported to caller
+	if _, ok := ctx.Deadline(); !ok {
+		return p.nc.Flush()
+	}
+	return p.nc.FlushWithContext(ctx)
+}
+
+func (p *natsPublisher) Close() error {
+	return p.nc.Drain()
+}
@@ -0,0 +1,77 @@
+package publisher
+
+import (
+	"context"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func runNatsServer(t *testing.T) *server.Server {
+	s, err := server.NewServer(&server.Options{
+		Host:   "127.0.0.1",
+		Port:   server.RANDOM_PORT,
+		NoLog:  true,
+		NoSigs: true,
+	})
+	require.NoError(t, err)
+
+	go s.Start()
+	if !s.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats server is not ready")
+	}
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+func TestNatsPublisher_Publish(t *testing.T) {
+	s := runNatsServer(t)
+
+	nc, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	defer nc.Close()
+
+	sub, err := nc.SubscribeSync("subscription.>")
+	require.NoError(t, err)
+	require.NoError(t, nc.Flush())
+
+	p, err := NewNats(s.ClientURL())
+	require.NoError(t, err)
+	defer func() { _ = p.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err = p.Publish(ctx, Message{
+		Subject: "subscription.created",
+		Key:     "1",
+		Data:    []byte(`{"id":1}`),
+	})
+	require.NoError(t, err)
+
+	msg, err := sub.NextMsg(time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, "subscription.created", msg.Subject)
+	assert.Equal(t, "1", msg.Header.Get(nats.MsgIdHdr))
+	assert.Equal(t, `{"id":1}`, string(msg.Data))
+}
+
+func TestNatsPublisher_PublishServerDown(t *testing.T) {
+	s := runNatsServer(t)
+
+	p, err := NewNats(s.ClientURL())
+	require.NoError(t, err)
+	defer func() { _ = p.Close() }()
+
+	s.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err = p.Publish(ctx, Message{Subject: "subscription.created", Data: []byte(`{}`)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,15 @@
+package publisher
+
+import "context"
+
+type Message struct {
+	Subject string
+	Key     string // unique message key, consumers use it for deduplication
+	Data    []byte
+}
+
+type Publisher interface {
+	// Publish returns nil only when message is accepted by the underlying transport
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
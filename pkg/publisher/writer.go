@@ -0,0 +1,41 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+type writerPublisher struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriter returns in-process publisher which writes messages to w as json lines (e.g. os.Stdout)
+func NewWriter(w io.Writer) Publisher {
+	return &writerPublisher{
+		enc: json.NewEncoder(w),
+	}
+}
+
+type writerMessage struct {
+	Subject string          `json:"subject"`
+	Key     string          `json:"key"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (p *writerPublisher) Publish(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.enc.Encode(writerMessage{
+		Subject: msg.Subject,
+		Key:     msg.Key,
+		Data:    msg.Data,
+	})
+}
+
+func (p *writerPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,28 @@
+package tenant
+
+import "context"
+
+// DefaultOrganization owns data created before organizations were introduced and requests without tenant
+const DefaultOrganization = 1
+
+type organizationKey struct{}
+
+// WithOrganization returns ctx bound to organization. Repositories called with this ctx see only data of organization
+func WithOrganization(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, organizationKey{}, id)
+}
+
+// Organization returns organization of ctx. ok is false for ctx without tenant, e.g. in background jobs
+// working with all organizations
+func Organization(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(organizationKey{}).(int)
+	return id, ok
+}
+
+// OrganizationOrDefault returns organization of ctx or DefaultOrganization if there is no one
+func OrganizationOrDefault(ctx context.Context) int {
+	if id, ok := Organization(ctx); ok {
+		return id
+	}
+	return DefaultOrganization
+}